@@ -31,50 +31,67 @@ type AbandonedCheckoutsResource struct {
 
 // AbandonedCheckout represents a Shopify abandoned checkout
 type AbandonedCheckout struct {
-	Id                       uint64               `json:"id,omitempty"`
-	Token                    string               `json:"token,omitempty"`
-	CartToken                string               `json:"cart_token,omitempty"`
-	Email                    string               `json:"email,omitempty"`
-	Gateway                  string               `json:"gateway,omitempty"`
-	BuyerAcceptsMarketing    bool                 `json:"buyer_accepts_marketing,omitempty"`
-	CreatedAt                *time.Time           `json:"created_at,omitempty"`
-	UpdatedAt                *time.Time           `json:"updated_at,omitempty"`
-	LandingSite              string               `json:"landing_site,omitempty"`
-	Note                     string               `json:"note,omitempty"`
-	NoteAttributes           []NoteAttribute      `json:"note_attributes,omitempty"`
-	ReferringSite            string               `json:"referring_site,omitempty"`
-	ShippingLines            []ShippingLines      `json:"shipping_lines,omitempty"`
-	TaxesIncluded            bool                 `json:"taxes_included,omitempty"`
-	TotalWeight              int                  `json:"total_weight,omitempty"`
-	Currency                 string               `json:"currency,omitempty"`
-	CompletedAt              *time.Time           `json:"completed_at,omitempty"`
-	ClosedAt                 *time.Time           `json:"closed_at,omitempty"`
-	UserId                   uint64               `json:"user_id,omitempty"`
-	SourceIdentifier         string               `json:"source_identifier,omitempty"`
-	SourceUrl                string               `json:"source_url,omitempty"`
-	DeviceId                 uint64               `json:"device_id,omitempty"`
-	Phone                    string               `json:"phone,omitempty"`
-	CustomerLocale           string               `json:"customer_locale,omitempty"`
-	Name                     string               `json:"name,omitempty"`
-	Source                   string               `json:"source,omitempty"`
-	AbandonedCheckoutUrl     string               `json:"abandoned_checkout_url,omitempty"`
-	DiscountCodes            []DiscountCode       `json:"discount_codes,omitempty"`
-	TaxLines                 []TaxLine            `json:"tax_lines,omitempty"`
-	SourceName               string               `json:"source_name,omitempty"`
-	PresentmentCurrency      string               `json:"presentment_currency,omitempty"`
-	BuyerAcceptsSmsMarketing bool                 `json:"buyer_accepts_sms_marketing,omitempty"`
-	SmsMarketingPhone        string               `json:"sms_marketing_phone,omitempty"`
-	TotalDiscounts           *decimal.Decimal     `json:"total_discounts,omitempty"`
-	TotalLineItemsPrice      *decimal.Decimal     `json:"total_line_items_price,omitempty"`
-	TotalPrice               *decimal.Decimal     `json:"total_price,omitempty"`
-	SubtotalPrice            *decimal.Decimal     `json:"subtotal_price,omitempty"`
-	TotalDuties              string               `json:"total_duties,omitempty"`
-	BillingAddress           *Address             `json:"billing_address,omitempty"`
-	ShippingAddress          *Address             `json:"shipping_address,omitempty"`
-	Customer                 *Customer            `json:"customer,omitempty"`
-	SmsMarketingConsent      *SmsMarketingConsent `json:"sms_marketing_consent,omitempty"`
-	AdminGraphqlApiId        string               `json:"admin_graphql_api_id,omitempty"`
-	DefaultAddress           *CustomerAddress     `json:"default_address,omitempty"`
+	Id                       uint64                 `json:"id,omitempty"`
+	Token                    string                 `json:"token,omitempty"`
+	CartToken                string                 `json:"cart_token,omitempty"`
+	Email                    string                 `json:"email,omitempty"`
+	Gateway                  string                 `json:"gateway,omitempty"`
+	BuyerAcceptsMarketing    bool                   `json:"buyer_accepts_marketing,omitempty"`
+	CreatedAt                *time.Time             `json:"created_at,omitempty"`
+	UpdatedAt                *time.Time             `json:"updated_at,omitempty"`
+	LandingSite              string                 `json:"landing_site,omitempty"`
+	Note                     string                 `json:"note,omitempty"`
+	NoteAttributes           []NoteAttribute        `json:"note_attributes,omitempty"`
+	ReferringSite            string                 `json:"referring_site,omitempty"`
+	ShippingLines            []ShippingLines        `json:"shipping_lines,omitempty"`
+	TaxesIncluded            bool                   `json:"taxes_included,omitempty"`
+	TotalWeight              int                    `json:"total_weight,omitempty"`
+	Currency                 string                 `json:"currency,omitempty"`
+	CompletedAt              *time.Time             `json:"completed_at,omitempty"`
+	ClosedAt                 *time.Time             `json:"closed_at,omitempty"`
+	UserId                   uint64                 `json:"user_id,omitempty"`
+	SourceIdentifier         string                 `json:"source_identifier,omitempty"`
+	SourceUrl                string                 `json:"source_url,omitempty"`
+	DeviceId                 uint64                 `json:"device_id,omitempty"`
+	Phone                    string                 `json:"phone,omitempty"`
+	CustomerLocale           string                 `json:"customer_locale,omitempty"`
+	Name                     string                 `json:"name,omitempty"`
+	Source                   string                 `json:"source,omitempty"`
+	AbandonedCheckoutUrl     string                 `json:"abandoned_checkout_url,omitempty"`
+	DiscountCodes            []DiscountCode         `json:"discount_codes,omitempty"`
+	TaxLines                 []TaxLine              `json:"tax_lines,omitempty"`
+	SourceName               string                 `json:"source_name,omitempty"`
+	PresentmentCurrency      string                 `json:"presentment_currency,omitempty"`
+	BuyerAcceptsSmsMarketing bool                   `json:"buyer_accepts_sms_marketing,omitempty"`
+	SmsMarketingPhone        string                 `json:"sms_marketing_phone,omitempty"`
+	TotalDiscounts           *decimal.Decimal       `json:"total_discounts,omitempty"`
+	TotalLineItemsPrice      *decimal.Decimal       `json:"total_line_items_price,omitempty"`
+	TotalPrice               *decimal.Decimal       `json:"total_price,omitempty"`
+	SubtotalPrice            *decimal.Decimal       `json:"subtotal_price,omitempty"`
+	TotalDuties              string                 `json:"total_duties,omitempty"`
+	BillingAddress           *Address               `json:"billing_address,omitempty"`
+	ShippingAddress          *Address               `json:"shipping_address,omitempty"`
+	Customer                 *Customer              `json:"customer,omitempty"`
+	SmsMarketingConsent      *SmsMarketingConsent   `json:"sms_marketing_consent,omitempty"`
+	AdminGraphqlApiId        string                 `json:"admin_graphql_api_id,omitempty"`
+	DefaultAddress           *CustomerAddress       `json:"default_address,omitempty"`
+	LineItems                []LineItem             `json:"line_items,omitempty"`
+	Recovery                 *CheckoutRecovery      `json:"recovery,omitempty"`
+	EmailMarketingConsent    *EmailMarketingConsent `json:"email_marketing_consent,omitempty"`
+}
+
+// Recovery email states of an abandoned checkout
+const (
+	CheckoutRecoveryEmailNotSent   = "not_sent"
+	CheckoutRecoveryEmailScheduled = "scheduled"
+	CheckoutRecoveryEmailSent      = "sent"
+)
+
+// CheckoutRecovery represents the recovery email status of an abandoned checkout
+type CheckoutRecovery struct {
+	EmailState  string     `json:"email_state,omitempty"`
+	EmailSentAt *time.Time `json:"email_sent_at,omitempty"`
+	RecoveredAt *time.Time `json:"recovered_at,omitempty"`
 }
 
 type SmsMarketingConsent struct {
@@ -84,6 +101,29 @@ type SmsMarketingConsent struct {
 	ConsentCollectedFrom string     `json:"consent_collected_from,omitempty"`
 }
 
+// IsRecoverable reports whether the checkout is still open and the buyer can
+// be contacted with its abandoned_checkout_url
+func (c AbandonedCheckout) IsRecoverable() bool {
+	if c.CompletedAt != nil || c.ClosedAt != nil || c.AbandonedCheckoutUrl == "" {
+		return false
+	}
+	if c.Recovery != nil && c.Recovery.RecoveredAt != nil {
+		return false
+	}
+	return c.Email != "" || c.Phone != "" || c.SmsMarketingPhone != ""
+}
+
+// RecoverableCheckouts returns the checkouts for which IsRecoverable is true
+func RecoverableCheckouts(checkouts []AbandonedCheckout) []AbandonedCheckout {
+	recoverable := make([]AbandonedCheckout, 0, len(checkouts))
+	for _, checkout := range checkouts {
+		if checkout.IsRecoverable() {
+			recoverable = append(recoverable, checkout)
+		}
+	}
+	return recoverable
+}
+
 // Get abandoned checkout list
 func (s *AbandonedCheckoutServiceOp) List(ctx context.Context, options interface{}) ([]AbandonedCheckout, error) {
 	path := fmt.Sprintf("/%s.json", abandonedCheckoutsBasePath)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/jarcoal/httpmock"
 )
@@ -32,3 +33,69 @@ func TestAbandonedCheckoutList(t *testing.T) {
 		t.Errorf("AbandonedCheckout.List returned %+v, expected %+v", abandonedCheckouts, expected)
 	}
 }
+
+func TestAbandonedCheckoutRecoveryFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder(
+		"GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/checkouts.json", client.pathPrefix),
+		httpmock.NewStringResponder(
+			200,
+			`{"checkouts": [{
+				"id":1,
+				"email":"bob@example.com",
+				"abandoned_checkout_url":"https://fooshop.myshopify.com/1/checkouts/abc/recover?key=def",
+				"completed_at":null,
+				"recovery":{"email_state":"sent","email_sent_at":"2024-01-02T10:00:00Z"},
+				"email_marketing_consent":{"state":"subscribed","opt_in_level":"single_opt_in"},
+				"line_items":[{"variant_id":2,"quantity":1}]
+			}]}`,
+		),
+	)
+
+	abandonedCheckouts, err := client.AbandonedCheckout.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("AbandonedCheckout.List returned error: %v", err)
+	}
+
+	checkout := abandonedCheckouts[0]
+	if checkout.Recovery == nil || checkout.Recovery.EmailState != CheckoutRecoveryEmailSent || checkout.Recovery.EmailSentAt == nil {
+		t.Errorf("AbandonedCheckout.Recovery returned %+v", checkout.Recovery)
+	}
+	if checkout.EmailMarketingConsent == nil || checkout.EmailMarketingConsent.State != "subscribed" {
+		t.Errorf("AbandonedCheckout.EmailMarketingConsent returned %+v", checkout.EmailMarketingConsent)
+	}
+	if len(checkout.LineItems) != 1 || checkout.LineItems[0].VariantId != 2 {
+		t.Errorf("AbandonedCheckout.LineItems returned %+v", checkout.LineItems)
+	}
+	if !checkout.IsRecoverable() {
+		t.Errorf("AbandonedCheckout.IsRecoverable returned false, expected true")
+	}
+}
+
+func TestRecoverableCheckouts(t *testing.T) {
+	now := time.Now()
+	url := "https://fooshop.myshopify.com/1/checkouts/abc/recover"
+	checkouts := []AbandonedCheckout{
+		{Id: 1, Email: "bob@example.com", AbandonedCheckoutUrl: url},
+		{Id: 2, Email: "bob@example.com", AbandonedCheckoutUrl: url, CompletedAt: &now},
+		{Id: 3, Email: "bob@example.com", AbandonedCheckoutUrl: url, ClosedAt: &now},
+		{Id: 4, AbandonedCheckoutUrl: url},
+		{Id: 5, Email: "bob@example.com"},
+		{Id: 6, Phone: "+15145551234", AbandonedCheckoutUrl: url},
+		{Id: 7, Email: "bob@example.com", AbandonedCheckoutUrl: url, Recovery: &CheckoutRecovery{RecoveredAt: &now}},
+	}
+
+	recoverable := RecoverableCheckouts(checkouts)
+	ids := []uint64{}
+	for _, checkout := range recoverable {
+		ids = append(ids, checkout.Id)
+	}
+
+	expected := []uint64{1, 6}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("RecoverableCheckouts returned %v, expected %v", ids, expected)
+	}
+}
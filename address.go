@@ -0,0 +1,107 @@
+package goshopify
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Normalize resolves the country and province of the address against
+// countries, e.g. the result of Country.List, and formats the phone number,
+// so creating orders and customers does not fail on malformed provinces.
+//
+// The country is matched on CountryCode or Country and the province on
+// ProvinceCode or Province, by code or name and ignoring case. Both codes
+// and names are then set to the values known by Shopify. A ValidationError
+// is returned when the country is unknown, or when the country has provinces
+// and the province is missing or unknown.
+//
+// Spaces and punctuation are removed from the phone number and an
+// international 00 prefix is replaced by +.
+func (a *Address) Normalize(countries []Country) error {
+	a.Phone = normalizePhone(a.Phone)
+
+	country := findCountry(countries, a.CountryCode, a.Country)
+	if country == nil {
+		return ValidationError{Field: "country", Message: "unknown country " + firstNonEmpty(a.CountryCode, a.Country)}
+	}
+	a.CountryCode = country.Code
+	a.Country = country.Name
+
+	if len(country.Provinces) == 0 {
+		return nil
+	}
+	province := findProvince(country.Provinces, a.ProvinceCode, a.Province)
+	if province == nil {
+		if a.ProvinceCode == "" && a.Province == "" {
+			return ValidationError{Field: "province", Message: "is required for " + country.Name}
+		}
+		return ValidationError{Field: "province", Message: "unknown province " + firstNonEmpty(a.ProvinceCode, a.Province) + " of " + country.Name}
+	}
+	a.ProvinceCode = province.Code
+	a.Province = province.Name
+	return nil
+}
+
+func findCountry(countries []Country, values ...string) *Country {
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		for i := range countries {
+			if strings.EqualFold(countries[i].Code, value) || strings.EqualFold(countries[i].Name, value) {
+				return &countries[i]
+			}
+		}
+	}
+	return nil
+}
+
+func findProvince(provinces []Province, values ...string) *Province {
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		for i := range provinces {
+			// codes are sometimes sent with the country prefix, e.g. CA-ON
+			code := provinces[i].Code
+			if strings.EqualFold(code, value) || strings.EqualFold(provinces[i].Name, value) ||
+				(strings.Contains(value, "-") && strings.EqualFold(code, value[strings.LastIndex(value, "-")+1:])) {
+				return &provinces[i]
+			}
+		}
+	}
+	return nil
+}
+
+// normalizePhone keeps the digits of a phone number and its leading +
+func normalizePhone(phone string) string {
+	phone = strings.TrimSpace(phone)
+	if phone == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	if strings.HasPrefix(phone, "+") {
+		b.WriteByte('+')
+	} else if strings.HasPrefix(phone, "00") {
+		b.WriteByte('+')
+		phone = phone[2:]
+	}
+	for _, r := range phone {
+		if unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,61 @@
+package goshopify
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var testCountries = []Country{
+	{Code: "CA", Name: "Canada", Provinces: []Province{{Code: "ON", Name: "Ontario"}, {Code: "QC", Name: "Quebec"}}},
+	{Code: "FR", Name: "France"},
+}
+
+func TestAddressNormalize(t *testing.T) {
+	cases := []struct {
+		address  Address
+		expected Address
+	}{
+		{
+			Address{Country: "canada", Province: "ontario", Phone: "(613) 555-0100"},
+			Address{Country: "Canada", CountryCode: "CA", Province: "Ontario", ProvinceCode: "ON", Phone: "6135550100"},
+		},
+		{
+			Address{CountryCode: "ca", ProvinceCode: "CA-QC"},
+			Address{Country: "Canada", CountryCode: "CA", Province: "Quebec", ProvinceCode: "QC"},
+		},
+		{
+			Address{Country: "FR", Phone: "0033 1 23 45 67 89"},
+			Address{Country: "France", CountryCode: "FR", Phone: "+33123456789"},
+		},
+	}
+
+	for _, c := range cases {
+		address := c.address
+		if err := address.Normalize(testCountries); err != nil {
+			t.Errorf("Address.Normalize(%+v) returned error: %v", c.address, err)
+		}
+		if !reflect.DeepEqual(address, c.expected) {
+			t.Errorf("Address.Normalize(%+v) returned %+v, expected %+v", c.address, address, c.expected)
+		}
+	}
+}
+
+func TestAddressNormalizeErrors(t *testing.T) {
+	cases := []struct {
+		address Address
+		field   string
+	}{
+		{Address{Country: "Atlantis"}, "country"},
+		{Address{CountryCode: "CA"}, "province"},
+		{Address{CountryCode: "CA", Province: "Texas"}, "province"},
+	}
+
+	for _, c := range cases {
+		err := c.address.Normalize(testCountries)
+		var validationError ValidationError
+		if !errors.As(err, &validationError) || validationError.Field != c.field {
+			t.Errorf("Address.Normalize(%+v) returned %v, expected a ValidationError on %s", c.address, err, c.field)
+		}
+	}
+}
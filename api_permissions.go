@@ -9,18 +9,20 @@ const apiPermissionsBasePath = "api_permissions"
 
 // ApiPermissionsService is an interface for interfacing with the API
 // permissions endpoints of the Shopify API.
-// See: https://help.shopify.com/api/reference/theme
+// See: https://shopify.dev/docs/api/admin-rest/latest/resources/accessscope
 type ApiPermissionsService interface {
 	Delete(context.Context) error
 }
 
-// ApiPermissionsServiceOp handles communication with the theme related methods of
-// the Shopify API.
+// ApiPermissionsServiceOp handles communication with the API permissions
+// related methods of the Shopify API.
 type ApiPermissionsServiceOp struct {
 	client *Client
 }
 
-// Uninstall an app.
+// Delete revokes the access token of the app, which uninstalls it from the
+// shop. The client can no longer be used afterwards and shopify sends the
+// app/uninstalled webhook.
 func (s *ApiPermissionsServiceOp) Delete(ctx context.Context) error {
 	path := fmt.Sprintf("%s/current.json", apiPermissionsBasePath)
 	return s.client.Delete(ctx, path)
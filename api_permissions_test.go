@@ -18,6 +18,6 @@ func TestApiPermissionsDelete(t *testing.T) {
 
 	err := client.ApiPermissions.Delete(context.Background())
 	if err != nil {
-		t.Errorf("Theme.Delete returned error: %v", err)
+		t.Errorf("ApiPermissions.Delete returned error: %v", err)
 	}
 }
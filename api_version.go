@@ -0,0 +1,101 @@
+package goshopify
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// apiVersionSupport is how long shopify supports a stable api version after
+// its release
+const apiVersionSupport = 12
+
+// apiVersionSunsetWarning is how long before its sunset NewClient warns that
+// the api version of the client will be unsupported
+const apiVersionSunsetWarning = 3
+
+// ApiVersion is a version of the admin api, e.g. "2024-01" or "unstable".
+// Shopify releases a stable version every quarter, in January, April, July and
+// October, and supports it for 12 months.
+type ApiVersion string
+
+// LatestStableVersion returns the most recent stable api version released
+func LatestStableVersion() ApiVersion {
+	return latestStableVersion(time.Now())
+}
+
+func latestStableVersion(now time.Time) ApiVersion {
+	now = now.UTC()
+	month := (int(now.Month())-1)/3*3 + 1
+	return ApiVersion(fmt.Sprintf("%04d-%02d", now.Year(), month))
+}
+
+// Valid reports whether the version is "unstable" or a stable version
+// formatted as YYYY-MM with the month of a quarterly release, e.g. "2024-04"
+// but not "2024-05" or "2024-13"
+func (v ApiVersion) Valid() bool {
+	if v == UnstableApiVersion {
+		return true
+	}
+	_, ok := v.Release()
+	return ok
+}
+
+// Release returns the release date of a stable version, ok is false for
+// unstable or versions shopify never releases
+func (v ApiVersion) Release() (release time.Time, ok bool) {
+	if !apiVersionRegex.MatchString(string(v)) {
+		return time.Time{}, false
+	}
+	year, _ := strconv.Atoi(string(v[:4]))
+	month, _ := strconv.Atoi(string(v[5:]))
+	if month < 1 || month > 12 || (month-1)%3 != 0 {
+		return time.Time{}, false
+	}
+	return time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC), true
+}
+
+// Sunset returns the date shopify stops supporting a stable version, ok is
+// false for unstable or versions shopify never releases
+func (v ApiVersion) Sunset() (sunset time.Time, ok bool) {
+	release, ok := v.Release()
+	if !ok {
+		return time.Time{}, false
+	}
+	return release.AddDate(0, apiVersionSupport, 0), true
+}
+
+// IsSupported reports whether the version is unstable or a released stable
+// version which is still supported
+func (v ApiVersion) IsSupported() bool {
+	return v.isSupported(time.Now())
+}
+
+func (v ApiVersion) isSupported(now time.Time) bool {
+	if v == UnstableApiVersion {
+		return true
+	}
+	release, ok := v.Release()
+	if !ok {
+		return false
+	}
+	sunset, _ := v.Sunset()
+	return !now.Before(release) && now.Before(sunset)
+}
+
+// warnApiVersionSunset warns when the stable api version of the client is
+// unsupported or will be within a quarter
+func (c *Client) warnApiVersionSunset(now time.Time) {
+	sunset, ok := ApiVersion(c.apiVersion).Sunset()
+	if !ok {
+		return
+	}
+	switch {
+	case !now.Before(sunset):
+		c.log.Warnf("api version %s is no longer supported since %s, use %s", c.apiVersion,
+			sunset.Format("2006-01-02"), latestStableVersion(now))
+	case now.After(sunset.AddDate(0, -apiVersionSunsetWarning, 0)):
+		c.log.Warnf("api version %s is unsupported from %s, use %s", c.apiVersion,
+			sunset.Format("2006-01-02"), latestStableVersion(now))
+	}
+}
@@ -0,0 +1,88 @@
+package goshopify
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestLatestStableVersion(t *testing.T) {
+	cases := []struct {
+		now      time.Time
+		expected ApiVersion
+	}{
+		{time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), "2024-01"},
+		{time.Date(2024, time.March, 31, 23, 0, 0, 0, time.UTC), "2024-01"},
+		{time.Date(2024, time.August, 15, 0, 0, 0, 0, time.UTC), "2024-07"},
+		{time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC), "2024-10"},
+	}
+
+	for _, c := range cases {
+		if version := latestStableVersion(c.now); version != c.expected {
+			t.Errorf("latestStableVersion(%s) returned %s, expected %s", c.now, version, c.expected)
+		}
+	}
+}
+
+func TestApiVersionIsSupported(t *testing.T) {
+	now := time.Date(2024, time.May, 10, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		version  ApiVersion
+		valid    bool
+		expected bool
+	}{
+		{"unstable", true, true},
+		{"2024-04", true, true},
+		{"2023-07", true, true},
+		{"2023-04", true, false},
+		{"2024-07", true, false},
+		{"2024-02", false, false},
+		{"2024-13", false, false},
+		{"9999-99", false, false},
+		{"9999-01", true, false},
+		{"2024-1", false, false},
+		{"latest", false, false},
+	}
+
+	for _, c := range cases {
+		if valid := c.version.Valid(); valid != c.valid {
+			t.Errorf("ApiVersion(%s).Valid returned %t, expected %t", c.version, valid, c.valid)
+		}
+		if supported := c.version.isSupported(now); supported != c.expected {
+			t.Errorf("ApiVersion(%s).isSupported returned %t, expected %t", c.version, supported, c.expected)
+		}
+	}
+}
+
+func TestApiVersionSunset(t *testing.T) {
+	sunset, ok := ApiVersion("2024-01").Sunset()
+	expected := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if !ok || !sunset.Equal(expected) {
+		t.Errorf("ApiVersion.Sunset returned %s, %t, expected %s", sunset, ok, expected)
+	}
+	if _, ok := ApiVersion(UnstableApiVersion).Sunset(); ok {
+		t.Error("ApiVersion.Sunset of unstable returned ok")
+	}
+}
+
+func TestWarnApiVersionSunset(t *testing.T) {
+	cases := []struct {
+		now      time.Time
+		expected string
+	}{
+		{time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC), ""},
+		{time.Date(2024, time.November, 1, 0, 0, 0, 0, time.UTC), "[WARN] api version 2024-01 is unsupported from 2025-01-01, use 2024-10\n"},
+		{time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC), "[WARN] api version 2024-01 is no longer supported since 2025-01-01, use 2025-01\n"},
+	}
+
+	for _, c := range cases {
+		out := &bytes.Buffer{}
+		client := MustNewClient(app, "fooshop", "abcd", WithVersion("2024-01"),
+			WithLogger(&LeveledLogger{Level: LevelWarn, stderrOverride: out}))
+		out.Reset()
+		client.warnApiVersionSunset(c.now)
+		if out.String() != c.expected {
+			t.Errorf("warnApiVersionSunset(%s) logged %q, expected %q", c.now, out.String(), c.expected)
+		}
+	}
+}
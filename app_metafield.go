@@ -0,0 +1,229 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// appNamespacePrefix is the reserved prefix of the namespaces owned by the
+// app making the request
+const appNamespacePrefix = "$app"
+
+const appMetafieldQuery = `query appMetafield($ownerId: ID!, $namespace: String!, $key: String!) {
+  node(id: $ownerId) {
+    ... on HasMetafields {
+      metafield(namespace: $namespace, key: $key) {
+        id
+        namespace
+        key
+        type
+        value
+      }
+    }
+  }
+}`
+
+const appMetafieldsQuery = `query appMetafields($ownerId: ID!, $namespace: String!, $after: String) {
+  node(id: $ownerId) {
+    ... on HasMetafields {
+      metafields(first: 250, namespace: $namespace, after: $after) {
+        nodes {
+          id
+          namespace
+          key
+          type
+          value
+        }
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+      }
+    }
+  }
+}`
+
+const metafieldsDeleteMutation = `mutation metafieldsDelete($metafields: [MetafieldIdentifierInput!]!) {
+  metafieldsDelete(metafields: $metafields) {
+    deletedMetafields {
+      key
+    }
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+const currentAppInstallationQuery = `query currentAppInstallation {
+  currentAppInstallation {
+    id
+  }
+}`
+
+// AppNamespace returns the reserved namespace owned by the app, e.g.
+// AppNamespace("settings") returns "$app:settings" and AppNamespace("")
+// returns "$app". Shopify resolves it to the namespace of the app making the
+// request, so only the app can read and write its metafields.
+func AppNamespace(name string) string {
+	if name == "" {
+		return appNamespacePrefix
+	}
+	return appNamespacePrefix + ":" + name
+}
+
+// IsAppNamespace reports whether namespace is owned by the app
+func IsAppNamespace(namespace string) bool {
+	return namespace == appNamespacePrefix || strings.HasPrefix(namespace, appNamespacePrefix+":")
+}
+
+// AppMetafieldService is an interface for reading and writing metafields in
+// the namespaces owned by the app, which the REST metafield endpoints cannot
+// target. Owners are identified by graphql ids, app-data metafields belong
+// to the app installation, see CurrentAppInstallationId.
+// See: https://shopify.dev/docs/apps/build/custom-data/ownership
+type AppMetafieldService interface {
+	Get(context.Context, string, string, string) (*GraphQLMetafield, error)
+	List(context.Context, string, string) ([]GraphQLMetafield, error)
+	Set(context.Context, []AppMetafield) ([]GraphQLMetafield, error)
+	Delete(context.Context, string, string, string) error
+	CurrentAppInstallationId(context.Context) (string, error)
+}
+
+// AppMetafieldServiceOp handles communication with the app owned metafields
+// of the Shopify API.
+type AppMetafieldServiceOp struct {
+	client *Client
+}
+
+// AppMetafield represents a metafield to set in an app owned namespace
+type AppMetafield struct {
+	OwnerId   string
+	Namespace string
+	Key       string
+	Type      MetafieldType
+	Value     string
+}
+
+func validateAppNamespace(field, namespace string) error {
+	if !IsAppNamespace(namespace) {
+		return ValidationError{Field: field, Message: fmt.Sprintf("%q is not an app namespace, see AppNamespace", namespace)}
+	}
+	return nil
+}
+
+// Get returns a metafield of an owner, nil when it is not set
+func (s *AppMetafieldServiceOp) Get(ctx context.Context, ownerId, namespace, key string) (*GraphQLMetafield, error) {
+	if err := validateAppNamespace("namespace", namespace); err != nil {
+		return nil, err
+	}
+	vars := map[string]interface{}{"ownerId": ownerId, "namespace": namespace, "key": key}
+	resp := struct {
+		Node *struct {
+			Metafield *GraphQLMetafield `json:"metafield"`
+		} `json:"node"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, appMetafieldQuery, vars, &resp)
+	if err != nil || resp.Node == nil {
+		return nil, err
+	}
+	return resp.Node.Metafield, nil
+}
+
+// List returns the metafields of an owner in a namespace
+func (s *AppMetafieldServiceOp) List(ctx context.Context, ownerId, namespace string) ([]GraphQLMetafield, error) {
+	if err := validateAppNamespace("namespace", namespace); err != nil {
+		return nil, err
+	}
+	metafields := []GraphQLMetafield{}
+	vars := map[string]interface{}{"ownerId": ownerId, "namespace": namespace}
+
+	for {
+		resp := struct {
+			Node *struct {
+				Metafields struct {
+					Nodes    []GraphQLMetafield `json:"nodes"`
+					PageInfo GraphQLPageInfo    `json:"pageInfo"`
+				} `json:"metafields"`
+			} `json:"node"`
+		}{}
+		err := s.client.GraphQL.Query(ctx, appMetafieldsQuery, vars, &resp)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Node == nil {
+			return metafields, nil
+		}
+		metafields = append(metafields, resp.Node.Metafields.Nodes...)
+
+		if !resp.Node.Metafields.PageInfo.HasNextPage {
+			return metafields, nil
+		}
+		vars["after"] = resp.Node.Metafields.PageInfo.EndCursor
+	}
+}
+
+// Set creates or updates metafields, up to 25 per call
+func (s *AppMetafieldServiceOp) Set(ctx context.Context, metafields []AppMetafield) ([]GraphQLMetafield, error) {
+	inputs := []metafieldInput{}
+	for i, metafield := range metafields {
+		if err := validateAppNamespace(fmt.Sprintf("metafields[%d].namespace", i), metafield.Namespace); err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, metafieldInput{
+			OwnerId:   metafield.OwnerId,
+			Namespace: metafield.Namespace,
+			Key:       metafield.Key,
+			Type:      string(metafield.Type),
+			Value:     metafield.Value,
+		})
+	}
+	resp := struct {
+		MetafieldsSet struct {
+			Metafields []GraphQLMetafield `json:"metafields"`
+			UserErrors []GraphQLUserError `json:"userErrors"`
+		} `json:"metafieldsSet"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, metafieldsSetMutation, map[string]interface{}{"metafields": inputs}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := userErrorsToResponseError(resp.MetafieldsSet.UserErrors); err != nil {
+		return nil, err
+	}
+	return resp.MetafieldsSet.Metafields, nil
+}
+
+// Delete removes a metafield of an owner
+func (s *AppMetafieldServiceOp) Delete(ctx context.Context, ownerId, namespace, key string) error {
+	if err := validateAppNamespace("namespace", namespace); err != nil {
+		return err
+	}
+	identifier := map[string]interface{}{"ownerId": ownerId, "namespace": namespace, "key": key}
+	resp := struct {
+		MetafieldsDelete struct {
+			UserErrors []GraphQLUserError `json:"userErrors"`
+		} `json:"metafieldsDelete"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, metafieldsDeleteMutation, map[string]interface{}{"metafields": []interface{}{identifier}}, &resp)
+	if err != nil {
+		return err
+	}
+	return userErrorsToResponseError(resp.MetafieldsDelete.UserErrors)
+}
+
+// CurrentAppInstallationId returns the graphql id of the installation of the
+// app on the shop, the owner of app-data metafields
+func (s *AppMetafieldServiceOp) CurrentAppInstallationId(ctx context.Context) (string, error) {
+	resp := struct {
+		CurrentAppInstallation struct {
+			Id string `json:"id"`
+		} `json:"currentAppInstallation"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, currentAppInstallationQuery, nil, &resp)
+	return resp.CurrentAppInstallation.Id, err
+}
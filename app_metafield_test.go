@@ -0,0 +1,66 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestAppNamespace(t *testing.T) {
+	if ns := AppNamespace("settings"); ns != "$app:settings" || !IsAppNamespace(ns) {
+		t.Errorf("AppNamespace returned %s", ns)
+	}
+	if ns := AppNamespace(""); ns != "$app" || !IsAppNamespace(ns) {
+		t.Errorf("AppNamespace returned %s", ns)
+	}
+	if IsAppNamespace("$application") || IsAppNamespace("custom") {
+		t.Errorf("IsAppNamespace accepted a namespace not owned by the app")
+	}
+}
+
+func TestAppMetafieldSet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	captured := graphQLTestRequest{}
+	registerGraphQLResponder(`{"data":{"metafieldsSet":{"metafields":[{"id":"gid://shopify/Metafield/1","namespace":"app--1--settings","key":"plan","type":"single_line_text_field","value":"pro"}],"userErrors":[]}}}`, &captured)
+
+	metafields, err := client.AppMetafield.Set(context.Background(), []AppMetafield{
+		{OwnerId: "gid://shopify/AppInstallation/2", Namespace: AppNamespace("settings"), Key: "plan", Type: MetafieldTypeSingleLineTextField, Value: "pro"},
+	})
+	if err != nil {
+		t.Fatalf("AppMetafield.Set returned error: %v", err)
+	}
+	if len(metafields) != 1 || metafields[0].Value != "pro" {
+		t.Errorf("AppMetafield.Set returned %+v", metafields)
+	}
+
+	expected := []interface{}{map[string]interface{}{
+		"ownerId": "gid://shopify/AppInstallation/2", "namespace": "$app:settings", "key": "plan", "type": "single_line_text_field", "value": "pro",
+	}}
+	if !reflect.DeepEqual(captured.Variables["metafields"], expected) {
+		t.Errorf("AppMetafield.Set sent %+v, expected %+v", captured.Variables["metafields"], expected)
+	}
+
+	_, err = client.AppMetafield.Set(context.Background(), []AppMetafield{{Namespace: "custom", Key: "plan"}})
+	var validationError ValidationError
+	if !errors.As(err, &validationError) || validationError.Field != "metafields[0].namespace" {
+		t.Errorf("AppMetafield.Set returned %v, expected a namespace ValidationError", err)
+	}
+}
+
+func TestAppMetafieldGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerGraphQLResponder(`{"data":{"node":{"metafield":{"id":"gid://shopify/Metafield/1","namespace":"app--1","key":"plan","type":"single_line_text_field","value":"pro"}}}}`, nil)
+
+	metafield, err := client.AppMetafield.Get(context.Background(), "gid://shopify/Product/1", AppNamespace(""), "plan")
+	if err != nil {
+		t.Fatalf("AppMetafield.Get returned error: %v", err)
+	}
+	if metafield == nil || metafield.Value != "pro" {
+		t.Errorf("AppMetafield.Get returned %+v", metafield)
+	}
+}
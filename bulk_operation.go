@@ -0,0 +1,309 @@
+package goshopify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"time"
+)
+
+const stagedUploadsCreateMutation = `mutation stagedUploadsCreate($input: [StagedUploadInput!]!) {
+  stagedUploadsCreate(input: $input) {
+    stagedTargets {
+      url
+      resourceUrl
+      parameters {
+        name
+        value
+      }
+    }
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+const bulkOperationFields = `
+      id
+      status
+      errorCode
+      objectCount
+      url
+      partialDataUrl
+      createdAt
+      completedAt`
+
+const bulkOperationRunMutationMutation = `mutation bulkOperationRunMutation($mutation: String!, $stagedUploadPath: String!) {
+  bulkOperationRunMutation(mutation: $mutation, stagedUploadPath: $stagedUploadPath) {
+    bulkOperation {` + bulkOperationFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const bulkOperationQuery = `query bulkOperation($id: ID!) {
+  node(id: $id) {
+    ... on BulkOperation {` + bulkOperationFields + `
+    }
+  }
+}`
+
+// Statuses of a bulk operation
+const (
+	BulkOperationStatusCreated   = "CREATED"
+	BulkOperationStatusRunning   = "RUNNING"
+	BulkOperationStatusCompleted = "COMPLETED"
+	BulkOperationStatusCanceling = "CANCELING"
+	BulkOperationStatusCanceled  = "CANCELED"
+	BulkOperationStatusFailed    = "FAILED"
+	BulkOperationStatusExpired   = "EXPIRED"
+)
+
+// maxBulkMutationVariablesSize is the largest JSONL file accepted by
+// bulkOperationRunMutation
+const maxBulkMutationVariablesSize = 20 * 1024 * 1024
+
+// defaultBulkOperationPollInterval is the delay between two polls of Wait
+const defaultBulkOperationPollInterval = 5 * time.Second
+
+// BulkOperationService is an interface for running graphql bulk operations.
+// See: https://shopify.dev/docs/api/usage/bulk-operations/imports
+type BulkOperationService interface {
+	NewMutation(string) *BulkMutationWriter
+	RunMutation(context.Context, string, io.Reader) (*BulkOperation, error)
+	Get(context.Context, string) (*BulkOperation, error)
+	Wait(context.Context, string, time.Duration, BulkProgressFunc) (*BulkOperation, error)
+}
+
+// BulkOperationServiceOp handles communication with the bulk operation
+// related methods of the Shopify API.
+type BulkOperationServiceOp struct {
+	client *Client
+}
+
+// BulkOperation represents a graphql bulk operation, Url is the JSONL file of
+// the results once it is completed
+type BulkOperation struct {
+	Id             string     `json:"id"`
+	Status         string     `json:"status"`
+	ErrorCode      string     `json:"errorCode"`
+	ObjectCount    uint64     `json:"objectCount,string"`
+	Url            string     `json:"url"`
+	PartialDataUrl string     `json:"partialDataUrl"`
+	CreatedAt      *time.Time `json:"createdAt"`
+	CompletedAt    *time.Time `json:"completedAt"`
+}
+
+// Done reports whether the bulk operation stopped running
+func (o BulkOperation) Done() bool {
+	switch o.Status {
+	case BulkOperationStatusCompleted, BulkOperationStatusCanceled, BulkOperationStatusFailed, BulkOperationStatusExpired:
+		return true
+	}
+	return false
+}
+
+// BulkMutationWriter accumulates the variables of a bulk mutation as JSONL,
+// e.g. to run 50k metafieldsSet mutations in a single job:
+//
+//	w := client.BulkOperation.NewMutation(mutation)
+//	for _, metafield := range metafields {
+//		w.Add(map[string]interface{}{"metafields": []interface{}{metafield}})
+//	}
+//	op, err := w.Run(ctx)
+//	op, err = client.BulkOperation.Wait(ctx, op.Id, 0, progress)
+type BulkMutationWriter struct {
+	service  *BulkOperationServiceOp
+	mutation string
+	buf      bytes.Buffer
+	count    int
+}
+
+// NewMutation returns a writer for the variables of a bulk mutation
+func (s *BulkOperationServiceOp) NewMutation(mutation string) *BulkMutationWriter {
+	return &BulkMutationWriter{service: s, mutation: mutation}
+}
+
+// Add appends the variables of one mutation
+func (w *BulkMutationWriter) Add(vars interface{}) error {
+	line, err := json.Marshal(vars)
+	if err != nil {
+		return err
+	}
+	if w.buf.Len()+len(line)+1 > maxBulkMutationVariablesSize {
+		return ValidationError{Field: "variables", Message: fmt.Sprintf("bulk mutation variables exceed %d bytes", maxBulkMutationVariablesSize)}
+	}
+	w.buf.Write(line)
+	w.buf.WriteByte('\n')
+	w.count++
+	return nil
+}
+
+// Len returns the number of mutations added
+func (w *BulkMutationWriter) Len() int {
+	return w.count
+}
+
+// Run uploads the variables and starts the bulk mutation
+func (w *BulkMutationWriter) Run(ctx context.Context) (*BulkOperation, error) {
+	if w.count == 0 {
+		return nil, ValidationError{Field: "variables", Message: "no mutation was added"}
+	}
+	return w.service.RunMutation(ctx, w.mutation, bytes.NewReader(w.buf.Bytes()))
+}
+
+// stagedTarget is where a staged upload is sent
+type stagedTarget struct {
+	Url         string `json:"url"`
+	ResourceUrl string `json:"resourceUrl"`
+	Parameters  []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"parameters"`
+}
+
+// RunMutation uploads a JSONL file of variables, one line per mutation, and
+// starts a bulk mutation running mutation with each line
+func (s *BulkOperationServiceOp) RunMutation(ctx context.Context, mutation string, variables io.Reader) (*BulkOperation, error) {
+	target, err := s.stageUpload(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stagedUploadPath, err := s.upload(ctx, target, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	vars := map[string]interface{}{"mutation": mutation, "stagedUploadPath": stagedUploadPath}
+	resp := struct {
+		BulkOperationRunMutation struct {
+			BulkOperation *BulkOperation     `json:"bulkOperation"`
+			UserErrors    []GraphQLUserError `json:"userErrors"`
+		} `json:"bulkOperationRunMutation"`
+	}{}
+	err = s.client.GraphQL.Query(ctx, bulkOperationRunMutationMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := userErrorsToResponseError(resp.BulkOperationRunMutation.UserErrors); err != nil {
+		return nil, err
+	}
+	return resp.BulkOperationRunMutation.BulkOperation, nil
+}
+
+func (s *BulkOperationServiceOp) stageUpload(ctx context.Context) (*stagedTarget, error) {
+	input := []map[string]interface{}{{
+		"resource":   "BULK_MUTATION_VARIABLES",
+		"filename":   "bulk_op_vars",
+		"mimeType":   "text/jsonl",
+		"httpMethod": "POST",
+	}}
+	resp := struct {
+		StagedUploadsCreate struct {
+			StagedTargets []stagedTarget     `json:"stagedTargets"`
+			UserErrors    []GraphQLUserError `json:"userErrors"`
+		} `json:"stagedUploadsCreate"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, stagedUploadsCreateMutation, map[string]interface{}{"input": input}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := userErrorsToResponseError(resp.StagedUploadsCreate.UserErrors); err != nil {
+		return nil, err
+	}
+	if len(resp.StagedUploadsCreate.StagedTargets) == 0 {
+		return nil, fmt.Errorf("stagedUploadsCreate returned no target")
+	}
+	return &resp.StagedUploadsCreate.StagedTargets[0], nil
+}
+
+// upload sends the variables to the staged target as a multipart form and
+// returns the staged upload path, the key parameter of the target
+func (s *BulkOperationServiceOp) upload(ctx context.Context, target *stagedTarget, variables io.Reader) (string, error) {
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	var key string
+	for _, parameter := range target.Parameters {
+		if parameter.Name == "key" {
+			key = parameter.Value
+		}
+		if err := form.WriteField(parameter.Name, parameter.Value); err != nil {
+			return "", err
+		}
+	}
+	file, err := form.CreateFormFile("file", "bulk_op_vars")
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(file, variables); err != nil {
+		return "", err
+	}
+	if err := form.Close(); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.Url, body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	resp, err := s.client.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		message, _ := io.ReadAll(resp.Body)
+		return "", ResponseError{Status: resp.StatusCode, Message: fmt.Sprintf("staged upload failed: %s", message)}
+	}
+	return key, nil
+}
+
+// Get returns a bulk operation by graphql id
+func (s *BulkOperationServiceOp) Get(ctx context.Context, id string) (*BulkOperation, error) {
+	resp := struct {
+		Node *BulkOperation `json:"node"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, bulkOperationQuery, map[string]interface{}{"id": id}, &resp)
+	return resp.Node, err
+}
+
+// Wait polls a bulk operation every interval, 5 seconds when zero, until it
+// stops running and returns it. progress is optional and called after each
+// poll with the number of objects processed, total is 0 as Shopify does not
+// report it.
+func (s *BulkOperationServiceOp) Wait(ctx context.Context, id string, interval time.Duration, progress BulkProgressFunc) (*BulkOperation, error) {
+	if interval <= 0 {
+		interval = defaultBulkOperationPollInterval
+	}
+	for {
+		operation, err := s.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if operation == nil {
+			return nil, ResponseError{Status: http.StatusNotFound, Message: fmt.Sprintf("bulk operation %s not found", id)}
+		}
+		if progress != nil {
+			progress(int(operation.ObjectCount), 0)
+		}
+		if operation.Done() {
+			return operation, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return operation, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
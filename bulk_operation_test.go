@@ -0,0 +1,120 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestBulkMutationWriterRun(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var uploaded, stagedUploadPath string
+	httpmock.RegisterResponder("POST", "https://shopify-staged-uploads.storage.googleapis.com/",
+		func(req *http.Request) (*http.Response, error) {
+			if err := req.ParseMultipartForm(1 << 20); err != nil {
+				return nil, err
+			}
+			if req.FormValue("key") != "tmp/1/bulk/vars.jsonl" || req.FormValue("policy") != "abc" {
+				t.Errorf("staged upload sent form %v", req.MultipartForm.Value)
+			}
+			file, _, err := req.FormFile("file")
+			if err != nil {
+				return nil, err
+			}
+			content, _ := io.ReadAll(file)
+			uploaded = string(content)
+			return httpmock.NewStringResponse(201, ""), nil
+		})
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			captured := graphQLTestRequest{}
+			if err := json.NewDecoder(req.Body).Decode(&captured); err != nil {
+				return nil, err
+			}
+			if strings.HasPrefix(captured.Query, "mutation stagedUploadsCreate") {
+				return httpmock.NewStringResponse(200, `{"data":{"stagedUploadsCreate":{"stagedTargets":[{"url":"https://shopify-staged-uploads.storage.googleapis.com/","parameters":[{"name":"key","value":"tmp/1/bulk/vars.jsonl"},{"name":"policy","value":"abc"}]}],"userErrors":[]}}}`), nil
+			}
+			stagedUploadPath, _ = captured.Variables["stagedUploadPath"].(string)
+			return httpmock.NewStringResponse(200, `{"data":{"bulkOperationRunMutation":{"bulkOperation":{"id":"gid://shopify/BulkOperation/1","status":"CREATED","objectCount":"0"},"userErrors":[]}}}`), nil
+		})
+
+	w := client.BulkOperation.NewMutation(metafieldsSetMutation)
+	for i := 1; i <= 2; i++ {
+		if err := w.Add(map[string]interface{}{"metafields": []metafieldInput{{OwnerId: fmt.Sprintf("gid://shopify/Product/%d", i), Namespace: "custom", Key: "rank", Type: "number_integer", Value: "1"}}}); err != nil {
+			t.Fatalf("BulkMutationWriter.Add returned error: %v", err)
+		}
+	}
+	if w.Len() != 2 {
+		t.Errorf("BulkMutationWriter.Len returned %d, expected 2", w.Len())
+	}
+
+	operation, err := w.Run(context.Background())
+	if err != nil {
+		t.Fatalf("BulkMutationWriter.Run returned error: %v", err)
+	}
+	if operation.Id != "gid://shopify/BulkOperation/1" || operation.Status != BulkOperationStatusCreated {
+		t.Errorf("BulkMutationWriter.Run returned %+v", operation)
+	}
+	if stagedUploadPath != "tmp/1/bulk/vars.jsonl" {
+		t.Errorf("bulkOperationRunMutation got staged upload path %q", stagedUploadPath)
+	}
+
+	expected := `{"metafields":[{"ownerId":"gid://shopify/Product/1","namespace":"custom","key":"rank","type":"number_integer","value":"1"}]}` + "\n" +
+		`{"metafields":[{"ownerId":"gid://shopify/Product/2","namespace":"custom","key":"rank","type":"number_integer","value":"1"}]}` + "\n"
+	if uploaded != expected {
+		t.Errorf("staged upload sent %q, expected %q", uploaded, expected)
+	}
+}
+
+func TestBulkMutationWriterRunEmpty(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.BulkOperation.NewMutation(metafieldsSetMutation).Run(context.Background())
+	var validationError ValidationError
+	if !errors.As(err, &validationError) {
+		t.Errorf("BulkMutationWriter.Run returned %v, expected a ValidationError", err)
+	}
+}
+
+func TestBulkOperationWait(t *testing.T) {
+	setup()
+	defer teardown()
+
+	responses := []string{
+		`{"data":{"node":{"id":"gid://shopify/BulkOperation/1","status":"RUNNING","objectCount":"10"}}}`,
+		`{"data":{"node":{"id":"gid://shopify/BulkOperation/1","status":"COMPLETED","objectCount":"20","url":"https://storage.example.com/result.jsonl"}}}`,
+	}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			body := responses[0]
+			if len(responses) > 1 {
+				responses = responses[1:]
+			}
+			return httpmock.NewStringResponse(200, body), nil
+		})
+
+	var progress []int
+	operation, err := client.BulkOperation.Wait(context.Background(), "gid://shopify/BulkOperation/1", 1, func(done, total int) {
+		progress = append(progress, done)
+	})
+	if err != nil {
+		t.Fatalf("BulkOperation.Wait returned error: %v", err)
+	}
+	if !operation.Done() || operation.ObjectCount != 20 || operation.Url != "https://storage.example.com/result.jsonl" {
+		t.Errorf("BulkOperation.Wait returned %+v", operation)
+	}
+	if len(progress) != 2 || progress[0] != 10 || progress[1] != 20 {
+		t.Errorf("BulkOperation.Wait reported progress %v", progress)
+	}
+}
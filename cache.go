@@ -0,0 +1,115 @@
+package goshopify
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CacheStore is used by the client to keep state that can be shared between
+// clients and processes, e.g. cached shop settings or the buckets of a
+// LeakyBucket. Implementations must be safe for concurrent use, see the
+// redisstore package for a store backed by redis.
+type CacheStore interface {
+	// Get returns the value stored for key and whether it was found
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set stores value for key, a ttl <= 0 means the value never expires
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes key, deleting a missing key is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// AtomicCacheStore is a CacheStore which sets a key only when it is absent in
+// a single operation, e.g. to claim a webhook delivery among the processes of
+// an app
+type AtomicCacheStore interface {
+	CacheStore
+
+	// SetNX stores value for key unless key is already set and reports
+	// whether it stored it, a ttl <= 0 means the value never expires
+	SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
+}
+
+// MemoryCacheStore is an in-process CacheStore, it is the default store of a
+// Client
+type MemoryCacheStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryCacheEntry
+
+	// used to override time.Now in tests
+	now func() time.Time
+}
+
+type memoryCacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemoryCacheStore returns an empty in-memory CacheStore
+func NewMemoryCacheStore() *MemoryCacheStore {
+	return &MemoryCacheStore{
+		entries: make(map[string]memoryCacheEntry),
+		now:     time.Now,
+	}
+}
+
+// Get returns the value stored for key if it has not expired
+func (s *MemoryCacheStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if !entry.expiresAt.IsZero() && !s.now().Before(entry.expiresAt) {
+		delete(s.entries, key)
+		return nil, false, nil
+	}
+
+	value := make([]byte, len(entry.value))
+	copy(value, entry.value)
+	return value, true, nil
+}
+
+// Set stores value for key, a ttl <= 0 means the value never expires
+func (s *MemoryCacheStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	entry := memoryCacheEntry{value: make([]byte, len(value))}
+	copy(entry.value, value)
+	if ttl > 0 {
+		entry.expiresAt = s.now().Add(ttl)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = entry
+	return nil
+}
+
+// SetNX stores value for key unless key is set and has not expired
+func (s *MemoryCacheStore) SetNX(_ context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	entry := memoryCacheEntry{value: make([]byte, len(value))}
+	copy(entry.value, value)
+	now := s.now()
+	if ttl > 0 {
+		entry.expiresAt = now.Add(ttl)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if current, ok := s.entries[key]; ok && (current.expiresAt.IsZero() || now.Before(current.expiresAt)) {
+		return false, nil
+	}
+	s.entries[key] = entry
+	return true, nil
+}
+
+// Delete removes key from the store
+func (s *MemoryCacheStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
@@ -0,0 +1,61 @@
+package goshopify
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryCacheStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryCacheStore()
+	store.now = func() time.Time { return now }
+
+	if _, found, _ := store.Get(ctx, "missing"); found {
+		t.Error("MemoryCacheStore.Get found a missing key")
+	}
+
+	_ = store.Set(ctx, "forever", []byte("a"), 0)
+	_ = store.Set(ctx, "short", []byte("b"), time.Minute)
+
+	value, found, err := store.Get(ctx, "short")
+	if err != nil || !found || string(value) != "b" {
+		t.Errorf("MemoryCacheStore.Get returned %s, %v, %v, expected b, true, nil", value, found, err)
+	}
+
+	now = now.Add(time.Minute)
+	if _, found, _ := store.Get(ctx, "short"); found {
+		t.Error("MemoryCacheStore.Get found an expired key")
+	}
+	if value, found, _ := store.Get(ctx, "forever"); !found || string(value) != "a" {
+		t.Errorf("MemoryCacheStore.Get returned %s, %v, expected a, true", value, found)
+	}
+
+	_ = store.Delete(ctx, "forever")
+	if _, found, _ := store.Get(ctx, "forever"); found {
+		t.Error("MemoryCacheStore.Get found a deleted key")
+	}
+}
+
+func TestMemoryCacheStoreSetNX(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryCacheStore()
+	store.now = func() time.Time { return now }
+
+	if stored, err := store.SetNX(ctx, "claim", []byte("a"), time.Minute); err != nil || !stored {
+		t.Errorf("MemoryCacheStore.SetNX returned %v, %v for a missing key, expected true, nil", stored, err)
+	}
+	if stored, _ := store.SetNX(ctx, "claim", []byte("b"), time.Minute); stored {
+		t.Error("MemoryCacheStore.SetNX stored a key already set")
+	}
+
+	now = now.Add(time.Minute)
+	if stored, _ := store.SetNX(ctx, "claim", []byte("c"), time.Minute); !stored {
+		t.Error("MemoryCacheStore.SetNX did not store an expired key")
+	}
+	if value, _, _ := store.Get(ctx, "claim"); string(value) != "c" {
+		t.Errorf("MemoryCacheStore.Get returned %s, expected c", value)
+	}
+}
@@ -0,0 +1,58 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestCancellationRequestServiceOp(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cases := []struct {
+		description string
+		path        string
+		call        func(context.Context, uint64, CancellationRequest) (*FulfillmentOrder, error)
+	}{
+		{"send", "cancellation_request.json", client.CancellationRequest.Send},
+		{"accept", "cancellation_request/accept.json", client.CancellationRequest.Accept},
+		{"reject", "cancellation_request/reject.json", client.CancellationRequest.Reject},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			var body map[string]map[string]string
+			httpmock.RegisterResponder(
+				http.MethodPost,
+				fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillment_orders/1046000823/%s", client.pathPrefix, c.path),
+				func(req *http.Request) (*http.Response, error) {
+					if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+						return nil, err
+					}
+					return httpmock.NewStringResponse(200, `{"fulfillment_order":{"id":1046000823,"status":"in_progress","request_status":"cancellation_requested"}}`), nil
+				},
+			)
+
+			result, err := c.call(context.Background(), 1046000823, CancellationRequest{Message: "The customer changed their mind."})
+			if err != nil {
+				t.Fatalf("CancellationRequest.%s returned error: %v", c.description, err)
+			}
+
+			expectedBody := map[string]map[string]string{"cancellation_request": {"message": "The customer changed their mind."}}
+			if !reflect.DeepEqual(body, expectedBody) {
+				t.Errorf("CancellationRequest.%s sent %+v, expected %+v", c.description, body, expectedBody)
+			}
+
+			expected := &FulfillmentOrder{Id: 1046000823, Status: "in_progress", RequestStatus: "cancellation_requested"}
+			if !reflect.DeepEqual(result, expected) {
+				t.Errorf("CancellationRequest.%s returned %+v, expected %+v", c.description, result, expected)
+			}
+		})
+	}
+}
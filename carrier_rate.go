@@ -0,0 +1,151 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ShippingRateBuilder assembles the response of a carrier service to a rate
+// request and validates it, e.g.
+//
+//	response, err := goshopify.NewShippingRateBuilder(query.Currency).
+//		AddRate("express", "Express", "Tracked", decimal.RequireFromString("12.50")).
+//		SetDeliveryDates(tomorrow, inTwoDays).
+//		SetPhoneRequired().
+//		Build()
+type ShippingRateBuilder struct {
+	currency string
+	rates    []ShippingRate
+
+	// error of a call which could not be applied, returned by Build
+	err error
+}
+
+// NewShippingRateBuilder returns a ShippingRateBuilder of rates in currency,
+// usually the currency of the ShippingRateQuery
+func NewShippingRateBuilder(currency string) *ShippingRateBuilder {
+	return &ShippingRateBuilder{currency: currency, rates: []ShippingRate{}}
+}
+
+// AddRate adds a rate whose price is in the currency of the builder, e.g.
+// 12.50 for USD. The price is rounded to the decimals of the currency and
+// converted to the subunits Shopify expects, see ShippingRatePrice.
+func (b *ShippingRateBuilder) AddRate(serviceCode, serviceName, description string, price decimal.Decimal) *ShippingRateBuilder {
+	b.rates = append(b.rates, ShippingRate{
+		ServiceCode: serviceCode,
+		ServiceName: serviceName,
+		Description: description,
+		Currency:    b.currency,
+		TotalPrice:  ShippingRatePrice(price, b.currency),
+	})
+	return b
+}
+
+// SetDeliveryDates sets the earliest and latest delivery dates of the last
+// rate added
+func (b *ShippingRateBuilder) SetDeliveryDates(min, max time.Time) *ShippingRateBuilder {
+	if rate := b.lastRate("delivery dates"); rate != nil {
+		rate.MinDeliveryDate, rate.MaxDeliveryDate = &min, &max
+	}
+	return b
+}
+
+// SetPhoneRequired requires the customer to give a phone number at checkout
+// to select the last rate added
+func (b *ShippingRateBuilder) SetPhoneRequired() *ShippingRateBuilder {
+	if rate := b.lastRate("phone required"); rate != nil {
+		rate.PhoneRequired = true
+	}
+	return b
+}
+
+// lastRate returns the last rate added, recording an error when there is none
+func (b *ShippingRateBuilder) lastRate(setting string) *ShippingRate {
+	if len(b.rates) == 0 {
+		if b.err == nil {
+			b.err = ValidationError{Field: "rates", Message: setting + " set before adding a rate"}
+		}
+		return nil
+	}
+	return &b.rates[len(b.rates)-1]
+}
+
+// Build validates and returns the response, the returned error is a
+// ValidationError naming the offending field. A response without rates is
+// valid, checkout then offers none of the carrier service.
+func (b *ShippingRateBuilder) Build() (ShippingRateResponse, error) {
+	if b.err != nil {
+		return ShippingRateResponse{}, b.err
+	}
+
+	for i, rate := range b.rates {
+		field := fmt.Sprintf("rates[%d]", i)
+		if rate.ServiceCode == "" {
+			return ShippingRateResponse{}, ValidationError{Field: field + ".service_code", Message: "is required"}
+		}
+		if rate.ServiceName == "" {
+			return ShippingRateResponse{}, ValidationError{Field: field + ".service_name", Message: "is required"}
+		}
+		if rate.TotalPrice.IsNegative() {
+			return ShippingRateResponse{}, ValidationError{Field: field + ".total_price", Message: "must be zero or positive"}
+		}
+		if rate.MinDeliveryDate != nil && rate.MaxDeliveryDate.Before(*rate.MinDeliveryDate) {
+			return ShippingRateResponse{}, ValidationError{Field: field + ".max_delivery_date", Message: "is before min_delivery_date"}
+		}
+	}
+
+	return ShippingRateResponse{Rates: b.rates}, nil
+}
+
+// ShippingRatePrice converts a price in currency, e.g. 12.345 USD, to the
+// total_price of a shipping rate: rounded to the decimals of the currency and
+// expressed in its subunits, e.g. 1235. Prices of currencies without subunits
+// are multiplied by 100, e.g. 1000 JPY is 100000.
+func ShippingRatePrice(price decimal.Decimal, currency string) decimal.Decimal {
+	decimals := CurrencyDecimals(currency)
+	if decimals == 0 {
+		return price.Round(0).Shift(2)
+	}
+	return price.Round(decimals).Shift(decimals)
+}
+
+// ShippingRateFunc returns the rates of a carrier service for a rate request
+// of shop, the myshopify domain of the shop
+type ShippingRateFunc func(ctx context.Context, shop string, query ShippingRateQuery) (ShippingRateResponse, error)
+
+// ShippingRateHandler returns the http.Handler of the callback url of a
+// carrier service. It verifies the request like a webhook, answering 401 when
+// it was not signed with a secret of the app, and responds with the rates of
+// rates. Shopify offers its backup rates when rates returns an error, which
+// is answered with a 500.
+func (app App) ShippingRateHandler(rates ShippingRateFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ok, _ := app.VerifyWebhookRequestVerbose(r); !ok {
+			http.Error(w, "invalid hmac", http.StatusUnauthorized)
+			return
+		}
+
+		var request ShippingRateRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, "invalid rate request", http.StatusBadRequest)
+			return
+		}
+
+		response, err := rates(r.Context(), r.Header.Get("X-Shopify-Shop-Domain"), request.Rate)
+		if err != nil {
+			http.Error(w, "could not compute rates", http.StatusInternalServerError)
+			return
+		}
+		if response.Rates == nil {
+			response.Rates = []ShippingRate{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	})
+}
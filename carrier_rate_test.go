@@ -0,0 +1,194 @@
+package goshopify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestShippingRateBuilderBuild(t *testing.T) {
+	min := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	max := min.AddDate(0, 0, 2)
+
+	response, err := NewShippingRateBuilder("USD").
+		AddRate("express", "Express", "Tracked", decimal.RequireFromString("12.345")).
+		SetDeliveryDates(min, max).
+		SetPhoneRequired().
+		AddRate("standard", "Standard", "", decimal.Zero).
+		Build()
+	if err != nil {
+		t.Fatalf("ShippingRateBuilder.Build returned error: %v", err)
+	}
+
+	if len(response.Rates) != 2 {
+		t.Fatalf("ShippingRateBuilder.Build returned %d rates, expected 2", len(response.Rates))
+	}
+	express := response.Rates[0]
+	if !express.TotalPrice.Equal(decimal.NewFromInt(1235)) || express.Currency != "USD" {
+		t.Errorf("ShippingRateBuilder.Build returned price %s %s, expected 1235 USD", express.TotalPrice, express.Currency)
+	}
+	if !express.PhoneRequired || !express.MinDeliveryDate.Equal(min) || !express.MaxDeliveryDate.Equal(max) {
+		t.Errorf("ShippingRateBuilder.Build returned rate %+v", express)
+	}
+	if response.Rates[1].PhoneRequired || response.Rates[1].MinDeliveryDate != nil {
+		t.Errorf("ShippingRateBuilder.Build returned rate %+v", response.Rates[1])
+	}
+}
+
+func TestShippingRatePrice(t *testing.T) {
+	cases := []struct {
+		price    string
+		currency string
+		expected int64
+	}{
+		{"12.50", "USD", 1250},
+		{"12.345", "EUR", 1235},
+		{"1000", "JPY", 100000},
+		{"999.6", "JPY", 100000},
+		{"1.2345", "KWD", 1235},
+	}
+
+	for _, c := range cases {
+		price := ShippingRatePrice(decimal.RequireFromString(c.price), c.currency)
+		if !price.Equal(decimal.NewFromInt(c.expected)) {
+			t.Errorf("ShippingRatePrice(%s %s) = %s, expected %d", c.price, c.currency, price, c.expected)
+		}
+	}
+}
+
+func TestShippingRateBuilderValidation(t *testing.T) {
+	now := time.Now()
+	price := decimal.NewFromInt(5)
+
+	cases := []struct {
+		description string
+		builder     *ShippingRateBuilder
+		field       string
+	}{
+		{
+			"no service code",
+			NewShippingRateBuilder("USD").AddRate("", "Express", "", price),
+			"rates[0].service_code",
+		},
+		{
+			"no service name",
+			NewShippingRateBuilder("USD").AddRate("standard", "Standard", "", price).AddRate("express", "", "", price),
+			"rates[1].service_name",
+		},
+		{
+			"negative price",
+			NewShippingRateBuilder("USD").AddRate("express", "Express", "", decimal.NewFromInt(-1)),
+			"rates[0].total_price",
+		},
+		{
+			"max before min delivery date",
+			NewShippingRateBuilder("USD").AddRate("express", "Express", "", price).SetDeliveryDates(now, now.Add(-time.Hour)),
+			"rates[0].max_delivery_date",
+		},
+		{
+			"phone required before rates",
+			NewShippingRateBuilder("USD").SetPhoneRequired().AddRate("express", "Express", "", price),
+			"rates",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			_, err := c.builder.Build()
+			var validationErr ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("ShippingRateBuilder.Build returned %v, expected a ValidationError", err)
+			}
+			if validationErr.Field != c.field {
+				t.Errorf("ShippingRateBuilder.Build returned error on field %s, expected %s", validationErr.Field, c.field)
+			}
+		})
+	}
+}
+
+func TestShippingRateHandler(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handler := app.ShippingRateHandler(func(ctx context.Context, shop string, query ShippingRateQuery) (ShippingRateResponse, error) {
+		if shop != "fooshop.myshopify.com" {
+			t.Errorf("ShippingRateHandler called rates for shop %s, expected fooshop.myshopify.com", shop)
+		}
+		if query.Currency == "" {
+			return ShippingRateResponse{}, errors.New("no currency")
+		}
+		return NewShippingRateBuilder(query.Currency).
+			AddRate("express", "Express", "", decimal.RequireFromString("12.5")).
+			Build()
+	})
+
+	sign := func(body string) string {
+		mac := hmac.New(sha256.New, []byte(app.ApiSecret))
+		mac.Write([]byte(body))
+		return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	}
+
+	cases := []struct {
+		description string
+		body        string
+		hmac        string
+		status      int
+		response    string
+	}{
+		{
+			"rates",
+			`{"rate":{"currency":"USD"}}`,
+			sign(`{"rate":{"currency":"USD"}}`),
+			http.StatusOK,
+			`{"rates":[{"service_name":"Express","description":"","service_code":"express","currency":"USD","total_price":"1250","min_delivery_date":null,"max_delivery_date":null}]}`,
+		},
+		{
+			"invalid hmac",
+			`{"rate":{"currency":"USD"}}`,
+			sign(`{"rate":{"currency":"EUR"}}`),
+			http.StatusUnauthorized,
+			"",
+		},
+		{
+			"invalid body",
+			`{"rate":`,
+			sign(`{"rate":`),
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"rates error",
+			`{"rate":{}}`,
+			sign(`{"rate":{}}`),
+			http.StatusInternalServerError,
+			"",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/rates", strings.NewReader(c.body))
+			req.Header.Set("X-Shopify-Hmac-Sha256", c.hmac)
+			req.Header.Set("X-Shopify-Shop-Domain", "fooshop.myshopify.com")
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != c.status {
+				t.Fatalf("ShippingRateHandler answered %d, expected %d", rec.Code, c.status)
+			}
+			if c.response != "" && strings.TrimSpace(rec.Body.String()) != c.response {
+				t.Errorf("ShippingRateHandler answered %s, expected %s", rec.Body.String(), c.response)
+			}
+		})
+	}
+}
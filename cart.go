@@ -0,0 +1,311 @@
+package goshopify
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// StorefrontCart is a cart of the Storefront API, as returned by a query
+// selecting the fields below, converted into an order or a draft order by
+// Order and DraftOrder to create it with the Admin API, e.g. from a headless
+// storefront:
+//
+//	cart(id: $id) {
+//	  id note
+//	  attributes { key value }
+//	  buyerIdentity { email phone }
+//	  cost { totalAmount { amount currencyCode } }
+//	  discountAllocations { ...allocation }
+//	  lines(first: 250) { nodes {
+//	    quantity
+//	    merchandise { ... on ProductVariant { id } }
+//	    attributes { key value }
+//	    discountAllocations { ...allocation }
+//	  } }
+//	  deliveryGroups(first: 10) { nodes {
+//	    selectedDeliveryOption { handle title estimatedCost { amount currencyCode } }
+//	  } }
+//	}
+//
+//	fragment allocation on CartDiscountAllocation {
+//	  discountedAmount { amount currencyCode }
+//	  ... on CartCodeDiscountAllocation { code }
+//	  ... on CartAutomaticDiscountAllocation { title }
+//	  ... on CartCustomDiscountAllocation { title }
+//	}
+type StorefrontCart struct {
+	Id                  string                   `json:"id"`
+	Note                string                   `json:"note"`
+	Attributes          []CartAttribute          `json:"attributes"`
+	BuyerIdentity       *CartBuyerIdentity       `json:"buyerIdentity"`
+	Cost                *CartCost                `json:"cost"`
+	DiscountAllocations []CartDiscountAllocation `json:"discountAllocations"`
+	Lines               CartLines                `json:"lines"`
+	DeliveryGroups      CartDeliveryGroups       `json:"deliveryGroups"`
+}
+
+// CartAttribute is a custom attribute of a cart or of a cart line
+type CartAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// CartBuyerIdentity is the customer a cart belongs to
+type CartBuyerIdentity struct {
+	Email string `json:"email"`
+	Phone string `json:"phone"`
+}
+
+// CartMoney is an amount of the Storefront API
+type CartMoney struct {
+	Amount       decimal.Decimal `json:"amount"`
+	CurrencyCode string          `json:"currencyCode"`
+}
+
+// CartCost is the cost of a cart
+type CartCost struct {
+	TotalAmount CartMoney `json:"totalAmount"`
+}
+
+// CartDiscountAllocation is a discount applied to a cart or a cart line, Code
+// is set for discount codes, Title for automatic and custom discounts
+type CartDiscountAllocation struct {
+	DiscountedAmount CartMoney `json:"discountedAmount"`
+	Code             string    `json:"code"`
+	Title            string    `json:"title"`
+}
+
+// CartLines is the lines connection of a cart, with nodes or edges
+type CartLines struct {
+	Nodes []CartLine `json:"nodes"`
+	Edges []struct {
+		Node CartLine `json:"node"`
+	} `json:"edges"`
+}
+
+// all returns the nodes of the connection
+func (l CartLines) all() []CartLine {
+	lines := append([]CartLine(nil), l.Nodes...)
+	for _, edge := range l.Edges {
+		lines = append(lines, edge.Node)
+	}
+	return lines
+}
+
+// CartLine is a line of a cart
+type CartLine struct {
+	Quantity            int                      `json:"quantity"`
+	Merchandise         CartMerchandise          `json:"merchandise"`
+	Attributes          []CartAttribute          `json:"attributes"`
+	DiscountAllocations []CartDiscountAllocation `json:"discountAllocations"`
+}
+
+// CartMerchandise is the product variant of a cart line, Id is its gid
+type CartMerchandise struct {
+	Id string `json:"id"`
+}
+
+// CartDeliveryGroups is the delivery groups connection of a cart, with
+// nodes or edges
+type CartDeliveryGroups struct {
+	Nodes []CartDeliveryGroup `json:"nodes"`
+	Edges []struct {
+		Node CartDeliveryGroup `json:"node"`
+	} `json:"edges"`
+}
+
+// all returns the nodes of the connection
+func (g CartDeliveryGroups) all() []CartDeliveryGroup {
+	groups := append([]CartDeliveryGroup(nil), g.Nodes...)
+	for _, edge := range g.Edges {
+		groups = append(groups, edge.Node)
+	}
+	return groups
+}
+
+// CartDeliveryGroup is a group of cart lines delivered together
+type CartDeliveryGroup struct {
+	SelectedDeliveryOption *CartDeliveryOption `json:"selectedDeliveryOption"`
+}
+
+// CartDeliveryOption is the delivery option selected for a delivery group
+type CartDeliveryOption struct {
+	Handle        string    `json:"handle"`
+	Title         string    `json:"title"`
+	EstimatedCost CartMoney `json:"estimatedCost"`
+}
+
+// DraftOrder converts the cart into a draft order with DraftOrderBuilder. The
+// discounts allocated to a line become a fixed amount discount of its line
+// item and the discounts allocated to the cart one of the draft order. The
+// selected delivery options are merged into the shipping line. The returned
+// error is a ValidationError naming the offending field.
+func (cart StorefrontCart) DraftOrder() (DraftOrder, error) {
+	builder := NewDraftOrderBuilder()
+	for i, line := range cart.Lines.all() {
+		variantId, err := line.variantId(i)
+		if err != nil {
+			return DraftOrder{}, err
+		}
+		builder.AddVariant(variantId, line.Quantity, allocationsDiscount(line.DiscountAllocations))
+		if properties := cartNoteAttributes(line.Attributes); len(properties) > 0 {
+			builder.SetLineItemProperties(properties...)
+		}
+	}
+
+	var titles []string
+	var price *decimal.Decimal
+	for _, option := range cart.deliveryOptions() {
+		titles = append(titles, option.Title)
+		total := option.EstimatedCost.Amount
+		if price != nil {
+			total = total.Add(*price)
+		}
+		price = &total
+	}
+	if price != nil {
+		builder.SetShippingLine(strings.Join(titles, ", "), *price)
+	}
+	builder.SetDiscount(allocationsDiscount(cart.DiscountAllocations))
+
+	draft, err := builder.Build()
+	if err != nil {
+		return DraftOrder{}, err
+	}
+	draft.Note = cart.Note
+	draft.NoteAttributes = cartNoteAttributes(cart.Attributes)
+	if cart.BuyerIdentity != nil {
+		draft.Email = cart.BuyerIdentity.Email
+	}
+	return draft, nil
+}
+
+// Order converts the cart into an order. Every selected delivery option
+// becomes a shipping line and the amounts allocated to a discount, on the
+// lines or the cart, are summed into a fixed amount discount code per
+// discount. Automatic and custom discounts have no code, they are named after
+// their title. The returned error is a ValidationError naming the offending
+// field, e.g. a discount allocation with neither code nor title.
+func (cart StorefrontCart) Order() (Order, error) {
+	order := Order{
+		Note:           cart.Note,
+		NoteAttributes: cartNoteAttributes(cart.Attributes),
+	}
+	if cart.BuyerIdentity != nil {
+		order.Email = cart.BuyerIdentity.Email
+		order.Phone = cart.BuyerIdentity.Phone
+	}
+	if cart.Cost != nil {
+		order.Currency = cart.Cost.TotalAmount.CurrencyCode
+	}
+
+	lines := cart.Lines.all()
+	if len(lines) == 0 {
+		return Order{}, ValidationError{Field: "lines", Message: "at least one line is required"}
+	}
+	// the discount allocations of the cart and its lines by field
+	type fieldAllocation struct {
+		field string
+		CartDiscountAllocation
+	}
+	var allocations []fieldAllocation
+	for i, allocation := range cart.DiscountAllocations {
+		allocations = append(allocations, fieldAllocation{fmt.Sprintf("discountAllocations[%d]", i), allocation})
+	}
+	for i, line := range lines {
+		variantId, err := line.variantId(i)
+		if err != nil {
+			return Order{}, err
+		}
+		if line.Quantity <= 0 {
+			return Order{}, ValidationError{Field: fmt.Sprintf("lines[%d].quantity", i), Message: fmt.Sprintf("must be positive, got %d", line.Quantity)}
+		}
+		order.LineItems = append(order.LineItems, LineItem{
+			VariantId:  variantId,
+			Quantity:   line.Quantity,
+			Properties: cartNoteAttributes(line.Attributes),
+		})
+		for j, allocation := range line.DiscountAllocations {
+			allocations = append(allocations, fieldAllocation{fmt.Sprintf("lines[%d].discountAllocations[%d]", i, j), allocation})
+		}
+	}
+
+	for _, option := range cart.deliveryOptions() {
+		price := option.EstimatedCost.Amount
+		order.ShippingLines = append(order.ShippingLines, ShippingLines{Title: option.Title, Code: option.Handle, Price: &price})
+	}
+
+	amounts := map[string]decimal.Decimal{}
+	for _, allocation := range allocations {
+		code := allocation.Code
+		if code == "" {
+			code = allocation.Title
+		}
+		if code == "" {
+			return Order{}, ValidationError{Field: allocation.field, Message: "a discount without code needs a title"}
+		}
+		if _, ok := amounts[code]; !ok {
+			order.DiscountCodes = append(order.DiscountCodes, DiscountCode{Code: code, Type: AppliedDiscountValueTypeFixedAmount})
+		}
+		amounts[code] = amounts[code].Add(allocation.DiscountedAmount.Amount)
+	}
+	for i, code := range order.DiscountCodes {
+		amount := amounts[code.Code]
+		order.DiscountCodes[i].Amount = &amount
+	}
+
+	return order, nil
+}
+
+// deliveryOptions returns the selected delivery options of the cart
+func (cart StorefrontCart) deliveryOptions() []CartDeliveryOption {
+	var options []CartDeliveryOption
+	for _, group := range cart.DeliveryGroups.all() {
+		if group.SelectedDeliveryOption != nil {
+			options = append(options, *group.SelectedDeliveryOption)
+		}
+	}
+	return options
+}
+
+// variantId returns the id of the product variant of the i-th line
+func (line CartLine) variantId(i int) (uint64, error) {
+	resourceType, id, err := ParseGid(line.Merchandise.Id)
+	if err != nil || resourceType != "ProductVariant" {
+		return 0, ValidationError{Field: fmt.Sprintf("lines[%d].merchandise.id", i), Message: fmt.Sprintf("%q is not a product variant gid", line.Merchandise.Id)}
+	}
+	return id, nil
+}
+
+// allocationsDiscount returns a fixed amount discount of the sum of the
+// allocations, titled after their codes or titles, nil without allocations
+func allocationsDiscount(allocations []CartDiscountAllocation) *AppliedDiscount {
+	if len(allocations) == 0 {
+		return nil
+	}
+
+	var titles []string
+	amount := decimal.Zero
+	for _, allocation := range allocations {
+		title := allocation.Code
+		if title == "" {
+			title = allocation.Title
+		}
+		if title != "" {
+			titles = append(titles, title)
+		}
+		amount = amount.Add(allocation.DiscountedAmount.Amount)
+	}
+	return FixedAmountDiscount(strings.Join(titles, ", "), "", amount)
+}
+
+// cartNoteAttributes converts cart attributes into note attributes
+func cartNoteAttributes(attributes []CartAttribute) []NoteAttribute {
+	var noteAttributes []NoteAttribute
+	for _, attribute := range attributes {
+		noteAttributes = append(noteAttributes, NoteAttribute{Name: attribute.Key, Value: attribute.Value})
+	}
+	return noteAttributes
+}
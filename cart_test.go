@@ -0,0 +1,167 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+const storefrontCartJSON = `{
+  "id": "gid://shopify/Cart/c1",
+  "note": "Leave at the door",
+  "attributes": [{"key": "gift", "value": "yes"}],
+  "buyerIdentity": {"email": "jane@example.com", "phone": "+15555550100"},
+  "cost": {"totalAmount": {"amount": "42.0", "currencyCode": "USD"}},
+  "discountAllocations": [{"discountedAmount": {"amount": "2.0", "currencyCode": "USD"}, "code": "WELCOME"}],
+  "lines": {"nodes": [
+    {
+      "quantity": 2,
+      "merchandise": {"id": "gid://shopify/ProductVariant/11"},
+      "attributes": [{"key": "Engraving", "value": "J.D."}],
+      "discountAllocations": [
+        {"discountedAmount": {"amount": "3.0", "currencyCode": "USD"}, "code": "WELCOME"},
+        {"discountedAmount": {"amount": "1.5", "currencyCode": "USD"}, "title": "Bundle"}
+      ]
+    },
+    {"quantity": 1, "merchandise": {"id": "gid://shopify/ProductVariant/12"}, "attributes": [], "discountAllocations": []}
+  ]},
+  "deliveryGroups": {"nodes": [
+    {"selectedDeliveryOption": {"handle": "express", "title": "Express", "estimatedCost": {"amount": "10.0", "currencyCode": "USD"}}},
+    {"selectedDeliveryOption": null}
+  ]}
+}`
+
+func decodeStorefrontCart(t *testing.T) StorefrontCart {
+	var cart StorefrontCart
+	if err := json.Unmarshal([]byte(storefrontCartJSON), &cart); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+	return cart
+}
+
+func TestStorefrontCartDraftOrder(t *testing.T) {
+	draft, err := decodeStorefrontCart(t).DraftOrder()
+	if err != nil {
+		t.Fatalf("StorefrontCart.DraftOrder returned error: %v", err)
+	}
+
+	shippingPrice := decimal.NewFromInt(10)
+	expected := DraftOrder{
+		Note:           "Leave at the door",
+		NoteAttributes: []NoteAttribute{{Name: "gift", Value: "yes"}},
+		Email:          "jane@example.com",
+		LineItems: []LineItem{
+			{
+				VariantId:       11,
+				Quantity:        2,
+				Properties:      []NoteAttribute{{Name: "Engraving", Value: "J.D."}},
+				AppliedDiscount: FixedAmountDiscount("WELCOME, Bundle", "", decimal.RequireFromString("4.5")),
+			},
+			{VariantId: 12, Quantity: 1},
+		},
+		ShippingLine:    &ShippingLines{Title: "Express", Price: &shippingPrice},
+		AppliedDiscount: FixedAmountDiscount("WELCOME", "", decimal.NewFromInt(2)),
+	}
+	actual, _ := json.Marshal(draft)
+	wanted, _ := json.Marshal(expected)
+	if string(actual) != string(wanted) {
+		t.Errorf("StorefrontCart.DraftOrder returned %s, expected %s", actual, wanted)
+	}
+}
+
+func TestStorefrontCartOrder(t *testing.T) {
+	order, err := decodeStorefrontCart(t).Order()
+	if err != nil {
+		t.Fatalf("StorefrontCart.Order returned error: %v", err)
+	}
+
+	shippingPrice := decimal.NewFromInt(10)
+	discount, bundle := decimal.NewFromInt(5), decimal.RequireFromString("1.5")
+	expected := Order{
+		Email:          "jane@example.com",
+		Phone:          "+15555550100",
+		Currency:       "USD",
+		Note:           "Leave at the door",
+		NoteAttributes: []NoteAttribute{{Name: "gift", Value: "yes"}},
+		LineItems: []LineItem{
+			{VariantId: 11, Quantity: 2, Properties: []NoteAttribute{{Name: "Engraving", Value: "J.D."}}},
+			{VariantId: 12, Quantity: 1},
+		},
+		ShippingLines: []ShippingLines{{Title: "Express", Code: "express", Price: &shippingPrice}},
+		DiscountCodes: []DiscountCode{
+			{Code: "WELCOME", Amount: &discount, Type: "fixed_amount"},
+			{Code: "Bundle", Amount: &bundle, Type: "fixed_amount"},
+		},
+	}
+	actual, _ := json.Marshal(order)
+	wanted, _ := json.Marshal(expected)
+	if string(actual) != string(wanted) {
+		t.Errorf("StorefrontCart.Order returned %s, expected %s", actual, wanted)
+	}
+}
+
+func TestStorefrontCartOrderAutomaticDiscount(t *testing.T) {
+	cart := StorefrontCart{
+		Lines: CartLines{Nodes: []CartLine{{Quantity: 1, Merchandise: CartMerchandise{Id: "gid://shopify/ProductVariant/1"}}}},
+		DiscountAllocations: []CartDiscountAllocation{
+			{DiscountedAmount: CartMoney{Amount: decimal.NewFromInt(4)}, Title: "Summer sale"},
+		},
+	}
+
+	order, err := cart.Order()
+	if err != nil {
+		t.Fatalf("StorefrontCart.Order returned error: %v", err)
+	}
+	if len(order.DiscountCodes) != 1 || order.DiscountCodes[0].Code != "Summer sale" ||
+		order.DiscountCodes[0].Amount == nil || !order.DiscountCodes[0].Amount.Equal(decimal.NewFromInt(4)) {
+		t.Errorf("StorefrontCart.Order returned discount codes %+v, expected the 4.00 of Summer sale", order.DiscountCodes)
+	}
+
+	cart.DiscountAllocations[0].Title = ""
+	var validationErr ValidationError
+	if _, err := cart.Order(); !errors.As(err, &validationErr) || validationErr.Field != "discountAllocations[0]" {
+		t.Errorf("StorefrontCart.Order returned %v, expected a ValidationError on discountAllocations[0]", err)
+	}
+}
+
+func TestStorefrontCartValidation(t *testing.T) {
+	cases := []struct {
+		description string
+		cart        StorefrontCart
+		draftField  string
+		orderField  string
+	}{
+		{
+			"no lines",
+			StorefrontCart{},
+			"line_items",
+			"lines",
+		},
+		{
+			"merchandise which is not a variant",
+			StorefrontCart{Lines: CartLines{Nodes: []CartLine{{Quantity: 1, Merchandise: CartMerchandise{Id: "gid://shopify/Product/1"}}}}},
+			"lines[0].merchandise.id",
+			"lines[0].merchandise.id",
+		},
+		{
+			"zero quantity",
+			StorefrontCart{Lines: CartLines{Nodes: []CartLine{{Merchandise: CartMerchandise{Id: "gid://shopify/ProductVariant/1"}}}}},
+			"line_items[0].quantity",
+			"lines[0].quantity",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			var validationErr ValidationError
+			if _, err := c.cart.DraftOrder(); !errors.As(err, &validationErr) || validationErr.Field != c.draftField {
+				t.Errorf("StorefrontCart.DraftOrder returned %v, expected a ValidationError on %s", err, c.draftField)
+			}
+			if _, err := c.cart.Order(); !errors.As(err, &validationErr) || validationErr.Field != c.orderField {
+				t.Errorf("StorefrontCart.Order returned %v, expected a ValidationError on %s", err, c.orderField)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// CheckoutService is an interface for the checkout endpoints of the sales
+// channel Checkout API.
+// See: https://shopify.dev/docs/api/admin-rest/latest/resources/checkout
+type CheckoutService interface {
+	ShippingRates(context.Context, string) ([]CheckoutShippingRate, bool, error)
+	PollShippingRates(context.Context, string) ([]CheckoutShippingRate, error)
+}
+
+// CheckoutServiceOp handles communication with the checkout related methods
+// of the Shopify API.
+type CheckoutServiceOp struct {
+	client *Client
+}
+
+// CheckoutShippingRate represents a shipping rate available to a checkout,
+// its handle is set as the shipping line of the checkout
+type CheckoutShippingRate struct {
+	Id            string                  `json:"id,omitempty"`
+	Handle        string                  `json:"handle,omitempty"`
+	Title         string                  `json:"title,omitempty"`
+	Price         *decimal.Decimal        `json:"price,omitempty"`
+	PhoneRequired bool                    `json:"phone_required,omitempty"`
+	Checkout      *CheckoutShippingTotals `json:"checkout,omitempty"`
+}
+
+// CheckoutShippingTotals are the totals of the checkout once a shipping rate
+// is selected
+type CheckoutShippingTotals struct {
+	TotalTax      *decimal.Decimal `json:"total_tax,omitempty"`
+	TotalPrice    *decimal.Decimal `json:"total_price,omitempty"`
+	SubtotalPrice *decimal.Decimal `json:"subtotal_price,omitempty"`
+}
+
+// CheckoutShippingRatesResource represents the result from the
+// checkouts/X/shipping_rates.json endpoint
+type CheckoutShippingRatesResource struct {
+	ShippingRates []CheckoutShippingRate `json:"shipping_rates"`
+}
+
+// shippingRatesPolling is the backoff between polls when shopify does not
+// send a Retry-After header
+var shippingRatesPolling = RetryPolicy{MinBackoff: 500 * time.Millisecond, MaxBackoff: 8 * time.Second}
+
+// ShippingRates returns the shipping rates of a checkout. Shopify calculates
+// the rates asynchronously, ready is false while they are being calculated.
+func (s *CheckoutServiceOp) ShippingRates(ctx context.Context, token string) ([]CheckoutShippingRate, bool, error) {
+	rates, retryAfter, err := s.shippingRates(ctx, token)
+	return rates, retryAfter == "", err
+}
+
+// PollShippingRates requests the shipping rates of a checkout until shopify
+// has calculated them, waiting the Retry-After shopify responds with or an
+// exponential backoff between requests. The context error is returned when
+// it is done before the rates are ready.
+func (s *CheckoutServiceOp) PollShippingRates(ctx context.Context, token string) ([]CheckoutShippingRate, error) {
+	for attempt := 1; ; attempt++ {
+		rates, retryAfter, err := s.shippingRates(ctx, token)
+		if err != nil || retryAfter == "" {
+			return rates, err
+		}
+
+		wait := shippingRatesPolling.backoff(attempt, retryAfter)
+		s.client.log.Debugf("shipping rates of checkout %s not ready, polling in %s", token, wait.String())
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// shippingRates returns the rates and the Retry-After header which shopify
+// sets on the 202 responses sent while the rates are calculated
+func (s *CheckoutServiceOp) shippingRates(ctx context.Context, token string) ([]CheckoutShippingRate, string, error) {
+	path := fmt.Sprintf("%s/%s/shipping_rates.json", abandonedCheckoutsBasePath, token)
+	resource := new(CheckoutShippingRatesResource)
+	headers, err := s.client.createAndDoGetHeaders(ctx, "GET", path, nil, nil, resource)
+	if err != nil {
+		return nil, "", err
+	}
+	return resource.ShippingRates, headers.Get("Retry-After"), nil
+}
@@ -0,0 +1,270 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const cartTransformCreateMutation = `mutation cartTransformCreate($functionId: String!, $blockOnFailure: Boolean, $metafields: [MetafieldInput!]) {
+  cartTransformCreate(functionId: $functionId, blockOnFailure: $blockOnFailure, metafields: $metafields) {
+    cartTransform {
+      id
+      functionId
+      blockOnFailure
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const cartTransformDeleteMutation = `mutation cartTransformDelete($id: ID!) {
+  cartTransformDelete(id: $id) {
+    deletedId
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+// customizationFields are the fields of a delivery or payment customization
+const customizationFields = `
+      id
+      title
+      enabled
+      functionId`
+
+// Kinds of checkout customizations, the prefix of their mutations
+const (
+	deliveryCustomizationKind = "deliveryCustomization"
+	paymentCustomizationKind  = "paymentCustomization"
+)
+
+// CartTransformService is an interface for activating the cart transform
+// function of the app with the Shopify API.
+// See: https://shopify.dev/docs/api/functions/reference/cart-transform
+type CartTransformService interface {
+	Create(context.Context, CartTransformCreate) (*CartTransform, error)
+	Delete(context.Context, string) error
+}
+
+// CartTransformServiceOp handles communication with the cart transform
+// mutations of the Shopify API.
+type CartTransformServiceOp struct {
+	client *Client
+}
+
+// CartTransform represents an active cart transform function
+type CartTransform struct {
+	Id             string `json:"id"`
+	FunctionId     string `json:"functionId"`
+	BlockOnFailure bool   `json:"blockOnFailure"`
+}
+
+// CartTransformCreate represents the arguments of cartTransformCreate
+type CartTransformCreate struct {
+	FunctionId     string
+	BlockOnFailure bool
+	Configuration  *FunctionConfiguration
+}
+
+// CustomizationService is an interface for managing the delivery or payment
+// customizations running a function of the app with the Shopify API.
+// See: https://shopify.dev/docs/api/functions/reference/delivery-customization
+// and https://shopify.dev/docs/api/functions/reference/payment-customization
+type CustomizationService interface {
+	Create(context.Context, CustomizationInput) (*Customization, error)
+	Update(context.Context, string, CustomizationInput) (*Customization, error)
+	Delete(context.Context, string) error
+}
+
+// CustomizationServiceOp handles communication with the delivery or payment
+// customization mutations of the Shopify API.
+type CustomizationServiceOp struct {
+	client *Client
+	kind   string
+}
+
+// Customization represents a delivery or payment customization
+type Customization struct {
+	Id         string `json:"id"`
+	Title      string `json:"title"`
+	Enabled    bool   `json:"enabled"`
+	FunctionId string `json:"functionId"`
+}
+
+// CustomizationInput represents a DeliveryCustomizationInput or
+// PaymentCustomizationInput, fields left empty are not changed on update
+type CustomizationInput struct {
+	FunctionId    string
+	Title         string
+	Enabled       *bool
+	Configuration *FunctionConfiguration
+}
+
+func (c CustomizationInput) variables() (map[string]interface{}, error) {
+	input := map[string]interface{}{}
+	if c.FunctionId != "" {
+		input["functionId"] = c.FunctionId
+	}
+	if c.Title != "" {
+		input["title"] = c.Title
+	}
+	if c.Enabled != nil {
+		input["enabled"] = *c.Enabled
+	}
+	if c.Configuration != nil {
+		metafield, err := c.Configuration.input("")
+		if err != nil {
+			return nil, err
+		}
+		input["metafields"] = []metafieldInput{metafield}
+	}
+	return input, nil
+}
+
+// Create activates a cart transform function
+func (s *CartTransformServiceOp) Create(ctx context.Context, create CartTransformCreate) (*CartTransform, error) {
+	vars := map[string]interface{}{
+		"functionId":     create.FunctionId,
+		"blockOnFailure": create.BlockOnFailure,
+	}
+	if create.Configuration != nil {
+		metafield, err := create.Configuration.input("")
+		if err != nil {
+			return nil, err
+		}
+		vars["metafields"] = []metafieldInput{metafield}
+	}
+	resp := struct {
+		CartTransformCreate struct {
+			CartTransform *CartTransform     `json:"cartTransform"`
+			UserErrors    []GraphQLUserError `json:"userErrors"`
+		} `json:"cartTransformCreate"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, cartTransformCreateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := userErrorsToResponseError(resp.CartTransformCreate.UserErrors); err != nil {
+		return nil, err
+	}
+	return resp.CartTransformCreate.CartTransform, nil
+}
+
+// Delete deactivates a cart transform by graphql id
+func (s *CartTransformServiceOp) Delete(ctx context.Context, id string) error {
+	resp := struct {
+		CartTransformDelete struct {
+			UserErrors []GraphQLUserError `json:"userErrors"`
+		} `json:"cartTransformDelete"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, cartTransformDeleteMutation, map[string]interface{}{"id": id}, &resp)
+	if err != nil {
+		return err
+	}
+	return userErrorsToResponseError(resp.CartTransformDelete.UserErrors)
+}
+
+// inputType is the graphql input type of the customization, e.g.
+// DeliveryCustomizationInput
+func (s *CustomizationServiceOp) inputType() string {
+	return strings.ToUpper(s.kind[:1]) + s.kind[1:] + "Input!"
+}
+
+// customizationPayload is the payload of the customization mutations
+type customizationPayload struct {
+	Customization *Customization     `json:"customization"`
+	UserErrors    []GraphQLUserError `json:"userErrors"`
+}
+
+func (s *CustomizationServiceOp) mutate(ctx context.Context, mutation, name string, vars map[string]interface{}) (*customizationPayload, error) {
+	resp := map[string]*customizationPayload{}
+	err := s.client.GraphQL.Query(ctx, mutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	payload := resp[name]
+	if payload == nil {
+		return &customizationPayload{}, nil
+	}
+	return payload, userErrorsToResponseError(payload.UserErrors)
+}
+
+// Create creates a customization running a function of the app
+func (s *CustomizationServiceOp) Create(ctx context.Context, create CustomizationInput) (*Customization, error) {
+	input, err := create.variables()
+	if err != nil {
+		return nil, err
+	}
+	name := s.kind + "Create"
+	mutation := fmt.Sprintf(`mutation %[1]s($input: %[2]s) {
+  %[1]s(%[3]s: $input) {
+    customization: %[3]s {%[4]s
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`, name, s.inputType(), s.kind, customizationFields)
+
+	payload, err := s.mutate(ctx, mutation, name, map[string]interface{}{"input": input})
+	if err != nil {
+		return nil, err
+	}
+	return payload.Customization, nil
+}
+
+// Update changes the title, function, status or configuration of a
+// customization by graphql id
+func (s *CustomizationServiceOp) Update(ctx context.Context, id string, update CustomizationInput) (*Customization, error) {
+	input, err := update.variables()
+	if err != nil {
+		return nil, err
+	}
+	name := s.kind + "Update"
+	mutation := fmt.Sprintf(`mutation %[1]s($id: ID!, $input: %[2]s) {
+  %[1]s(id: $id, %[3]s: $input) {
+    customization: %[3]s {%[4]s
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`, name, s.inputType(), s.kind, customizationFields)
+
+	payload, err := s.mutate(ctx, mutation, name, map[string]interface{}{"id": id, "input": input})
+	if err != nil {
+		return nil, err
+	}
+	return payload.Customization, nil
+}
+
+// Delete deletes a customization by graphql id
+func (s *CustomizationServiceOp) Delete(ctx context.Context, id string) error {
+	name := s.kind + "Delete"
+	mutation := fmt.Sprintf(`mutation %[1]s($id: ID!) {
+  %[1]s(id: $id) {
+    deletedId
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`, name)
+
+	_, err := s.mutate(ctx, mutation, name, map[string]interface{}{"id": id})
+	return err
+}
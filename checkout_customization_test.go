@@ -0,0 +1,122 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCartTransformCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var captured graphQLTestRequest
+	registerGraphQLResponder(`{"data":{"cartTransformCreate":{
+		"cartTransform":{"id":"gid://shopify/CartTransform/1","functionId":"fn-1","blockOnFailure":true},
+		"userErrors":[]
+	}}}`, &captured)
+
+	cartTransform, err := client.CartTransform.Create(context.Background(), CartTransformCreate{FunctionId: "fn-1", BlockOnFailure: true})
+	if err != nil {
+		t.Fatalf("CartTransform.Create returned error: %v", err)
+	}
+
+	expected := &CartTransform{Id: "gid://shopify/CartTransform/1", FunctionId: "fn-1", BlockOnFailure: true}
+	if !reflect.DeepEqual(cartTransform, expected) {
+		t.Errorf("CartTransform.Create returned %+v, expected %+v", cartTransform, expected)
+	}
+	if captured.Variables["functionId"] != "fn-1" || captured.Variables["blockOnFailure"] != true {
+		t.Errorf("CartTransform.Create sent %+v", captured.Variables)
+	}
+}
+
+func TestCartTransformDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerGraphQLResponder(`{"data":{"cartTransformDelete":{
+		"deletedId":null,
+		"userErrors":[{"field":["id"],"message":"Could not find cart transform","code":"NOT_FOUND"}]
+	}}}`, nil)
+
+	err := client.CartTransform.Delete(context.Background(), "gid://shopify/CartTransform/1")
+	if err == nil || err.Error() != "id: Could not find cart transform" {
+		t.Errorf("CartTransform.Delete returned %v", err)
+	}
+}
+
+func TestDeliveryCustomizationCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var captured graphQLTestRequest
+	registerGraphQLResponder(`{"data":{"deliveryCustomizationCreate":{
+		"customization":{"id":"gid://shopify/DeliveryCustomization/1","title":"Hide express","enabled":true,"functionId":"fn-1"},
+		"userErrors":[]
+	}}}`, &captured)
+
+	enabled := true
+	customization, err := client.DeliveryCustomization.Create(context.Background(), CustomizationInput{
+		FunctionId:    "fn-1",
+		Title:         "Hide express",
+		Enabled:       &enabled,
+		Configuration: &FunctionConfiguration{Namespace: "$app:delivery", Key: "function-configuration", Value: []string{"Express"}},
+	})
+	if err != nil {
+		t.Fatalf("DeliveryCustomization.Create returned error: %v", err)
+	}
+
+	expected := &Customization{Id: "gid://shopify/DeliveryCustomization/1", Title: "Hide express", Enabled: true, FunctionId: "fn-1"}
+	if !reflect.DeepEqual(customization, expected) {
+		t.Errorf("DeliveryCustomization.Create returned %+v, expected %+v", customization, expected)
+	}
+	if !strings.Contains(captured.Query, "deliveryCustomizationCreate($input: DeliveryCustomizationInput!)") ||
+		!strings.Contains(captured.Query, "deliveryCustomizationCreate(deliveryCustomization: $input)") {
+		t.Errorf("DeliveryCustomization.Create sent query %s", captured.Query)
+	}
+
+	input := captured.Variables["input"].(map[string]interface{})
+	if input["functionId"] != "fn-1" || input["enabled"] != true || len(input["metafields"].([]interface{})) != 1 {
+		t.Errorf("DeliveryCustomization.Create sent %+v", input)
+	}
+}
+
+func TestPaymentCustomizationUpdateAndDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var captured graphQLTestRequest
+	registerGraphQLResponder(`{"data":{"paymentCustomizationUpdate":{
+		"customization":{"id":"gid://shopify/PaymentCustomization/1","title":"Hide COD","enabled":false,"functionId":"fn-2"},
+		"userErrors":[]
+	}}}`, &captured)
+
+	disabled := false
+	customization, err := client.PaymentCustomization.Update(context.Background(), "gid://shopify/PaymentCustomization/1", CustomizationInput{Enabled: &disabled})
+	if err != nil {
+		t.Fatalf("PaymentCustomization.Update returned error: %v", err)
+	}
+	if customization.Enabled {
+		t.Errorf("PaymentCustomization.Update returned %+v", customization)
+	}
+
+	expected := map[string]interface{}{
+		"id":    "gid://shopify/PaymentCustomization/1",
+		"input": map[string]interface{}{"enabled": false},
+	}
+	if !reflect.DeepEqual(captured.Variables, expected) {
+		t.Errorf("PaymentCustomization.Update sent %+v, expected %+v", captured.Variables, expected)
+	}
+	if !strings.Contains(captured.Query, "paymentCustomizationUpdate(id: $id, paymentCustomization: $input)") {
+		t.Errorf("PaymentCustomization.Update sent query %s", captured.Query)
+	}
+
+	registerGraphQLResponder(`{"data":{"paymentCustomizationDelete":{"deletedId":"gid://shopify/PaymentCustomization/1","userErrors":[]}}}`, &captured)
+	if err := client.PaymentCustomization.Delete(context.Background(), "gid://shopify/PaymentCustomization/1"); err != nil {
+		t.Errorf("PaymentCustomization.Delete returned error: %v", err)
+	}
+	if !strings.Contains(captured.Query, "paymentCustomizationDelete(id: $id)") {
+		t.Errorf("PaymentCustomization.Delete sent query %s", captured.Query)
+	}
+}
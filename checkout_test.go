@@ -0,0 +1,85 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+const checkoutShippingRatesResponse = `{"shipping_rates":[{
+	"id":"shopify-Standard-5.00",
+	"handle":"shopify-Standard-5.00",
+	"title":"Standard",
+	"price":"5.00",
+	"checkout":{"total_tax":"0.00","total_price":"25.00","subtotal_price":"20.00"}
+}]}`
+
+func registerShippingRatesResponder(pending int) *int {
+	calls := 0
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/checkouts/abc/shipping_rates.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls <= pending {
+				resp := httpmock.NewStringResponse(http.StatusAccepted, `{"shipping_rates":[]}`)
+				resp.Header.Set("Retry-After", "0.01")
+				return resp, nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, checkoutShippingRatesResponse), nil
+		})
+	return &calls
+}
+
+func TestCheckoutShippingRates(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerShippingRatesResponder(1)
+
+	_, ready, err := client.Checkout.ShippingRates(context.Background(), "abc")
+	if err != nil || ready {
+		t.Errorf("Checkout.ShippingRates returned ready %v, %v, expected not ready", ready, err)
+	}
+
+	rates, ready, err := client.Checkout.ShippingRates(context.Background(), "abc")
+	if err != nil || !ready || len(rates) != 1 {
+		t.Fatalf("Checkout.ShippingRates returned %+v, ready %v, %v", rates, ready, err)
+	}
+	if rates[0].Handle != "shopify-Standard-5.00" || !rates[0].Price.Equal(decimal.RequireFromString("5")) {
+		t.Errorf("Checkout.ShippingRates returned %+v", rates[0])
+	}
+}
+
+func TestCheckoutPollShippingRates(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := registerShippingRatesResponder(2)
+
+	rates, err := client.Checkout.PollShippingRates(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("Checkout.PollShippingRates returned error: %v", err)
+	}
+	if len(rates) != 1 || *calls != 3 {
+		t.Errorf("Checkout.PollShippingRates returned %d rates after %d calls, expected 1 rate after 3 calls", len(rates), *calls)
+	}
+}
+
+func TestCheckoutPollShippingRatesContextDone(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerShippingRatesResponder(1000)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.Checkout.PollShippingRates(ctx, "abc")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Checkout.PollShippingRates returned %v, expected context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,96 @@
+package goshopify
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ClientPool hands out the clients of many shops for apps syncing hundreds of
+// stores concurrently. Its clients share one http client, and so its
+// connections, and one LeakyBucket modelling the bucket of every shop
+// independently. The LeakyBucket keeps the buckets in the store set with
+// WithCacheStore, so the pools of replicas sharing a store pace their calls
+// together:
+//
+//	pool := goshopify.NewClientPool(app, goshopify.WithRetry(3))
+//	client, err := pool.Client(installation.Shop, installation.Token)
+//
+// A client is created on the first call for a shop and reused until the
+// token of the shop changes, e.g. after a reinstall. The same client is handed
+// to every caller, it may send requests from many goroutines at once: the
+// retries and metadata of a request are kept per request, read the rate
+// limits of the shop with CurrentRateLimits rather than the RateLimits field.
+type ClientPool struct {
+	app        App
+	opts       []Option
+	httpClient *http.Client
+	limiter    *LeakyBucket
+
+	mu      sync.Mutex
+	clients map[string]pooledClient
+}
+
+// pooledClient is a client of a ClientPool and the token it was created with
+type pooledClient struct {
+	client *Client
+	token  string
+}
+
+// NewClientPool returns a ClientPool creating its clients with opts, which
+// may replace the shared http client with WithHTTPClient or the shared rate
+// limiter with WithRateLimiter. The shared LeakyBucket is created with the
+// CacheStore of the first client.
+func NewClientPool(app App, opts ...Option) *ClientPool {
+	return &ClientPool{
+		app:  app,
+		opts: opts,
+		httpClient: &http.Client{
+			Timeout: time.Second * defaultHttpTimeout,
+		},
+		clients: map[string]pooledClient{},
+	}
+}
+
+// Client returns the client of a shop, created with token when the pool has
+// no client of the shop or one created with another token
+func (p *ClientPool) Client(shopName, token string) (*Client, error) {
+	shop := ShopFullName(shopName)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if pooled, ok := p.clients[shop]; ok && pooled.token == token {
+		return pooled.client, nil
+	}
+
+	opts := append([]Option{WithHTTPClient(p.httpClient)}, p.opts...)
+	client, err := NewClient(p.app, shop, token, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if client.rateLimiter == nil {
+		if p.limiter == nil {
+			p.limiter = NewLeakyBucket(client.cache)
+		}
+		client.rateLimiter = p.limiter
+	}
+	p.clients[shop] = pooledClient{client: client, token: token}
+	return client, nil
+}
+
+// Remove drops the client of a shop, e.g. once the app is uninstalled
+func (p *ClientPool) Remove(shopName string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.clients, ShopFullName(shopName))
+}
+
+// Len returns the number of shops the pool has a client for
+func (p *ClientPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.clients)
+}
@@ -0,0 +1,116 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestClientPool(t *testing.T) {
+	pool := NewClientPool(app, WithVersion(testApiVersion))
+
+	first, err := pool.Client("fooshop", "abcd")
+	if err != nil {
+		t.Fatalf("ClientPool.Client returned error: %v", err)
+	}
+	if again, _ := pool.Client("fooshop.myshopify.com", "abcd"); again != first {
+		t.Error("ClientPool.Client created a new client for the same shop and token")
+	}
+
+	rotated, _ := pool.Client("fooshop", "efgh")
+	if rotated == first || rotated.token != "efgh" {
+		t.Error("ClientPool.Client reused the client of another token")
+	}
+
+	other, _ := pool.Client("barshop", "abcd")
+	if other.Client != rotated.Client || other.rateLimiter != rotated.rateLimiter {
+		t.Error("ClientPool.Client returned clients not sharing the http client and rate limiter")
+	}
+	if pool.Len() != 2 {
+		t.Errorf("ClientPool.Len returned %d, expected 2", pool.Len())
+	}
+
+	store := NewMemoryCacheStore()
+	shared, _ := NewClientPool(app, WithCacheStore(store)).Client("fooshop", "abcd")
+	if bucket, ok := shared.rateLimiter.(*LeakyBucket); !ok || bucket.store != store {
+		t.Error("ClientPool.Client returned a client whose buckets are not kept in its cache store")
+	}
+
+	pool.Remove("fooshop")
+	if pool.Len() != 1 {
+		t.Errorf("ClientPool.Len returned %d after Remove, expected 1", pool.Len())
+	}
+}
+
+func TestClientPoolBuckets(t *testing.T) {
+	pool := NewClientPool(app, WithVersion(testApiVersion))
+	httpmock.ActivateNonDefault(pool.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	for shop, used := range map[string]string{"fooshop": "39/40", "barshop": "1/40"} {
+		responder := httpmock.NewStringResponder(200, `{"shop":{"id":1}}`).HeaderSet(http.Header{"X-Shopify-Shop-Api-Call-Limit": {used}})
+		httpmock.RegisterResponder("GET", "https://"+shop+".myshopify.com/admin/api/"+testApiVersion+"/shop.json", responder)
+
+		client, _ := pool.Client(shop, "abcd")
+		if _, err := client.Shop.Get(context.Background(), nil); err != nil {
+			t.Fatalf("Shop.Get returned error: %v", err)
+		}
+	}
+
+	foo, _ := pool.limiter.load(context.Background(), "fooshop.myshopify.com")
+	bar, _ := pool.limiter.load(context.Background(), "barshop.myshopify.com")
+	if foo == nil || bar == nil || foo.Level <= bar.Level {
+		t.Errorf("ClientPool modelled buckets %+v and %+v, expected independent buckets per shop", foo, bar)
+	}
+}
+
+func TestClientPoolConcurrentRequests(t *testing.T) {
+	pool := NewClientPool(app, WithRetry(3))
+	httpmock.ActivateNonDefault(pool.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	unavailable := httpmock.NewStringResponder(http.StatusServiceUnavailable, "")
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/shop.json", func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("fields") == "unavailable" {
+			return unavailable(req)
+		}
+		resp := httpmock.NewStringResponse(http.StatusOK, `{"shop":{"id":1}}`)
+		resp.Header.Set("X-Shopify-Shop-Api-Call-Limit", "1/40")
+		resp.Header.Set("X-Shopify-API-Version", testApiVersion)
+		return resp, nil
+	})
+
+	client, err := pool.Client("fooshop", "abcd")
+	if err != nil {
+		t.Fatalf("ClientPool.Client returned error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Shop.Get(context.Background(), nil); err != nil {
+				t.Errorf("Shop.Get returned error: %v", err)
+			}
+			client.CurrentRateLimits()
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := client.Shop.GetWithFields(context.Background(), "unavailable")
+			var responseError ResponseError
+			if !errors.As(err, &responseError) || responseError.Retries != 2 {
+				t.Errorf("Shop.Get returned %#v, expected a 503 after 2 retries", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if limits := client.CurrentRateLimits(); limits.BucketSize != 40 {
+		t.Errorf("CurrentRateLimits returned %+v, expected the call limit of the responses", limits)
+	}
+}
@@ -3,6 +3,7 @@ package goshopify
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"time"
 )
 
@@ -13,8 +14,11 @@ const collectsBasePath = "collects"
 // See: https://help.shopify.com/api/reference/products/collect
 type CollectService interface {
 	List(context.Context, interface{}) ([]Collect, error)
+	ListAll(context.Context, interface{}) ([]Collect, error)
+	ListWithPagination(context.Context, interface{}) ([]Collect, *Pagination, error)
 	Count(context.Context, interface{}) (int, error)
 	Get(context.Context, uint64, interface{}) (*Collect, error)
+	GetByProductAndCollection(context.Context, uint64, uint64) (*Collect, error)
 	Create(context.Context, Collect) (*Collect, error)
 	Delete(context.Context, uint64) error
 }
@@ -37,6 +41,13 @@ type Collect struct {
 	SortValue    string     `json:"sort_value,omitempty"`
 }
 
+// CollectListOptions filters the collects of List and Count
+type CollectListOptions struct {
+	ListOptions
+	ProductId    uint64 `url:"product_id,omitempty"`
+	CollectionId uint64 `url:"collection_id,omitempty"`
+}
+
 // Represents the result from the collects/X.json endpoint
 type CollectResource struct {
 	Collect *Collect `json:"collect"`
@@ -49,10 +60,47 @@ type CollectsResource struct {
 
 // List collects
 func (s *CollectServiceOp) List(ctx context.Context, options interface{}) ([]Collect, error) {
+	collects, _, err := s.ListWithPagination(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	return collects, nil
+}
+
+// ListAll lists all collects, iterating over pages
+func (s *CollectServiceOp) ListAll(ctx context.Context, options interface{}) ([]Collect, error) {
+	collector := []Collect{}
+
+	for {
+		entities, pagination, err := s.ListWithPagination(ctx, options)
+
+		if err != nil {
+			return collector, err
+		}
+
+		collector = append(collector, entities...)
+
+		if pagination.NextPageOptions == nil {
+			break
+		}
+
+		options = pagination.NextPageOptions
+	}
+
+	return collector, nil
+}
+
+// ListWithPagination lists collects and return pagination to retrieve next/previous results.
+func (s *CollectServiceOp) ListWithPagination(ctx context.Context, options interface{}) ([]Collect, *Pagination, error) {
 	path := fmt.Sprintf("%s.json", collectsBasePath)
 	resource := new(CollectsResource)
-	err := s.client.Get(ctx, path, resource, options)
-	return resource.Collects, err
+
+	pagination, err := s.client.ListWithPagination(ctx, path, resource, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resource.Collects, pagination, nil
 }
 
 // Count collects
@@ -69,6 +117,28 @@ func (s *CollectServiceOp) Get(ctx context.Context, collectId uint64, options in
 	return resource.Collect, err
 }
 
+// GetByProductAndCollection finds the collect linking a product to a custom
+// collection, e.g. to delete it. The error matches ErrNotFound when the
+// product is not in the collection.
+func (s *CollectServiceOp) GetByProductAndCollection(ctx context.Context, productId, collectionId uint64) (*Collect, error) {
+	options := CollectListOptions{
+		ListOptions:  ListOptions{Limit: 1},
+		ProductId:    productId,
+		CollectionId: collectionId,
+	}
+	collects, err := s.List(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	if len(collects) == 0 {
+		return nil, ResponseError{
+			Status:  http.StatusNotFound,
+			Message: fmt.Sprintf("no collect for product %d in collection %d", productId, collectionId),
+		}
+	}
+	return &collects[0], nil
+}
+
 // Create collects
 func (s *CollectServiceOp) Create(ctx context.Context, collect Collect) (*Collect, error) {
 	path := fmt.Sprintf("%s.json", collectsBasePath)
@@ -2,7 +2,9 @@ package goshopify
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
 	"testing"
 
@@ -133,3 +135,61 @@ func TestCollectDelete(t *testing.T) {
 		t.Errorf("Collect.Delete returned error: %v", err)
 	}
 }
+
+func TestCollectListAll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/collects.json", client.pathPrefix)
+	httpmock.RegisterResponder("GET", listURL,
+		func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("page_info") == "pg2" {
+				return httpmock.NewStringResponse(200, `{"collects": [{"id":3}]}`), nil
+			}
+			resp := httpmock.NewStringResponse(200, `{"collects": [{"id":1},{"id":2}]}`)
+			resp.Header.Set("Link", fmt.Sprintf(`<%s?page_info=pg2&limit=2>; rel="next"`, listURL))
+			return resp, nil
+		})
+
+	collects, err := client.Collect.ListAll(context.Background(), CollectListOptions{ListOptions: ListOptions{Limit: 2}})
+	if err != nil {
+		t.Errorf("Collect.ListAll returned error: %v", err)
+	}
+
+	expected := []Collect{{Id: 1}, {Id: 2}, {Id: 3}}
+	if !reflect.DeepEqual(collects, expected) {
+		t.Errorf("Collect.ListAll returned %+v, expected %+v", collects, expected)
+	}
+}
+
+func TestCollectGetByProductAndCollection(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{"product_id": "632910392", "collection_id": "841564295", "limit": "1"}
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/collects.json", client.pathPrefix),
+		params, httpmock.NewStringResponder(200, `{"collects": [{"id":1,"product_id":632910392,"collection_id":841564295}]}`))
+
+	collect, err := client.Collect.GetByProductAndCollection(context.Background(), 632910392, 841564295)
+	if err != nil {
+		t.Fatalf("Collect.GetByProductAndCollection returned error: %v", err)
+	}
+
+	expected := &Collect{Id: 1, ProductId: 632910392, CollectionId: 841564295}
+	if !reflect.DeepEqual(collect, expected) {
+		t.Errorf("Collect.GetByProductAndCollection returned %+v, expected %+v", collect, expected)
+	}
+}
+
+func TestCollectGetByProductAndCollectionNotFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/collects.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"collects": []}`))
+
+	_, err := client.Collect.GetByProductAndCollection(context.Background(), 1, 2)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Collect.GetByProductAndCollection returned %v, expected ErrNotFound", err)
+	}
+}
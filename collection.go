@@ -37,6 +37,18 @@ type Collection struct {
 	PublishedScope string     `json:"published_scope"`
 }
 
+// CollectionListOptions represents the filters of the custom_collections.json
+// and smart_collections.json endpoints
+type CollectionListOptions struct {
+	ListOptions
+	Handle          string    `url:"handle,omitempty"`
+	Title           string    `url:"title,omitempty"`
+	ProductId       uint64    `url:"product_id,omitempty"`
+	PublishedAtMin  time.Time `url:"published_at_min,omitempty"`
+	PublishedAtMax  time.Time `url:"published_at_max,omitempty"`
+	PublishedStatus string    `url:"published_status,omitempty"`
+}
+
 // Represents the result from the collections/X.json endpoint
 type CollectionResource struct {
 	Collection *Collection `json:"collection"`
@@ -0,0 +1,72 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+)
+
+// collectionImageUpdate is the body of a collection update which only
+// changes the image, a nil Image is sent as null to remove it
+type collectionImageUpdate struct {
+	Id    uint64 `json:"id"`
+	Image *Image `json:"image"`
+}
+
+// ImageFromURL returns an image which Shopify downloads from src
+func ImageFromURL(src, alt string) Image {
+	return Image{Src: src, Alt: alt}
+}
+
+// ImageFromReader returns an image uploaded as a base64 attachment with the
+// content of r
+func ImageFromReader(r io.Reader, filename, alt string) (Image, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return Image{}, err
+	}
+	return Image{
+		Attachment: base64.StdEncoding.EncodeToString(content),
+		Filename:   filename,
+		Alt:        alt,
+	}, nil
+}
+
+// SetImage replaces the image of a custom collection, see ImageFromURL and
+// ImageFromReader
+func (s *CustomCollectionServiceOp) SetImage(ctx context.Context, collectionId uint64, image Image) (*CustomCollection, error) {
+	return s.updateImage(ctx, collectionId, &image)
+}
+
+// RemoveImage removes the image of a custom collection
+func (s *CustomCollectionServiceOp) RemoveImage(ctx context.Context, collectionId uint64) (*CustomCollection, error) {
+	return s.updateImage(ctx, collectionId, nil)
+}
+
+func (s *CustomCollectionServiceOp) updateImage(ctx context.Context, collectionId uint64, image *Image) (*CustomCollection, error) {
+	path := fmt.Sprintf("%s/%d.json", customCollectionsBasePath, collectionId)
+	wrappedData := map[string]collectionImageUpdate{"custom_collection": {Id: collectionId, Image: image}}
+	resource := new(CustomCollectionResource)
+	err := s.client.Put(ctx, path, wrappedData, resource)
+	return resource.Collection, err
+}
+
+// SetImage replaces the image of a smart collection, see ImageFromURL and
+// ImageFromReader
+func (s *SmartCollectionServiceOp) SetImage(ctx context.Context, collectionId uint64, image Image) (*SmartCollection, error) {
+	return s.updateImage(ctx, collectionId, &image)
+}
+
+// RemoveImage removes the image of a smart collection
+func (s *SmartCollectionServiceOp) RemoveImage(ctx context.Context, collectionId uint64) (*SmartCollection, error) {
+	return s.updateImage(ctx, collectionId, nil)
+}
+
+func (s *SmartCollectionServiceOp) updateImage(ctx context.Context, collectionId uint64, image *Image) (*SmartCollection, error) {
+	path := fmt.Sprintf("%s/%d.json", smartCollectionsBasePath, collectionId)
+	wrappedData := map[string]collectionImageUpdate{"smart_collection": {Id: collectionId, Image: image}}
+	resource := new(SmartCollectionResource)
+	err := s.client.Put(ctx, path, wrappedData, resource)
+	return resource.Collection, err
+}
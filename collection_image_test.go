@@ -0,0 +1,92 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func registerCollectionImageResponder(path, body string, sent *string) {
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/%s", client.pathPrefix, path),
+		func(req *http.Request) (*http.Response, error) {
+			b, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			*sent = string(b)
+			return httpmock.NewStringResponse(200, body), nil
+		})
+}
+
+func TestCustomCollectionSetImage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent string
+	registerCollectionImageResponder("custom_collections/1.json", `{"custom_collection":{"id":1,"image":{"src":"https://example.com/a.png"}}}`, &sent)
+
+	collection, err := client.CustomCollection.SetImage(context.Background(), 1, ImageFromURL("https://example.com/a.png", "A"))
+	if err != nil {
+		t.Fatalf("CustomCollection.SetImage returned error: %v", err)
+	}
+
+	expected := `{"custom_collection":{"id":1,"image":{"src":"https://example.com/a.png","alt":"A"}}}`
+	if sent != expected {
+		t.Errorf("CustomCollection.SetImage sent %s, expected %s", sent, expected)
+	}
+	if collection.Image.Src != "https://example.com/a.png" {
+		t.Errorf("CustomCollection.SetImage returned image %+v", collection.Image)
+	}
+}
+
+func TestCustomCollectionRemoveImage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent string
+	registerCollectionImageResponder("custom_collections/1.json", `{"custom_collection":{"id":1}}`, &sent)
+
+	if _, err := client.CustomCollection.RemoveImage(context.Background(), 1); err != nil {
+		t.Fatalf("CustomCollection.RemoveImage returned error: %v", err)
+	}
+
+	expected := `{"custom_collection":{"id":1,"image":null}}`
+	if sent != expected {
+		t.Errorf("CustomCollection.RemoveImage sent %s, expected %s", sent, expected)
+	}
+}
+
+func TestSmartCollectionSetAndRemoveImage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent string
+	registerCollectionImageResponder("smart_collections/2.json", `{"smart_collection":{"id":2}}`, &sent)
+
+	image, err := ImageFromReader(strings.NewReader("png"), "a.png", "")
+	if err != nil {
+		t.Fatalf("ImageFromReader returned error: %v", err)
+	}
+	if _, err := client.SmartCollection.SetImage(context.Background(), 2, image); err != nil {
+		t.Fatalf("SmartCollection.SetImage returned error: %v", err)
+	}
+
+	expected := `{"smart_collection":{"id":2,"image":{"attachment":"cG5n","filename":"a.png"}}}`
+	if sent != expected {
+		t.Errorf("SmartCollection.SetImage sent %s, expected %s", sent, expected)
+	}
+
+	if _, err := client.SmartCollection.RemoveImage(context.Background(), 2); err != nil {
+		t.Fatalf("SmartCollection.RemoveImage returned error: %v", err)
+	}
+
+	expected = `{"smart_collection":{"id":2,"image":null}}`
+	if sent != expected {
+		t.Errorf("SmartCollection.RemoveImage sent %s, expected %s", sent, expected)
+	}
+}
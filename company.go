@@ -0,0 +1,758 @@
+package goshopify
+
+import (
+	"context"
+	"time"
+)
+
+const companyFields = `
+      id
+      name
+      note
+      externalId
+      customerSince
+      createdAt
+      updatedAt`
+
+const companyLocationFields = `
+      id
+      name
+      externalId
+      phone
+      locale
+      note
+      createdAt
+      updatedAt
+      billingAddress {` + companyAddressFields + `
+      }
+      shippingAddress {` + companyAddressFields + `
+      }
+      buyerExperienceConfiguration {
+        checkoutToDraft
+        paymentTermsTemplate {
+          id
+        }
+      }`
+
+const companyAddressFields = `
+        address1
+        address2
+        city
+        zip
+        countryCode
+        zoneCode
+        recipient
+        phone`
+
+const companyContactFields = `
+      id
+      title
+      locale
+      isMainContact
+      createdAt
+      updatedAt
+      customer {
+        id
+        email
+        firstName
+        lastName
+        phone
+      }`
+
+const companiesQuery = `query companies($query: String, $after: String) {
+  companies(first: 50, query: $query, after: $after) {
+    nodes {` + companyFields + `
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+const companyQuery = `query company($id: ID!) {
+  company(id: $id) {` + companyFields + `
+  }
+}`
+
+const companyCreateMutation = `mutation companyCreate($input: CompanyCreateInput!) {
+  companyCreate(input: $input) {
+    company {` + companyFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const companyUpdateMutation = `mutation companyUpdate($companyId: ID!, $input: CompanyInput!) {
+  companyUpdate(companyId: $companyId, input: $input) {
+    company {` + companyFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const companyLocationsQuery = `query companyLocations($companyId: ID!, $after: String) {
+  company(id: $companyId) {
+    locations(first: 50, after: $after) {
+      nodes {` + companyLocationFields + `
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+const companyLocationQuery = `query companyLocation($id: ID!) {
+  companyLocation(id: $id) {` + companyLocationFields + `
+  }
+}`
+
+const companyLocationCreateMutation = `mutation companyLocationCreate($companyId: ID!, $input: CompanyLocationInput!) {
+  companyLocationCreate(companyId: $companyId, input: $input) {
+    companyLocation {` + companyLocationFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const companyLocationUpdateMutation = `mutation companyLocationUpdate($companyLocationId: ID!, $input: CompanyLocationUpdateInput!) {
+  companyLocationUpdate(companyLocationId: $companyLocationId, input: $input) {
+    companyLocation {` + companyLocationFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const companyContactsQuery = `query companyContacts($companyId: ID!, $after: String) {
+  company(id: $companyId) {
+    contacts(first: 50, after: $after) {
+      nodes {` + companyContactFields + `
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+const companyContactQuery = `query companyContact($id: ID!) {
+  companyContact(id: $id) {` + companyContactFields + `
+  }
+}`
+
+const companyContactCreateMutation = `mutation companyContactCreate($companyId: ID!, $input: CompanyContactInput!) {
+  companyContactCreate(companyId: $companyId, input: $input) {
+    companyContact {` + companyContactFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const companyContactUpdateMutation = `mutation companyContactUpdate($companyContactId: ID!, $input: CompanyContactInput!) {
+  companyContactUpdate(companyContactId: $companyContactId, input: $input) {
+    companyContact {` + companyContactFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+// CompanyService is an interface for interfacing with the B2B company
+// endpoints of the Shopify API. Ids are numeric as with the REST resources
+// and converted to graphql ids.
+// See: https://shopify.dev/docs/apps/build/b2b
+type CompanyService interface {
+	List(context.Context, string) ([]Company, error)
+	Get(context.Context, uint64) (*Company, error)
+	Create(context.Context, CompanyCreate) (*Company, error)
+	Update(context.Context, Company) (*Company, error)
+}
+
+// CompanyServiceOp handles communication with the company related methods of
+// the Shopify API.
+type CompanyServiceOp struct {
+	client *Client
+}
+
+// CompanyLocationService is an interface for interfacing with the locations
+// of B2B companies with the Shopify API.
+type CompanyLocationService interface {
+	List(context.Context, uint64) ([]CompanyLocation, error)
+	Get(context.Context, uint64) (*CompanyLocation, error)
+	Create(context.Context, uint64, CompanyLocation) (*CompanyLocation, error)
+	Update(context.Context, CompanyLocation) (*CompanyLocation, error)
+}
+
+// CompanyLocationServiceOp handles communication with the company location
+// related methods of the Shopify API.
+type CompanyLocationServiceOp struct {
+	client *Client
+}
+
+// CompanyContactService is an interface for interfacing with the contacts of
+// B2B companies with the Shopify API.
+type CompanyContactService interface {
+	List(context.Context, uint64) ([]CompanyContact, error)
+	Get(context.Context, uint64) (*CompanyContact, error)
+	Create(context.Context, uint64, CompanyContact) (*CompanyContact, error)
+	Update(context.Context, CompanyContact) (*CompanyContact, error)
+}
+
+// CompanyContactServiceOp handles communication with the company contact
+// related methods of the Shopify API.
+type CompanyContactServiceOp struct {
+	client *Client
+}
+
+// Company represents a Shopify B2B company
+type Company struct {
+	Id            uint64     `json:"id,omitempty"`
+	Name          string     `json:"name,omitempty"`
+	Note          string     `json:"note,omitempty"`
+	ExternalId    string     `json:"external_id,omitempty"`
+	CustomerSince *time.Time `json:"customer_since,omitempty"`
+	CreatedAt     *time.Time `json:"created_at,omitempty"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+}
+
+// CompanyCreate represents a company created along with its first location
+// and main contact, both optional
+type CompanyCreate struct {
+	Company         Company
+	CompanyLocation *CompanyLocation
+	CompanyContact  *CompanyContact
+}
+
+// CompanyAddress represents the billing or shipping address of a company
+// location
+type CompanyAddress struct {
+	Address1    string `json:"address1,omitempty"`
+	Address2    string `json:"address2,omitempty"`
+	City        string `json:"city,omitempty"`
+	Zip         string `json:"zip,omitempty"`
+	CountryCode string `json:"countryCode,omitempty"`
+	ZoneCode    string `json:"zoneCode,omitempty"`
+	Recipient   string `json:"recipient,omitempty"`
+	Phone       string `json:"phone,omitempty"`
+}
+
+// CompanyLocation represents a location of a B2B company. PaymentTermsTemplateId
+// is the graphql id of the payment terms applied to its orders and
+// CheckoutToDraft sends its checkouts to review as draft orders.
+type CompanyLocation struct {
+	Id                     uint64          `json:"id,omitempty"`
+	Name                   string          `json:"name,omitempty"`
+	ExternalId             string          `json:"external_id,omitempty"`
+	Phone                  string          `json:"phone,omitempty"`
+	Locale                 string          `json:"locale,omitempty"`
+	Note                   string          `json:"note,omitempty"`
+	BillingAddress         *CompanyAddress `json:"billing_address,omitempty"`
+	ShippingAddress        *CompanyAddress `json:"shipping_address,omitempty"`
+	PaymentTermsTemplateId string          `json:"payment_terms_template_id,omitempty"`
+	CheckoutToDraft        bool            `json:"checkout_to_draft,omitempty"`
+	CreatedAt              *time.Time      `json:"created_at,omitempty"`
+	UpdatedAt              *time.Time      `json:"updated_at,omitempty"`
+}
+
+// CompanyContact represents a customer buying for a B2B company
+type CompanyContact struct {
+	Id            uint64     `json:"id,omitempty"`
+	CustomerId    uint64     `json:"customer_id,omitempty"`
+	Email         string     `json:"email,omitempty"`
+	FirstName     string     `json:"first_name,omitempty"`
+	LastName      string     `json:"last_name,omitempty"`
+	Phone         string     `json:"phone,omitempty"`
+	Title         string     `json:"title,omitempty"`
+	Locale        string     `json:"locale,omitempty"`
+	IsMainContact bool       `json:"is_main_contact,omitempty"`
+	CreatedAt     *time.Time `json:"created_at,omitempty"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+}
+
+type companyNode struct {
+	Id            string     `json:"id"`
+	Name          string     `json:"name"`
+	Note          string     `json:"note"`
+	ExternalId    string     `json:"externalId"`
+	CustomerSince *time.Time `json:"customerSince"`
+	CreatedAt     *time.Time `json:"createdAt"`
+	UpdatedAt     *time.Time `json:"updatedAt"`
+}
+
+func (n *companyNode) company() (*Company, error) {
+	if n == nil {
+		return nil, nil
+	}
+	_, id, err := ParseGid(n.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &Company{
+		Id:            id,
+		Name:          n.Name,
+		Note:          n.Note,
+		ExternalId:    n.ExternalId,
+		CustomerSince: n.CustomerSince,
+		CreatedAt:     n.CreatedAt,
+		UpdatedAt:     n.UpdatedAt,
+	}, nil
+}
+
+type companyLocationNode struct {
+	Id                           string          `json:"id"`
+	Name                         string          `json:"name"`
+	ExternalId                   string          `json:"externalId"`
+	Phone                        string          `json:"phone"`
+	Locale                       string          `json:"locale"`
+	Note                         string          `json:"note"`
+	BillingAddress               *CompanyAddress `json:"billingAddress"`
+	ShippingAddress              *CompanyAddress `json:"shippingAddress"`
+	BuyerExperienceConfiguration *struct {
+		CheckoutToDraft      bool `json:"checkoutToDraft"`
+		PaymentTermsTemplate *struct {
+			Id string `json:"id"`
+		} `json:"paymentTermsTemplate"`
+	} `json:"buyerExperienceConfiguration"`
+	CreatedAt *time.Time `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt"`
+}
+
+func (n *companyLocationNode) companyLocation() (*CompanyLocation, error) {
+	if n == nil {
+		return nil, nil
+	}
+	_, id, err := ParseGid(n.Id)
+	if err != nil {
+		return nil, err
+	}
+	location := &CompanyLocation{
+		Id:              id,
+		Name:            n.Name,
+		ExternalId:      n.ExternalId,
+		Phone:           n.Phone,
+		Locale:          n.Locale,
+		Note:            n.Note,
+		BillingAddress:  n.BillingAddress,
+		ShippingAddress: n.ShippingAddress,
+		CreatedAt:       n.CreatedAt,
+		UpdatedAt:       n.UpdatedAt,
+	}
+	if config := n.BuyerExperienceConfiguration; config != nil {
+		location.CheckoutToDraft = config.CheckoutToDraft
+		if config.PaymentTermsTemplate != nil {
+			location.PaymentTermsTemplateId = config.PaymentTermsTemplate.Id
+		}
+	}
+	return location, nil
+}
+
+type companyContactNode struct {
+	Id            string     `json:"id"`
+	Title         string     `json:"title"`
+	Locale        string     `json:"locale"`
+	IsMainContact bool       `json:"isMainContact"`
+	CreatedAt     *time.Time `json:"createdAt"`
+	UpdatedAt     *time.Time `json:"updatedAt"`
+	Customer      *struct {
+		Id        string `json:"id"`
+		Email     string `json:"email"`
+		FirstName string `json:"firstName"`
+		LastName  string `json:"lastName"`
+		Phone     string `json:"phone"`
+	} `json:"customer"`
+}
+
+func (n *companyContactNode) companyContact() (*CompanyContact, error) {
+	if n == nil {
+		return nil, nil
+	}
+	_, id, err := ParseGid(n.Id)
+	if err != nil {
+		return nil, err
+	}
+	contact := &CompanyContact{
+		Id:            id,
+		Title:         n.Title,
+		Locale:        n.Locale,
+		IsMainContact: n.IsMainContact,
+		CreatedAt:     n.CreatedAt,
+		UpdatedAt:     n.UpdatedAt,
+	}
+	if customer := n.Customer; customer != nil {
+		if _, contact.CustomerId, err = ParseGid(customer.Id); err != nil {
+			return nil, err
+		}
+		contact.Email = customer.Email
+		contact.FirstName = customer.FirstName
+		contact.LastName = customer.LastName
+		contact.Phone = customer.Phone
+	}
+	return contact, nil
+}
+
+// input returns a CompanyInput
+func (c Company) input() map[string]interface{} {
+	input := map[string]interface{}{}
+	setIfNotEmpty(input, "name", c.Name)
+	setIfNotEmpty(input, "note", c.Note)
+	setIfNotEmpty(input, "externalId", c.ExternalId)
+	if c.CustomerSince != nil {
+		input["customerSince"] = c.CustomerSince
+	}
+	return input
+}
+
+// input returns a CompanyLocationInput, or a CompanyLocationUpdateInput
+// without the addresses and buyer experience when update is true
+func (l CompanyLocation) input(update bool) map[string]interface{} {
+	input := map[string]interface{}{}
+	setIfNotEmpty(input, "name", l.Name)
+	setIfNotEmpty(input, "externalId", l.ExternalId)
+	setIfNotEmpty(input, "phone", l.Phone)
+	setIfNotEmpty(input, "locale", l.Locale)
+	setIfNotEmpty(input, "note", l.Note)
+	if update {
+		return input
+	}
+	if l.BillingAddress != nil {
+		input["billingAddress"] = l.BillingAddress
+	}
+	if l.ShippingAddress != nil {
+		input["shippingAddress"] = l.ShippingAddress
+	}
+	if l.PaymentTermsTemplateId != "" || l.CheckoutToDraft {
+		config := map[string]interface{}{"checkoutToDraft": l.CheckoutToDraft}
+		setIfNotEmpty(config, "paymentTermsTemplateId", l.PaymentTermsTemplateId)
+		input["buyerExperienceConfiguration"] = config
+	}
+	return input
+}
+
+// input returns a CompanyContactInput
+func (c CompanyContact) input() map[string]interface{} {
+	input := map[string]interface{}{}
+	setIfNotEmpty(input, "email", c.Email)
+	setIfNotEmpty(input, "firstName", c.FirstName)
+	setIfNotEmpty(input, "lastName", c.LastName)
+	setIfNotEmpty(input, "phone", c.Phone)
+	setIfNotEmpty(input, "title", c.Title)
+	setIfNotEmpty(input, "locale", c.Locale)
+	return input
+}
+
+func setIfNotEmpty(input map[string]interface{}, key, value string) {
+	if value != "" {
+		input[key] = value
+	}
+}
+
+// List returns the companies matching query, in the search syntax of the
+// Shopify admin, or every company when query is empty
+func (s *CompanyServiceOp) List(ctx context.Context, query string) ([]Company, error) {
+	companies := []Company{}
+	vars := map[string]interface{}{}
+	if query != "" {
+		vars["query"] = query
+	}
+
+	for {
+		resp := struct {
+			Companies struct {
+				Nodes    []companyNode   `json:"nodes"`
+				PageInfo GraphQLPageInfo `json:"pageInfo"`
+			} `json:"companies"`
+		}{}
+		err := s.client.GraphQL.Query(ctx, companiesQuery, vars, &resp)
+		if err != nil {
+			return nil, err
+		}
+		for i := range resp.Companies.Nodes {
+			company, err := resp.Companies.Nodes[i].company()
+			if err != nil {
+				return nil, err
+			}
+			companies = append(companies, *company)
+		}
+
+		if !resp.Companies.PageInfo.HasNextPage {
+			return companies, nil
+		}
+		vars["after"] = resp.Companies.PageInfo.EndCursor
+	}
+}
+
+// Get individual company, nil when it does not exist
+func (s *CompanyServiceOp) Get(ctx context.Context, companyId uint64) (*Company, error) {
+	resp := struct {
+		Company *companyNode `json:"company"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, companyQuery, map[string]interface{}{"id": NewGid("Company", companyId)}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Company.company()
+}
+
+// Create a new company
+func (s *CompanyServiceOp) Create(ctx context.Context, create CompanyCreate) (*Company, error) {
+	input := map[string]interface{}{"company": create.Company.input()}
+	if create.CompanyLocation != nil {
+		input["companyLocation"] = create.CompanyLocation.input(false)
+	}
+	if create.CompanyContact != nil {
+		input["companyContact"] = create.CompanyContact.input()
+	}
+	resp := struct {
+		CompanyCreate struct {
+			Company    *companyNode       `json:"company"`
+			UserErrors []GraphQLUserError `json:"userErrors"`
+		} `json:"companyCreate"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, companyCreateMutation, map[string]interface{}{"input": input}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := userErrorsToResponseError(resp.CompanyCreate.UserErrors); err != nil {
+		return nil, err
+	}
+	return resp.CompanyCreate.Company.company()
+}
+
+// Update an existing company
+func (s *CompanyServiceOp) Update(ctx context.Context, company Company) (*Company, error) {
+	vars := map[string]interface{}{
+		"companyId": NewGid("Company", company.Id),
+		"input":     company.input(),
+	}
+	resp := struct {
+		CompanyUpdate struct {
+			Company    *companyNode       `json:"company"`
+			UserErrors []GraphQLUserError `json:"userErrors"`
+		} `json:"companyUpdate"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, companyUpdateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := userErrorsToResponseError(resp.CompanyUpdate.UserErrors); err != nil {
+		return nil, err
+	}
+	return resp.CompanyUpdate.Company.company()
+}
+
+// List returns the locations of a company
+func (s *CompanyLocationServiceOp) List(ctx context.Context, companyId uint64) ([]CompanyLocation, error) {
+	locations := []CompanyLocation{}
+	vars := map[string]interface{}{"companyId": NewGid("Company", companyId)}
+
+	for {
+		resp := struct {
+			Company *struct {
+				Locations struct {
+					Nodes    []companyLocationNode `json:"nodes"`
+					PageInfo GraphQLPageInfo       `json:"pageInfo"`
+				} `json:"locations"`
+			} `json:"company"`
+		}{}
+		err := s.client.GraphQL.Query(ctx, companyLocationsQuery, vars, &resp)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Company == nil {
+			return locations, nil
+		}
+		for i := range resp.Company.Locations.Nodes {
+			location, err := resp.Company.Locations.Nodes[i].companyLocation()
+			if err != nil {
+				return nil, err
+			}
+			locations = append(locations, *location)
+		}
+
+		if !resp.Company.Locations.PageInfo.HasNextPage {
+			return locations, nil
+		}
+		vars["after"] = resp.Company.Locations.PageInfo.EndCursor
+	}
+}
+
+// Get individual company location, nil when it does not exist
+func (s *CompanyLocationServiceOp) Get(ctx context.Context, locationId uint64) (*CompanyLocation, error) {
+	resp := struct {
+		CompanyLocation *companyLocationNode `json:"companyLocation"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, companyLocationQuery, map[string]interface{}{"id": NewGid("CompanyLocation", locationId)}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.CompanyLocation.companyLocation()
+}
+
+// Create a new location for a company
+func (s *CompanyLocationServiceOp) Create(ctx context.Context, companyId uint64, location CompanyLocation) (*CompanyLocation, error) {
+	vars := map[string]interface{}{
+		"companyId": NewGid("Company", companyId),
+		"input":     location.input(false),
+	}
+	resp := struct {
+		CompanyLocationCreate struct {
+			CompanyLocation *companyLocationNode `json:"companyLocation"`
+			UserErrors      []GraphQLUserError   `json:"userErrors"`
+		} `json:"companyLocationCreate"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, companyLocationCreateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := userErrorsToResponseError(resp.CompanyLocationCreate.UserErrors); err != nil {
+		return nil, err
+	}
+	return resp.CompanyLocationCreate.CompanyLocation.companyLocation()
+}
+
+// Update the name, contact details and note of an existing company location
+func (s *CompanyLocationServiceOp) Update(ctx context.Context, location CompanyLocation) (*CompanyLocation, error) {
+	vars := map[string]interface{}{
+		"companyLocationId": NewGid("CompanyLocation", location.Id),
+		"input":             location.input(true),
+	}
+	resp := struct {
+		CompanyLocationUpdate struct {
+			CompanyLocation *companyLocationNode `json:"companyLocation"`
+			UserErrors      []GraphQLUserError   `json:"userErrors"`
+		} `json:"companyLocationUpdate"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, companyLocationUpdateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := userErrorsToResponseError(resp.CompanyLocationUpdate.UserErrors); err != nil {
+		return nil, err
+	}
+	return resp.CompanyLocationUpdate.CompanyLocation.companyLocation()
+}
+
+// List returns the contacts of a company
+func (s *CompanyContactServiceOp) List(ctx context.Context, companyId uint64) ([]CompanyContact, error) {
+	contacts := []CompanyContact{}
+	vars := map[string]interface{}{"companyId": NewGid("Company", companyId)}
+
+	for {
+		resp := struct {
+			Company *struct {
+				Contacts struct {
+					Nodes    []companyContactNode `json:"nodes"`
+					PageInfo GraphQLPageInfo      `json:"pageInfo"`
+				} `json:"contacts"`
+			} `json:"company"`
+		}{}
+		err := s.client.GraphQL.Query(ctx, companyContactsQuery, vars, &resp)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Company == nil {
+			return contacts, nil
+		}
+		for i := range resp.Company.Contacts.Nodes {
+			contact, err := resp.Company.Contacts.Nodes[i].companyContact()
+			if err != nil {
+				return nil, err
+			}
+			contacts = append(contacts, *contact)
+		}
+
+		if !resp.Company.Contacts.PageInfo.HasNextPage {
+			return contacts, nil
+		}
+		vars["after"] = resp.Company.Contacts.PageInfo.EndCursor
+	}
+}
+
+// Get individual company contact, nil when it does not exist
+func (s *CompanyContactServiceOp) Get(ctx context.Context, contactId uint64) (*CompanyContact, error) {
+	resp := struct {
+		CompanyContact *companyContactNode `json:"companyContact"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, companyContactQuery, map[string]interface{}{"id": NewGid("CompanyContact", contactId)}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.CompanyContact.companyContact()
+}
+
+// Create a new contact for a company, the customer is created from the
+// contact details
+func (s *CompanyContactServiceOp) Create(ctx context.Context, companyId uint64, contact CompanyContact) (*CompanyContact, error) {
+	vars := map[string]interface{}{
+		"companyId": NewGid("Company", companyId),
+		"input":     contact.input(),
+	}
+	resp := struct {
+		CompanyContactCreate struct {
+			CompanyContact *companyContactNode `json:"companyContact"`
+			UserErrors     []GraphQLUserError  `json:"userErrors"`
+		} `json:"companyContactCreate"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, companyContactCreateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := userErrorsToResponseError(resp.CompanyContactCreate.UserErrors); err != nil {
+		return nil, err
+	}
+	return resp.CompanyContactCreate.CompanyContact.companyContact()
+}
+
+// Update an existing company contact
+func (s *CompanyContactServiceOp) Update(ctx context.Context, contact CompanyContact) (*CompanyContact, error) {
+	vars := map[string]interface{}{
+		"companyContactId": NewGid("CompanyContact", contact.Id),
+		"input":            contact.input(),
+	}
+	resp := struct {
+		CompanyContactUpdate struct {
+			CompanyContact *companyContactNode `json:"companyContact"`
+			UserErrors     []GraphQLUserError  `json:"userErrors"`
+		} `json:"companyContactUpdate"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, companyContactUpdateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := userErrorsToResponseError(resp.CompanyContactUpdate.UserErrors); err != nil {
+		return nil, err
+	}
+	return resp.CompanyContactUpdate.CompanyContact.companyContact()
+}
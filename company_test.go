@@ -0,0 +1,114 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestCompanyList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	captured := graphQLTestRequest{}
+	registerGraphQLResponder(`{"data":{"companies":{"nodes":[{"id":"gid://shopify/Company/1","name":"Acme","externalId":"A-1"}],"pageInfo":{"hasNextPage":false}}}}`, &captured)
+
+	companies, err := client.Company.List(context.Background(), "name:Acme")
+	if err != nil {
+		t.Fatalf("Company.List returned error: %v", err)
+	}
+
+	expected := []Company{{Id: 1, Name: "Acme", ExternalId: "A-1"}}
+	if !reflect.DeepEqual(companies, expected) {
+		t.Errorf("Company.List returned %+v, expected %+v", companies, expected)
+	}
+	if captured.Variables["query"] != "name:Acme" {
+		t.Errorf("Company.List sent query %v", captured.Variables["query"])
+	}
+}
+
+func TestCompanyCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	captured := graphQLTestRequest{}
+	registerGraphQLResponder(`{"data":{"companyCreate":{"company":{"id":"gid://shopify/Company/1","name":"Acme"},"userErrors":[]}}}`, &captured)
+
+	company, err := client.Company.Create(context.Background(), CompanyCreate{
+		Company:         Company{Name: "Acme"},
+		CompanyLocation: &CompanyLocation{Name: "HQ", CheckoutToDraft: true},
+	})
+	if err != nil {
+		t.Fatalf("Company.Create returned error: %v", err)
+	}
+	if company.Id != 1 || company.Name != "Acme" {
+		t.Errorf("Company.Create returned %+v", company)
+	}
+
+	expected := map[string]interface{}{
+		"company": map[string]interface{}{"name": "Acme"},
+		"companyLocation": map[string]interface{}{
+			"name":                         "HQ",
+			"buyerExperienceConfiguration": map[string]interface{}{"checkoutToDraft": true},
+		},
+	}
+	if !reflect.DeepEqual(captured.Variables["input"], expected) {
+		t.Errorf("Company.Create sent input %+v, expected %+v", captured.Variables["input"], expected)
+	}
+}
+
+func TestCompanyUpdateUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerGraphQLResponder(`{"data":{"companyUpdate":{"company":null,"userErrors":[{"field":["input","name"],"message":"is too long"}]}}}`, nil)
+
+	_, err := client.Company.Update(context.Background(), Company{Id: 1, Name: "Acme"})
+	if err == nil || err.Error() != "input.name: is too long" {
+		t.Errorf("Company.Update returned error %v", err)
+	}
+}
+
+func TestCompanyLocationGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerGraphQLResponder(`{"data":{"companyLocation":{"id":"gid://shopify/CompanyLocation/2","name":"HQ","billingAddress":{"city":"Ottawa","countryCode":"CA"},"buyerExperienceConfiguration":{"checkoutToDraft":true,"paymentTermsTemplate":{"id":"gid://shopify/PaymentTermsTemplate/4"}}}}}`, nil)
+
+	location, err := client.CompanyLocation.Get(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("CompanyLocation.Get returned error: %v", err)
+	}
+
+	expected := &CompanyLocation{
+		Id:                     2,
+		Name:                   "HQ",
+		BillingAddress:         &CompanyAddress{City: "Ottawa", CountryCode: "CA"},
+		PaymentTermsTemplateId: "gid://shopify/PaymentTermsTemplate/4",
+		CheckoutToDraft:        true,
+	}
+	if !reflect.DeepEqual(location, expected) {
+		t.Errorf("CompanyLocation.Get returned %+v, expected %+v", location, expected)
+	}
+}
+
+func TestCompanyContactList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	captured := graphQLTestRequest{}
+	registerGraphQLResponder(`{"data":{"company":{"contacts":{"nodes":[{"id":"gid://shopify/CompanyContact/3","title":"Buyer","isMainContact":true,"customer":{"id":"gid://shopify/Customer/5","email":"buyer@example.com"}}],"pageInfo":{"hasNextPage":false}}}}}`, &captured)
+
+	contacts, err := client.CompanyContact.List(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("CompanyContact.List returned error: %v", err)
+	}
+
+	expected := []CompanyContact{{Id: 3, CustomerId: 5, Email: "buyer@example.com", Title: "Buyer", IsMainContact: true}}
+	if !reflect.DeepEqual(contacts, expected) {
+		t.Errorf("CompanyContact.List returned %+v, expected %+v", contacts, expected)
+	}
+	if captured.Variables["companyId"] != "gid://shopify/Company/1" {
+		t.Errorf("CompanyContact.List sent companyId %v", captured.Variables["companyId"])
+	}
+}
@@ -0,0 +1,119 @@
+package goshopify
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// AcceptEncoding is the Accept-Encoding header of the requests of clients
+// created WithCompressedResponses, the responses compressed with one of its
+// encodings are decompressed before they reach the middlewares. A request
+// sets another with RequestHeader.
+const AcceptEncoding = "gzip, deflate"
+
+// compressBody gzips body, the body of req, when WithGzipRequests is set and
+// the body is large enough, and returns the body to send
+func (c *Client) compressBody(req *http.Request, body []byte) ([]byte, error) {
+	if c.gzipRequestsMinSize == 0 || len(body) < c.gzipRequestsMinSize || req.Header.Get("Content-Encoding") != "" {
+		return body, nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(body); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	compressed := buf.Bytes()
+	req.Header.Set("Content-Encoding", "gzip")
+	req.ContentLength = int64(len(compressed))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(compressed)), nil
+	}
+	return compressed, nil
+}
+
+// decompress decompresses the bodies of the responses of next encoded with
+// gzip or deflate, for clients created WithCompressedResponses. The http
+// transport only does so when it sets Accept-Encoding itself, which those
+// clients do instead to advertise deflate.
+func decompress(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		resp, err := next(req)
+		if err != nil {
+			return resp, err
+		}
+		if err := decompressBody(req, resp); err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		return resp, nil
+	}
+}
+
+// decompressBody replaces the body of resp, the response to req, with its
+// decompressed content. Responses without a body, e.g. to a HEAD request or a
+// 204, and responses in another encoding, e.g. br from a proxy, are left as
+// they are.
+func decompressBody(req *http.Request, resp *http.Response) error {
+	if !hasResponseBody(req, resp) {
+		return nil
+	}
+
+	var reader io.ReadCloser
+	var err error
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "gzip", "x-gzip":
+		reader, err = gzip.NewReader(resp.Body)
+	case "deflate":
+		reader, err = zlib.NewReader(resp.Body)
+	default:
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("decompress response: %w", err)
+	}
+
+	resp.Body = decompressedBody{ReadCloser: reader, compressed: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
+
+// hasResponseBody reports whether resp, the response to req, may have a body
+// to decompress
+func hasResponseBody(req *http.Request, resp *http.Response) bool {
+	if req.Method == http.MethodHead || resp.ContentLength == 0 || resp.Body == nil || resp.Body == http.NoBody {
+		return false
+	}
+	switch resp.StatusCode {
+	case http.StatusNoContent, http.StatusNotModified:
+		return false
+	}
+	return true
+}
+
+// decompressedBody is the decompressed body of a response, closing the
+// compressed body with it
+type decompressedBody struct {
+	io.ReadCloser
+	compressed io.Closer
+}
+
+func (b decompressedBody) Close() error {
+	err := b.ReadCloser.Close()
+	if closeErr := b.compressed.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,224 @@
+package goshopify
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestCompressedResponses(t *testing.T) {
+	setup()
+	defer teardown()
+	WithCompressedResponses()(client)
+
+	body := `{"products":[{"id":1},{"id":2}]}`
+	var gzipped, deflated bytes.Buffer
+	gw := gzip.NewWriter(&gzipped)
+	gw.Write([]byte(body))
+	gw.Close()
+	zw := zlib.NewWriter(&deflated)
+	zw.Write([]byte(body))
+	zw.Close()
+
+	cases := []struct {
+		encoding string
+		body     []byte
+	}{
+		{"", []byte(body)},
+		{"gzip", gzipped.Bytes()},
+		{"deflate", deflated.Bytes()},
+	}
+
+	for _, c := range cases {
+		t.Run(c.encoding, func(t *testing.T) {
+			var acceptEncoding string
+			httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
+				func(req *http.Request) (*http.Response, error) {
+					acceptEncoding = req.Header.Get("Accept-Encoding")
+					resp := httpmock.NewBytesResponse(200, c.body)
+					if c.encoding != "" {
+						resp.Header.Set("Content-Encoding", c.encoding)
+					}
+					return resp, nil
+				})
+
+			products, err := client.Product.List(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("Product.List returned error: %v", err)
+			}
+			if len(products) != 2 || products[1].Id != 2 {
+				t.Errorf("Product.List returned %+v, expected products 1 and 2", products)
+			}
+			if acceptEncoding != AcceptEncoding {
+				t.Errorf("request sent with Accept-Encoding %q, expected %q", acceptEncoding, AcceptEncoding)
+			}
+		})
+	}
+}
+
+func TestAcceptEncodingOptIn(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var acceptEncoding string
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			acceptEncoding = req.Header.Get("Accept-Encoding")
+			return httpmock.NewStringResponse(200, `{"products":[]}`), nil
+		})
+
+	if _, err := client.Product.List(context.Background(), nil); err != nil {
+		t.Fatalf("Product.List returned error: %v", err)
+	}
+	if acceptEncoding != "" {
+		t.Errorf("request sent with Accept-Encoding %q, expected none without WithCompressedResponses", acceptEncoding)
+	}
+}
+
+func TestCompressedResponseEmpty(t *testing.T) {
+	setup()
+	defer teardown()
+	WithCompressedResponses()(client)
+
+	cases := []struct {
+		method string
+		status int
+	}{
+		{http.MethodHead, http.StatusOK},
+		{http.MethodDelete, http.StatusNoContent},
+		{http.MethodGet, http.StatusNotModified},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%s %d", c.method, c.status), func(t *testing.T) {
+			httpmock.RegisterResponder(c.method, fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+				func(req *http.Request) (*http.Response, error) {
+					resp := httpmock.NewBytesResponse(c.status, nil)
+					resp.Header.Set("Content-Encoding", "gzip")
+					return resp, nil
+				})
+
+			req, err := client.NewRequest(context.Background(), c.method, fmt.Sprintf("%s/products/1.json", client.pathPrefix), nil, nil)
+			if err != nil {
+				t.Fatalf("NewRequest returned error: %v", err)
+			}
+			resp, err := client.DoWithResponse(req, nil)
+			if err != nil && strings.Contains(err.Error(), "decompress response") {
+				t.Errorf("%s returned %v, expected the empty body not to be decompressed", c.method, err)
+			}
+			if resp == nil || resp.StatusCode != c.status {
+				t.Errorf("%s returned response %+v, %v, expected status %d", c.method, resp, err, c.status)
+			}
+		})
+	}
+}
+
+func TestCompressedResponseOtherEncoding(t *testing.T) {
+	for _, compressed := range []bool{false, true} {
+		t.Run(fmt.Sprintf("compressed responses %t", compressed), func(t *testing.T) {
+			setup()
+			defer teardown()
+			if compressed {
+				WithCompressedResponses()(client)
+			}
+
+			var encoding string
+			httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
+				func(req *http.Request) (*http.Response, error) {
+					resp := httpmock.NewStringResponse(200, `{"products":[{"id":1}]}`)
+					resp.Header.Set("Content-Encoding", "br")
+					return resp, nil
+				})
+			client.Use(func(next RoundTripFunc) RoundTripFunc {
+				return func(req *http.Request) (*http.Response, error) {
+					resp, err := next(req)
+					if resp != nil {
+						encoding = resp.Header.Get("Content-Encoding")
+					}
+					return resp, err
+				}
+			})
+
+			products, err := client.Product.List(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("Product.List returned error: %v", err)
+			}
+			if len(products) != 1 {
+				t.Errorf("Product.List returned %+v, expected product 1", products)
+			}
+			if encoding != "br" {
+				t.Errorf("middleware got Content-Encoding %q, expected the response to pass through as br", encoding)
+			}
+		})
+	}
+}
+
+func TestCompressedResponseInvalid(t *testing.T) {
+	setup()
+	defer teardown()
+	WithCompressedResponses()(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(200, `{"products":[]}`)
+			resp.Header.Set("Content-Encoding", "gzip")
+			return resp, nil
+		})
+
+	_, err := client.Product.List(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "decompress response") {
+		t.Errorf("Product.List returned %v, expected a decompression error", err)
+	}
+}
+
+func TestWithGzipRequests(t *testing.T) {
+	setup()
+	defer teardown()
+	WithGzipRequests(100)(client)
+
+	var encodings, bodies []string
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			encodings = append(encodings, req.Header.Get("Content-Encoding"))
+			var reader io.Reader = req.Body
+			if req.Header.Get("Content-Encoding") == "gzip" {
+				gr, err := gzip.NewReader(req.Body)
+				if err != nil {
+					return nil, err
+				}
+				reader = gr
+			}
+			body, err := io.ReadAll(reader)
+			if err != nil {
+				return nil, err
+			}
+			bodies = append(bodies, string(body))
+			if len(bodies) == 1 {
+				return httpmock.NewStringResponse(503, `{"errors":"Unavailable"}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"product":{"id":1}}`), nil
+		})
+
+	large := Product{Id: 1, BodyHTML: strings.Repeat("<p>Lorem ipsum</p>", 10)}
+	if _, err := client.Product.Update(context.Background(), large); err != nil {
+		t.Fatalf("Product.Update returned error: %v", err)
+	}
+	if _, err := client.Product.Update(context.Background(), Product{Id: 1, Title: "Small"}); err != nil {
+		t.Fatalf("Product.Update returned error: %v", err)
+	}
+
+	if len(encodings) != 3 || encodings[0] != "gzip" || encodings[1] != "gzip" || encodings[2] != "" {
+		t.Errorf("requests were sent with Content-Encoding %q, expected gzip for the large body and its retry only", encodings)
+	}
+	if len(bodies) == 3 && (bodies[0] != bodies[1] || !strings.Contains(bodies[0], "Lorem ipsum")) {
+		t.Errorf("requests were sent with bodies %q, expected the large product twice", bodies)
+	}
+}
@@ -0,0 +1,143 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	// defaultRESTBucketSize is the REST bucket of a standard shop
+	defaultRESTBucketSize = 40
+
+	// defaultGraphQLBucketSize and defaultGraphQLRestoreRate are the graphql
+	// cost limits of a standard shop
+	defaultGraphQLBucketSize  = 1000
+	defaultGraphQLRestoreRate = 50
+
+	// graphQLMutationCost is the cost of a mutation returning a few fields
+	graphQLMutationCost = 10
+
+	// metafieldsSetLimit is the most metafields a metafieldsSet call accepts
+	metafieldsSetLimit = 25
+)
+
+// SyncPlan describes a planned sync writing Products products, each with
+// MetafieldsPerProduct metafields
+type SyncPlan struct {
+	Products             int
+	MetafieldsPerProduct int
+}
+
+// CostEstimate is the estimated cost of a SyncPlan over each transport.
+// Durations are the time spent waiting for the rate limits, network latency
+// is not included.
+type CostEstimate struct {
+	RESTCalls       int
+	RESTDuration    time.Duration
+	GraphQLCalls    int
+	GraphQLCost     int
+	GraphQLDuration time.Duration
+}
+
+// String returns a one line report of the estimate
+func (e CostEstimate) String() string {
+	return fmt.Sprintf("REST: %d calls in %s, GraphQL: %d calls costing %d points in %s",
+		e.RESTCalls, e.RESTDuration, e.GraphQLCalls, e.GraphQLCost, e.GraphQLDuration)
+}
+
+// CostEstimator estimates how long a sync takes under the rate limits of a
+// shop, so large jobs can be planned, e.g.
+//
+//	estimate := goshopify.NewCostEstimator(client).Estimate(goshopify.SyncPlan{Products: 10000, MetafieldsPerProduct: 5})
+//	log.Println(estimate)
+type CostEstimator struct {
+	// REST bucket size and number of calls it leaks per second
+	RESTBucketSize int
+	RESTLeakRate   float64
+
+	// REST calls already in the bucket when the sync starts
+	RESTRequestCount int
+
+	// RESTReserve is the number of calls the rate limiter leaves free in the
+	// bucket
+	RESTReserve int
+
+	// graphql points available when the sync starts and points restored per
+	// second
+	GraphQLAvailable   float64
+	GraphQLRestoreRate float64
+}
+
+// NewCostEstimator returns a CostEstimator using the REST bucket modelled by
+// the LeakyBucket of the client, including its reserve, when one is set with
+// WithRateLimiter or by a ClientPool. Otherwise it uses the limits last
+// reported to the client, or the limits of a standard shop before any request
+// was made.
+func NewCostEstimator(c *Client) *CostEstimator {
+	e := &CostEstimator{
+		RESTBucketSize:     defaultRESTBucketSize,
+		RESTLeakRate:       restLeakRate,
+		RESTReserve:        restPacingReserve,
+		GraphQLAvailable:   defaultGraphQLBucketSize,
+		GraphQLRestoreRate: defaultGraphQLRestoreRate,
+	}
+	limits := c.CurrentRateLimits()
+	limiter, _ := c.rateLimiter.(*LeakyBucket)
+	if limiter != nil {
+		limiter = e.useLeakyBucket(limiter, c.shop)
+	}
+	if limiter == nil && limits.BucketSize > 0 {
+		e.RESTBucketSize = limits.BucketSize
+		e.RESTRequestCount = limits.RequestCount
+		// Plus shops have a bucket of 400 leaking 20 calls per second
+		e.RESTLeakRate = restLeakRate * float64(limits.BucketSize) / defaultRESTBucketSize
+	}
+	if cost := limits.GraphQLCost; cost != nil && cost.ThrottleStatus.MaximumAvailable > 0 {
+		e.GraphQLAvailable = cost.ThrottleStatus.CurrentlyAvailable
+		e.GraphQLRestoreRate = cost.ThrottleStatus.RestoreRate
+	}
+	return e
+}
+
+// useLeakyBucket sets the REST limits from the bucket of shop modelled by
+// limiter, it returns nil when the bucket cannot be read from its store
+func (e *CostEstimator) useLeakyBucket(limiter *LeakyBucket, shop string) *LeakyBucket {
+	bucket, err := limiter.current(context.Background(), shop)
+	if err != nil {
+		return nil
+	}
+	e.RESTBucketSize = bucket.Size
+	e.RESTRequestCount = int(math.Ceil(bucket.Level))
+	e.RESTLeakRate = bucket.leakRate()
+	e.RESTReserve = limiter.Reserve
+	return limiter
+}
+
+// Estimate returns the cost of plan. Over REST every product and metafield is
+// written with its own call, over graphql products are written one per
+// mutation and metafields are batched by 25 in metafieldsSet.
+func (e *CostEstimator) Estimate(plan SyncPlan) CostEstimate {
+	metafields := plan.Products * plan.MetafieldsPerProduct
+	estimate := CostEstimate{
+		RESTCalls:    plan.Products + metafields,
+		GraphQLCalls: plan.Products + int(math.Ceil(float64(metafields)/metafieldsSetLimit)),
+	}
+	estimate.GraphQLCost = estimate.GraphQLCalls * graphQLMutationCost
+
+	// calls fill the free part of the bucket at once, the others wait for it
+	// to leak
+	restFree := e.RESTBucketSize - e.RESTRequestCount - e.RESTReserve
+	if overflow := estimate.RESTCalls - restFree; overflow > 0 && e.RESTLeakRate > 0 {
+		estimate.RESTDuration = secondsToDuration(float64(overflow) / e.RESTLeakRate)
+	}
+	if overflow := float64(estimate.GraphQLCost) - e.GraphQLAvailable; overflow > 0 && e.GraphQLRestoreRate > 0 {
+		estimate.GraphQLDuration = secondsToDuration(overflow / e.GraphQLRestoreRate)
+	}
+	return estimate
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds)) * time.Second
+}
@@ -0,0 +1,78 @@
+package goshopify
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCostEstimatorDefaults(t *testing.T) {
+	setup()
+	defer teardown()
+
+	estimate := NewCostEstimator(client).Estimate(SyncPlan{Products: 1000, MetafieldsPerProduct: 5})
+
+	expected := CostEstimate{
+		RESTCalls: 6000,
+		// 38 calls fit in the bucket, the others leak at 2 per second
+		RESTDuration: 2981 * time.Second,
+		GraphQLCalls: 1200,
+		GraphQLCost:  12000,
+		// 11000 points restored at 50 per second
+		GraphQLDuration: 220 * time.Second,
+	}
+	if estimate != expected {
+		t.Errorf("CostEstimator.Estimate returned %+v, expected %+v", estimate, expected)
+	}
+	if s := estimate.String(); s != "REST: 6000 calls in 49m41s, GraphQL: 1200 calls costing 12000 points in 3m40s" {
+		t.Errorf("CostEstimate.String returned %s", s)
+	}
+}
+
+func TestCostEstimatorReportedLimits(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.RateLimits.BucketSize = 400
+	client.RateLimits.RequestCount = 100
+	client.RateLimits.GraphQLCost = &GraphQLCost{ThrottleStatus: GraphQLThrottleStatus{MaximumAvailable: 2000, CurrentlyAvailable: 500, RestoreRate: 100}}
+
+	estimate := NewCostEstimator(client).Estimate(SyncPlan{Products: 100})
+	if estimate.RESTCalls != 100 || estimate.RESTDuration != 0 {
+		t.Errorf("CostEstimator.Estimate returned REST %d calls in %s", estimate.RESTCalls, estimate.RESTDuration)
+	}
+	if estimate.GraphQLCost != 1000 || estimate.GraphQLDuration != 5*time.Second {
+		t.Errorf("CostEstimator.Estimate returned GraphQL cost %d in %s", estimate.GraphQLCost, estimate.GraphQLDuration)
+	}
+
+	estimate = NewCostEstimator(client).Estimate(SyncPlan{Products: 1000})
+	// 298 calls fit in the bucket, the others leak at 20 per second
+	if estimate.RESTDuration != 36*time.Second {
+		t.Errorf("CostEstimator.Estimate returned REST duration %s", estimate.RESTDuration)
+	}
+}
+
+func TestCostEstimatorLeakyBucket(t *testing.T) {
+	setup()
+	defer teardown()
+
+	limiter := NewLeakyBucket(nil)
+	limiter.Reserve = 10
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+	limiter.Update(client.shop, 30, 80)
+	client.rateLimiter = limiter
+	// the limits last reported are ignored in favor of the limiter
+	client.RateLimits.BucketSize = 40
+	client.RateLimits.RequestCount = 40
+
+	estimator := NewCostEstimator(client)
+	if estimator.RESTBucketSize != 80 || estimator.RESTRequestCount != 30 || estimator.RESTReserve != 10 || estimator.RESTLeakRate != 4 {
+		t.Errorf("NewCostEstimator returned %+v", estimator)
+	}
+
+	// 40 calls fit in the bucket, the others leak at 4 per second
+	estimate := estimator.Estimate(SyncPlan{Products: 100})
+	if estimate.RESTDuration != 15*time.Second {
+		t.Errorf("CostEstimator.Estimate returned REST duration %s", estimate.RESTDuration)
+	}
+}
@@ -0,0 +1,71 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+const countriesBasePath = "countries"
+
+// CountryService is an interface for interfacing with the country endpoints
+// of the Shopify API.
+// See: https://shopify.dev/docs/api/admin-rest/latest/resources/country
+type CountryService interface {
+	List(context.Context, interface{}) ([]Country, error)
+	Get(context.Context, uint64, interface{}) (*Country, error)
+}
+
+// CountryServiceOp handles communication with the country related methods of
+// the Shopify API.
+type CountryServiceOp struct {
+	client *Client
+}
+
+// Country represents a country the shop charges taxes in, with its provinces
+type Country struct {
+	Id        uint64           `json:"id,omitempty"`
+	Code      string           `json:"code,omitempty"`
+	Name      string           `json:"name,omitempty"`
+	Tax       *decimal.Decimal `json:"tax,omitempty"`
+	TaxName   string           `json:"tax_name,omitempty"`
+	Provinces []Province       `json:"provinces,omitempty"`
+}
+
+// Province represents a province, state or region of a country
+type Province struct {
+	Id        uint64           `json:"id,omitempty"`
+	CountryId uint64           `json:"country_id,omitempty"`
+	Code      string           `json:"code,omitempty"`
+	Name      string           `json:"name,omitempty"`
+	Tax       *decimal.Decimal `json:"tax,omitempty"`
+	TaxName   string           `json:"tax_name,omitempty"`
+	TaxType   string           `json:"tax_type,omitempty"`
+}
+
+// CountriesResource represents the result from the countries.json endpoint
+type CountriesResource struct {
+	Countries []Country `json:"countries"`
+}
+
+// CountryResource represents the result from the countries/X.json endpoint
+type CountryResource struct {
+	Country *Country `json:"country"`
+}
+
+// List countries
+func (s *CountryServiceOp) List(ctx context.Context, options interface{}) ([]Country, error) {
+	path := fmt.Sprintf("%s.json", countriesBasePath)
+	resource := new(CountriesResource)
+	err := s.client.Get(ctx, path, resource, options)
+	return resource.Countries, err
+}
+
+// Get individual country
+func (s *CountryServiceOp) Get(ctx context.Context, countryId uint64, options interface{}) (*Country, error) {
+	path := fmt.Sprintf("%s/%d.json", countriesBasePath, countryId)
+	resource := new(CountryResource)
+	err := s.client.Get(ctx, path, resource, options)
+	return resource.Country, err
+}
@@ -0,0 +1,41 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestCountryList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/countries.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"countries":[{"id":1,"code":"CA","name":"Canada","provinces":[{"id":2,"country_id":1,"code":"ON","name":"Ontario"}]}]}`))
+
+	countries, err := client.Country.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Country.List returned error: %v", err)
+	}
+	if len(countries) != 1 || countries[0].Code != "CA" || len(countries[0].Provinces) != 1 || countries[0].Provinces[0].Name != "Ontario" {
+		t.Errorf("Country.List returned %+v", countries)
+	}
+}
+
+func TestCountryGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/countries/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"country":{"id":1,"code":"CA","name":"Canada"}}`))
+
+	country, err := client.Country.Get(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("Country.Get returned error: %v", err)
+	}
+	if country.Id != 1 || country.Name != "Canada" {
+		t.Errorf("Country.Get returned %+v", country)
+	}
+}
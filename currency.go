@@ -0,0 +1,19 @@
+package goshopify
+
+import "strings"
+
+// currencies whose minor unit is not 2 decimals, see ISO 4217
+var currencyDecimals = map[string]int32{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// CurrencyDecimals returns the number of decimals of the minor unit of the
+// ISO 4217 currency code, e.g. 2 for USD and 0 for JPY
+func CurrencyDecimals(currency string) int32 {
+	if decimals, ok := currencyDecimals[strings.ToUpper(currency)]; ok {
+		return decimals
+	}
+	return 2
+}
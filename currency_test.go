@@ -0,0 +1,17 @@
+package goshopify
+
+import "testing"
+
+func TestCurrencyDecimals(t *testing.T) {
+	cases := map[string]int32{
+		"USD": 2,
+		"jpy": 0,
+		"KWD": 3,
+		"":    2,
+	}
+	for currency, expected := range cases {
+		if actual := CurrencyDecimals(currency); actual != expected {
+			t.Errorf("CurrencyDecimals(%s) returned %d, expected %d", currency, actual, expected)
+		}
+	}
+}
@@ -21,6 +21,8 @@ type CustomCollectionService interface {
 	Create(context.Context, CustomCollection) (*CustomCollection, error)
 	Update(context.Context, CustomCollection) (*CustomCollection, error)
 	Delete(context.Context, uint64) error
+	SetImage(context.Context, uint64, Image) (*CustomCollection, error)
+	RemoveImage(context.Context, uint64) (*CustomCollection, error)
 
 	// MetafieldsService used for CustomCollection resource to communicate with Metafields resource
 	MetafieldsService
@@ -46,6 +48,7 @@ type CustomCollection struct {
 	PublishedAt    *time.Time  `json:"published_at,omitempty"`
 	PublishedScope string      `json:"published_scope,omitempty"`
 	Metafields     []Metafield `json:"metafields,omitempty,omitempty"`
+	NullFields     []string    `json:"-"`
 }
 
 // CustomCollectionResource represents the result form the custom_collections/X.json endpoint
@@ -110,6 +113,12 @@ func (s *CustomCollectionServiceOp) ListMetafields(ctx context.Context, customCo
 	return metafieldService.List(ctx, options)
 }
 
+// ListMetafieldsByNamespace lists all metafields of a namespace for a custom collection
+func (s *CustomCollectionServiceOp) ListMetafieldsByNamespace(ctx context.Context, customCollectionId uint64, namespace string) ([]Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: customCollectionsResourceName, resourceId: customCollectionId}
+	return metafieldService.ListByNamespace(ctx, namespace)
+}
+
 // Count metafields for a custom collection
 func (s *CustomCollectionServiceOp) CountMetafields(ctx context.Context, customCollectionId uint64, options interface{}) (int, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: customCollectionsResourceName, resourceId: customCollectionId}
@@ -49,6 +49,34 @@ func TestCustomCollectionList(t *testing.T) {
 	}
 }
 
+func TestCustomCollectionListWithOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{
+		"handle":           "summer",
+		"product_id":       "632910392",
+		"published_status": "published",
+	}
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/custom_collections.json", client.pathPrefix),
+		params, httpmock.NewStringResponder(200, `{"custom_collections": [{"id":1}]}`))
+
+	options := CollectionListOptions{
+		Handle:          "summer",
+		ProductId:       632910392,
+		PublishedStatus: PublishedStatusPublished,
+	}
+	collections, err := client.CustomCollection.List(context.Background(), options)
+	if err != nil {
+		t.Errorf("CustomCollection.List returned error: %v", err)
+	}
+
+	expected := []CustomCollection{{Id: 1}}
+	if !reflect.DeepEqual(collections, expected) {
+		t.Errorf("CustomCollection.List returned %+v, expected %+v", collections, expected)
+	}
+}
+
 func TestCustomCollectionCount(t *testing.T) {
 	setup()
 	defer teardown()
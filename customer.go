@@ -3,6 +3,7 @@ package goshopify
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -28,6 +29,8 @@ type CustomerService interface {
 	Delete(context.Context, uint64) error
 	ListOrders(context.Context, uint64, interface{}) ([]Order, error)
 	ListTags(context.Context, interface{}) ([]string, error)
+	UpdateMarketingConsent(context.Context, uint64, MarketingConsentUpdate) (*Customer, error)
+	ExportSegment(context.Context, string, io.Writer) (int, error)
 
 	// MetafieldsService used for Customer resource to communicate with Metafields resource
 	MetafieldsService
@@ -65,6 +68,8 @@ type Customer struct {
 	CreatedAt                 *time.Time             `json:"created_at,omitempty"`
 	UpdatedAt                 *time.Time             `json:"updated_at,omitempty"`
 	Metafields                []Metafield            `json:"metafields,omitempty"`
+	NullFields                []string               `json:"-"`
+	UnknownJSONFields
 }
 
 // Represents the result from the customers/X.json endpoint
@@ -91,12 +96,16 @@ type CustomerSearchOptions struct {
 	Query  string `url:"query,omitempty"`
 }
 
+// EmailMarketingConsent represents the email marketing consent of a customer,
+// State is one of the MarketingConsentState constants and OptInLevel one of
+// the MarketingOptInLevel constants
 type EmailMarketingConsent struct {
 	State            string     `json:"state"`
 	OptInLevel       string     `json:"opt_in_level"`
 	ConsentUpdatedAt *time.Time `json:"consent_updated_at"`
 }
 
+// SMSMarketingConsent represents the SMS marketing consent of a customer
 type SMSMarketingConsent struct {
 	State                string     `json:"state"`
 	OptInLevel           string     `json:"opt_in_level"`
@@ -159,6 +168,9 @@ func (s *CustomerServiceOp) Get(ctx context.Context, customerId uint64, options
 	path := fmt.Sprintf("%s/%v.json", customersBasePath, customerId)
 	resource := new(CustomerResource)
 	err := s.client.Get(ctx, path, resource, options)
+	if s.client.needsGraphQLFallback(err) {
+		return s.getFromGraphQL(ctx, customerId, err)
+	}
 	return resource.Customer, err
 }
 
@@ -216,6 +228,12 @@ func (s *CustomerServiceOp) ListMetafields(ctx context.Context, customerId uint6
 	return metafieldService.List(ctx, options)
 }
 
+// ListMetafieldsByNamespace lists all metafields of a namespace for a customer
+func (s *CustomerServiceOp) ListMetafieldsByNamespace(ctx context.Context, customerId uint64, namespace string) ([]Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: customersResourceName, resourceId: customerId}
+	return metafieldService.ListByNamespace(ctx, namespace)
+}
+
 // Count metafields for a customer
 func (s *CustomerServiceOp) CountMetafields(ctx context.Context, customerId uint64, options interface{}) (int, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: customersResourceName, resourceId: customerId}
@@ -0,0 +1,115 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// States of the email and SMS marketing consent of a customer. Redacted and
+// invalid are set by Shopify and cannot be sent.
+const (
+	MarketingConsentStateSubscribed    = "subscribed"
+	MarketingConsentStateNotSubscribed = "not_subscribed"
+	MarketingConsentStatePending       = "pending"
+	MarketingConsentStateUnsubscribed  = "unsubscribed"
+	MarketingConsentStateRedacted      = "redacted"
+	MarketingConsentStateInvalid       = "invalid"
+)
+
+// Opt-in levels of the email and SMS marketing consent of a customer
+const (
+	MarketingOptInLevelSingleOptIn    = "single_opt_in"
+	MarketingOptInLevelConfirmedOptIn = "confirmed_opt_in"
+	MarketingOptInLevelUnknown        = "unknown"
+)
+
+// IsSubscribed reports whether the customer agreed to receive marketing emails
+func (c *EmailMarketingConsent) IsSubscribed() bool {
+	return c != nil && c.State == MarketingConsentStateSubscribed
+}
+
+// IsSubscribed reports whether the customer agreed to receive marketing SMS
+func (c *SMSMarketingConsent) IsSubscribed() bool {
+	return c != nil && c.State == MarketingConsentStateSubscribed
+}
+
+// MarketingConsentUpdate represents a change of the marketing consent of a
+// customer, a nil consent is left unchanged
+type MarketingConsentUpdate struct {
+	Email *EmailMarketingConsent
+	SMS   *SMSMarketingConsent
+}
+
+// consentPayload is a marketing consent sent without the fields left empty
+type consentPayload struct {
+	State                string     `json:"state"`
+	OptInLevel           string     `json:"opt_in_level,omitempty"`
+	ConsentUpdatedAt     *time.Time `json:"consent_updated_at,omitempty"`
+	ConsentCollectedFrom string     `json:"consent_collected_from,omitempty"`
+}
+
+func validateConsentState(field, state string) error {
+	switch state {
+	case MarketingConsentStateSubscribed, MarketingConsentStateNotSubscribed,
+		MarketingConsentStatePending, MarketingConsentStateUnsubscribed:
+		return nil
+	}
+	return ValidationError{Field: field + ".state", Message: fmt.Sprintf("cannot be set to %q", state)}
+}
+
+// Validate checks a consent is given and its states can be sent to Shopify
+func (u MarketingConsentUpdate) Validate() error {
+	if u.Email == nil && u.SMS == nil {
+		return ValidationError{Field: "marketing_consent", Message: "email or sms consent is required"}
+	}
+	if u.Email != nil {
+		if err := validateConsentState("email_marketing_consent", u.Email.State); err != nil {
+			return err
+		}
+	}
+	if u.SMS != nil {
+		if err := validateConsentState("sms_marketing_consent", u.SMS.State); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateMarketingConsent changes the email and/or SMS marketing consent of a
+// customer. Only the consents are sent, so the other fields of the customer
+// and the consent which is not given are left unchanged, unlike updating the
+// consents with Update. It replaces setting accepts_marketing, which Shopify
+// deprecated.
+func (s *CustomerServiceOp) UpdateMarketingConsent(ctx context.Context, customerId uint64, update MarketingConsentUpdate) (*Customer, error) {
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+
+	customer := struct {
+		Id    uint64          `json:"id"`
+		Email *consentPayload `json:"email_marketing_consent,omitempty"`
+		SMS   *consentPayload `json:"sms_marketing_consent,omitempty"`
+	}{Id: customerId}
+	if update.Email != nil {
+		customer.Email = &consentPayload{
+			State:            update.Email.State,
+			OptInLevel:       update.Email.OptInLevel,
+			ConsentUpdatedAt: update.Email.ConsentUpdatedAt,
+		}
+	}
+	if update.SMS != nil {
+		customer.SMS = &consentPayload{
+			State:                update.SMS.State,
+			OptInLevel:           update.SMS.OptInLevel,
+			ConsentUpdatedAt:     update.SMS.ConsentUpdatedAt,
+			ConsentCollectedFrom: update.SMS.ConsentCollectedFrom,
+		}
+	}
+
+	path := fmt.Sprintf("%s/%d.json", customersBasePath, customerId)
+	wrappedData := map[string]interface{}{"customer": customer}
+	resource := new(CustomerResource)
+	err := s.client.Put(ctx, path, wrappedData, resource)
+	return resource.Customer, err
+}
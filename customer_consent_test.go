@@ -0,0 +1,63 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestCustomerUpdateMarketingConsent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body map[string]interface{}
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/1.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			return httpmock.NewStringResponse(200, `{"customer":{"id":1,"email_marketing_consent":{"state":"subscribed","opt_in_level":"confirmed_opt_in"}}}`), nil
+		})
+
+	customer, err := client.Customer.UpdateMarketingConsent(context.Background(), 1, MarketingConsentUpdate{
+		Email: &EmailMarketingConsent{State: MarketingConsentStateSubscribed, OptInLevel: MarketingOptInLevelConfirmedOptIn},
+	})
+	if err != nil {
+		t.Fatalf("Customer.UpdateMarketingConsent returned error: %v", err)
+	}
+	if !customer.EmailMarketingConsent.IsSubscribed() || customer.SMSMarketingConsent.IsSubscribed() {
+		t.Errorf("Customer.UpdateMarketingConsent returned %+v", customer)
+	}
+
+	expected := map[string]interface{}{
+		"customer": map[string]interface{}{
+			"id":                      float64(1),
+			"email_marketing_consent": map[string]interface{}{"state": "subscribed", "opt_in_level": "confirmed_opt_in"},
+		},
+	}
+	if !reflect.DeepEqual(body, expected) {
+		t.Errorf("Customer.UpdateMarketingConsent sent %v, expected %v", body, expected)
+	}
+}
+
+func TestCustomerUpdateMarketingConsentValidation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	for _, update := range []MarketingConsentUpdate{
+		{},
+		{SMS: &SMSMarketingConsent{State: MarketingConsentStateRedacted}},
+	} {
+		_, err := client.Customer.UpdateMarketingConsent(context.Background(), 1, update)
+		var validationError ValidationError
+		if !errors.As(err, &validationError) {
+			t.Errorf("Customer.UpdateMarketingConsent(%+v) returned %v, expected ValidationError", update, err)
+		}
+	}
+}
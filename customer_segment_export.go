@@ -0,0 +1,141 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/shopspring/decimal"
+)
+
+const customerSegmentMembersQuery = `query customerSegmentMembers($query: String!, $after: String) {
+  customerSegmentMembers(first: 250, query: $query, after: $after) {
+    edges {
+      node {
+        id
+        firstName
+        lastName
+        numberOfOrders
+        amountSpent {
+          amount
+          currencyCode
+        }
+        defaultEmailAddress {
+          emailAddress
+          marketingState
+        }
+        defaultPhoneNumber {
+          phoneNumber
+          marketingState
+        }
+      }
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+// CustomerSegmentMember is a customer as exported by ExportSegment, marketing
+// states are in the graphql format, e.g. SUBSCRIBED
+type CustomerSegmentMember struct {
+	Id                  uint64           `json:"id"`
+	FirstName           string           `json:"first_name,omitempty"`
+	LastName            string           `json:"last_name,omitempty"`
+	Email               string           `json:"email,omitempty"`
+	EmailMarketingState string           `json:"email_marketing_state,omitempty"`
+	Phone               string           `json:"phone,omitempty"`
+	SMSMarketingState   string           `json:"sms_marketing_state,omitempty"`
+	NumberOfOrders      uint64           `json:"number_of_orders"`
+	AmountSpent         *decimal.Decimal `json:"amount_spent,omitempty"`
+	Currency            string           `json:"currency,omitempty"`
+}
+
+type customerSegmentMemberNode struct {
+	Id             string `json:"id"`
+	FirstName      string `json:"firstName"`
+	LastName       string `json:"lastName"`
+	NumberOfOrders uint64 `json:"numberOfOrders,string"`
+	AmountSpent    *struct {
+		Amount       *decimal.Decimal `json:"amount"`
+		CurrencyCode string           `json:"currencyCode"`
+	} `json:"amountSpent"`
+	DefaultEmailAddress *struct {
+		EmailAddress   string `json:"emailAddress"`
+		MarketingState string `json:"marketingState"`
+	} `json:"defaultEmailAddress"`
+	DefaultPhoneNumber *struct {
+		PhoneNumber    string `json:"phoneNumber"`
+		MarketingState string `json:"marketingState"`
+	} `json:"defaultPhoneNumber"`
+}
+
+func (n customerSegmentMemberNode) member() (CustomerSegmentMember, error) {
+	_, id, err := ParseGid(n.Id)
+	if err != nil {
+		return CustomerSegmentMember{}, err
+	}
+	member := CustomerSegmentMember{
+		Id:             id,
+		FirstName:      n.FirstName,
+		LastName:       n.LastName,
+		NumberOfOrders: n.NumberOfOrders,
+	}
+	if n.AmountSpent != nil {
+		member.AmountSpent = n.AmountSpent.Amount
+		member.Currency = n.AmountSpent.CurrencyCode
+	}
+	if n.DefaultEmailAddress != nil {
+		member.Email = n.DefaultEmailAddress.EmailAddress
+		member.EmailMarketingState = n.DefaultEmailAddress.MarketingState
+	}
+	if n.DefaultPhoneNumber != nil {
+		member.Phone = n.DefaultPhoneNumber.PhoneNumber
+		member.SMSMarketingState = n.DefaultPhoneNumber.MarketingState
+	}
+	return member, nil
+}
+
+// ExportSegment writes the customers matching a segment query, e.g.
+// "email_subscription_status = 'SUBSCRIBED'", to w as newline delimited JSON
+// CustomerSegmentMember objects. Pages are written as they are received so
+// large segments are not held in memory. It returns the number of customers
+// written.
+// See: https://shopify.dev/docs/api/shopifyql/segment-query-language-reference
+func (s *CustomerServiceOp) ExportSegment(ctx context.Context, segmentQuery string, w io.Writer) (int, error) {
+	encoder := json.NewEncoder(w)
+	vars := map[string]interface{}{"query": segmentQuery}
+	count := 0
+
+	for {
+		resp := struct {
+			CustomerSegmentMembers struct {
+				Edges []struct {
+					Node customerSegmentMemberNode `json:"node"`
+				} `json:"edges"`
+				PageInfo GraphQLPageInfo `json:"pageInfo"`
+			} `json:"customerSegmentMembers"`
+		}{}
+		err := s.client.GraphQL.Query(ctx, customerSegmentMembersQuery, vars, &resp)
+		if err != nil {
+			return count, err
+		}
+
+		for _, edge := range resp.CustomerSegmentMembers.Edges {
+			member, err := edge.Node.member()
+			if err != nil {
+				return count, err
+			}
+			if err := encoder.Encode(member); err != nil {
+				return count, err
+			}
+			count++
+		}
+
+		if !resp.CustomerSegmentMembers.PageInfo.HasNextPage {
+			return count, nil
+		}
+		vars["after"] = resp.CustomerSegmentMembers.PageInfo.EndCursor
+	}
+}
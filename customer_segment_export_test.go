@@ -0,0 +1,44 @@
+package goshopify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestCustomerExportSegment(t *testing.T) {
+	setup()
+	defer teardown()
+
+	pages := []string{
+		`{"data":{"customerSegmentMembers":{"edges":[{"node":{"id":"gid://shopify/CustomerSegmentMember/1","firstName":"Bob","numberOfOrders":"3","amountSpent":{"amount":"12.50","currencyCode":"CAD"},"defaultEmailAddress":{"emailAddress":"bob@example.com","marketingState":"SUBSCRIBED"}}}],"pageInfo":{"hasNextPage":true,"endCursor":"abc"}}}}`,
+		`{"data":{"customerSegmentMembers":{"edges":[{"node":{"id":"gid://shopify/CustomerSegmentMember/2","numberOfOrders":"0"}}],"pageInfo":{"hasNextPage":false}}}}`,
+	}
+	requests := 0
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			page := pages[requests]
+			requests++
+			return httpmock.NewStringResponse(200, page), nil
+		})
+
+	out := &bytes.Buffer{}
+	count, err := client.Customer.ExportSegment(context.Background(), "number_of_orders > 0", out)
+	if err != nil {
+		t.Fatalf("Customer.ExportSegment returned error: %v", err)
+	}
+	if count != 2 || requests != 2 {
+		t.Errorf("Customer.ExportSegment exported %d customers in %d requests, expected 2 in 2", count, requests)
+	}
+
+	expected := `{"id":1,"first_name":"Bob","email":"bob@example.com","email_marketing_state":"SUBSCRIBED","number_of_orders":3,"amount_spent":"12.5","currency":"CAD"}
+{"id":2,"number_of_orders":0}
+`
+	if out.String() != expected {
+		t.Errorf("Customer.ExportSegment wrote\n%s\nexpected\n%s", out.String(), expected)
+	}
+}
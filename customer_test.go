@@ -592,6 +592,25 @@ func TestCustomerListMetafields(t *testing.T) {
 	}
 }
 
+func TestCustomerListMetafieldsByNamespace(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/1/metafields.json", client.pathPrefix),
+		map[string]string{"limit": "250", "namespace": "loyalty"},
+		httpmock.NewStringResponder(200, `{"metafields": [{"id":1},{"id":2}]}`))
+
+	metafields, err := client.Customer.ListMetafieldsByNamespace(context.Background(), 1, "loyalty")
+	if err != nil {
+		t.Errorf("Customer.ListMetafieldsByNamespace() returned error: %v", err)
+	}
+
+	expected := []Metafield{{Id: 1}, {Id: 2}}
+	if !reflect.DeepEqual(metafields, expected) {
+		t.Errorf("Customer.ListMetafieldsByNamespace() returned %+v, expected %+v", metafields, expected)
+	}
+}
+
 func TestCustomerCountMetafields(t *testing.T) {
 	setup()
 	defer teardown()
@@ -0,0 +1,210 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// UnknownJSONFields is embedded in resources to keep the JSON fields the
+// struct does not declare when the client is created with
+// WithUnknownFieldCapture. UnknownFields is nil when every field is known.
+type UnknownJSONFields struct {
+	UnknownFields map[string]json.RawMessage `json:"-"`
+}
+
+func (u *UnknownJSONFields) setUnknownFields(fields map[string]json.RawMessage) {
+	u.UnknownFields = fields
+}
+
+type unknownFieldsSetter interface {
+	setUnknownFields(map[string]json.RawMessage)
+}
+
+var unknownJSONFieldsType = reflect.TypeOf(UnknownJSONFields{})
+
+// decodeHook is a DecodeHook registered with WithDecodeHook, called with a
+// pointer to the decoded resource
+type decodeHook func(reflect.Value, json.RawMessage) error
+
+// decodeResponse decodes body into v, walking the decoded value along with
+// the raw JSON when unknown fields are captured or decode hooks are set.
+// Resources implementing streamDecoder decode the body themselves.
+func (c *Client) decodeResponse(body io.Reader, v interface{}) error {
+	if stream, ok := v.(streamDecoder); ok {
+		return stream.decodeStream(c, body)
+	}
+	if !c.captureUnknownFields && len(c.decodeHooks) == 0 {
+		return json.NewDecoder(body).Decode(&v)
+	}
+
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return err
+	}
+	return walkDecoded(reflect.ValueOf(v), raw, c.visitDecoded)
+}
+
+func (c *Client) visitDecoded(v reflect.Value, raw json.RawMessage) error {
+	if c.captureUnknownFields {
+		if err := captureUnknownFields(v, raw); err != nil {
+			return err
+		}
+	}
+	for _, hook := range c.decodeHooks[v.Elem().Type()] {
+		if err := hook(v, raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkDecoded calls visit with a pointer to every addressable struct of v and
+// the raw JSON it was decoded from
+func walkDecoded(v reflect.Value, raw json.RawMessage, visit func(reflect.Value, json.RawMessage) error) error {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		return walkDecoded(v.Elem(), raw, visit)
+
+	case reflect.Struct:
+		if v.Type() == unknownJSONFieldsType {
+			return nil
+		}
+		if v.CanAddr() {
+			if err := visit(v.Addr(), raw); err != nil {
+				return err
+			}
+		}
+
+		var object map[string]json.RawMessage
+		if json.Unmarshal(raw, &object) != nil {
+			return nil
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, embedded := jsonFieldName(field)
+			if embedded {
+				if err := walkDecoded(v.Field(i), raw, visit); err != nil {
+					return err
+				}
+				continue
+			}
+			if fieldRaw, ok := lookupJSONField(object, name); ok {
+				if err := walkDecoded(v.Field(i), fieldRaw, visit); err != nil {
+					return err
+				}
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		var elements []json.RawMessage
+		if json.Unmarshal(raw, &elements) != nil {
+			return nil
+		}
+		for i := 0; i < v.Len() && i < len(elements); i++ {
+			if err := walkDecoded(v.Index(i), elements[i], visit); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// jsonFieldName returns the JSON name of a struct field, embedded is true for
+// untagged embedded structs whose fields are promoted by encoding/json
+func jsonFieldName(field reflect.StructField) (name string, embedded bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	name = strings.Split(tag, ",")[0]
+	if name == "" && field.Anonymous {
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct {
+			return "", true
+		}
+	}
+	if name == "" {
+		name = field.Name
+	}
+	return name, false
+}
+
+// lookupJSONField finds a key the way encoding/json does, preferring an exact
+// match over a case-insensitive one
+func lookupJSONField(object map[string]json.RawMessage, name string) (json.RawMessage, bool) {
+	if raw, ok := object[name]; ok {
+		return raw, true
+	}
+	for key, raw := range object {
+		if strings.EqualFold(key, name) {
+			return raw, true
+		}
+	}
+	return nil, false
+}
+
+// knownJSONFields collects the JSON names of a struct type, including the
+// fields promoted from embedded structs
+func knownJSONFields(t reflect.Type, known map[string]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		name, embedded := jsonFieldName(field)
+		if embedded {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			knownJSONFields(fieldType, known)
+			continue
+		}
+		if name != "" {
+			known[strings.ToLower(name)] = true
+		}
+	}
+}
+
+func captureUnknownFields(v reflect.Value, raw json.RawMessage) error {
+	setter, ok := v.Interface().(unknownFieldsSetter)
+	if !ok {
+		return nil
+	}
+
+	var object map[string]json.RawMessage
+	if json.Unmarshal(raw, &object) != nil {
+		return nil
+	}
+
+	known := map[string]bool{}
+	knownJSONFields(v.Elem().Type(), known)
+
+	var unknown map[string]json.RawMessage
+	for key, value := range object {
+		if known[strings.ToLower(key)] {
+			continue
+		}
+		if unknown == nil {
+			unknown = map[string]json.RawMessage{}
+		}
+		unknown[key] = value
+	}
+	setter.setUnknownFields(unknown)
+	return nil
+}
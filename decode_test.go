@@ -0,0 +1,119 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+const productWithUnknownFields = `{"product":{
+	"id":1,
+	"title":"Shirt",
+	"Vendor":"Acme",
+	"category":{"id":"gid://shopify/TaxonomyCategory/aa-1"},
+	"variants":[{"id":2,"sku":"SKU-A","unit_price_measurement":{"quantity_unit":"ml"}}]
+}}`
+
+func TestUnknownFieldCapture(t *testing.T) {
+	setup()
+	defer teardown()
+	WithUnknownFieldCapture()(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, productWithUnknownFields))
+
+	product, err := client.Product.Get(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("Product.Get returned error: %v", err)
+	}
+
+	expected := map[string]json.RawMessage{"category": json.RawMessage(`{"id":"gid://shopify/TaxonomyCategory/aa-1"}`)}
+	if !reflect.DeepEqual(product.UnknownFields, expected) {
+		t.Errorf("Product.UnknownFields is %s, expected %s", product.UnknownFields, expected)
+	}
+
+	expected = map[string]json.RawMessage{"unit_price_measurement": json.RawMessage(`{"quantity_unit":"ml"}`)}
+	if !reflect.DeepEqual(product.Variants[0].UnknownFields, expected) {
+		t.Errorf("Variant.UnknownFields is %s, expected %s", product.Variants[0].UnknownFields, expected)
+	}
+
+	if product.Vendor != "Acme" {
+		t.Errorf("Product.Vendor is %s, expected Acme", product.Vendor)
+	}
+}
+
+func TestUnknownFieldCaptureDisabled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, productWithUnknownFields))
+
+	product, err := client.Product.Get(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("Product.Get returned error: %v", err)
+	}
+
+	if product.UnknownFields != nil || product.Variants[0].UnknownFields != nil {
+		t.Errorf("Product.UnknownFields is %s, expected nil", product.UnknownFields)
+	}
+}
+
+func TestDecodeHook(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var raws []string
+	WithDecodeHook(func(v *Variant, raw json.RawMessage) error {
+		v.Sku = "normalized-" + v.Sku
+		raws = append(raws, string(raw))
+		return nil
+	})(client)
+	WithDecodeHook(func(p *Product, raw json.RawMessage) error {
+		p.Title += " (hooked)"
+		return nil
+	})(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, productWithUnknownFields))
+
+	product, err := client.Product.Get(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("Product.Get returned error: %v", err)
+	}
+
+	if product.Title != "Shirt (hooked)" {
+		t.Errorf("Product.Title is %s, expected the product hook to run", product.Title)
+	}
+	if product.Variants[0].Sku != "normalized-SKU-A" {
+		t.Errorf("Variant.Sku is %s, expected the variant hook to run", product.Variants[0].Sku)
+	}
+	if len(raws) != 1 || raws[0] != `{"id":2,"sku":"SKU-A","unit_price_measurement":{"quantity_unit":"ml"}}` {
+		t.Errorf("variant hook was called with %v", raws)
+	}
+	if product.UnknownFields != nil {
+		t.Errorf("Product.UnknownFields is %s, expected nil without WithUnknownFieldCapture", product.UnknownFields)
+	}
+}
+
+func TestDecodeHookError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	hookErr := fmt.Errorf("bad variant")
+	WithDecodeHook(func(v *Variant, raw json.RawMessage) error {
+		return hookErr
+	})(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, productWithUnknownFields))
+
+	_, err := client.Product.Get(context.Background(), 1, nil)
+	if err != hookErr {
+		t.Errorf("Product.Get returned %v, expected the hook error", err)
+	}
+}
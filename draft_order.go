@@ -25,6 +25,11 @@ type DraftOrderService interface {
 	Delete(context.Context, uint64) error
 	Invoice(context.Context, uint64, DraftOrderInvoice) (*DraftOrderInvoice, error)
 	Complete(context.Context, uint64, bool) (*DraftOrder, error)
+	CreateForCompany(context.Context, DraftOrder, CompanyPurchase) (*DraftOrder, error)
+	ApplyPaymentTerms(context.Context, uint64, PaymentTermsInput) error
+	CreatePurchaseOrder(context.Context, DraftOrder, CompanyPurchase, DraftOrderInvoice) (*DraftOrder, error)
+	Duplicate(context.Context, uint64) (*DraftOrder, error)
+	DuplicateOrder(context.Context, uint64) (*DraftOrder, error)
 
 	// MetafieldsService used for DrafT Order resource to communicate with Metafields resource
 	MetafieldsService
@@ -65,7 +70,9 @@ type DraftOrder struct {
 	UpdatedAt       *time.Time       `json:"updated_at,omitempty"`
 	Status          string           `json:"status,omitempty"`
 	// only in request to flag using the customer's default address
-	UseCustomerDefaultAddress bool `json:"use_customer_default_address,omitempty"`
+	UseCustomerDefaultAddress bool     `json:"use_customer_default_address,omitempty"`
+	NullFields                []string `json:"-"`
+	UnknownJSONFields
 }
 
 // AppliedDiscount is the discount applied to the line item or the draft order object.
@@ -188,6 +195,12 @@ func (s *DraftOrderServiceOp) ListMetafields(ctx context.Context, draftOrderId u
 	return metafieldService.List(ctx, options)
 }
 
+// ListMetafieldsByNamespace lists all metafields of a namespace for a draft order
+func (s *DraftOrderServiceOp) ListMetafieldsByNamespace(ctx context.Context, draftOrderId uint64, namespace string) ([]Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: draftOrdersResourceName, resourceId: draftOrderId}
+	return metafieldService.ListByNamespace(ctx, namespace)
+}
+
 // Count metafields for an order
 func (s *DraftOrderServiceOp) CountMetafields(ctx context.Context, draftOrderId uint64, options interface{}) (int, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: draftOrdersResourceName, resourceId: draftOrderId}
@@ -0,0 +1,274 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const draftOrderCreateMutation = `mutation draftOrderCreate($input: DraftOrderInput!) {
+  draftOrderCreate(input: $input) {
+    draftOrder {` + draftOrderB2BFields + `
+    }
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+const paymentTermsCreateMutation = `mutation paymentTermsCreate($referenceId: ID!, $paymentTermsAttributes: PaymentTermsCreateInput!) {
+  paymentTermsCreate(referenceId: $referenceId, paymentTermsAttributes: $paymentTermsAttributes) {
+    paymentTerms {
+      id
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const draftOrderB2BFields = `
+      id
+      name
+      status
+      email
+      invoiceUrl
+      createdAt
+      updatedAt`
+
+// CompanyPurchase identifies the company location a draft order is placed
+// for, the contact ordering on its behalf and optionally the payment terms
+// of the resulting order
+type CompanyPurchase struct {
+	CompanyId         uint64
+	CompanyLocationId uint64
+	CompanyContactId  uint64
+	PaymentTerms      *PaymentTermsInput
+}
+
+// PaymentTermsInput represents the payment terms of a B2B order.
+// TemplateId is the graphql id of a payment terms template, IssuedAt is
+// used by net terms and DueAt by fixed terms.
+type PaymentTermsInput struct {
+	TemplateId string
+	IssuedAt   *time.Time
+	DueAt      *time.Time
+}
+
+func (t PaymentTermsInput) input() map[string]interface{} {
+	input := map[string]interface{}{"paymentTermsTemplateId": t.TemplateId}
+	if t.IssuedAt != nil || t.DueAt != nil {
+		schedule := map[string]interface{}{}
+		if t.IssuedAt != nil {
+			schedule["issuedAt"] = t.IssuedAt
+		}
+		if t.DueAt != nil {
+			schedule["dueAt"] = t.DueAt
+		}
+		input["paymentSchedules"] = []map[string]interface{}{schedule}
+	}
+	return input
+}
+
+func (p CompanyPurchase) validate() error {
+	switch {
+	case p.CompanyId == 0:
+		return ValidationError{Field: "company_id", Message: "is required"}
+	case p.CompanyLocationId == 0:
+		return ValidationError{Field: "company_location_id", Message: "is required"}
+	case p.CompanyContactId == 0:
+		return ValidationError{Field: "company_contact_id", Message: "is required"}
+	case p.PaymentTerms != nil && p.PaymentTerms.TemplateId == "":
+		return ValidationError{Field: "payment_terms.template_id", Message: "is required"}
+	}
+	return nil
+}
+
+type draftOrderNode struct {
+	Id         string     `json:"id"`
+	Name       string     `json:"name"`
+	Status     string     `json:"status"`
+	Email      string     `json:"email"`
+	InvoiceUrl string     `json:"invoiceUrl"`
+	CreatedAt  *time.Time `json:"createdAt"`
+	UpdatedAt  *time.Time `json:"updatedAt"`
+}
+
+func (n *draftOrderNode) draftOrder() (*DraftOrder, error) {
+	if n == nil {
+		return nil, nil
+	}
+	_, id, err := ParseGid(n.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &DraftOrder{
+		Id:         id,
+		Name:       n.Name,
+		Status:     strings.ToLower(n.Status),
+		Email:      n.Email,
+		InvoiceURL: n.InvoiceUrl,
+		CreatedAt:  n.CreatedAt,
+		UpdatedAt:  n.UpdatedAt,
+	}, nil
+}
+
+// draftOrderInput converts a REST draft order into a graphql DraftOrderInput,
+// only the line items, discount, shipping line and contact fields are kept
+func draftOrderInput(draftOrder DraftOrder) (map[string]interface{}, error) {
+	input := map[string]interface{}{}
+	setIfNotEmpty(input, "email", draftOrder.Email)
+	setIfNotEmpty(input, "note", draftOrder.Note)
+	if draftOrder.Tags != "" {
+		tags := []string{}
+		for _, tag := range strings.Split(draftOrder.Tags, ",") {
+			tags = append(tags, strings.TrimSpace(tag))
+		}
+		input["tags"] = tags
+	}
+
+	lineItems := []map[string]interface{}{}
+	for i, lineItem := range draftOrder.LineItems {
+		item := map[string]interface{}{"quantity": lineItem.Quantity}
+		if lineItem.VariantId != 0 {
+			item["variantId"] = NewGid("ProductVariant", lineItem.VariantId)
+		} else {
+			item["title"] = lineItem.Title
+			if lineItem.Price != nil {
+				item["originalUnitPrice"] = lineItem.Price.String()
+			}
+		}
+		setIfNotEmpty(item, "sku", lineItem.SKU)
+		if len(lineItem.Properties) > 0 {
+			attributes := []map[string]string{}
+			for _, property := range lineItem.Properties {
+				attributes = append(attributes, map[string]string{"key": property.Name, "value": fmt.Sprint(property.Value)})
+			}
+			item["customAttributes"] = attributes
+		}
+		if lineItem.AppliedDiscount != nil {
+			discount, err := appliedDiscountInput(fmt.Sprintf("line_items[%d].applied_discount", i), lineItem.AppliedDiscount)
+			if err != nil {
+				return nil, err
+			}
+			item["appliedDiscount"] = discount
+		}
+		lineItems = append(lineItems, item)
+	}
+	input["lineItems"] = lineItems
+
+	if draftOrder.AppliedDiscount != nil {
+		discount, err := appliedDiscountInput("applied_discount", draftOrder.AppliedDiscount)
+		if err != nil {
+			return nil, err
+		}
+		input["appliedDiscount"] = discount
+	}
+	if shippingLine := draftOrder.ShippingLine; shippingLine != nil {
+		line := map[string]interface{}{"title": shippingLine.Title}
+		if shippingLine.Price != nil {
+			line["price"] = shippingLine.Price.String()
+		}
+		input["shippingLine"] = line
+	}
+	return input, nil
+}
+
+// appliedDiscountInput converts an AppliedDiscount into a graphql
+// DraftOrderAppliedDiscountInput
+func appliedDiscountInput(field string, discount *AppliedDiscount) (map[string]interface{}, error) {
+	value, err := decimal.NewFromString(discount.Value)
+	if err != nil {
+		return nil, ValidationError{Field: field + ".value", Message: "must be a number"}
+	}
+	floatValue, _ := value.Float64()
+	input := map[string]interface{}{
+		"value":     floatValue,
+		"valueType": strings.ToUpper(discount.ValueType),
+	}
+	setIfNotEmpty(input, "title", discount.Title)
+	setIfNotEmpty(input, "description", discount.Description)
+	setIfNotEmpty(input, "amount", discount.Amount)
+	return input, nil
+}
+
+// CreateForCompany creates a draft order purchased by a company location,
+// e.g. from a DraftOrderBuilder. The payment terms of the purchase are
+// applied when given. Only the line items, discounts, shipping line, email,
+// note and tags of the draft order are sent.
+func (s *DraftOrderServiceOp) CreateForCompany(ctx context.Context, draftOrder DraftOrder, purchase CompanyPurchase) (*DraftOrder, error) {
+	if err := purchase.validate(); err != nil {
+		return nil, err
+	}
+	input, err := draftOrderInput(draftOrder)
+	if err != nil {
+		return nil, err
+	}
+	input["purchasingEntity"] = map[string]interface{}{
+		"purchasingCompany": map[string]interface{}{
+			"companyId":         NewGid("Company", purchase.CompanyId),
+			"companyLocationId": NewGid("CompanyLocation", purchase.CompanyLocationId),
+			"companyContactId":  NewGid("CompanyContact", purchase.CompanyContactId),
+		},
+	}
+	if purchase.PaymentTerms != nil {
+		input["paymentTerms"] = purchase.PaymentTerms.input()
+	}
+	resp := struct {
+		DraftOrderCreate struct {
+			DraftOrder *draftOrderNode    `json:"draftOrder"`
+			UserErrors []GraphQLUserError `json:"userErrors"`
+		} `json:"draftOrderCreate"`
+	}{}
+
+	err = s.client.GraphQL.Query(ctx, draftOrderCreateMutation, map[string]interface{}{"input": input}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := userErrorsToResponseError(resp.DraftOrderCreate.UserErrors); err != nil {
+		return nil, err
+	}
+	return resp.DraftOrderCreate.DraftOrder.draftOrder()
+}
+
+// ApplyPaymentTerms sets the payment terms of an existing draft order
+func (s *DraftOrderServiceOp) ApplyPaymentTerms(ctx context.Context, draftOrderId uint64, terms PaymentTermsInput) error {
+	if terms.TemplateId == "" {
+		return ValidationError{Field: "template_id", Message: "is required"}
+	}
+	vars := map[string]interface{}{
+		"referenceId":            NewGid("DraftOrder", draftOrderId),
+		"paymentTermsAttributes": terms.input(),
+	}
+	resp := struct {
+		PaymentTermsCreate struct {
+			UserErrors []GraphQLUserError `json:"userErrors"`
+		} `json:"paymentTermsCreate"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, paymentTermsCreateMutation, vars, &resp)
+	if err != nil {
+		return err
+	}
+	return userErrorsToResponseError(resp.PaymentTermsCreate.UserErrors)
+}
+
+// CreatePurchaseOrder creates a draft order for a company location with
+// CreateForCompany and sends its invoice to the buyer for review. The draft
+// order is returned along with the error when sending the invoice fails.
+func (s *DraftOrderServiceOp) CreatePurchaseOrder(ctx context.Context, draftOrder DraftOrder, purchase CompanyPurchase, invoice DraftOrderInvoice) (*DraftOrder, error) {
+	created, err := s.CreateForCompany(ctx, draftOrder, purchase)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.Invoice(ctx, created.Id, invoice); err != nil {
+		return created, fmt.Errorf("draft order %d: %w", created.Id, err)
+	}
+	return created, nil
+}
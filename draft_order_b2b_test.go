@@ -0,0 +1,101 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func TestDraftOrderCreatePurchaseOrder(t *testing.T) {
+	setup()
+	defer teardown()
+
+	captured := graphQLTestRequest{}
+	registerGraphQLResponder(`{"data":{"draftOrderCreate":{"draftOrder":{"id":"gid://shopify/DraftOrder/1","name":"#D1","status":"OPEN"},"userErrors":[]}}}`, &captured)
+	httpmock.RegisterResponder(
+		"POST",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/draft_orders/1/send_invoice.json", client.pathPrefix),
+		httpmock.NewStringResponder(201, `{"draft_order_invoice":{"to":"buyer@example.com"}}`))
+
+	draft, err := NewDraftOrderBuilder().
+		AddVariant(2, 10, PercentageDiscount("Wholesale", "", decimal.NewFromInt(15))).
+		Build()
+	if err != nil {
+		t.Fatalf("DraftOrderBuilder.Build returned error: %v", err)
+	}
+	purchase := CompanyPurchase{
+		CompanyId:         3,
+		CompanyLocationId: 4,
+		CompanyContactId:  5,
+		PaymentTerms:      &PaymentTermsInput{TemplateId: "gid://shopify/PaymentTermsTemplate/6"},
+	}
+
+	created, err := client.DraftOrder.CreatePurchaseOrder(context.Background(), draft, purchase, DraftOrderInvoice{To: "buyer@example.com"})
+	if err != nil {
+		t.Fatalf("DraftOrder.CreatePurchaseOrder returned error: %v", err)
+	}
+
+	expected := &DraftOrder{Id: 1, Name: "#D1", Status: "open"}
+	if !reflect.DeepEqual(created, expected) {
+		t.Errorf("DraftOrder.CreatePurchaseOrder returned %+v, expected %+v", created, expected)
+	}
+
+	expectedInput := map[string]interface{}{
+		"lineItems": []interface{}{
+			map[string]interface{}{
+				"variantId":       "gid://shopify/ProductVariant/2",
+				"quantity":        float64(10),
+				"appliedDiscount": map[string]interface{}{"title": "Wholesale", "value": float64(15), "valueType": "PERCENTAGE"},
+			},
+		},
+		"purchasingEntity": map[string]interface{}{
+			"purchasingCompany": map[string]interface{}{
+				"companyId":         "gid://shopify/Company/3",
+				"companyLocationId": "gid://shopify/CompanyLocation/4",
+				"companyContactId":  "gid://shopify/CompanyContact/5",
+			},
+		},
+		"paymentTerms": map[string]interface{}{"paymentTermsTemplateId": "gid://shopify/PaymentTermsTemplate/6"},
+	}
+	if !reflect.DeepEqual(captured.Variables["input"], expectedInput) {
+		t.Errorf("DraftOrder.CreatePurchaseOrder sent input %+v, expected %+v", captured.Variables["input"], expectedInput)
+	}
+
+	info := httpmock.GetCallCountInfo()
+	if info[fmt.Sprintf("POST https://fooshop.myshopify.com/%s/draft_orders/1/send_invoice.json", client.pathPrefix)] != 1 {
+		t.Errorf("DraftOrder.CreatePurchaseOrder did not send the invoice")
+	}
+}
+
+func TestDraftOrderCreateForCompanyValidation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.DraftOrder.CreateForCompany(context.Background(), DraftOrder{}, CompanyPurchase{CompanyId: 1, CompanyLocationId: 2})
+
+	var validationError ValidationError
+	if !errors.As(err, &validationError) || validationError.Field != "company_contact_id" {
+		t.Errorf("DraftOrder.CreateForCompany returned error %v, expected company_contact_id ValidationError", err)
+	}
+}
+
+func TestDraftOrderApplyPaymentTerms(t *testing.T) {
+	setup()
+	defer teardown()
+
+	captured := graphQLTestRequest{}
+	registerGraphQLResponder(`{"data":{"paymentTermsCreate":{"paymentTerms":{"id":"gid://shopify/PaymentTerms/1"},"userErrors":[]}}}`, &captured)
+
+	err := client.DraftOrder.ApplyPaymentTerms(context.Background(), 1, PaymentTermsInput{TemplateId: "gid://shopify/PaymentTermsTemplate/6"})
+	if err != nil {
+		t.Fatalf("DraftOrder.ApplyPaymentTerms returned error: %v", err)
+	}
+	if captured.Variables["referenceId"] != "gid://shopify/DraftOrder/1" {
+		t.Errorf("DraftOrder.ApplyPaymentTerms sent referenceId %v", captured.Variables["referenceId"])
+	}
+}
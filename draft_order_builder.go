@@ -0,0 +1,221 @@
+package goshopify
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// Value types of an AppliedDiscount
+const (
+	AppliedDiscountValueTypeFixedAmount = "fixed_amount"
+	AppliedDiscountValueTypePercentage  = "percentage"
+)
+
+var oneHundred = decimal.NewFromInt(100)
+
+// FixedAmountDiscount returns an AppliedDiscount taking amount off the line
+// item or draft order it is applied to
+func FixedAmountDiscount(title, description string, amount decimal.Decimal) *AppliedDiscount {
+	return &AppliedDiscount{
+		Title:       title,
+		Description: description,
+		Value:       amount.String(),
+		ValueType:   AppliedDiscountValueTypeFixedAmount,
+		Amount:      amount.String(),
+	}
+}
+
+// PercentageDiscount returns an AppliedDiscount taking percent, between 0 and
+// 100, off the line item or draft order it is applied to
+func PercentageDiscount(title, description string, percent decimal.Decimal) *AppliedDiscount {
+	return &AppliedDiscount{
+		Title:       title,
+		Description: description,
+		Value:       percent.String(),
+		ValueType:   AppliedDiscountValueTypePercentage,
+	}
+}
+
+// DraftOrderBuilder assembles the line items, discounts and shipping line of
+// a draft order and validates the amounts before the draft order is sent, e.g.
+//
+//	draft, err := goshopify.NewDraftOrderBuilder().
+//		AddVariant(variantId, 2, goshopify.PercentageDiscount("VIP", "", decimal.NewFromInt(10))).
+//		SetShippingLine("Express", decimal.NewFromInt(15)).
+//		Build()
+type DraftOrderBuilder struct {
+	draft DraftOrder
+
+	// error of a call which could not be applied, returned by Build
+	err error
+}
+
+// CustomLineItem is a line item of a draft order which is not tied to a
+// product variant, see DraftOrderBuilder.AddCustom. Shopify requires the
+// title and the price.
+type CustomLineItem struct {
+	Title           string
+	Price           decimal.Decimal
+	Quantity        int
+	SKU             string
+	Grams           int
+	Properties      []NoteAttribute
+	AppliedDiscount *AppliedDiscount
+}
+
+// NewDraftOrderBuilder returns an empty DraftOrderBuilder
+func NewDraftOrderBuilder() *DraftOrderBuilder {
+	return &DraftOrderBuilder{}
+}
+
+// AddVariant adds a line item for a product variant, discount is optional
+func (b *DraftOrderBuilder) AddVariant(variantId uint64, quantity int, discount *AppliedDiscount) *DraftOrderBuilder {
+	return b.AddLineItem(LineItem{
+		VariantId:       variantId,
+		Quantity:        quantity,
+		AppliedDiscount: discount,
+	})
+}
+
+// AddCustom adds a line item which is not tied to a product variant, with
+// its sku, weight and properties
+func (b *DraftOrderBuilder) AddCustom(item CustomLineItem) *DraftOrderBuilder {
+	return b.AddLineItem(LineItem{
+		Title:           item.Title,
+		Price:           &item.Price,
+		Quantity:        item.Quantity,
+		SKU:             item.SKU,
+		Grams:           item.Grams,
+		Properties:      item.Properties,
+		AppliedDiscount: item.AppliedDiscount,
+	})
+}
+
+// SetLineItemProperties sets the properties of the last line item added, e.g.
+//
+//	builder.AddVariant(variantId, 1, nil).
+//		SetLineItemProperties(goshopify.NoteAttribute{Name: "Engraving", Value: "J.D."})
+func (b *DraftOrderBuilder) SetLineItemProperties(properties ...NoteAttribute) *DraftOrderBuilder {
+	if len(b.draft.LineItems) == 0 {
+		if b.err == nil {
+			b.err = ValidationError{Field: "line_items", Message: "properties set before adding a line item"}
+		}
+		return b
+	}
+	b.draft.LineItems[len(b.draft.LineItems)-1].Properties = properties
+	return b
+}
+
+// AddLineItem adds a line item as is
+func (b *DraftOrderBuilder) AddLineItem(lineItem LineItem) *DraftOrderBuilder {
+	b.draft.LineItems = append(b.draft.LineItems, lineItem)
+	return b
+}
+
+// SetShippingLine sets a custom shipping line
+func (b *DraftOrderBuilder) SetShippingLine(title string, price decimal.Decimal) *DraftOrderBuilder {
+	b.draft.ShippingLine = &ShippingLines{
+		Title: title,
+		Price: &price,
+	}
+	return b
+}
+
+// SetDiscount sets the discount applied to the whole draft order
+func (b *DraftOrderBuilder) SetDiscount(discount *AppliedDiscount) *DraftOrderBuilder {
+	b.draft.AppliedDiscount = discount
+	return b
+}
+
+// Build validates and returns the draft order, the returned error is a
+// ValidationError naming the offending field
+func (b *DraftOrderBuilder) Build() (DraftOrder, error) {
+	if err := b.validate(); err != nil {
+		return DraftOrder{}, err
+	}
+	return b.draft, nil
+}
+
+func (b *DraftOrderBuilder) validate() error {
+	if b.err != nil {
+		return b.err
+	}
+	if len(b.draft.LineItems) == 0 {
+		return ValidationError{Field: "line_items", Message: "at least one line item is required"}
+	}
+
+	for i, lineItem := range b.draft.LineItems {
+		if err := validateDraftOrderLineItem(fmt.Sprintf("line_items[%d]", i), lineItem); err != nil {
+			return err
+		}
+	}
+
+	if shipping := b.draft.ShippingLine; shipping != nil {
+		if shipping.Title == "" {
+			return ValidationError{Field: "shipping_line.title", Message: "is required"}
+		}
+		if shipping.Price == nil || shipping.Price.IsNegative() {
+			return ValidationError{Field: "shipping_line.price", Message: "must be zero or positive"}
+		}
+	}
+
+	return validateAppliedDiscount("applied_discount", b.draft.AppliedDiscount, nil)
+}
+
+func validateDraftOrderLineItem(field string, lineItem LineItem) error {
+	if lineItem.Quantity <= 0 {
+		return ValidationError{Field: field + ".quantity", Message: fmt.Sprintf("must be positive, got %d", lineItem.Quantity)}
+	}
+
+	var lineTotal *decimal.Decimal
+	if lineItem.VariantId == 0 {
+		if lineItem.Title == "" {
+			return ValidationError{Field: field + ".title", Message: "is required for custom line items"}
+		}
+		if lineItem.Price == nil || lineItem.Price.IsNegative() {
+			return ValidationError{Field: field + ".price", Message: "must be zero or positive for custom line items"}
+		}
+		total := lineItem.Price.Mul(decimal.NewFromInt(int64(lineItem.Quantity)))
+		lineTotal = &total
+	}
+
+	for i, property := range lineItem.Properties {
+		if property.Name == "" {
+			return ValidationError{Field: fmt.Sprintf("%s.properties[%d].name", field, i), Message: "is required"}
+		}
+	}
+
+	return validateAppliedDiscount(field+".applied_discount", lineItem.AppliedDiscount, lineTotal)
+}
+
+// validateAppliedDiscount checks the discount value, fixed amounts are also
+// checked against total when it is known
+func validateAppliedDiscount(field string, discount *AppliedDiscount, total *decimal.Decimal) error {
+	if discount == nil {
+		return nil
+	}
+
+	value, err := decimal.NewFromString(discount.Value)
+	if err != nil {
+		return ValidationError{Field: field + ".value", Message: fmt.Sprintf("%q is not a number", discount.Value)}
+	}
+	if !value.IsPositive() {
+		return ValidationError{Field: field + ".value", Message: "must be positive"}
+	}
+
+	switch discount.ValueType {
+	case AppliedDiscountValueTypePercentage:
+		if value.GreaterThan(oneHundred) {
+			return ValidationError{Field: field + ".value", Message: fmt.Sprintf("percentage %s is above 100", value)}
+		}
+	case AppliedDiscountValueTypeFixedAmount:
+		if total != nil && value.GreaterThan(*total) {
+			return ValidationError{Field: field + ".value", Message: fmt.Sprintf("amount %s is above the line total %s", value, total)}
+		}
+	default:
+		return ValidationError{Field: field + ".value_type", Message: fmt.Sprintf("must be %s or %s", AppliedDiscountValueTypeFixedAmount, AppliedDiscountValueTypePercentage)}
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestDraftOrderBuilderBuild(t *testing.T) {
+	draft, err := NewDraftOrderBuilder().
+		AddVariant(1, 2, PercentageDiscount("VIP", "10% off", decimal.NewFromInt(10))).
+		AddCustom(CustomLineItem{
+			Title:           "Gift wrap",
+			Price:           decimal.NewFromInt(5),
+			Quantity:        1,
+			AppliedDiscount: FixedAmountDiscount("Promo", "", decimal.NewFromInt(5)),
+		}).
+		SetShippingLine("Express", decimal.NewFromInt(15)).
+		SetDiscount(FixedAmountDiscount("Welcome", "", decimal.NewFromInt(3))).
+		Build()
+	if err != nil {
+		t.Fatalf("DraftOrderBuilder.Build returned error: %v", err)
+	}
+
+	if len(draft.LineItems) != 2 {
+		t.Fatalf("DraftOrderBuilder.Build returned %d line items, expected 2", len(draft.LineItems))
+	}
+	discount := draft.LineItems[0].AppliedDiscount
+	if discount.ValueType != AppliedDiscountValueTypePercentage || discount.Value != "10" || discount.Description != "10% off" {
+		t.Errorf("DraftOrderBuilder.Build returned line item discount %+v", discount)
+	}
+	if draft.LineItems[1].Title != "Gift wrap" || !draft.LineItems[1].Price.Equal(decimal.NewFromInt(5)) {
+		t.Errorf("DraftOrderBuilder.Build returned custom line item %+v", draft.LineItems[1])
+	}
+	if draft.ShippingLine.Title != "Express" || !draft.ShippingLine.Price.Equal(decimal.NewFromInt(15)) {
+		t.Errorf("DraftOrderBuilder.Build returned shipping line %+v", draft.ShippingLine)
+	}
+	if draft.AppliedDiscount.Amount != "3" {
+		t.Errorf("DraftOrderBuilder.Build returned discount %+v", draft.AppliedDiscount)
+	}
+}
+
+func TestDraftOrderBuilderCustomItems(t *testing.T) {
+	draft, err := NewDraftOrderBuilder().
+		AddVariant(1, 1, nil).
+		SetLineItemProperties(NoteAttribute{Name: "Engraving", Value: "J.D."}).
+		AddCustom(CustomLineItem{
+			Title:           "Gift wrap",
+			Price:           decimal.NewFromInt(5),
+			Quantity:        2,
+			SKU:             "WRAP",
+			Grams:           100,
+			Properties:      []NoteAttribute{{Name: "Color", Value: "red"}},
+			AppliedDiscount: PercentageDiscount("Promo", "", decimal.NewFromInt(50)),
+		}).
+		Build()
+	if err != nil {
+		t.Fatalf("DraftOrderBuilder.Build returned error: %v", err)
+	}
+
+	lineItems, err := json.Marshal(draft.LineItems)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+	expected := `[{"variant_id":1,"quantity":1,"properties":[{"name":"Engraving","value":"J.D."}]},` +
+		`{"quantity":2,"price":"5","title":"Gift wrap","sku":"WRAP","properties":[{"name":"Color","value":"red"}],"grams":100,` +
+		`"applied_discount":{"title":"Promo","value":"50","value_type":"percentage"}}]`
+	if string(lineItems) != expected {
+		t.Errorf("DraftOrderBuilder.Build returned line items %s, expected %s", lineItems, expected)
+	}
+}
+
+func TestDraftOrderBuilderValidation(t *testing.T) {
+	cases := []struct {
+		description string
+		builder     *DraftOrderBuilder
+		field       string
+	}{
+		{
+			"no line items",
+			NewDraftOrderBuilder(),
+			"line_items",
+		},
+		{
+			"zero quantity",
+			NewDraftOrderBuilder().AddVariant(1, 0, nil),
+			"line_items[0].quantity",
+		},
+		{
+			"custom item without title",
+			NewDraftOrderBuilder().AddVariant(1, 1, nil).AddCustom(CustomLineItem{Price: decimal.NewFromInt(1), Quantity: 1}),
+			"line_items[1].title",
+		},
+		{
+			"negative custom item price",
+			NewDraftOrderBuilder().AddCustom(CustomLineItem{Title: "Gift wrap", Price: decimal.NewFromInt(-1), Quantity: 1}),
+			"line_items[0].price",
+		},
+		{
+			"custom item without price",
+			NewDraftOrderBuilder().AddLineItem(LineItem{Title: "Gift wrap", Quantity: 1}),
+			"line_items[0].price",
+		},
+		{
+			"property without name",
+			NewDraftOrderBuilder().AddVariant(1, 1, nil).SetLineItemProperties(NoteAttribute{Value: "J.D."}),
+			"line_items[0].properties[0].name",
+		},
+		{
+			"properties before line items",
+			NewDraftOrderBuilder().SetLineItemProperties(NoteAttribute{Name: "Engraving"}).AddVariant(1, 1, nil),
+			"line_items",
+		},
+		{
+			"percentage above 100",
+			NewDraftOrderBuilder().AddVariant(1, 1, PercentageDiscount("", "", decimal.NewFromInt(101))),
+			"line_items[0].applied_discount.value",
+		},
+		{
+			"fixed amount above line total",
+			NewDraftOrderBuilder().AddCustom(CustomLineItem{Title: "Gift wrap", Price: decimal.NewFromInt(5), Quantity: 2, AppliedDiscount: FixedAmountDiscount("", "", decimal.NewFromInt(11))}),
+			"line_items[0].applied_discount.value",
+		},
+		{
+			"negative fixed amount",
+			NewDraftOrderBuilder().AddVariant(1, 1, FixedAmountDiscount("", "", decimal.NewFromInt(-1))),
+			"line_items[0].applied_discount.value",
+		},
+		{
+			"unknown value type",
+			NewDraftOrderBuilder().AddVariant(1, 1, &AppliedDiscount{Value: "1", ValueType: "foo"}),
+			"line_items[0].applied_discount.value_type",
+		},
+		{
+			"shipping line without title",
+			NewDraftOrderBuilder().AddVariant(1, 1, nil).SetShippingLine("", decimal.NewFromInt(1)),
+			"shipping_line.title",
+		},
+		{
+			"negative shipping price",
+			NewDraftOrderBuilder().AddVariant(1, 1, nil).SetShippingLine("Express", decimal.NewFromInt(-1)),
+			"shipping_line.price",
+		},
+		{
+			"order discount above 100%",
+			NewDraftOrderBuilder().AddVariant(1, 1, nil).SetDiscount(PercentageDiscount("", "", decimal.NewFromInt(150))),
+			"applied_discount.value",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			_, err := c.builder.Build()
+			var validationErr ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("DraftOrderBuilder.Build returned %v, expected a ValidationError", err)
+			}
+			if validationErr.Field != c.field {
+				t.Errorf("DraftOrderBuilder.Build returned error on field %s, expected %s", validationErr.Field, c.field)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package goshopify
+
+import "context"
+
+// Duplicate creates a new draft order with the line items, customer, shipping
+// address and note attributes of a draft order
+func (s *DraftOrderServiceOp) Duplicate(ctx context.Context, draftOrderId uint64) (*DraftOrder, error) {
+	draftOrder, err := s.Get(ctx, draftOrderId, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.Create(ctx, DuplicateDraftOrder(*draftOrder))
+}
+
+// DuplicateOrder creates a new draft order with the line items, customer,
+// shipping address and note attributes of an order, e.g. to reorder it
+func (s *DraftOrderServiceOp) DuplicateOrder(ctx context.Context, orderId uint64) (*DraftOrder, error) {
+	order, err := s.client.Order.Get(ctx, orderId, struct {
+		Fields string `url:"fields"`
+	}{"id,line_items,customer,shipping_address,note_attributes"})
+	if err != nil {
+		return nil, err
+	}
+	return s.Create(ctx, DuplicateOrder(*order))
+}
+
+// DuplicateDraftOrder returns a draft order to create with the line items,
+// customer, shipping address and note attributes of draftOrder. Line item
+// discounts are kept.
+func DuplicateDraftOrder(draftOrder DraftOrder) DraftOrder {
+	duplicate := duplicateDraftOrder(draftOrder.LineItems, draftOrder.Customer, draftOrder.ShippingAddress, draftOrder.NoteAttributes)
+	for i := range duplicate.LineItems {
+		duplicate.LineItems[i].AppliedDiscount = draftOrder.LineItems[i].AppliedDiscount
+	}
+	return duplicate
+}
+
+// DuplicateOrder returns a draft order to create with the line items,
+// customer, shipping address and note attributes of order. Line items whose
+// product was deleted are copied as custom items at the price paid.
+func DuplicateOrder(order Order) DraftOrder {
+	return duplicateDraftOrder(order.LineItems, order.Customer, order.ShippingAddress, order.NoteAttributes)
+}
+
+func duplicateDraftOrder(lineItems []LineItem, customer *Customer, shippingAddress *Address, noteAttributes []NoteAttribute) DraftOrder {
+	duplicate := DraftOrder{
+		LineItems:      make([]LineItem, 0, len(lineItems)),
+		NoteAttributes: append([]NoteAttribute(nil), noteAttributes...),
+	}
+	for _, lineItem := range lineItems {
+		duplicate.LineItems = append(duplicate.LineItems, duplicateLineItem(lineItem))
+	}
+	if customer != nil && customer.Id != 0 {
+		duplicate.Customer = &Customer{Id: customer.Id}
+	}
+	if shippingAddress != nil {
+		address := *shippingAddress
+		address.Id = 0
+		duplicate.ShippingAddress = &address
+	}
+	return duplicate
+}
+
+// duplicateLineItem keeps the fields a draft order accepts, a line item
+// without a variant is copied as a custom item
+func duplicateLineItem(lineItem LineItem) LineItem {
+	duplicate := LineItem{
+		VariantId:  lineItem.VariantId,
+		Quantity:   lineItem.Quantity,
+		Properties: append([]NoteAttribute(nil), lineItem.Properties...),
+	}
+	if lineItem.VariantId == 0 {
+		duplicate.Title = lineItem.Title
+		duplicate.Price = lineItem.Price
+		duplicate.Taxable = lineItem.Taxable
+		duplicate.RequiresShipping = lineItem.RequiresShipping
+		duplicate.Grams = lineItem.Grams
+	}
+	return duplicate
+}
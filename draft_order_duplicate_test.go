@@ -0,0 +1,96 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func TestDuplicateOrder(t *testing.T) {
+	price := decimal.RequireFromString("4.50")
+	order := Order{
+		Id:       1,
+		Name:     "#1001",
+		Customer: &Customer{Id: 7, Email: "jane@example.com"},
+		ShippingAddress: &Address{
+			Id:       9,
+			Address1: "1 Main St",
+			City:     "Ottawa",
+		},
+		NoteAttributes: []NoteAttribute{{Name: "gift", Value: "yes"}},
+		LineItems: []LineItem{
+			{Id: 11, VariantId: 100, ProductId: 10, Quantity: 2, Price: &price, Title: "Shirt", Properties: []NoteAttribute{{Name: "size", Value: "M"}}},
+			{Id: 12, Quantity: 1, Title: "Gift wrap", Price: &price, Taxable: true},
+		},
+	}
+
+	expected := DraftOrder{
+		Customer:        &Customer{Id: 7},
+		ShippingAddress: &Address{Address1: "1 Main St", City: "Ottawa"},
+		NoteAttributes:  []NoteAttribute{{Name: "gift", Value: "yes"}},
+		LineItems: []LineItem{
+			{VariantId: 100, Quantity: 2, Properties: []NoteAttribute{{Name: "size", Value: "M"}}},
+			{Quantity: 1, Title: "Gift wrap", Price: &price, Taxable: true},
+		},
+	}
+
+	if actual := DuplicateOrder(order); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("DuplicateOrder returned %+v, expected %+v", actual, expected)
+	}
+	if order.ShippingAddress.Id != 9 {
+		t.Errorf("DuplicateOrder changed the shipping address of the order")
+	}
+}
+
+func TestDuplicateDraftOrderKeepsDiscounts(t *testing.T) {
+	discount := PercentageDiscount("VIP", "", decimal.NewFromInt(10))
+	duplicate := DuplicateDraftOrder(DraftOrder{
+		Id:        1,
+		Status:    "completed",
+		LineItems: []LineItem{{VariantId: 100, Quantity: 1, AppliedDiscount: discount}},
+	})
+
+	if duplicate.Id != 0 || duplicate.Status != "" {
+		t.Errorf("DuplicateDraftOrder returned %+v, expected a new draft order", duplicate)
+	}
+	if duplicate.LineItems[0].AppliedDiscount != discount {
+		t.Errorf("DuplicateDraftOrder returned line item discount %+v, expected %+v", duplicate.LineItems[0].AppliedDiscount, discount)
+	}
+}
+
+func TestDraftOrderDuplicateOrder(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"order":{"id":1,"customer":{"id":7},"line_items":[{"id":11,"variant_id":100,"quantity":3}]}}`))
+
+	var sent DraftOrder
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/draft_orders.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			resource := DraftOrderResource{}
+			if err := json.NewDecoder(req.Body).Decode(&resource); err != nil {
+				return nil, err
+			}
+			sent = *resource.DraftOrder
+			resource.DraftOrder.Id = 2
+			return httpmock.NewJsonResponse(201, resource)
+		})
+
+	draftOrder, err := client.DraftOrder.DuplicateOrder(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("DraftOrder.DuplicateOrder returned error: %v", err)
+	}
+	if draftOrder.Id != 2 {
+		t.Errorf("DraftOrder.DuplicateOrder returned %+v, expected draft order 2", draftOrder)
+	}
+	if sent.Customer == nil || sent.Customer.Id != 7 || len(sent.LineItems) != 1 || sent.LineItems[0].VariantId != 100 || sent.LineItems[0].Quantity != 3 {
+		t.Errorf("DraftOrder.DuplicateOrder sent %+v", sent)
+	}
+}
@@ -0,0 +1,88 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrEnqueued is returned by write requests handed to the Enqueuer of the
+// client instead of being sent, nothing is decoded into the response
+var ErrEnqueued = errors.New("shopify: write enqueued")
+
+// WriteJob is a write request serialized to be sent later, Path holds the
+// api version and query string of the request
+type WriteJob struct {
+	Shop   string          `json:"shop"`
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Body   json.RawMessage `json:"body,omitempty"`
+
+	// IdempotencyKey is the Idempotency-Key header of the request, sent
+	// again by Replay
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+}
+
+// Enqueuer pushes write jobs to a durable queue, see WithEnqueuer. The
+// worker consuming the queue sends them with Client.Replay.
+type Enqueuer interface {
+	Enqueue(context.Context, WriteJob) error
+}
+
+// EnqueuerFunc adapts a function to the Enqueuer interface
+type EnqueuerFunc func(context.Context, WriteJob) error
+
+// Enqueue calls f(ctx, job)
+func (f EnqueuerFunc) Enqueue(ctx context.Context, job WriteJob) error {
+	return f(ctx, job)
+}
+
+type replayContextKey struct{}
+
+// enqueueWrite hands a mutating request to the enqueuer of the client, it
+// returns false when the request must be sent
+func (c *Client) enqueueWrite(req *http.Request, body []byte) (bool, error) {
+	if c.enqueuer == nil || !isMutatingRequest(req, body) {
+		return false, nil
+	}
+	if replay, _ := req.Context().Value(replayContextKey{}).(bool); replay {
+		return false, nil
+	}
+
+	job := WriteJob{
+		Shop:           c.shop,
+		Method:         req.Method,
+		Path:           req.URL.RequestURI(),
+		IdempotencyKey: req.Header.Get(IdempotencyKeyHeader),
+	}
+	if len(body) > 0 {
+		job.Body = json.RawMessage(body)
+	}
+	if err := c.enqueuer.Enqueue(req.Context(), job); err != nil {
+		return true, fmt.Errorf("enqueue %s %s: %w", job.Method, job.Path, err)
+	}
+	return true, ErrEnqueued
+}
+
+// Replay sends a job enqueued by a client of the same shop and decodes the
+// response into v, which is optional
+func (c *Client) Replay(ctx context.Context, job WriteJob, v interface{}) error {
+	if job.Shop != c.shop {
+		return fmt.Errorf("job of shop %s replayed by a client of %s", job.Shop, c.shop)
+	}
+
+	var body interface{}
+	if len(job.Body) > 0 {
+		body = job.Body
+	}
+	req, err := c.NewRequest(context.WithValue(ctx, replayContextKey{}, true), job.Method, job.Path, body, nil)
+	if err != nil {
+		return err
+	}
+	if job.IdempotencyKey != "" {
+		req.Header.Set(IdempotencyKeyHeader, job.IdempotencyKey)
+	}
+	return c.Do(req, v)
+}
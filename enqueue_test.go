@@ -0,0 +1,143 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestEnqueuerWrites(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var jobs []WriteJob
+	WithEnqueuer(EnqueuerFunc(func(ctx context.Context, job WriteJob) error {
+		jobs = append(jobs, job)
+		return nil
+	}))(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"product":{"id":1}}`))
+
+	if _, err := client.Product.Get(context.Background(), 1, nil); err != nil {
+		t.Errorf("Product.Get returned error: %v", err)
+	}
+	if _, err := client.Product.Update(context.Background(), Product{Id: 1, Title: "Shirt"}); !errors.Is(err, ErrEnqueued) {
+		t.Errorf("Product.Update returned %v, expected ErrEnqueued", err)
+	}
+	if err := client.GraphQL.Query(context.Background(), "mutation { tagsAdd }", nil, nil); !errors.Is(err, ErrEnqueued) {
+		t.Errorf("GraphQL.Query returned %v, expected ErrEnqueued", err)
+	}
+
+	if len(jobs) != 2 {
+		t.Fatalf("enqueued %d jobs, expected 2", len(jobs))
+	}
+	job := jobs[0]
+	if job.Shop != "fooshop.myshopify.com" || job.Method != "PUT" || job.Path != fmt.Sprintf("/%s/products/1.json", client.pathPrefix) {
+		t.Errorf("enqueued job %+v", job)
+	}
+	if string(job.Body) != `{"product":{"id":1,"title":"Shirt","image":{}}}` {
+		t.Errorf("enqueued job body %s", job.Body)
+	}
+	if info := httpmock.GetCallCountInfo(); info[fmt.Sprintf("PUT https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix)] != 0 {
+		t.Errorf("enqueued write was sent")
+	}
+}
+
+func TestEnqueuerError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	queueDown := errors.New("queue down")
+	WithEnqueuer(EnqueuerFunc(func(ctx context.Context, job WriteJob) error {
+		return queueDown
+	}))(client)
+
+	err := client.Product.Delete(context.Background(), 1)
+	if !errors.Is(err, queueDown) || errors.Is(err, ErrEnqueued) {
+		t.Errorf("Product.Delete returned %v, expected the enqueue error", err)
+	}
+}
+
+func TestClientReplay(t *testing.T) {
+	setup()
+	defer teardown()
+
+	WithEnqueuer(EnqueuerFunc(func(ctx context.Context, job WriteJob) error {
+		t.Errorf("Replay enqueued %+v", job)
+		return nil
+	}))(client)
+
+	var sent []byte
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			sent, _ = io.ReadAll(req.Body)
+			return httpmock.NewStringResponse(200, `{"product":{"id":1,"title":"Shirt"}}`), nil
+		})
+
+	data, _ := json.Marshal(WriteJob{
+		Shop:   "fooshop.myshopify.com",
+		Method: "PUT",
+		Path:   fmt.Sprintf("/%s/products/1.json", client.pathPrefix),
+		Body:   json.RawMessage(`{"product":{"id":1,"title":"Shirt"}}`),
+	})
+	var job WriteJob
+	if err := json.Unmarshal(data, &job); err != nil {
+		t.Fatal(err)
+	}
+
+	resource := new(ProductResource)
+	if err := client.Replay(context.Background(), job, resource); err != nil {
+		t.Fatalf("Client.Replay returned error: %v", err)
+	}
+	if resource.Product == nil || resource.Product.Title != "Shirt" {
+		t.Errorf("Client.Replay decoded %+v", resource.Product)
+	}
+	if string(sent) != `{"product":{"id":1,"title":"Shirt"}}` {
+		t.Errorf("Client.Replay sent %s", sent)
+	}
+
+	job.Shop = "other.myshopify.com"
+	if err := client.Replay(context.Background(), job, nil); err == nil {
+		t.Errorf("Client.Replay sent a job of another shop")
+	}
+}
+
+func TestEnqueuerIdempotencyKey(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var jobs []WriteJob
+	WithIdempotencyKeys()(client)
+	WithEnqueuer(EnqueuerFunc(func(ctx context.Context, job WriteJob) error {
+		jobs = append(jobs, job)
+		return nil
+	}))(client)
+
+	if _, err := client.Product.Create(context.Background(), Product{Title: "Shirt"}); !errors.Is(err, ErrEnqueued) {
+		t.Fatalf("Product.Create returned %v, expected ErrEnqueued", err)
+	}
+	if len(jobs) != 1 || jobs[0].IdempotencyKey == "" {
+		t.Fatalf("Product.Create enqueued %+v, expected a job with an idempotency key", jobs)
+	}
+
+	var sent string
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			sent = req.Header.Get(IdempotencyKeyHeader)
+			return httpmock.NewStringResponse(201, `{"product":{"id":1}}`), nil
+		})
+
+	if err := client.Replay(context.Background(), jobs[0], nil); err != nil {
+		t.Fatalf("Client.Replay returned error: %v", err)
+	}
+	if sent != jobs[0].IdempotencyKey {
+		t.Errorf("Client.Replay sent idempotency key %q, expected the one of the job %q", sent, jobs[0].IdempotencyKey)
+	}
+}
@@ -0,0 +1,120 @@
+package goshopify
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors that response errors can be compared against with
+// errors.Is, e.g.
+//
+//	if errors.Is(err, goshopify.ErrNotFound) {
+//		// the resource was deleted
+//	}
+//
+// The original ResponseError, RateLimitError or HTTPError is still available
+// through errors.As, a ResponseError is available for every non-2xx response
+// along with its ResponseMetadata. GraphQL errors are matched by their extensions code:
+// THROTTLED is ErrRateLimited, ACCESS_DENIED is ErrForbiddenScope and
+// INTERNAL_SERVER_ERROR is ErrServerError.
+var (
+	ErrNotFound       = errors.New("shopify: not found")
+	ErrUnauthorized   = errors.New("shopify: unauthorized")
+	ErrRateLimited    = errors.New("shopify: rate limited")
+	ErrForbiddenScope = errors.New("shopify: forbidden scope")
+	ErrServerError    = errors.New("shopify: server error")
+)
+
+// Is reports whether the response error matches one of the sentinel errors
+// based on its http status
+func (e ResponseError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.Status == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.Status == http.StatusUnauthorized
+	case ErrRateLimited:
+		return e.Status == http.StatusTooManyRequests
+	case ErrForbiddenScope:
+		return e.Status == http.StatusForbidden
+	case ErrServerError:
+		return e.Status >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// Is reports whether the rate limit error matches ErrRateLimited, graphql
+// throttling is returned with a 200 status so it cannot rely on the status.
+func (e RateLimitError) Is(target error) bool {
+	if target == ErrRateLimited {
+		return true
+	}
+	return e.ResponseError.Is(target)
+}
+
+// Unwrap returns the embedded ResponseError so errors.As finds it
+func (e RateLimitError) Unwrap() error {
+	return e.ResponseError
+}
+
+// Unwrap returns the embedded ResponseError so errors.As finds it
+func (e HTTPError) Unwrap() error {
+	return e.ResponseError
+}
+
+// As lets errors.As convert a decoding error of a non-2xx response to a
+// ResponseError carrying its status and metadata
+func (e ResponseDecodingError) As(target interface{}) bool {
+	responseError, ok := target.(*ResponseError)
+	if !ok || e.Status < http.StatusMultipleChoices {
+		return false
+	}
+	*responseError = ResponseError{Status: e.Status, Message: e.Message, ResponseMetadata: e.ResponseMetadata}
+	return true
+}
+
+// withResponseMetadata calls update with the metadata of the errors returned
+// for non-2xx responses, other errors are returned as is
+func withResponseMetadata(err error, update func(*ResponseMetadata)) error {
+	switch e := err.(type) {
+	case ResponseError:
+		update(&e.ResponseMetadata)
+		return e
+	case RateLimitError:
+		update(&e.ResponseMetadata)
+		return e
+	case HTTPError:
+		update(&e.ResponseMetadata)
+		return e
+	case ResponseDecodingError:
+		update(&e.ResponseMetadata)
+		return e
+	}
+	return err
+}
+
+// withRetries records the number of retries attempted before err
+func withRetries(err error, retries int) error {
+	return withResponseMetadata(err, func(m *ResponseMetadata) {
+		m.Retries = retries
+	})
+}
+
+// invalidTokenMessage is the message shopify responds with when the access
+// token was revoked, usually because the app was uninstalled
+const invalidTokenMessage = "Invalid API key or access token"
+
+// isInvalidTokenError reports whether err means the access token is no
+// longer valid for the shop
+func isInvalidTokenError(err error) bool {
+	if errors.Is(err, ErrUnauthorized) {
+		return true
+	}
+
+	var responseError ResponseError
+	if errors.As(err, &responseError) {
+		return strings.Contains(responseError.Message, invalidTokenMessage)
+	}
+	return false
+}
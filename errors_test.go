@@ -0,0 +1,189 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestResponseErrorIs(t *testing.T) {
+	cases := []struct {
+		err      error
+		target   error
+		expected bool
+	}{
+		{ResponseError{Status: 404}, ErrNotFound, true},
+		{ResponseError{Status: 401}, ErrUnauthorized, true},
+		{ResponseError{Status: 403}, ErrForbiddenScope, true},
+		{ResponseError{Status: 429}, ErrRateLimited, true},
+		{ResponseError{Status: 500}, ErrNotFound, false},
+		{ResponseError{Status: 500}, ErrServerError, true},
+		{ResponseError{Status: 503}, ErrServerError, true},
+		{ResponseError{Status: 422}, ErrServerError, false},
+		{ResponseError{Status: 404}, ErrUnauthorized, false},
+		{RateLimitError{ResponseError: ResponseError{Status: 429}}, ErrRateLimited, true},
+		{RateLimitError{ResponseError: ResponseError{Status: 200}}, ErrRateLimited, true},
+		{RateLimitError{ResponseError: ResponseError{Status: 200}}, ErrNotFound, false},
+		{HTTPError{ResponseError: ResponseError{Status: 401}}, ErrUnauthorized, true},
+		{fmt.Errorf("wrapped: %w", ResponseError{Status: 404}), ErrNotFound, true},
+	}
+
+	for _, c := range cases {
+		if actual := errors.Is(c.err, c.target); actual != c.expected {
+			t.Errorf("errors.Is(%#v, %v): expected %v, actual %v", c.err, c.target, c.expected, actual)
+		}
+	}
+}
+
+func TestResponseErrorIsFromRequest(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(404, `{"errors":"Not Found"}`))
+
+	_, err := client.Product.Get(context.Background(), 1, nil)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Product.Get returned %#v, expected ErrNotFound", err)
+	}
+
+	var responseError ResponseError
+	if !errors.As(err, &responseError) || responseError.Message != "Not Found" {
+		t.Errorf("Product.Get returned %#v, expected ResponseError with message Not Found", err)
+	}
+}
+
+func TestGraphQLErrorIs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cases := []struct {
+		code     string
+		target   error
+		expected bool
+	}{
+		{"ACCESS_DENIED", ErrForbiddenScope, true},
+		{"INTERNAL_SERVER_ERROR", ErrServerError, true},
+		{"INTERNAL_SERVER_ERROR", ErrForbiddenScope, false},
+		{"THROTTLED", ErrRateLimited, true},
+		{"BAD_USER_INPUT", ErrForbiddenScope, false},
+		{"BAD_USER_INPUT", ErrServerError, false},
+	}
+
+	for _, c := range cases {
+		registerGraphQLResponder(fmt.Sprintf(`{"errors":[{"message":"failed","extensions":{"code":%q}}]}`, c.code), nil)
+
+		err := client.GraphQL.Query(context.Background(), "query {}", nil, &struct{}{})
+		if actual := errors.Is(err, c.target); actual != c.expected {
+			t.Errorf("errors.Is(%#v, %v) for code %s: expected %v, actual %v", err, c.target, c.code, c.expected, actual)
+		}
+	}
+}
+
+func TestResponseErrorMetadata(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cases := []struct {
+		path      string
+		responder httpmock.Responder
+	}{
+		{"products/1.json", httpmock.NewStringResponder(500, "")},
+		{"products/2.json", httpmock.NewStringResponder(502, "<html></html>")},
+		{"products/3.json", httpmock.NewStringResponder(500, "{<html></html>")},
+	}
+
+	for _, c := range cases {
+		requestURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/%s", client.pathPrefix, c.path)
+		httpmock.RegisterResponder("GET", requestURL, func(req *http.Request) (*http.Response, error) {
+			resp, err := c.responder(req)
+			if resp != nil {
+				resp.Header.Set("X-Request-Id", "abc-123")
+			}
+			return resp, err
+		})
+
+		req, err := client.NewRequest(context.Background(), "GET", fmt.Sprintf("%s/%s", client.pathPrefix, c.path), nil, nil)
+		if err != nil {
+			t.Fatalf("NewRequest returned error: %v", err)
+		}
+
+		err = client.Do(req, nil)
+		var responseError ResponseError
+		if !errors.As(err, &responseError) {
+			t.Errorf("Do(%s) returned %#v, expected a ResponseError", c.path, err)
+			continue
+		}
+		if responseError.Status < 500 || responseError.RequestURL != requestURL || responseError.RequestId != "abc-123" {
+			t.Errorf("Do(%s) returned %#v, expected status, url and request id", c.path, responseError)
+		}
+	}
+}
+
+func TestResponseErrorRetriesConcurrent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/unavailable", httpmock.NewStringResponder(http.StatusServiceUnavailable, ""))
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/missing", httpmock.NewStringResponder(http.StatusNotFound, ""))
+
+	cases := map[string]int{"foo/unavailable": maxRetries - 1, "foo/missing": 0}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		for path, retries := range cases {
+			wg.Add(1)
+			go func(path string, retries int) {
+				defer wg.Done()
+				req, err := client.NewRequest(context.Background(), "GET", path, nil, nil)
+				if err != nil {
+					t.Errorf("NewRequest returned error: %v", err)
+					return
+				}
+				var responseError ResponseError
+				if err := client.Do(req, nil); !errors.As(err, &responseError) || responseError.Retries != retries {
+					t.Errorf("Do(%s) returned %#v, expected %d retries", path, err, retries)
+				}
+			}(path, retries)
+		}
+	}
+	wg.Wait()
+}
+
+func TestResponseErrorStatusMessage(t *testing.T) {
+	err := ResponseError{Status: http.StatusBadGateway}
+	if err.Error() != "502 Bad Gateway" {
+		t.Errorf("ResponseError.Error() returned %q, expected 502 Bad Gateway", err.Error())
+	}
+}
+
+func TestRateLimitErrorCallLimit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(429, `{"errors":"Exceeded 2 calls per second for api client."}`)
+			resp.Header.Set("Retry-After", "1.5")
+			resp.Header.Set("X-Shopify-Shop-Api-Call-Limit", "40/40")
+			return resp, nil
+		})
+
+	// without retries the rate limit error is returned at once
+	client.retries = 0
+	_, err := client.Product.Get(context.Background(), 1, nil)
+
+	var rateLimitErr RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("Product.Get returned %#v, expected a RateLimitError", err)
+	}
+	if rateLimitErr.RetryAfter != 1500*time.Millisecond || rateLimitErr.RequestCount != 40 || rateLimitErr.BucketSize != 40 {
+		t.Errorf("Product.Get returned %+v, expected a retry after 1.5s at 40/40", rateLimitErr)
+	}
+}
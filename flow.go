@@ -0,0 +1,79 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+const flowTriggerReceiveMutation = `mutation flowTriggerReceive($handle: String, $payload: JSON) {
+  flowTriggerReceive(handle: $handle, payload: $payload) {
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+// FlowService is an interface for interfacing with the Shopify Flow
+// endpoints of the Shopify API.
+// See: https://shopify.dev/docs/apps/flow/triggers
+type FlowService interface {
+	Trigger(context.Context, FlowTrigger) error
+}
+
+// FlowServiceOp handles communication with the Shopify Flow related methods
+// of the Shopify API.
+type FlowServiceOp struct {
+	client *Client
+}
+
+// FlowTrigger represents a custom Shopify Flow trigger submission.
+// Payload holds the trigger properties and is usually a struct whose json
+// tags match the property keys defined in the trigger extension, e.g.
+//
+//	type OrderFlagged struct {
+//		OrderId  uint64 `json:"order_id"`
+//		Reason   string `json:"Reason"`
+//	}
+type FlowTrigger struct {
+	Handle  string
+	Payload interface{}
+}
+
+// flowTriggerMaxPayloadSize is the maximum payload size accepted by
+// flowTriggerReceive
+const flowTriggerMaxPayloadSize = 50000
+
+// Trigger fires a custom Shopify Flow trigger using the flowTriggerReceive
+// mutation
+func (s *FlowServiceOp) Trigger(ctx context.Context, trigger FlowTrigger) error {
+	if trigger.Handle == "" {
+		return errors.New("flow trigger handle is required")
+	}
+
+	payload, err := json.Marshal(trigger.Payload)
+	if err != nil {
+		return err
+	}
+	if len(payload) > flowTriggerMaxPayloadSize {
+		return errors.New("flow trigger payload exceeds 50KB")
+	}
+
+	vars := map[string]interface{}{
+		"handle":  trigger.Handle,
+		"payload": json.RawMessage(payload),
+	}
+	resp := struct {
+		FlowTriggerReceive struct {
+			UserErrors []GraphQLUserError `json:"userErrors"`
+		} `json:"flowTriggerReceive"`
+	}{}
+
+	err = s.client.GraphQL.Query(ctx, flowTriggerReceiveMutation, vars, &resp)
+	if err != nil {
+		return err
+	}
+
+	return userErrorsToResponseError(resp.FlowTriggerReceive.UserErrors)
+}
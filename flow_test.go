@@ -0,0 +1,86 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestFlowTrigger(t *testing.T) {
+	setup()
+	defer teardown()
+
+	type orderFlagged struct {
+		OrderId uint64 `json:"order_id"`
+		Reason  string `json:"Reason"`
+	}
+
+	var body struct {
+		Query     string `json:"query"`
+		Variables struct {
+			Handle  string       `json:"handle"`
+			Payload orderFlagged `json:"payload"`
+		} `json:"variables"`
+	}
+
+	httpmock.RegisterResponder(
+		"POST",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := io.ReadAll(req.Body)
+			if err := json.Unmarshal(b, &body); err != nil {
+				return nil, err
+			}
+			return httpmock.NewStringResponse(200, `{"data":{"flowTriggerReceive":{"userErrors":[]}}}`), nil
+		},
+	)
+
+	err := client.Flow.Trigger(context.Background(), FlowTrigger{
+		Handle:  "order-flagged",
+		Payload: orderFlagged{OrderId: 1, Reason: "fraud"},
+	})
+	if err != nil {
+		t.Errorf("Flow.Trigger returned error: %v", err)
+	}
+
+	if body.Variables.Handle != "order-flagged" {
+		t.Errorf("Flow.Trigger sent handle %s, expected order-flagged", body.Variables.Handle)
+	}
+
+	expectedPayload := orderFlagged{OrderId: 1, Reason: "fraud"}
+	if body.Variables.Payload != expectedPayload {
+		t.Errorf("Flow.Trigger sent payload %#v, expected %#v", body.Variables.Payload, expectedPayload)
+	}
+}
+
+func TestFlowTriggerUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder(
+		"POST",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{"flowTriggerReceive":{"userErrors":[{"field":["body"],"message":"Errors validating schema"}]}}}`),
+	)
+
+	err := client.Flow.Trigger(context.Background(), FlowTrigger{Handle: "order-flagged"})
+	expected := "body: Errors validating schema"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Flow.Trigger returned error %v, expected %s", err, expected)
+	}
+}
+
+func TestFlowTriggerMissingHandle(t *testing.T) {
+	setup()
+	defer teardown()
+
+	err := client.Flow.Trigger(context.Background(), FlowTrigger{})
+	if err == nil {
+		t.Error("Flow.Trigger expected error for missing handle")
+	}
+}
@@ -0,0 +1,191 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+)
+
+// Statuses of a FulfillmentOrder which can still be fulfilled
+const (
+	FulfillmentOrderStatusOpen       = "open"
+	FulfillmentOrderStatusInProgress = "in_progress"
+)
+
+// FulfillSpec describes what FulfillOrder should fulfill. When Items is empty
+// every fulfillable item of the order is fulfilled.
+type FulfillSpec struct {
+	Items          []FulfillSpecItem
+	LocationId     uint64
+	TrackingInfo   FulfillmentTrackingInfo
+	NotifyCustomer bool
+}
+
+// FulfillSpecItem is a quantity of an order line item to fulfill, identified
+// by its SKU or its variant id
+type FulfillSpecItem struct {
+	SKU       string
+	VariantId uint64
+	Quantity  uint64
+}
+
+// fulfillAllocation is a quantity taken from a fulfillment order line item
+type fulfillAllocation struct {
+	fulfillmentOrder *FulfillmentOrder
+	lineItemId       uint64
+	quantity         uint64
+}
+
+// FulfillOrder fulfills the items of spec for an order. The open fulfillment
+// orders are fetched, the requested quantities are taken from their line
+// items and one fulfillment is created per assigned location. When a
+// fulfillment fails the fulfillments created so far are returned along with
+// the error.
+func (s *FulfillmentOrderServiceOp) FulfillOrder(ctx context.Context, orderId uint64, spec FulfillSpec) ([]Fulfillment, error) {
+	fulfillmentOrders, err := s.List(ctx, orderId, nil)
+	if err != nil {
+		return nil, err
+	}
+	fulfillmentOrders = fulfillableOrders(fulfillmentOrders, spec.LocationId)
+
+	var allocations []fulfillAllocation
+	if len(spec.Items) == 0 {
+		allocations = allocateAll(fulfillmentOrders)
+	} else {
+		order, err := s.client.Order.Get(ctx, orderId, struct {
+			Fields string `url:"fields"`
+		}{"id,line_items"})
+		if err != nil {
+			return nil, err
+		}
+		allocations, err = allocateItems(fulfillmentOrders, order.LineItems, spec.Items)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(allocations) == 0 {
+		return nil, ValidationError{Field: "items", Message: fmt.Sprintf("order %d has nothing left to fulfill", orderId)}
+	}
+
+	fulfillments := []Fulfillment{}
+	for _, fulfillment := range groupAllocations(allocations) {
+		fulfillment.TrackingInfo = spec.TrackingInfo
+		fulfillment.NotifyCustomer = spec.NotifyCustomer
+		created, err := s.client.Fulfillment.Create(ctx, fulfillment)
+		if err != nil {
+			return fulfillments, err
+		}
+		fulfillments = append(fulfillments, *created)
+	}
+	return fulfillments, nil
+}
+
+// fulfillableOrders keeps the open fulfillment orders, assigned to locationId
+// when it is not zero
+func fulfillableOrders(fulfillmentOrders []FulfillmentOrder, locationId uint64) []FulfillmentOrder {
+	fulfillable := []FulfillmentOrder{}
+	for _, fulfillmentOrder := range fulfillmentOrders {
+		if fulfillmentOrder.Status != FulfillmentOrderStatusOpen && fulfillmentOrder.Status != FulfillmentOrderStatusInProgress {
+			continue
+		}
+		if locationId != 0 && fulfillmentOrder.AssignedLocationId != locationId {
+			continue
+		}
+		fulfillable = append(fulfillable, fulfillmentOrder)
+	}
+	return fulfillable
+}
+
+func allocateAll(fulfillmentOrders []FulfillmentOrder) []fulfillAllocation {
+	allocations := []fulfillAllocation{}
+	for i := range fulfillmentOrders {
+		for _, lineItem := range fulfillmentOrders[i].LineItems {
+			if lineItem.FulfillableQuantity > 0 {
+				allocations = append(allocations, fulfillAllocation{&fulfillmentOrders[i], lineItem.Id, lineItem.FulfillableQuantity})
+			}
+		}
+	}
+	return allocations
+}
+
+// allocateItems takes the quantity of every item from the fulfillment order
+// line items of the matching order line items, in the fulfillment order order
+func allocateItems(fulfillmentOrders []FulfillmentOrder, orderLineItems []LineItem, items []FulfillSpecItem) ([]fulfillAllocation, error) {
+	allocations := []fulfillAllocation{}
+	used := map[uint64]uint64{}
+
+	for i, item := range items {
+		field := fmt.Sprintf("items[%d]", i)
+		if item.Quantity == 0 {
+			return nil, ValidationError{Field: field + ".quantity", Message: "must be positive"}
+		}
+
+		lineItemIds := map[uint64]bool{}
+		for _, lineItem := range orderLineItems {
+			if (item.SKU != "" && lineItem.SKU == item.SKU) || (item.VariantId != 0 && lineItem.VariantId == item.VariantId) {
+				lineItemIds[lineItem.Id] = true
+			}
+		}
+		if len(lineItemIds) == 0 {
+			return nil, ValidationError{Field: field, Message: fmt.Sprintf("no line item matches sku %q or variant %d", item.SKU, item.VariantId)}
+		}
+
+		remaining := item.Quantity
+		for j := range fulfillmentOrders {
+			for _, lineItem := range fulfillmentOrders[j].LineItems {
+				if remaining == 0 {
+					break
+				}
+				if !lineItemIds[lineItem.LineItemId] || lineItem.FulfillableQuantity <= used[lineItem.Id] {
+					continue
+				}
+				quantity := lineItem.FulfillableQuantity - used[lineItem.Id]
+				if quantity > remaining {
+					quantity = remaining
+				}
+				used[lineItem.Id] += quantity
+				remaining -= quantity
+				allocations = append(allocations, fulfillAllocation{&fulfillmentOrders[j], lineItem.Id, quantity})
+			}
+		}
+		if remaining > 0 {
+			return nil, ValidationError{Field: field + ".quantity", Message: fmt.Sprintf("only %d of %d can be fulfilled", item.Quantity-remaining, item.Quantity)}
+		}
+	}
+	return allocations, nil
+}
+
+// groupAllocations builds one fulfillment per assigned location, as a
+// fulfillment can only contain fulfillment orders of a single location
+func groupAllocations(allocations []fulfillAllocation) []Fulfillment {
+	fulfillments := []Fulfillment{}
+	byLocation := map[uint64]int{}
+
+	for _, allocation := range allocations {
+		locationId := allocation.fulfillmentOrder.AssignedLocationId
+		index, ok := byLocation[locationId]
+		if !ok {
+			index = len(fulfillments)
+			byLocation[locationId] = index
+			fulfillments = append(fulfillments, Fulfillment{})
+		}
+
+		fulfillment := &fulfillments[index]
+		quantity := LineItemByFulfillmentOrderItemQuantity{Id: allocation.lineItemId, Quantity: allocation.quantity}
+		found := false
+		for k := range fulfillment.LineItemsByFulfillmentOrder {
+			byOrder := &fulfillment.LineItemsByFulfillmentOrder[k]
+			if byOrder.FulfillmentOrderId == allocation.fulfillmentOrder.Id {
+				byOrder.FulfillmentOrderLineItems = append(byOrder.FulfillmentOrderLineItems, quantity)
+				found = true
+				break
+			}
+		}
+		if !found {
+			fulfillment.LineItemsByFulfillmentOrder = append(fulfillment.LineItemsByFulfillmentOrder, LineItemByFulfillmentOrder{
+				FulfillmentOrderId:        allocation.fulfillmentOrder.Id,
+				FulfillmentOrderLineItems: []LineItemByFulfillmentOrderItemQuantity{quantity},
+			})
+		}
+	}
+	return fulfillments
+}
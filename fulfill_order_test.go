@@ -0,0 +1,135 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+const fulfillOrderFulfillmentOrders = `{"fulfillment_orders": [
+	{"id":1,"status":"open","assigned_location_id":10,"line_items":[
+		{"id":11,"line_item_id":100,"variant_id":1000,"fulfillable_quantity":2},
+		{"id":12,"line_item_id":200,"variant_id":2000,"fulfillable_quantity":1}
+	]},
+	{"id":2,"status":"closed","assigned_location_id":10,"line_items":[
+		{"id":21,"line_item_id":100,"variant_id":1000,"fulfillable_quantity":5}
+	]},
+	{"id":3,"status":"open","assigned_location_id":20,"line_items":[
+		{"id":31,"line_item_id":100,"variant_id":1000,"fulfillable_quantity":3}
+	]}
+]}`
+
+func registerFulfillOrderResponders(created *[]Fulfillment) {
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/123/fulfillment_orders.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, fulfillOrderFulfillmentOrders))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/123.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"order":{"id":123,"line_items":[{"id":100,"variant_id":1000,"sku":"SKU-A"},{"id":200,"variant_id":2000,"sku":"SKU-B"}]}}`))
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillments.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			resource := FulfillmentResource{}
+			if err := json.NewDecoder(req.Body).Decode(&resource); err != nil {
+				return nil, err
+			}
+			resource.Fulfillment.Id = uint64(len(*created) + 1)
+			*created = append(*created, *resource.Fulfillment)
+			return httpmock.NewJsonResponse(201, resource)
+		})
+}
+
+func TestFulfillmentOrderFulfillOrder(t *testing.T) {
+	setup()
+	defer teardown()
+
+	created := []Fulfillment{}
+	registerFulfillOrderResponders(&created)
+
+	fulfillments, err := client.FulfillmentOrder.FulfillOrder(context.Background(), 123, FulfillSpec{
+		Items: []FulfillSpecItem{
+			{SKU: "SKU-A", Quantity: 4},
+			{VariantId: 2000, Quantity: 1},
+		},
+		TrackingInfo:   FulfillmentTrackingInfo{Company: "UPS", Number: "1Z"},
+		NotifyCustomer: true,
+	})
+	if err != nil {
+		t.Fatalf("FulfillmentOrder.FulfillOrder returned error: %v", err)
+	}
+	if len(fulfillments) != 2 {
+		t.Fatalf("FulfillmentOrder.FulfillOrder returned %d fulfillments, expected 2", len(fulfillments))
+	}
+
+	expected := [][]LineItemByFulfillmentOrder{
+		{{FulfillmentOrderId: 1, FulfillmentOrderLineItems: []LineItemByFulfillmentOrderItemQuantity{{Id: 11, Quantity: 2}, {Id: 12, Quantity: 1}}}},
+		{{FulfillmentOrderId: 3, FulfillmentOrderLineItems: []LineItemByFulfillmentOrderItemQuantity{{Id: 31, Quantity: 2}}}},
+	}
+	for i, fulfillment := range created {
+		if !reflect.DeepEqual(fulfillment.LineItemsByFulfillmentOrder, expected[i]) {
+			t.Errorf("FulfillmentOrder.FulfillOrder sent %+v, expected %+v", fulfillment.LineItemsByFulfillmentOrder, expected[i])
+		}
+		if fulfillment.TrackingInfo.Number != "1Z" || !fulfillment.NotifyCustomer {
+			t.Errorf("FulfillmentOrder.FulfillOrder sent tracking %+v and notify %v", fulfillment.TrackingInfo, fulfillment.NotifyCustomer)
+		}
+	}
+}
+
+func TestFulfillmentOrderFulfillOrderAll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	created := []Fulfillment{}
+	registerFulfillOrderResponders(&created)
+
+	_, err := client.FulfillmentOrder.FulfillOrder(context.Background(), 123, FulfillSpec{LocationId: 20})
+	if err != nil {
+		t.Fatalf("FulfillmentOrder.FulfillOrder returned error: %v", err)
+	}
+
+	expected := []Fulfillment{{
+		Id: 1,
+		LineItemsByFulfillmentOrder: []LineItemByFulfillmentOrder{
+			{FulfillmentOrderId: 3, FulfillmentOrderLineItems: []LineItemByFulfillmentOrderItemQuantity{{Id: 31, Quantity: 3}}},
+		},
+	}}
+	if !reflect.DeepEqual(created, expected) {
+		t.Errorf("FulfillmentOrder.FulfillOrder sent %+v, expected %+v", created, expected)
+	}
+}
+
+func TestFulfillmentOrderFulfillOrderValidation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	created := []Fulfillment{}
+	registerFulfillOrderResponders(&created)
+
+	cases := []struct {
+		description string
+		items       []FulfillSpecItem
+		field       string
+	}{
+		{"unknown sku", []FulfillSpecItem{{SKU: "SKU-C", Quantity: 1}}, "items[0]"},
+		{"too many", []FulfillSpecItem{{SKU: "SKU-A", Quantity: 6}}, "items[0].quantity"},
+		{"too many across items", []FulfillSpecItem{{SKU: "SKU-A", Quantity: 3}, {VariantId: 1000, Quantity: 3}}, "items[1].quantity"},
+		{"zero quantity", []FulfillSpecItem{{SKU: "SKU-A"}}, "items[0].quantity"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			_, err := client.FulfillmentOrder.FulfillOrder(context.Background(), 123, FulfillSpec{Items: c.items})
+			var validationErr ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != c.field {
+				t.Errorf("FulfillmentOrder.FulfillOrder returned %v, expected a ValidationError on %s", err, c.field)
+			}
+		})
+	}
+
+	if len(created) != 0 {
+		t.Errorf("FulfillmentOrder.FulfillOrder created %d fulfillments, expected none", len(created))
+	}
+}
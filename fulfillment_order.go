@@ -20,19 +20,34 @@ type FulfillmentOrderService interface {
 	Reschedule(context.Context, uint64) (*FulfillmentOrder, error)
 	SetDeadline(context.Context, []uint64, time.Time) error
 	Move(context.Context, uint64, FulfillmentOrderMoveRequest) (*FulfillmentOrderMoveResource, error)
+	FulfillOrder(context.Context, uint64, FulfillSpec) ([]Fulfillment, error)
+	Reconcile(context.Context, []uint64) ([]OrderFulfillmentSummary, error)
+	ShippingOrigin(context.Context, FulfillmentOrder) (*ShippingRateAddress, error)
 }
 
 // FulfillmentOrderHoldReason represents the reason for a fulfillment hold
 type FulfillmentOrderHoldReason string
 
 const (
-	HoldReasonAwaitingPayment  FulfillmentOrderHoldReason = "awaiting_payment"
-	HoldReasonHighRiskOfFraud  FulfillmentOrderHoldReason = "high_risk_of_fraud"
-	HoldReasonIncorrectAddress FulfillmentOrderHoldReason = "incorrect_address"
-	HoldReasonOutOfStock       FulfillmentOrderHoldReason = "inventory_out_of_stock"
-	HoldReasonOther            FulfillmentOrderHoldReason = "other"
+	HoldReasonAwaitingPayment     FulfillmentOrderHoldReason = "awaiting_payment"
+	HoldReasonAwaitingReturnItems FulfillmentOrderHoldReason = "awaiting_return_items"
+	HoldReasonHighRiskOfFraud     FulfillmentOrderHoldReason = "high_risk_of_fraud"
+	HoldReasonIncorrectAddress    FulfillmentOrderHoldReason = "incorrect_address"
+	HoldReasonOutOfStock          FulfillmentOrderHoldReason = "inventory_out_of_stock"
+	HoldReasonUnknownDeliveryDate FulfillmentOrderHoldReason = "unknown_delivery_date"
+	HoldReasonOther               FulfillmentOrderHoldReason = "other"
 )
 
+// IsKnown reports whether the reason is one of the hold reason constants
+func (r FulfillmentOrderHoldReason) IsKnown() bool {
+	switch r {
+	case HoldReasonAwaitingPayment, HoldReasonAwaitingReturnItems, HoldReasonHighRiskOfFraud,
+		HoldReasonIncorrectAddress, HoldReasonOutOfStock, HoldReasonUnknownDeliveryDate, HoldReasonOther:
+		return true
+	}
+	return false
+}
+
 // FulfillmentOrderServiceOp handles communication with the fulfillment order
 // related methods of the Shopify API.
 type FulfillmentOrderServiceOp struct {
@@ -49,12 +64,40 @@ type FulfillmentOrderMoveRequest struct {
 	LineItems     []FulfillmentOrderLineItemQuantity `json:"fulfillment_order_line_items,omitempty"`
 }
 
+// Method types of a FulfillmentOrderDeliveryMethod
+const (
+	DeliveryMethodTypeLocal    = "local"
+	DeliveryMethodTypeNone     = "none"
+	DeliveryMethodTypePickUp   = "pick_up"
+	DeliveryMethodTypeRetail   = "retail"
+	DeliveryMethodTypeShipping = "shipping"
+)
+
 // FulfillmentOrderDeliveryMethod represents a delivery method for a FulfillmentOrder
 type FulfillmentOrderDeliveryMethod struct {
-	Id                  uint64    `json:"id,omitempty"`
-	MethodType          string    `json:"method_type,omitempty"`
-	MinDeliveryDateTime time.Time `json:"min_delivery_date_time,omitempty"`
-	MaxDeliveryDateTime time.Time `json:"max_delivery_date_time,omitempty"`
+	Id                  uint64     `json:"id,omitempty"`
+	MethodType          string     `json:"method_type,omitempty"`
+	MinDeliveryDateTime *time.Time `json:"min_delivery_date_time,omitempty"`
+	MaxDeliveryDateTime *time.Time `json:"max_delivery_date_time,omitempty"`
+}
+
+// IsLocalDelivery reports whether the order is delivered by the merchant
+func (m FulfillmentOrderDeliveryMethod) IsLocalDelivery() bool {
+	return m.MethodType == DeliveryMethodTypeLocal
+}
+
+// IsPickUp reports whether the buyer picks the order up at the assigned location
+func (m FulfillmentOrderDeliveryMethod) IsPickUp() bool {
+	return m.MethodType == DeliveryMethodTypePickUp
+}
+
+// Window returns the delivery or pickup window, ok is false when Shopify did
+// not provide one
+func (m FulfillmentOrderDeliveryMethod) Window() (start, end time.Time, ok bool) {
+	if m.MinDeliveryDateTime == nil || m.MaxDeliveryDateTime == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	return *m.MinDeliveryDateTime, *m.MaxDeliveryDateTime, true
 }
 
 // FulfillmentOrderDestination represents a destination for a FulfillmentOrder
@@ -100,9 +143,9 @@ type FulfillmentOrderLineItem struct {
 type FulfillmentOrderMerchantRequest struct {
 	Message        string `json:"message,omitempty"`
 	RequestOptions struct {
-		ShippingMethod string    `json:"shipping_method,omitempty"`
-		Note           string    `json:"note,omitempty"`
-		Date           time.Time `json:"date,omitempty"`
+		ShippingMethod string     `json:"shipping_method,omitempty"`
+		Note           string     `json:"note,omitempty"`
+		Date           *time.Time `json:"date,omitempty"`
 	} `json:"request_options"`
 	Kind string `json:"kind,omitempty"`
 }
@@ -181,6 +224,26 @@ func (s *FulfillmentOrderServiceOp) Get(ctx context.Context, fulfillmentId uint6
 	return resource.FulfillmentOrder, err
 }
 
+// ShippingOrigin returns the address of the location assigned to a
+// fulfillment order, looked up with Location.GetCached, e.g. as the origin of
+// the rates of a carrier integration
+func (s *FulfillmentOrderServiceOp) ShippingOrigin(ctx context.Context, fulfillmentOrder FulfillmentOrder) (*ShippingRateAddress, error) {
+	locationId := fulfillmentOrder.AssignedLocationId
+	if locationId == 0 {
+		locationId = fulfillmentOrder.AssignedLocation.LocationId
+	}
+	if locationId == 0 {
+		return nil, fmt.Errorf("fulfillment order %d has no assigned location", fulfillmentOrder.Id)
+	}
+
+	location, err := s.client.Location.GetCached(ctx, locationId)
+	if err != nil {
+		return nil, err
+	}
+	origin := location.ShippingRateAddress()
+	return &origin, nil
+}
+
 // Cancel cancels a fulfillment order
 func (s *FulfillmentOrderServiceOp) Cancel(ctx context.Context, fulfillmentId uint64) (*FulfillmentOrder, error) {
 	prefix := FulfillmentOrderPathPrefix("fulfillment_orders", fulfillmentId)
@@ -204,7 +267,9 @@ func (s *FulfillmentOrderServiceOp) Close(ctx context.Context, fulfillmentId uin
 	return resource.FulfillmentOrder, err
 }
 
-// Hold applies a fulfillment hold on an open fulfillment order
+// Hold applies a fulfillment hold on an open fulfillment order, notify sends
+// a notification to the fulfillment service and notes is required when the
+// reason is HoldReasonOther
 func (s *FulfillmentOrderServiceOp) Hold(ctx context.Context, fulfillmentId uint64, notify bool, reason FulfillmentOrderHoldReason, notes string) (*FulfillmentOrder, error) {
 	type holdRequest struct {
 		Reason         FulfillmentOrderHoldReason `json:"reason"`
@@ -236,7 +301,8 @@ func (s *FulfillmentOrderServiceOp) Open(ctx context.Context, fulfillmentId uint
 	return resource.FulfillmentOrder, err
 }
 
-// ReleaseHold releases the fulfillment hold on a fulfillment order
+// ReleaseHold releases the fulfillment hold on a fulfillment order, which
+// becomes open again
 func (s *FulfillmentOrderServiceOp) ReleaseHold(ctx context.Context, fulfillmentId uint64) (*FulfillmentOrder, error) {
 	prefix := FulfillmentOrderPathPrefix("fulfillment_orders", fulfillmentId)
 	path := fmt.Sprintf("%s/release_hold.json", prefix)
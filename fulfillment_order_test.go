@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"reflect"
 	"testing"
 	"time"
@@ -116,6 +117,36 @@ func TestFulfillmentOrderHold(t *testing.T) {
 	FulfillmentOrderTests(t, *returnedFulfillment)
 }
 
+func TestFulfillmentOrderHoldRequest(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body map[string]map[string]interface{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillment_orders/1/hold.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			return httpmock.NewBytesResponse(200, loadFixture("fulfillment_order.json")), nil
+		})
+
+	_, err := client.FulfillmentOrder.Hold(context.Background(), 1, true, HoldReasonIncorrectAddress, "address could not be verified")
+	if err != nil {
+		t.Fatalf("FulfillmentOrder.Hold returned error: %v", err)
+	}
+
+	expected := map[string]map[string]interface{}{
+		"fulfillment_hold": {
+			"reason":          "incorrect_address",
+			"reason_notes":    "address could not be verified",
+			"notify_merchant": true,
+		},
+	}
+	if !reflect.DeepEqual(body, expected) {
+		t.Errorf("FulfillmentOrder.Hold sent %+v, expected %+v", body, expected)
+	}
+}
+
 func TestFulfillmentOrderMove(t *testing.T) {
 	setup()
 	defer teardown()
@@ -217,3 +248,61 @@ func TestFulfillmentOrderSetDeadline(t *testing.T) {
 		t.Errorf("FulfillmentOrder.SetDeadline returned error: %v", err)
 	}
 }
+
+func TestFulfillmentOrderDeliveryMethod(t *testing.T) {
+	fulfillmentOrder := FulfillmentOrder{}
+	err := json.Unmarshal([]byte(`{"id":1,"delivery_method":{"id":2,"method_type":"local","min_delivery_date_time":"2024-03-01T14:00:00Z","max_delivery_date_time":"2024-03-01T16:00:00Z"}}`), &fulfillmentOrder)
+	if err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+
+	deliveryMethod := fulfillmentOrder.DeliveryMethod
+	if !deliveryMethod.IsLocalDelivery() || deliveryMethod.IsPickUp() {
+		t.Errorf("FulfillmentOrderDeliveryMethod with method type %s is not local delivery", deliveryMethod.MethodType)
+	}
+
+	start, end, ok := deliveryMethod.Window()
+	expectedStart := time.Date(2024, 3, 1, 14, 0, 0, 0, time.UTC)
+	expectedEnd := time.Date(2024, 3, 1, 16, 0, 0, 0, time.UTC)
+	if !ok || !start.Equal(expectedStart) || !end.Equal(expectedEnd) {
+		t.Errorf("FulfillmentOrderDeliveryMethod.Window returned %v, %v, %v", start, end, ok)
+	}
+
+	if _, _, ok := (FulfillmentOrderDeliveryMethod{MethodType: DeliveryMethodTypePickUp}).Window(); ok {
+		t.Errorf("FulfillmentOrderDeliveryMethod.Window returned ok without dates")
+	}
+}
+
+func TestFulfillmentOrderShippingOrigin(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/locations/4688969785.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("location.json")))
+
+	fulfillmentOrderService := &FulfillmentOrderServiceOp{client: client}
+
+	origin, err := fulfillmentOrderService.ShippingOrigin(context.Background(), FulfillmentOrder{
+		Id:               1,
+		AssignedLocation: FulfillmentOrderAssignedLocation{LocationId: 4688969785},
+	})
+	if err != nil {
+		t.Fatalf("FulfillmentOrder.ShippingOrigin returned error: %v", err)
+	}
+
+	expected := &ShippingRateAddress{
+		Country:    "PL",
+		PostalCode: "10-001",
+		City:       "Olsztyn",
+		Name:       "Bajkowa",
+		Address1:   "Bajkowa",
+		Phone:      "12312312",
+	}
+	if !reflect.DeepEqual(origin, expected) {
+		t.Errorf("FulfillmentOrder.ShippingOrigin returned %+v, expected %+v", origin, expected)
+	}
+
+	if _, err := fulfillmentOrderService.ShippingOrigin(context.Background(), FulfillmentOrder{Id: 2}); err == nil {
+		t.Errorf("FulfillmentOrder.ShippingOrigin returned no error for a fulfillment order without location")
+	}
+}
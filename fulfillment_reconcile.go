@@ -0,0 +1,168 @@
+package goshopify
+
+import (
+	"context"
+	"time"
+)
+
+// Statuses of a FulfillmentOrder which is waiting
+const (
+	FulfillmentOrderStatusScheduled = "scheduled"
+	FulfillmentOrderStatusOnHold    = "on_hold"
+)
+
+// fulfillmentStatusSuccess is the status of a completed fulfillment
+const fulfillmentStatusSuccess = "success"
+
+// LineFulfillmentStatus summarizes the fulfillment of an order line item
+type LineFulfillmentStatus string
+
+const (
+	LineFulfillmentStatusUnfulfilled        LineFulfillmentStatus = "unfulfilled"
+	LineFulfillmentStatusPartiallyFulfilled LineFulfillmentStatus = "partially_fulfilled"
+	LineFulfillmentStatusFulfilled          LineFulfillmentStatus = "fulfilled"
+	LineFulfillmentStatusOnHold             LineFulfillmentStatus = "on_hold"
+	LineFulfillmentStatusScheduled          LineFulfillmentStatus = "scheduled"
+)
+
+// LineFulfillmentSummary is the fulfillment state of an order line item.
+// The open, on hold and scheduled quantities are the quantities still to
+// fulfill in fulfillment orders of that status.
+type LineFulfillmentSummary struct {
+	LineItemId        uint64
+	VariantId         uint64
+	SKU               string
+	Title             string
+	Quantity          uint64
+	FulfilledQuantity uint64
+	OpenQuantity      uint64
+	OnHoldQuantity    uint64
+	ScheduledQuantity uint64
+	HoldReasons       []FulfillmentOrderHoldReason
+	FulfillAt         *time.Time
+	Status            LineFulfillmentStatus
+}
+
+// OrderFulfillmentSummary is the fulfillment state of the line items of an
+// order
+type OrderFulfillmentSummary struct {
+	OrderId uint64
+	Lines   []LineFulfillmentSummary
+}
+
+// Status returns the status of the order, fulfilled when every line is,
+// unfulfilled when no line is fulfilled and none is partially fulfilled,
+// otherwise partially fulfilled. Holds and schedules are reported by the
+// lines.
+func (s OrderFulfillmentSummary) Status() LineFulfillmentStatus {
+	fulfilled, started := 0, false
+	for _, line := range s.Lines {
+		if line.Status == LineFulfillmentStatusFulfilled {
+			fulfilled++
+		}
+		if line.FulfilledQuantity > 0 {
+			started = true
+		}
+	}
+	switch {
+	case len(s.Lines) > 0 && fulfilled == len(s.Lines):
+		return LineFulfillmentStatusFulfilled
+	case started:
+		return LineFulfillmentStatusPartiallyFulfilled
+	}
+	return LineFulfillmentStatusUnfulfilled
+}
+
+// Reconcile fetches the line items, fulfillments and fulfillment orders of
+// each order and summarizes the fulfillment of every line item. A line is
+// fulfilled once its whole quantity is, otherwise it is on hold or scheduled
+// when some of its remaining quantity is, then partially fulfilled or
+// unfulfilled. Summaries are returned in the order of orderIds, along with
+// the ones built so far when a request fails.
+func (s *FulfillmentOrderServiceOp) Reconcile(ctx context.Context, orderIds []uint64) ([]OrderFulfillmentSummary, error) {
+	summaries := []OrderFulfillmentSummary{}
+	for _, orderId := range orderIds {
+		order, err := s.client.Order.Get(ctx, orderId, struct {
+			Fields string `url:"fields"`
+		}{"id,line_items,fulfillments"})
+		if err != nil {
+			return summaries, err
+		}
+		fulfillmentOrders, err := s.List(ctx, orderId, nil)
+		if err != nil {
+			return summaries, err
+		}
+		summaries = append(summaries, reconcileFulfillment(*order, fulfillmentOrders))
+	}
+	return summaries, nil
+}
+
+func reconcileFulfillment(order Order, fulfillmentOrders []FulfillmentOrder) OrderFulfillmentSummary {
+	summary := OrderFulfillmentSummary{OrderId: order.Id}
+	lines := map[uint64]*LineFulfillmentSummary{}
+	for _, lineItem := range order.LineItems {
+		summary.Lines = append(summary.Lines, LineFulfillmentSummary{
+			LineItemId: lineItem.Id,
+			VariantId:  lineItem.VariantId,
+			SKU:        lineItem.SKU,
+			Title:      lineItem.Title,
+			Quantity:   uint64(lineItem.Quantity),
+		})
+	}
+	for i := range summary.Lines {
+		lines[summary.Lines[i].LineItemId] = &summary.Lines[i]
+	}
+
+	for _, fulfillment := range order.Fulfillments {
+		if fulfillment.Status != fulfillmentStatusSuccess {
+			continue
+		}
+		for _, lineItem := range fulfillment.LineItems {
+			if line, ok := lines[lineItem.Id]; ok {
+				line.FulfilledQuantity += uint64(lineItem.Quantity)
+			}
+		}
+	}
+
+	for _, fulfillmentOrder := range fulfillmentOrders {
+		for _, lineItem := range fulfillmentOrder.LineItems {
+			line, ok := lines[lineItem.LineItemId]
+			if !ok || lineItem.FulfillableQuantity == 0 {
+				continue
+			}
+			switch fulfillmentOrder.Status {
+			case FulfillmentOrderStatusOpen, FulfillmentOrderStatusInProgress:
+				line.OpenQuantity += lineItem.FulfillableQuantity
+			case FulfillmentOrderStatusOnHold:
+				line.OnHoldQuantity += lineItem.FulfillableQuantity
+				for _, hold := range fulfillmentOrder.FulfillmentHolds {
+					line.HoldReasons = append(line.HoldReasons, hold.Reason)
+				}
+			case FulfillmentOrderStatusScheduled:
+				line.ScheduledQuantity += lineItem.FulfillableQuantity
+				if line.FulfillAt == nil || (fulfillmentOrder.FulfillAt != nil && fulfillmentOrder.FulfillAt.Before(*line.FulfillAt)) {
+					line.FulfillAt = fulfillmentOrder.FulfillAt
+				}
+			}
+		}
+	}
+
+	for i := range summary.Lines {
+		summary.Lines[i].Status = summary.Lines[i].status()
+	}
+	return summary
+}
+
+func (l LineFulfillmentSummary) status() LineFulfillmentStatus {
+	switch {
+	case l.Quantity > 0 && l.FulfilledQuantity >= l.Quantity:
+		return LineFulfillmentStatusFulfilled
+	case l.OnHoldQuantity > 0:
+		return LineFulfillmentStatusOnHold
+	case l.ScheduledQuantity > 0:
+		return LineFulfillmentStatusScheduled
+	case l.FulfilledQuantity > 0:
+		return LineFulfillmentStatusPartiallyFulfilled
+	}
+	return LineFulfillmentStatusUnfulfilled
+}
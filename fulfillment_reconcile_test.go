@@ -0,0 +1,64 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestFulfillmentOrderReconcile(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"order":{"id":1,
+			"line_items":[{"id":10,"quantity":2},{"id":11,"quantity":3},{"id":12,"quantity":1},{"id":13,"quantity":1},{"id":14,"quantity":1}],
+			"fulfillments":[
+				{"id":100,"status":"success","line_items":[{"id":10,"quantity":2},{"id":11,"quantity":1}]},
+				{"id":101,"status":"cancelled","line_items":[{"id":14,"quantity":1}]}
+			]}}`))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1/fulfillment_orders.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"fulfillment_orders":[
+			{"id":200,"status":"closed","line_items":[{"line_item_id":10,"quantity":2,"fulfillable_quantity":0}]},
+			{"id":201,"status":"open","line_items":[{"line_item_id":11,"quantity":2,"fulfillable_quantity":2},{"line_item_id":14,"quantity":1,"fulfillable_quantity":1}]},
+			{"id":202,"status":"on_hold","fulfillment_holds":[{"reason":"awaiting_payment"}],"line_items":[{"line_item_id":12,"quantity":1,"fulfillable_quantity":1}]},
+			{"id":203,"status":"scheduled","fulfill_at":"2026-01-01T00:00:00Z","line_items":[{"line_item_id":13,"quantity":1,"fulfillable_quantity":1}]}
+		]}`))
+
+	summaries, err := client.FulfillmentOrder.Reconcile(context.Background(), []uint64{1})
+	if err != nil {
+		t.Fatalf("FulfillmentOrder.Reconcile returned error: %v", err)
+	}
+	if len(summaries) != 1 || len(summaries[0].Lines) != 5 {
+		t.Fatalf("FulfillmentOrder.Reconcile returned %+v", summaries)
+	}
+
+	expected := []LineFulfillmentStatus{
+		LineFulfillmentStatusFulfilled,
+		LineFulfillmentStatusPartiallyFulfilled,
+		LineFulfillmentStatusOnHold,
+		LineFulfillmentStatusScheduled,
+		LineFulfillmentStatusUnfulfilled,
+	}
+	for i, line := range summaries[0].Lines {
+		if line.Status != expected[i] {
+			t.Errorf("line %d has status %s, expected %s", line.LineItemId, line.Status, expected[i])
+		}
+	}
+
+	partial := summaries[0].Lines[1]
+	if partial.FulfilledQuantity != 1 || partial.OpenQuantity != 2 {
+		t.Errorf("line 11 has fulfilled %d and open %d, expected 1 and 2", partial.FulfilledQuantity, partial.OpenQuantity)
+	}
+	if held := summaries[0].Lines[2]; len(held.HoldReasons) != 1 || held.HoldReasons[0] != HoldReasonAwaitingPayment {
+		t.Errorf("line 12 has hold reasons %v", held.HoldReasons)
+	}
+	if scheduled := summaries[0].Lines[3]; scheduled.FulfillAt == nil {
+		t.Errorf("line 13 has no fulfill_at")
+	}
+	if status := summaries[0].Status(); status != LineFulfillmentStatusPartiallyFulfilled {
+		t.Errorf("order status is %s, expected %s", status, LineFulfillmentStatusPartiallyFulfilled)
+	}
+}
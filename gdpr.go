@@ -0,0 +1,207 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const customerSubscriptionContractsQuery = `query customerSubscriptionContracts($id: ID!, $after: String) {
+  customer(id: $id) {
+    subscriptionContracts(first: 50, after: $after) {
+      nodes {
+        id
+        status
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+const subscriptionContractCancelMutation = `mutation subscriptionContractCancel($subscriptionContractId: ID!) {
+  subscriptionContractCancel(subscriptionContractId: $subscriptionContractId) {
+    contract {
+      id
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const customerRequestDataErasureMutation = `mutation customerRequestDataErasure($customerId: ID!) {
+  customerRequestDataErasure(customerId: $customerId) {
+    customerId
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+// Statuses of subscription contracts which are already over
+const (
+	subscriptionContractStatusCancelled = "CANCELLED"
+	subscriptionContractStatusExpired   = "EXPIRED"
+)
+
+// graphQLAccessDeniedMessage starts the graphql errors of fields the access
+// token has no scope for
+const graphQLAccessDeniedMessage = "Access denied"
+
+// GDPRService is an interface for fulfilling the data protection duties of
+// an app, e.g. on customers/redact webhooks.
+// See: https://shopify.dev/docs/apps/build/privacy-law-compliance
+type GDPRService interface {
+	EraseCustomer(context.Context, uint64) (*CustomerErasure, error)
+}
+
+// GDPRServiceOp handles the data protection helpers of the Shopify API.
+type GDPRServiceOp struct {
+	client *Client
+}
+
+// CustomerErasure reports what EraseCustomer did. When Shopify does not
+// allow deleting the customer, usually because they have orders, erasure of
+// their data is requested instead and processed by Shopify.
+type CustomerErasure struct {
+	MetafieldsDeleted      int
+	SubscriptionsCancelled int
+	Deleted                bool
+	ErasureRequested       bool
+}
+
+// EraseCustomer erases the data of a customer: its metafields are deleted,
+// its active subscription contracts are cancelled when the app has access to
+// subscriptions, then the customer is deleted. The progress made so far is
+// returned along with the error of a failed step.
+func (s *GDPRServiceOp) EraseCustomer(ctx context.Context, customerId uint64) (*CustomerErasure, error) {
+	erasure := &CustomerErasure{}
+
+	metafields, err := s.client.Customer.ListMetafields(ctx, customerId, nil)
+	if err != nil {
+		return erasure, fmt.Errorf("list metafields: %w", err)
+	}
+	for _, metafield := range metafields {
+		if err := s.client.Customer.DeleteMetafield(ctx, customerId, metafield.Id); err != nil && !errors.Is(err, ErrNotFound) {
+			return erasure, fmt.Errorf("delete metafield %d: %w", metafield.Id, err)
+		}
+		erasure.MetafieldsDeleted++
+	}
+
+	if err := s.cancelSubscriptions(ctx, customerId, erasure); err != nil {
+		return erasure, err
+	}
+
+	err = s.client.Customer.Delete(ctx, customerId)
+	var responseError ResponseError
+	switch {
+	case err == nil:
+		erasure.Deleted = true
+	case errors.Is(err, ErrNotFound):
+		erasure.Deleted = true
+	case errors.As(err, &responseError) && responseError.Status == http.StatusUnprocessableEntity:
+		if err := s.requestErasure(ctx, customerId); err != nil {
+			return erasure, fmt.Errorf("request data erasure: %w", err)
+		}
+		erasure.ErasureRequested = true
+	default:
+		return erasure, fmt.Errorf("delete customer: %w", err)
+	}
+	return erasure, nil
+}
+
+// cancelSubscriptions cancels the active subscription contracts of a
+// customer, nothing is done when the app cannot access subscriptions
+func (s *GDPRServiceOp) cancelSubscriptions(ctx context.Context, customerId uint64, erasure *CustomerErasure) error {
+	vars := map[string]interface{}{"id": NewGid("Customer", customerId)}
+	for {
+		resp := struct {
+			Customer *struct {
+				SubscriptionContracts struct {
+					Nodes []struct {
+						Id     string `json:"id"`
+						Status string `json:"status"`
+					} `json:"nodes"`
+					PageInfo GraphQLPageInfo `json:"pageInfo"`
+				} `json:"subscriptionContracts"`
+			} `json:"customer"`
+		}{}
+		err := s.client.GraphQL.Query(ctx, customerSubscriptionContractsQuery, vars, &resp)
+		if err != nil {
+			if isAccessDeniedError(err) {
+				return nil
+			}
+			return fmt.Errorf("list subscription contracts: %w", err)
+		}
+		if resp.Customer == nil {
+			return nil
+		}
+
+		for _, contract := range resp.Customer.SubscriptionContracts.Nodes {
+			if contract.Status == subscriptionContractStatusCancelled || contract.Status == subscriptionContractStatusExpired {
+				continue
+			}
+			if err := s.cancelSubscription(ctx, contract.Id); err != nil {
+				return fmt.Errorf("cancel subscription contract %s: %w", contract.Id, err)
+			}
+			erasure.SubscriptionsCancelled++
+		}
+
+		if !resp.Customer.SubscriptionContracts.PageInfo.HasNextPage {
+			return nil
+		}
+		vars["after"] = resp.Customer.SubscriptionContracts.PageInfo.EndCursor
+	}
+}
+
+func (s *GDPRServiceOp) cancelSubscription(ctx context.Context, contractId string) error {
+	resp := struct {
+		SubscriptionContractCancel struct {
+			UserErrors []GraphQLUserError `json:"userErrors"`
+		} `json:"subscriptionContractCancel"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, subscriptionContractCancelMutation, map[string]interface{}{"subscriptionContractId": contractId}, &resp)
+	if err != nil {
+		return err
+	}
+	return userErrorsToResponseError(resp.SubscriptionContractCancel.UserErrors)
+}
+
+func (s *GDPRServiceOp) requestErasure(ctx context.Context, customerId uint64) error {
+	resp := struct {
+		CustomerRequestDataErasure struct {
+			UserErrors []GraphQLUserError `json:"userErrors"`
+		} `json:"customerRequestDataErasure"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, customerRequestDataErasureMutation, map[string]interface{}{"customerId": NewGid("Customer", customerId)}, &resp)
+	if err != nil {
+		return err
+	}
+	return userErrorsToResponseError(resp.CustomerRequestDataErasure.UserErrors)
+}
+
+// isAccessDeniedError reports whether a graphql query failed because the
+// access token has no scope for a field
+func isAccessDeniedError(err error) bool {
+	if errors.Is(err, ErrForbiddenScope) {
+		return true
+	}
+	var responseError ResponseError
+	if errors.As(err, &responseError) {
+		for _, message := range append([]string{responseError.Message}, responseError.Errors...) {
+			if strings.HasPrefix(message, graphQLAccessDeniedMessage) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestGDPREraseCustomer(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/1/metafields.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"metafields":[{"id":2},{"id":3}]}`))
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/1/metafields/2.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{}`))
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/1/metafields/3.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{}`))
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(422, `{"errors":{"base":["Error deleting customer"]}}`))
+
+	var queries []string
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			captured := graphQLTestRequest{}
+			if err := json.NewDecoder(req.Body).Decode(&captured); err != nil {
+				return nil, err
+			}
+			queries = append(queries, captured.Query)
+			switch {
+			case strings.HasPrefix(captured.Query, "query customerSubscriptionContracts"):
+				return httpmock.NewStringResponse(200, `{"data":{"customer":{"subscriptionContracts":{"nodes":[{"id":"gid://shopify/SubscriptionContract/4","status":"ACTIVE"},{"id":"gid://shopify/SubscriptionContract/5","status":"CANCELLED"}],"pageInfo":{"hasNextPage":false}}}}}`), nil
+			case strings.HasPrefix(captured.Query, "mutation subscriptionContractCancel"):
+				if captured.Variables["subscriptionContractId"] != "gid://shopify/SubscriptionContract/4" {
+					t.Errorf("cancelled %v", captured.Variables["subscriptionContractId"])
+				}
+				return httpmock.NewStringResponse(200, `{"data":{"subscriptionContractCancel":{"userErrors":[]}}}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"data":{"customerRequestDataErasure":{"customerId":"gid://shopify/Customer/1","userErrors":[]}}}`), nil
+		})
+
+	erasure, err := client.GDPR.EraseCustomer(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GDPR.EraseCustomer returned error: %v", err)
+	}
+
+	expected := &CustomerErasure{MetafieldsDeleted: 2, SubscriptionsCancelled: 1, ErasureRequested: true}
+	if !reflect.DeepEqual(erasure, expected) {
+		t.Errorf("GDPR.EraseCustomer returned %+v, expected %+v", erasure, expected)
+	}
+	if len(queries) != 3 || !strings.HasPrefix(queries[2], "mutation customerRequestDataErasure") {
+		t.Errorf("GDPR.EraseCustomer sent graphql queries %v", queries)
+	}
+}
+
+func TestGDPREraseCustomerWithoutSubscriptionAccess(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/1/metafields.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"metafields":[]}`))
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{}`))
+	registerGraphQLResponder(`{"errors":[{"message":"Access denied for subscriptionContracts field."}]}`, nil)
+
+	erasure, err := client.GDPR.EraseCustomer(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GDPR.EraseCustomer returned error: %v", err)
+	}
+	if !erasure.Deleted || erasure.SubscriptionsCancelled != 0 {
+		t.Errorf("GDPR.EraseCustomer returned %+v", erasure)
+	}
+}
@@ -12,4 +12,6 @@ require (
 	github.com/google/go-querystring v1.0.0
 	github.com/jarcoal/httpmock v1.3.0
 	github.com/shopspring/decimal v0.0.0-20200105231215-408a2507e114
+	go.opentelemetry.io/otel v1.28.0
+	go.opentelemetry.io/otel/trace v1.28.0
 )
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"path"
@@ -17,9 +18,12 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/go-querystring/query"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -45,6 +49,10 @@ type App struct {
 	Scope       string
 	Password    string
 	Client      *Client // see GetAccessToken
+
+	// PreviousApiSecrets are still accepted by the verification helpers
+	// while ApiSecret is rotated
+	PreviousApiSecrets []string
 }
 
 type RateLimitInfo struct {
@@ -60,6 +68,9 @@ type Client struct {
 	Client *http.Client
 	log    LeveledLoggerInterface
 
+	// structured logger of the requests, see WithSlogLogger
+	slog *slog.Logger
+
 	// App settings
 	app App
 
@@ -68,21 +79,102 @@ type Client struct {
 	// its own client.
 	baseURL *url.URL
 
+	// myshopify domain of the shop, which identifies it even when the
+	// baseURL is a proxy, see WithBaseURL
+	shop string
+
 	// URL Prefix, defaults to "admin" see WithVersion
 	pathPrefix string
 
 	// version you're currently using of the api, defaults to "stable"
 	apiVersion string
 
+	// error of an invalid option, returned by NewClient
+	optionErr error
+
 	// A permanent access token
 	token string
 
 	// max number of retries, defaults to 0 for no retries see WithRetry option
-	retries  int
-	attempts int
+	retries int
+
+	// backoff between retries, see WithRetryPolicy
+	retryPolicy *RetryPolicy
+
+	// throttles REST requests before they are sent, see WithRateLimiter
+	rateLimiter RateLimiter
+
+	// calls back when the buckets fill up, see WithRateLimitCallback
+	rateLimitAnnouncer *rateLimitAnnouncer
+
+	// wrap the requests sent to shopify, see Use
+	middlewares []Middleware
 
+	// starts a span for every request, see WithTracerProvider
+	tracer trace.Tracer
+
+	// observes every request, see WithMetricsCollector
+	metrics MetricsCollector
+
+	// RateLimits is the rate limit state of the last response, read it with
+	// CurrentRateLimits when the client is shared by goroutines
 	RateLimits RateLimitInfo
 
+	// guards RateLimits and apiVersion, updated by the responses of
+	// concurrent requests
+	stateMu sync.Mutex
+
+	// called when shopify rejects the access token, see WithUnauthorizedHandler
+	onUnauthorized UnauthorizedHandler
+
+	// store for cached shop state, defaults to an in-memory store see WithCacheStore
+	cache CacheStore
+
+	// keep undeclared JSON fields of resources, see WithUnknownFieldCapture
+	captureUnknownFields bool
+
+	// hooks run on decoded resources by type, see WithDecodeHook
+	decodeHooks map[reflect.Type][]decodeHook
+
+	// shop domain patterns allowed to be mutated, see WithProductionGuard
+	productionGuard []string
+
+	// refuse every mutating request, see WithReadOnly
+	readOnly bool
+
+	// removes sensitive data before logging, see WithRedactor
+	redactor Redactor
+
+	// read resources with graphql when REST does not find them, see
+	// WithGraphQLFallbackOnNotFound
+	graphQLFallbackOnNotFound bool
+
+	// receives write requests instead of sending them, see WithEnqueuer
+	enqueuer Enqueuer
+
+	// compare updated_at before sending updates, see WithUpdatedAtPrecondition
+	updatedAtPrecondition bool
+
+	// set an idempotency key on mutating requests, see WithIdempotencyKeys
+	idempotencyKeys bool
+
+	// advertise AcceptEncoding on requests, see WithCompressedResponses
+	compressedResponses bool
+
+	// gzip request bodies of at least gzipRequestsMinSize bytes, see
+	// WithGzipRequests
+	gzipRequestsMinSize int
+
+	// records the exchanges with shopify, see WithHTTPDump
+	httpDumper HTTPDumper
+
+	// token of the storefront api, see WithStorefrontAccessToken
+	storefrontToken string
+
+	// graphql endpoint of the storefront api replacing the one of the shop,
+	// see WithMockShop
+	storefrontURL string
+
 	// Services used for communicating with the API
 	Product                    ProductService
 	CustomCollection           CustomCollectionService
@@ -93,6 +185,7 @@ type Client struct {
 	Fulfillment                FulfillmentService
 	DraftOrder                 DraftOrderService
 	AbandonedCheckout          AbandonedCheckoutService
+	Checkout                   CheckoutService
 	Shop                       ShopService
 	Webhook                    WebhookService
 	Variant                    VariantService
@@ -128,9 +221,27 @@ type Client struct {
 	AssignedFulfillmentOrder   AssignedFulfillmentOrderService
 	FulfillmentEvent           FulfillmentEventService
 	FulfillmentRequest         FulfillmentRequestService
+	CancellationRequest        CancellationRequestService
 	PaymentsTransactions       PaymentsTransactionsService
 	OrderRisk                  OrderRiskService
 	ApiPermissions             ApiPermissionsService
+	Flow                       FlowService
+	Refund                     RefundService
+	ProductOption              ProductOptionService
+	ShopifyQL                  ShopifyQLService
+	ShopifyFunction            ShopifyFunctionService
+	CartTransform              CartTransformService
+	DeliveryCustomization      CustomizationService
+	PaymentCustomization       CustomizationService
+	Company                    CompanyService
+	CompanyLocation            CompanyLocationService
+	CompanyContact             CompanyContactService
+	Country                    CountryService
+	Return                     ReturnService
+	GDPR                       GDPRService
+	AppMetafield               AppMetafieldService
+	BulkOperation              BulkOperationService
+	Storefront                 StorefrontService
 }
 
 // A general response error that follows a similar layout to Shopify's response
@@ -139,6 +250,20 @@ type ResponseError struct {
 	Status  int
 	Message string
 	Errors  []string
+	ResponseMetadata
+}
+
+// ResponseMetadata describes the request of a response, it is embedded in the
+// response errors returned for non-2xx responses and GraphQL errors, see
+// CaptureResponseMetadata for successful responses
+type ResponseMetadata struct {
+	// Retries is the number of retries attempted before the response
+	Retries int
+	// RequestURL is the url of the request
+	RequestURL string
+	// RequestId is the X-Request-Id shopify responded with, support asks
+	// for it when investigating a failure
+	RequestId string
 }
 
 // GetStatus returns http  response status
@@ -168,6 +293,9 @@ func (e ResponseError) Error() string {
 		return s
 	}
 
+	if e.Status >= http.StatusMultipleChoices {
+		return strings.TrimSpace(fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status)))
+	}
 	return "Unknown Error"
 }
 
@@ -177,17 +305,39 @@ type ResponseDecodingError struct {
 	Body    []byte
 	Message string
 	Status  int
+	ResponseMetadata
 }
 
 func (e ResponseDecodingError) Error() string {
 	return e.Message
 }
 
+// HTTPError occurs when Shopify, or a proxy in front of it, responds with an
+// error whose body is not JSON, e.g. an HTML 502 page. Embeds the ResponseError
+// to allow consumers to handle it the same was a normal ResponseError.
+type HTTPError struct {
+	ResponseError
+
+	// Snippet holds the beginning of the response body, see httpErrorSnippetSize
+	Snippet string
+	Header  http.Header
+}
+
+// maximum number of body bytes kept in HTTPError.Snippet
+const httpErrorSnippetSize = 512
+
 // An error specific to a rate-limiting response. Embeds the ResponseError to
 // allow consumers to handle it the same was a normal ResponseError.
 type RateLimitError struct {
 	ResponseError
-	RetryAfter int
+
+	// RetryAfter is the wait shopify asks for before the next request
+	RetryAfter time.Duration
+
+	// RequestCount and BucketSize are the REST call limit reported by the
+	// X-Shopify-Shop-Api-Call-Limit header, zero when it is not set
+	RequestCount int
+	BucketSize   int
 }
 
 // Creates an API request. A relative URL can be provided in urlStr, which will
@@ -203,6 +353,13 @@ func (c *Client) NewRequest(ctx context.Context, method, relPath string, body, o
 	// Make the full url based on the relative path
 	u := c.baseURL.ResolveReference(rel)
 
+	// Reject invalid options before they reach shopify
+	if v, ok := options.(Validator); ok && !isNilPointer(options) {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	// Add custom options
 	if options != nil {
 		optionsQuery, err := query.Values(options)
@@ -238,12 +395,19 @@ func (c *Client) NewRequest(ctx context.Context, method, relPath string, body, o
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("User-Agent", UserAgent)
+	if c.compressedResponses {
+		req.Header.Add("Accept-Encoding", AcceptEncoding)
+	}
 
 	if c.token != "" {
 		req.Header.Add("X-Shopify-Access-Token", c.token)
 	} else if c.app.Password != "" {
 		req.SetBasicAuth(c.app.ApiKey, c.app.Password)
 	}
+	applyRequestOptions(ctx, req)
+	if err := c.setIdempotencyKey(req); err != nil {
+		return nil, err
+	}
 
 	return req, nil
 }
@@ -284,9 +448,11 @@ func NewClient(app App, shopName, token string, opts ...Option) (*Client, error)
 		log:        &LeveledLogger{},
 		app:        app,
 		baseURL:    baseURL,
+		shop:       baseURL.Host,
 		token:      token,
 		apiVersion: defaultApiVersion,
 		pathPrefix: defaultApiPathPrefix,
+		cache:      NewMemoryCacheStore(),
 	}
 
 	c.Product = &ProductServiceOp{client: c}
@@ -298,6 +464,7 @@ func NewClient(app App, shopName, token string, opts ...Option) (*Client, error)
 	c.Fulfillment = &FulfillmentServiceOp{client: c}
 	c.DraftOrder = &DraftOrderServiceOp{client: c}
 	c.AbandonedCheckout = &AbandonedCheckoutServiceOp{client: c}
+	c.Checkout = &CheckoutServiceOp{client: c}
 	c.Shop = &ShopServiceOp{client: c}
 	c.Webhook = &WebhookServiceOp{client: c}
 	c.Variant = &VariantServiceOp{client: c}
@@ -333,14 +500,36 @@ func NewClient(app App, shopName, token string, opts ...Option) (*Client, error)
 	c.AssignedFulfillmentOrder = &AssignedFulfillmentOrderServiceOp{client: c}
 	c.FulfillmentEvent = &FulfillmentEventServiceOp{client: c}
 	c.FulfillmentRequest = &FulfillmentRequestServiceOp{client: c}
+	c.CancellationRequest = &CancellationRequestServiceOp{client: c}
 	c.PaymentsTransactions = &PaymentsTransactionsServiceOp{client: c}
 	c.OrderRisk = &OrderRiskServiceOp{client: c}
 	c.ApiPermissions = &ApiPermissionsServiceOp{client: c}
+	c.Flow = &FlowServiceOp{client: c}
+	c.Refund = &RefundServiceOp{client: c}
+	c.ProductOption = &ProductOptionServiceOp{client: c}
+	c.ShopifyQL = &ShopifyQLServiceOp{client: c}
+	c.ShopifyFunction = &ShopifyFunctionServiceOp{client: c}
+	c.CartTransform = &CartTransformServiceOp{client: c}
+	c.DeliveryCustomization = &CustomizationServiceOp{client: c, kind: deliveryCustomizationKind}
+	c.PaymentCustomization = &CustomizationServiceOp{client: c, kind: paymentCustomizationKind}
+	c.Company = &CompanyServiceOp{client: c}
+	c.CompanyLocation = &CompanyLocationServiceOp{client: c}
+	c.CompanyContact = &CompanyContactServiceOp{client: c}
+	c.Country = &CountryServiceOp{client: c}
+	c.Return = &ReturnServiceOp{client: c}
+	c.GDPR = &GDPRServiceOp{client: c}
+	c.AppMetafield = &AppMetafieldServiceOp{client: c}
+	c.BulkOperation = &BulkOperationServiceOp{client: c}
+	c.Storefront = &StorefrontServiceOp{client: c}
 
 	// apply any options
 	for _, opt := range opts {
 		opt(c)
 	}
+	if c.optionErr != nil {
+		return nil, c.optionErr
+	}
+	c.warnApiVersionSunset(time.Now())
 
 	return c, nil
 }
@@ -358,13 +547,25 @@ func (c *Client) Do(req *http.Request, v interface{}) error {
 }
 
 // doGetHeaders executes a request, decoding the response into `v` and also returns any response headers.
-func (c *Client) doGetHeaders(req *http.Request, v interface{}) (http.Header, error) {
+func (c *Client) doGetHeaders(req *http.Request, v interface{}) (_ http.Header, err error) {
 	var resp *http.Response
-	var err error
 	retries := c.retries
-	c.attempts = 0
 	c.logRequest(req)
 
+	if timeout := requestTimeout(req.Context()); timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+	req, attempts := withRequestAttempts(req)
+
+	req, span := c.startSpan(req)
+	start, metrics := time.Now(), c.startMetrics(req)
+	defer func() {
+		c.endSpan(req, span, resp, err)
+		c.doneMetrics(req, metrics, start, resp, err)
+	}()
+
 	// copy request body so it can be re-used
 	var body []byte
 	if req.Body != nil {
@@ -375,89 +576,157 @@ func (c *Client) doGetHeaders(req *http.Request, v interface{}) (http.Header, er
 		}
 	}
 
+	if err := c.checkGuards(req, body); err != nil {
+		return nil, err
+	}
+	if err := c.checkUpdatedAt(req, body); err != nil {
+		return nil, err
+	}
+	if enqueued, err := c.enqueueWrite(req, body); enqueued {
+		return nil, err
+	}
+	sent, err := c.compressBody(req, body)
+	if err != nil {
+		return nil, err
+	}
+
 	for {
-		c.attempts++
-		req.Body = ioutil.NopCloser(bytes.NewBuffer(body))
-		resp, err = c.Client.Do(req)
-		c.logResponse(resp)
+		*attempts++
+		if err := c.waitRateLimiter(req); err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewBuffer(sent))
+		attemptStart := time.Now()
+		resp, err = c.roundTrip(req)
+		c.logAttempt(req, resp, err, attemptStart)
+		c.dumpAttempt(req, body, resp, err, attemptStart)
 		if err != nil {
 			return nil, err // http client errors, not api responses
 		}
+		c.captureResponseMetadata(req, resp)
+		c.updateRateLimiter(req, resp)
+		c.announceCallLimit(req, resp)
 
 		respErr := CheckResponseError(resp)
 		if respErr == nil {
 			break // no errors, break out of the retry loop
 		}
 
+		if c.onUnauthorized != nil && isInvalidTokenError(respErr) {
+			c.onUnauthorized(req.Context(), c.shop, respErr)
+		}
+
 		// retry scenario, close resp and any continue will retry
 		resp.Body.Close()
 
 		if retries <= 1 {
-			return nil, respErr
+			return nil, withRetries(respErr, *attempts-1)
 		}
 
-		if rateLimitErr, isRetryErr := respErr.(RateLimitError); isRetryErr {
-			// back off and retry
-
-			wait := time.Duration(rateLimitErr.RetryAfter) * time.Second
-			c.log.Debugf("rate limited waiting %s", wait.String())
-			time.Sleep(wait)
-			retries--
-			continue
+		policy := c.currentRetryPolicy()
+		if !policy.retries(req.Method, resp.StatusCode) {
+			return nil, withRetries(respErr, *attempts-1)
 		}
-
-		var doRetry bool
-		switch resp.StatusCode {
-		case http.StatusServiceUnavailable:
-			c.log.Debugf("service unavailable, retrying")
-			doRetry = true
-			retries--
+		wait := policy.backoff(*attempts, resp.Header.Get("Retry-After"))
+		retry, err := policy.beforeRetry(req.Context(), RetryAttempt{
+			Request: req,
+			Retry:   *attempts,
+			Wait:    wait,
+			Elapsed: time.Since(start),
+			Err:     respErr,
+		})
+		if err != nil {
+			return nil, err
 		}
-
-		if doRetry {
-			continue
+		if !retry {
+			c.log.Debugf("%d response, retry budget of %s spent", resp.StatusCode, policy.Budget.String())
+			return nil, withRetries(respErr, *attempts-1)
 		}
-
-		// no retry attempts, just return the err
-		return nil, respErr
+		c.log.Debugf("%d response, retrying in %s", resp.StatusCode, wait.String())
+		if err := sleepContext(req.Context(), wait); err != nil {
+			return nil, err
+		}
+		retries--
 	}
 
 	defer resp.Body.Close()
 
-	if c.apiVersion == defaultApiVersion && resp.Header.Get("X-Shopify-API-Version") != "" {
+	if version := resp.Header.Get("X-Shopify-API-Version"); version != "" {
+		c.stateMu.Lock()
 		// if using stable on first request set the api version
-		c.apiVersion = resp.Header.Get("X-Shopify-API-Version")
-		c.log.Infof("api version not set, now using %s", c.apiVersion)
+		stable := c.apiVersion == defaultApiVersion
+		if stable {
+			c.apiVersion = version
+		}
+		c.stateMu.Unlock()
+		if stable {
+			c.log.Infof("api version not set, now using %s", version)
+		}
 	}
 
 	if v != nil {
-		decoder := json.NewDecoder(resp.Body)
-		err := decoder.Decode(&v)
+		err := c.decodeResponse(resp.Body, v)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	if s := strings.Split(resp.Header.Get("X-Shopify-Shop-Api-Call-Limit"), "/"); len(s) == 2 {
-		c.RateLimits.RequestCount, _ = strconv.Atoi(s[0])
-		c.RateLimits.BucketSize, _ = strconv.Atoi(s[1])
-	}
-
-	c.RateLimits.RetryAfterSeconds, _ = strconv.ParseFloat(resp.Header.Get("Retry-After"), 64)
+	c.updateRateLimits(func(limits *RateLimitInfo) {
+		if used, size, ok := parseCallLimit(resp.Header); ok {
+			limits.RequestCount, limits.BucketSize = used, size
+		}
+		limits.RetryAfterSeconds, _ = strconv.ParseFloat(resp.Header.Get("Retry-After"), 64)
+	})
 
 	return resp.Header, nil
 }
 
+// CurrentRateLimits returns a copy of RateLimits, safe to call while other
+// goroutines send requests with the client
+func (c *Client) CurrentRateLimits() RateLimitInfo {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	return c.RateLimits
+}
+
+// updateRateLimits changes RateLimits with update
+func (c *Client) updateRateLimits(update func(*RateLimitInfo)) {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	update(&c.RateLimits)
+}
+
+// currentApiVersion returns the api version of the client, which the first
+// response sets when the client uses the stable version
+func (c *Client) currentApiVersion() string {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	return c.apiVersion
+}
+
 func (c *Client) logRequest(req *http.Request) {
 	if req == nil {
 		return
 	}
+	if c.slog != nil {
+		c.slogRequest(req)
+		return
+	}
 	if req.URL != nil {
-		c.log.Debugf("%s: %s", req.Method, req.URL.String())
+		c.log.Debugf("%s: %s", req.Method, c.redactedURL(req))
 	}
 	c.logBody(&req.Body, "SENT: %s")
 }
 
+// logAttempt logs the response to an attempt of req started at start
+func (c *Client) logAttempt(req *http.Request, resp *http.Response, err error, start time.Time) {
+	if c.slog != nil {
+		c.slogResponse(req, resp, err, time.Since(start))
+		return
+	}
+	c.logResponse(resp)
+}
+
 func (c *Client) logResponse(res *http.Response) {
 	if res == nil {
 		return
@@ -468,28 +737,53 @@ func (c *Client) logResponse(res *http.Response) {
 	c.logBody(&res.Body, "RESP: %s")
 }
 
+// logBody logs body at debug level, the body is only read when the logger
+// logs debug messages so it can be streamed otherwise, see ListEach
 func (c *Client) logBody(body *io.ReadCloser, format string) {
-	if body == nil {
+	if !c.logsDebug() {
 		return
 	}
+	if logged := c.redactedBody(body); len(logged) > 0 {
+		c.log.Debugf(format, string(logged))
+	}
+}
+
+// logsDebug reports whether the logger of the client may log debug messages,
+// loggers other than the LeveledLogger are assumed to
+func (c *Client) logsDebug() bool {
+	if l, ok := c.log.(*LeveledLogger); ok {
+		return l.Level >= LevelDebug
+	}
+	return true
+}
+
+// redactedBody reads body, which can still be read afterwards, and returns it
+// without its redacted fields
+func (c *Client) redactedBody(body *io.ReadCloser) []byte {
+	if body == nil || *body == nil {
+		return nil
+	}
 	b, err := ioutil.ReadAll(*body)
 	if err != nil && !errors.Is(err, io.EOF) {
-		return
-	}
-	if len(b) > 0 {
-		c.log.Debugf(format, string(b))
+		return nil
 	}
 	*body = ioutil.NopCloser(bytes.NewBuffer(b))
+	if len(b) > 0 && c.redactor != nil {
+		return c.redactor.RedactBody(b)
+	}
+	return b
 }
 
 func wrapSpecificError(r *http.Response, err ResponseError) error {
 	// see https://www.shopify.dev/concepts/about-apis/response-codes
 	if err.Status == http.StatusTooManyRequests {
 		f, _ := strconv.ParseFloat(r.Header.Get("Retry-After"), 64)
-		return RateLimitError{
+		rateLimitErr := RateLimitError{
 			ResponseError: err,
-			RetryAfter:    int(f),
+			RetryAfter:    time.Duration(f * float64(time.Second)),
 		}
+		rateLimitErr.RequestCount, rateLimitErr.BucketSize, _ = parseCallLimit(r.Header)
+		return rateLimitErr
 	}
 
 	// if err.Status == http.StatusSeeOther {
@@ -505,7 +799,58 @@ func wrapSpecificError(r *http.Response, err ResponseError) error {
 	return err
 }
 
+// parseCallLimit returns the used calls and the bucket size reported by the
+// X-Shopify-Shop-Api-Call-Limit header, e.g. "32/40". ok is false when the
+// header is not set, invalid numbers are returned as 0.
+func parseCallLimit(header http.Header) (used, size int, ok bool) {
+	s := strings.Split(header.Get("X-Shopify-Shop-Api-Call-Limit"), "/")
+	if len(s) != 2 {
+		return 0, 0, false
+	}
+	used, _ = strconv.Atoi(s[0])
+	size, _ = strconv.Atoi(s[1])
+	return used, size, true
+}
+
+// looksLikeJSON reports whether the body starts like a JSON object or array
+func looksLikeJSON(body []byte) bool {
+	trimmed := bytes.TrimSpace(body)
+	return len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[')
+}
+
+func newHTTPError(r *http.Response, body []byte) HTTPError {
+	snippet := bytes.TrimSpace(body)
+	if len(snippet) > httpErrorSnippetSize {
+		// back off to the start of a rune so it is not split
+		end := httpErrorSnippetSize
+		for end > 0 && !utf8.RuneStart(snippet[end]) {
+			end--
+		}
+		snippet = snippet[:end]
+	}
+
+	return HTTPError{
+		ResponseError: ResponseError{
+			Status:  r.StatusCode,
+			Message: strings.TrimSpace(fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode))),
+		},
+		Snippet: string(snippet),
+		Header:  r.Header,
+	}
+}
+
+// CheckResponseError returns the error of a non-2xx response, nil otherwise.
+// The error carries the request url and id in its ResponseMetadata.
 func CheckResponseError(r *http.Response) error {
+	return withResponseMetadata(checkResponseError(r), func(m *ResponseMetadata) {
+		if r.Request != nil && r.Request.URL != nil {
+			m.RequestURL = r.Request.URL.String()
+		}
+		m.RequestId = r.Header.Get("X-Request-Id")
+	})
+}
+
+func checkResponseError(r *http.Response) error {
 	if http.StatusOK <= r.StatusCode && r.StatusCode < http.StatusMultipleChoices {
 		return nil
 	}
@@ -521,6 +866,12 @@ func CheckResponseError(r *http.Response) error {
 		return err
 	}
 
+	// a body that isn't JSON at all is usually an html page served by shopify's
+	// edge or a proxy, keep what we can of it instead of failing to decode
+	if len(bodyBytes) > 0 && !looksLikeJSON(bodyBytes) {
+		return newHTTPError(r, bodyBytes)
+	}
+
 	// empty body, this probably means shopify returned an error with no body
 	// we'll handle that error in wrapSpecificError()
 	if len(bodyBytes) > 0 {
@@ -676,7 +1027,17 @@ func (c *Client) Get(ctx context.Context, path string, resource, options interfa
 }
 
 // ListWithPagination performs a GET request for the given path and saves the result in the
-// given resource and returns the pagination.
+// given resource and returns the pagination. It works with any REST endpoint
+// paginated with Link headers, including the ones the package does not wrap:
+//
+//   - path is relative to the api version, e.g. "webhooks.json"
+//   - resource is a pointer to the struct the page is decoded into, usually
+//     a struct with a single slice field named after the endpoint
+//   - options are url encoded into the query string of the first page
+//
+// The pages after the first one are requested with the NextPageOptions of the
+// returned pagination as options, Shopify rejects the filters of the first
+// page once page_info is set. NextPageOptions is nil on the last page.
 func (c *Client) ListWithPagination(ctx context.Context, path string, resource, options interface{}) (*Pagination, error) {
 	headers, err := c.createAndDoGetHeaders(ctx, "GET", path, nil, options, resource)
 	if err != nil {
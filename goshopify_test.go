@@ -14,12 +14,13 @@ import (
 	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/jarcoal/httpmock"
 )
 
 const (
-	testApiVersion = "9999-99"
+	testApiVersion = "9999-01"
 	maxRetries     = 3
 )
 
@@ -275,12 +276,12 @@ func TestDo(t *testing.T) {
 		{
 			"foo/2",
 			httpmock.NewStringResponder(404, `{"error": "does not exist"}`),
-			ResponseError{Status: 404, Message: "does not exist"},
+			ResponseError{Status: 404, Message: "does not exist", ResponseMetadata: ResponseMetadata{RequestURL: "https://fooshop.myshopify.com/foo/2"}},
 		},
 		{
 			"foo/3",
 			httpmock.NewStringResponder(400, `{"errors": {"title": ["wrong"]}}`),
-			ResponseError{Status: 400, Message: "title: wrong", Errors: []string{"title: wrong"}},
+			ResponseError{Status: 400, Message: "title: wrong", Errors: []string{"title: wrong"}, ResponseMetadata: ResponseMetadata{RequestURL: "https://fooshop.myshopify.com/foo/3"}},
 		},
 		{
 			"foo/4",
@@ -300,10 +301,11 @@ func TestDo(t *testing.T) {
 				return resp, nil
 			},
 			RateLimitError{
-				RetryAfter: 2,
+				RetryAfter: 2 * time.Second,
 				ResponseError: ResponseError{
-					Status:  429,
-					Message: "Exceeded 2 calls per second for api client. Reduce request rates to resume uninterrupted service.",
+					Status:           429,
+					Message:          "Exceeded 2 calls per second for api client. Reduce request rates to resume uninterrupted service.",
+					ResponseMetadata: ResponseMetadata{Retries: 2},
 				},
 			},
 		},
@@ -311,17 +313,32 @@ func TestDo(t *testing.T) {
 			"foo/7",
 			httpmock.NewStringResponder(406, ``),
 			ResponseError{
-				Status:  406,
-				Message: "Not Acceptable",
+				Status:           406,
+				Message:          "Not Acceptable",
+				ResponseMetadata: ResponseMetadata{RequestURL: "https://fooshop.myshopify.com/foo/7"},
 			},
 		},
 		{
 			"foo/8",
 			httpmock.NewStringResponder(500, "<html></html>"),
+			HTTPError{
+				ResponseError: ResponseError{
+					Status:           500,
+					Message:          "500 Internal Server Error",
+					ResponseMetadata: ResponseMetadata{RequestURL: "https://fooshop.myshopify.com/foo/8"},
+				},
+				Snippet: "<html></html>",
+				Header:  http.Header{},
+			},
+		},
+		{
+			"foo/9",
+			httpmock.NewStringResponder(500, "{<html></html>"),
 			ResponseDecodingError{
-				Body:    []byte("<html></html>"),
-				Message: "invalid character '<' looking for beginning of value",
-				Status:  500,
+				Body:             []byte("{<html></html>"),
+				Message:          "invalid character '<' looking for beginning of object key string",
+				Status:           500,
+				ResponseMetadata: ResponseMetadata{RequestURL: "https://fooshop.myshopify.com/foo/9"},
 			},
 		},
 	}
@@ -415,10 +432,11 @@ func TestRetry(t *testing.T) {
 			relPath: "foo/3",
 			retries: maxRetries,
 			expected: RateLimitError{
-				RetryAfter: 2,
+				RetryAfter: 2 * time.Second,
 				ResponseError: ResponseError{
-					Status:  429,
-					Message: "Exceeded 2 calls per second for api client. Reduce request rates to resume uninterrupted service.",
+					Status:           429,
+					Message:          "Exceeded 2 calls per second for api client. Reduce request rates to resume uninterrupted service.",
+					ResponseMetadata: ResponseMetadata{Retries: 2},
 				},
 			},
 			responder: func(req *http.Request) (*http.Response, error) {
@@ -444,7 +462,8 @@ func TestRetry(t *testing.T) {
 			relPath: "foo/5",
 			retries: maxRetries,
 			expected: ResponseError{
-				Status: http.StatusServiceUnavailable,
+				Status:           http.StatusServiceUnavailable,
+				ResponseMetadata: ResponseMetadata{Retries: 2},
 			},
 			responder: func(req *http.Request) (*http.Response, error) {
 				return httpmock.NewStringResponse(http.StatusServiceUnavailable, ""), nil
@@ -456,15 +475,16 @@ func TestRetry(t *testing.T) {
 		retries = c.retries
 		httpmock.RegisterResponder("GET", fmt.Sprintf(urlFormat, c.relPath), c.responder)
 		body := new(MyStruct)
-		req, err := client.NewRequest(context.Background(), "GET", c.relPath, nil, nil)
+		var metadata ResponseMetadata
+		req, err := client.NewRequest(CaptureResponseMetadata(context.Background(), &metadata), "GET", c.relPath, nil, nil)
 		if err != nil {
 			t.Error("error creating request: ", err)
 		}
 
 		err = client.Do(req, body)
 
-		if client.attempts != c.retries {
-			t.Errorf("Do(): attempts do not match retries %#v, actual %#v", client.attempts, c.retries)
+		if metadata.Retries+1 != c.retries {
+			t.Errorf("Do(): attempts do not match retries %#v, actual %#v", metadata.Retries+1, c.retries)
 		}
 
 		if err != nil {
@@ -498,10 +518,11 @@ func TestRetryPost(t *testing.T) {
 		return resp, nil
 	}
 	expected := RateLimitError{
-		RetryAfter: 2,
+		RetryAfter: 2 * time.Second,
 		ResponseError: ResponseError{
-			Status:  429,
-			Message: "Exceeded 2 calls per second for api client. Reduce request rates to resume uninterrupted service.",
+			Status:           429,
+			Message:          "Exceeded 2 calls per second for api client. Reduce request rates to resume uninterrupted service.",
+			ResponseMetadata: ResponseMetadata{Retries: 1},
 		},
 	}
 
@@ -822,6 +843,64 @@ func TestCheckResponseError(t *testing.T) {
 	}
 }
 
+func TestCheckResponseErrorNonJSONBody(t *testing.T) {
+	longBody := "<html><body>" + strings.Repeat("a", 1000) + "</body></html>"
+	// the runes of the body start at odd offsets, the snippet cannot end at 512
+	accentedBody := "x" + strings.Repeat("é", 400)
+
+	cases := []struct {
+		resp            *http.Response
+		expectedMessage string
+		expectedSnippet string
+	}{
+		{
+			httpmock.NewStringResponse(502, "<html><body>Bad Gateway</body></html>"),
+			"502 Bad Gateway",
+			"<html><body>Bad Gateway</body></html>",
+		},
+		{
+			httpmock.NewStringResponse(406, "Not Acceptable\n"),
+			"406 Not Acceptable",
+			"Not Acceptable",
+		},
+		{
+			httpmock.NewStringResponse(503, longBody),
+			"503 Service Unavailable",
+			longBody[:httpErrorSnippetSize],
+		},
+		{
+			httpmock.NewStringResponse(503, accentedBody),
+			"503 Service Unavailable",
+			accentedBody[:httpErrorSnippetSize-1],
+		},
+	}
+
+	for _, c := range cases {
+		c.resp.Header.Set("Content-Type", "text/html")
+		err := CheckResponseError(c.resp)
+
+		httpErr, ok := err.(HTTPError)
+		if !ok {
+			t.Fatalf("CheckResponseError(): expected HTTPError, actual %#v", err)
+		}
+		if httpErr.Error() != c.expectedMessage {
+			t.Errorf("HTTPError.Error(): expected %s, actual %s", c.expectedMessage, httpErr.Error())
+		}
+		if httpErr.Snippet != c.expectedSnippet {
+			t.Errorf("HTTPError.Snippet: expected %s, actual %s", c.expectedSnippet, httpErr.Snippet)
+		}
+		if !utf8.ValidString(httpErr.Snippet) {
+			t.Errorf("HTTPError.Snippet: expected valid utf-8, actual %q", httpErr.Snippet)
+		}
+		if httpErr.Status != c.resp.StatusCode {
+			t.Errorf("HTTPError.Status: expected %d, actual %d", c.resp.StatusCode, httpErr.Status)
+		}
+		if httpErr.Header.Get("Content-Type") != "text/html" {
+			t.Errorf("HTTPError.Header: expected Content-Type text/html, actual %v", httpErr.Header)
+		}
+	}
+}
+
 func TestCount(t *testing.T) {
 	setup()
 	defer teardown()
@@ -960,6 +1039,51 @@ func TestDoRateLimit(t *testing.T) {
 	}
 }
 
+func TestListWithPaginationNextPage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	type webhooksResource struct {
+		Webhooks []struct {
+			Id uint64 `json:"id"`
+		} `json:"webhooks"`
+	}
+
+	httpmock.RegisterResponderWithQuery("GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/webhooks.json", client.pathPrefix),
+		"limit=1&topic=orders%2Fcreate",
+		httpmock.NewStringResponder(200, `{"webhooks":[{"id":1}]}`).
+			HeaderSet(http.Header{"Link": {fmt.Sprintf(`<https://fooshop.myshopify.com/%s/webhooks.json?page_info=abc&limit=1>; rel="next"`, client.pathPrefix)}}))
+	httpmock.RegisterResponderWithQuery("GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/webhooks.json", client.pathPrefix),
+		"limit=1&page_info=abc",
+		httpmock.NewStringResponder(200, `{"webhooks":[{"id":2}]}`))
+
+	var ids []uint64
+	var options interface{} = struct {
+		Limit int    `url:"limit"`
+		Topic string `url:"topic"`
+	}{1, "orders/create"}
+	for {
+		resource := new(webhooksResource)
+		pagination, err := client.ListWithPagination(context.Background(), "webhooks.json", resource, options)
+		if err != nil {
+			t.Fatalf("Client.ListWithPagination returned error: %v", err)
+		}
+		for _, webhook := range resource.Webhooks {
+			ids = append(ids, webhook.Id)
+		}
+		if pagination.NextPageOptions == nil {
+			break
+		}
+		options = pagination.NextPageOptions
+	}
+
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("Client.ListWithPagination paged webhooks %v", ids)
+	}
+}
+
 func TestListWithPagination(t *testing.T) {
 	setup()
 	defer teardown()
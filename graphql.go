@@ -2,7 +2,13 @@ package goshopify
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"math"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -11,6 +17,7 @@ import (
 // See https://shopify.dev/docs/admin-api/graphql/reference
 type GraphQLService interface {
 	Query(context.Context, string, interface{}, interface{}) error
+	ForEachPage(context.Context, string, map[string]interface{}, GraphQLPageFunc) error
 }
 
 // GraphQLServiceOp handles communication with the graphql endpoint of
@@ -54,15 +61,108 @@ type graphQLErrorExtensions struct {
 	Documentation string
 }
 
+// Codes of graphql errors, see graphQLErrorStatus for the http status they
+// are reported with
 const (
-	graphQLErrorCodeThrottled = "THROTTLED"
+	graphQLErrorCodeThrottled           = "THROTTLED"
+	graphQLErrorCodeAccessDenied        = "ACCESS_DENIED"
+	graphQLErrorCodeInternalServerError = "INTERNAL_SERVER_ERROR"
 )
 
+// graphQLErrorStatus returns the http status a REST endpoint would respond
+// with for a graphql error, so that both transports match the same sentinel
+// errors. Errors without a known code keep the 200 status of the response.
+func graphQLErrorStatus(e graphQLError) int {
+	if e.Extensions == nil {
+		return http.StatusOK
+	}
+	switch e.Extensions.Code {
+	case graphQLErrorCodeThrottled:
+		return http.StatusTooManyRequests
+	case graphQLErrorCodeAccessDenied:
+		return http.StatusForbidden
+	case graphQLErrorCodeInternalServerError:
+		return http.StatusInternalServerError
+	}
+	return http.StatusOK
+}
+
 type graphQLErrorLocation struct {
 	Line   int `json:"line"`
 	Column int `json:"column"`
 }
 
+// GraphQLPageInfo is the pageInfo of a graphql connection
+type GraphQLPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// GraphQLPageFunc decodes the "data" of one page of a paginated query and
+// returns the pageInfo of the connection being paginated
+type GraphQLPageFunc func(data json.RawMessage) (GraphQLPageInfo, error)
+
+// gidPrefix is the prefix of shopify graphql global ids
+const gidPrefix = "gid://shopify/"
+
+// NewGid returns the graphql global id of a REST resource id, e.g.
+// NewGid("Product", 1) returns "gid://shopify/Product/1"
+func NewGid(resourceType string, id uint64) string {
+	return fmt.Sprintf("%s%s/%d", gidPrefix, resourceType, id)
+}
+
+// ParseGid returns the resource type and numeric id of a graphql global id
+func ParseGid(gid string) (string, uint64, error) {
+	rest := strings.TrimPrefix(gid, gidPrefix)
+	if rest == gid {
+		return "", 0, fmt.Errorf("invalid gid %q", gid)
+	}
+
+	// drop any query parameters, e.g. gid://shopify/LineItem/1?foo=bar
+	if i := strings.Index(rest, "?"); i >= 0 {
+		rest = rest[:i]
+	}
+
+	parts := strings.Split(rest, "/")
+	if len(parts) != 2 {
+		return "", 0, fmt.Errorf("invalid gid %q", gid)
+	}
+
+	id, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid gid %q: %w", gid, err)
+	}
+	return parts[0], id, nil
+}
+
+// GraphQLUserError represents an entry of the userErrors list returned by
+// Shopify graphql mutations
+type GraphQLUserError struct {
+	Field   []string `json:"field"`
+	Message string   `json:"message"`
+	Code    string   `json:"code,omitempty"`
+}
+
+// userErrorsToResponseError converts mutation user errors into a ResponseError
+// so they can be handled the same way as REST validation errors
+func userErrorsToResponseError(userErrors []GraphQLUserError) error {
+	if len(userErrors) == 0 {
+		return nil
+	}
+
+	responseError := ResponseError{Status: 200}
+	for _, userError := range userErrors {
+		message := userError.Message
+		if len(userError.Field) > 0 {
+			message = fmt.Sprintf("%s: %s", strings.Join(userError.Field, "."), userError.Message)
+		}
+		responseError.Errors = append(responseError.Errors, message)
+	}
+	responseError.Message = strings.Join(responseError.Errors, ", ")
+
+	return responseError
+}
+
 // Query creates a graphql query against the Shopify API
 // the "data" portion of the response is unmarshalled into resp
 func (s *GraphQLServiceOp) Query(ctx context.Context, q string, vars, resp interface{}) error {
@@ -74,6 +174,9 @@ func (s *GraphQLServiceOp) Query(ctx context.Context, q string, vars, resp inter
 		Variables: vars,
 	}
 
+	ctx, metadata := responseMetadataCapture(ctx)
+	policy := s.client.currentRetryPolicy()
+	start := time.Now()
 	attempts := 0
 
 	for {
@@ -81,7 +184,11 @@ func (s *GraphQLServiceOp) Query(ctx context.Context, q string, vars, resp inter
 			Data: resp,
 		}
 
-		err := s.client.Post(ctx, "graphql.json", data, &gr)
+		req, err := s.client.NewRequest(ctx, "POST", path.Join(s.client.pathPrefix, "graphql.json"), data, nil)
+		if err != nil {
+			return err
+		}
+		err = s.client.Do(req, &gr)
 
 		// internal attempts count towards outer total
 		attempts += 1
@@ -90,40 +197,67 @@ func (s *GraphQLServiceOp) Query(ctx context.Context, q string, vars, resp inter
 
 		if gr.Extensions != nil {
 			retryAfterSecs = gr.Extensions.Cost.RetryAfterSeconds()
-			s.client.RateLimits.GraphQLCost = &gr.Extensions.Cost
-			s.client.RateLimits.RetryAfterSeconds = retryAfterSecs
+			s.client.updateRateLimits(func(limits *RateLimitInfo) {
+				limits.GraphQLCost = &gr.Extensions.Cost
+				limits.RetryAfterSeconds = retryAfterSecs
+			})
+			s.client.announceQueryCost(gr.Extensions.Cost)
 		}
 
 		if len(gr.Errors) > 0 {
-			responseError := ResponseError{Status: 200}
-			var doRetry bool
+			responseError := ResponseError{Status: http.StatusOK}
+			var throttled *RateLimitError
 
 			for _, err := range gr.Errors {
-				if err.Extensions != nil && err.Extensions.Code == graphQLErrorCodeThrottled {
-					if attempts >= s.client.retries {
-						return RateLimitError{
-							RetryAfter: int(math.Ceil(retryAfterSecs)),
-							ResponseError: ResponseError{
-								Status:  200,
-								Message: err.Message,
-							},
-						}
+				status := graphQLErrorStatus(err)
+				if status == http.StatusTooManyRequests && throttled == nil {
+					// keeps the 200 status, RateLimitError always
+					// matches ErrRateLimited
+					throttled = &RateLimitError{
+						RetryAfter: time.Duration(math.Ceil(retryAfterSecs)) * time.Second,
+						ResponseError: ResponseError{
+							Status:           http.StatusOK,
+							Message:          err.Message,
+							ResponseMetadata: graphQLResponseMetadata(metadata, attempts),
+						},
 					}
-
-					// only need to retry graphql throttled retries
-					doRetry = true
 				}
 
+				// the first classified error sets the status
+				if responseError.Status == http.StatusOK {
+					responseError.Status = status
+				}
 				responseError.Errors = append(responseError.Errors, err.Message)
 			}
 
-			if doRetry {
-				wait := time.Duration(math.Ceil(retryAfterSecs)) * time.Second
-				s.client.log.Debugf("rate limited waiting %s", wait.String())
-				time.Sleep(wait)
+			// only need to retry graphql throttled retries
+			if throttled != nil {
+				if attempts >= s.client.retries {
+					return *throttled
+				}
+				wait := policy.backoff(attempts, strconv.FormatFloat(throttled.RetryAfter.Seconds(), 'f', -1, 64))
+				retry, err := policy.beforeRetry(ctx, RetryAttempt{
+					Request: req,
+					Retry:   attempts,
+					Wait:    wait,
+					Elapsed: time.Since(start),
+					Err:     *throttled,
+				})
+				if err != nil {
+					return err
+				}
+				if !retry {
+					s.client.log.Debugf("throttled, retry budget of %s spent", policy.Budget.String())
+					return *throttled
+				}
+				s.client.log.Debugf("throttled, retrying in %s", wait.String())
+				if err := sleepContext(ctx, wait); err != nil {
+					return err
+				}
 				continue
 			}
 
+			responseError.ResponseMetadata = graphQLResponseMetadata(metadata, attempts)
 			err = responseError
 		}
 
@@ -131,6 +265,47 @@ func (s *GraphQLServiceOp) Query(ctx context.Context, q string, vars, resp inter
 	}
 }
 
+// graphQLResponseMetadata is the metadata of the GraphQL errors of the last
+// response, counting the retries of throttled queries
+func graphQLResponseMetadata(metadata *ResponseMetadata, attempts int) ResponseMetadata {
+	return ResponseMetadata{Retries: attempts - 1, RequestURL: metadata.RequestURL, RequestId: metadata.RequestId}
+}
+
+// ForEachPage runs a paginated query, calling decode with every page until the
+// connection has no next page. The query takes the cursor of the next page
+// in an $after variable, e.g.
+//
+//	query products($after: String) {
+//	  products(first: 250, after: $after) {
+//	    nodes { id title }
+//	    pageInfo { hasNextPage endCursor }
+//	  }
+//	}
+func (s *GraphQLServiceOp) ForEachPage(ctx context.Context, q string, vars map[string]interface{}, decode GraphQLPageFunc) error {
+	pageVars := make(map[string]interface{}, len(vars)+1)
+	for k, v := range vars {
+		pageVars[k] = v
+	}
+
+	for {
+		var data json.RawMessage
+		if err := s.Query(ctx, q, pageVars, &data); err != nil {
+			return err
+		}
+		pageInfo, err := decode(data)
+		if err != nil {
+			return err
+		}
+		if !pageInfo.HasNextPage {
+			return nil
+		}
+		if pageInfo.EndCursor == "" {
+			return fmt.Errorf("graphql page has a next page but no end cursor")
+		}
+		pageVars["after"] = pageInfo.EndCursor
+	}
+}
+
 // RetryAfterSeconds returns the estimated retry after seconds based on
 // the requested query cost and throttle status
 func (c GraphQLCost) RetryAfterSeconds() float64 {
@@ -0,0 +1,508 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const productFallbackQuery = `query product($id: ID!) {
+  product(id: $id) {
+    id
+    title
+    descriptionHtml
+    vendor
+    productType
+    handle
+    status
+    tags
+    templateSuffix
+    createdAt
+    updatedAt
+    publishedAt
+    options {
+      id
+      name
+      position
+      values
+    }
+    variants(first: 250) {
+      nodes {` + productVariantFields + `
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+const productVariantFields = `
+        id
+        title
+        sku
+        position
+        price
+        compareAtPrice
+        barcode
+        inventoryQuantity
+        inventoryPolicy
+        taxable
+        createdAt
+        updatedAt
+        inventoryItem {
+          id
+        }
+        selectedOptions {
+          value
+        }`
+
+const orderFallbackQuery = `query order($id: ID!) {
+  order(id: $id) {
+    id
+    name
+    email
+    phone
+    note
+    tags
+    test
+    currencyCode
+    presentmentCurrencyCode
+    displayFinancialStatus
+    displayFulfillmentStatus
+    createdAt
+    updatedAt
+    processedAt
+    closedAt
+    cancelledAt
+    customer {
+      id
+    }
+    totalPriceSet {` + moneyBagFields + `
+    }
+    subtotalPriceSet {` + moneyBagFields + `
+    }
+    totalTaxSet {` + moneyBagFields + `
+    }
+    totalDiscountsSet {` + moneyBagFields + `
+    }
+    lineItems(first: 250) {
+      nodes {
+        id
+        title
+        name
+        sku
+        vendor
+        variantTitle
+        quantity
+        variant {
+          id
+        }
+        product {
+          id
+        }
+        originalUnitPriceSet {` + moneyBagFields + `
+        }
+      }
+    }
+  }
+}`
+
+const moneyBagFields = `
+      shopMoney {
+        amount
+        currencyCode
+      }
+      presentmentMoney {
+        amount
+        currencyCode
+      }`
+
+const customerFallbackQuery = `query customer($id: ID!) {
+  customer(id: $id) {
+    id
+    email
+    phone
+    firstName
+    lastName
+    state
+    note
+    tags
+    verifiedEmail
+    taxExempt
+    numberOfOrders
+    amountSpent {
+      amount
+    }
+    createdAt
+    updatedAt
+  }
+}`
+
+// needsGraphQLFallback reports whether a REST read should be retried with
+// graphql, i.e. WithGraphQLFallbackOnNotFound is set and REST answered that
+// the resource or endpoint does not exist
+func (c *Client) needsGraphQLFallback(err error) bool {
+	if !c.graphQLFallbackOnNotFound || err == nil {
+		return false
+	}
+	var statusError interface{ GetStatus() int }
+	if !errors.As(err, &statusError) {
+		return false
+	}
+	status := statusError.GetStatus()
+	return status == http.StatusNotFound || status == http.StatusGone
+}
+
+// gidToId returns the numeric id of an optional graphql id, 0 when it is
+// empty or invalid
+func gidToId(gid string) uint64 {
+	_, id, _ := ParseGid(gid)
+	return id
+}
+
+// gidRef is a graphql object of which only the id is queried
+type gidRef struct {
+	Id string `json:"id"`
+}
+
+func (r *gidRef) id() uint64 {
+	if r == nil {
+		return 0
+	}
+	return gidToId(r.Id)
+}
+
+type graphQLMoney struct {
+	Amount       *decimal.Decimal `json:"amount"`
+	CurrencyCode string           `json:"currencyCode"`
+}
+
+type graphQLMoneyBag struct {
+	ShopMoney        graphQLMoney `json:"shopMoney"`
+	PresentmentMoney graphQLMoney `json:"presentmentMoney"`
+}
+
+func (m *graphQLMoneyBag) amountSet() *AmountSet {
+	if m == nil {
+		return nil
+	}
+	return &AmountSet{
+		ShopMoney:        AmountSetEntry{Amount: m.ShopMoney.Amount, CurrencyCode: m.ShopMoney.CurrencyCode},
+		PresentmentMoney: AmountSetEntry{Amount: m.PresentmentMoney.Amount, CurrencyCode: m.PresentmentMoney.CurrencyCode},
+	}
+}
+
+func (m *graphQLMoneyBag) shopAmount() *decimal.Decimal {
+	if m == nil {
+		return nil
+	}
+	return m.ShopMoney.Amount
+}
+
+type productNode struct {
+	Id              string     `json:"id"`
+	Title           string     `json:"title"`
+	DescriptionHtml string     `json:"descriptionHtml"`
+	Vendor          string     `json:"vendor"`
+	ProductType     string     `json:"productType"`
+	Handle          string     `json:"handle"`
+	Status          string     `json:"status"`
+	Tags            []string   `json:"tags"`
+	TemplateSuffix  string     `json:"templateSuffix"`
+	CreatedAt       *time.Time `json:"createdAt"`
+	UpdatedAt       *time.Time `json:"updatedAt"`
+	PublishedAt     *time.Time `json:"publishedAt"`
+	Options         []struct {
+		Id       string   `json:"id"`
+		Name     string   `json:"name"`
+		Position int      `json:"position"`
+		Values   []string `json:"values"`
+	} `json:"options"`
+	Variants struct {
+		Nodes    []variantNode   `json:"nodes"`
+		PageInfo GraphQLPageInfo `json:"pageInfo"`
+	} `json:"variants"`
+}
+
+func (n *productNode) product() *Product {
+	if n == nil {
+		return nil
+	}
+	id := gidToId(n.Id)
+	product := &Product{
+		Id:                id,
+		Title:             n.Title,
+		BodyHTML:          n.DescriptionHtml,
+		Vendor:            n.Vendor,
+		ProductType:       n.ProductType,
+		Handle:            n.Handle,
+		Status:            ProductStatus(strings.ToLower(n.Status)),
+		Tags:              strings.Join(n.Tags, ", "),
+		TemplateSuffix:    n.TemplateSuffix,
+		CreatedAt:         n.CreatedAt,
+		UpdatedAt:         n.UpdatedAt,
+		PublishedAt:       n.PublishedAt,
+		AdminGraphqlApiId: n.Id,
+	}
+	for _, option := range n.Options {
+		product.Options = append(product.Options, ProductOption{
+			Id:        gidToId(option.Id),
+			ProductId: id,
+			Name:      option.Name,
+			Position:  option.Position,
+			Values:    option.Values,
+		})
+	}
+	for i := range n.Variants.Nodes {
+		product.Variants = append(product.Variants, n.Variants.Nodes[i].variant(id))
+	}
+	return product
+}
+
+type variantNode struct {
+	Id                string           `json:"id"`
+	Title             string           `json:"title"`
+	Sku               string           `json:"sku"`
+	Position          int              `json:"position"`
+	Price             *decimal.Decimal `json:"price"`
+	CompareAtPrice    *decimal.Decimal `json:"compareAtPrice"`
+	Barcode           string           `json:"barcode"`
+	InventoryQuantity int              `json:"inventoryQuantity"`
+	InventoryPolicy   string           `json:"inventoryPolicy"`
+	Taxable           bool             `json:"taxable"`
+	CreatedAt         *time.Time       `json:"createdAt"`
+	UpdatedAt         *time.Time       `json:"updatedAt"`
+	InventoryItem     *gidRef          `json:"inventoryItem"`
+	SelectedOptions   []struct {
+		Value string `json:"value"`
+	} `json:"selectedOptions"`
+}
+
+func (n *variantNode) variant(productId uint64) Variant {
+	variant := Variant{
+		Id:                gidToId(n.Id),
+		ProductId:         productId,
+		Title:             n.Title,
+		Sku:               n.Sku,
+		Position:          n.Position,
+		Price:             n.Price,
+		CompareAtPrice:    n.CompareAtPrice,
+		Barcode:           n.Barcode,
+		InventoryQuantity: n.InventoryQuantity,
+		InventoryPolicy:   variantInventoryPolicy(strings.ToLower(n.InventoryPolicy)),
+		Taxable:           n.Taxable,
+		CreatedAt:         n.CreatedAt,
+		UpdatedAt:         n.UpdatedAt,
+		InventoryItemId:   n.InventoryItem.id(),
+		AdminGraphqlApiId: n.Id,
+	}
+	options := []*string{&variant.Option1, &variant.Option2, &variant.Option3}
+	for i, option := range n.SelectedOptions {
+		if i < len(options) {
+			*options[i] = option.Value
+		}
+	}
+	return variant
+}
+
+type orderNode struct {
+	Id                       string           `json:"id"`
+	Name                     string           `json:"name"`
+	Email                    string           `json:"email"`
+	Phone                    string           `json:"phone"`
+	Note                     string           `json:"note"`
+	Tags                     []string         `json:"tags"`
+	Test                     bool             `json:"test"`
+	CurrencyCode             string           `json:"currencyCode"`
+	PresentmentCurrencyCode  string           `json:"presentmentCurrencyCode"`
+	DisplayFinancialStatus   string           `json:"displayFinancialStatus"`
+	DisplayFulfillmentStatus string           `json:"displayFulfillmentStatus"`
+	CreatedAt                *time.Time       `json:"createdAt"`
+	UpdatedAt                *time.Time       `json:"updatedAt"`
+	ProcessedAt              *time.Time       `json:"processedAt"`
+	ClosedAt                 *time.Time       `json:"closedAt"`
+	CancelledAt              *time.Time       `json:"cancelledAt"`
+	Customer                 *gidRef          `json:"customer"`
+	TotalPriceSet            *graphQLMoneyBag `json:"totalPriceSet"`
+	SubtotalPriceSet         *graphQLMoneyBag `json:"subtotalPriceSet"`
+	TotalTaxSet              *graphQLMoneyBag `json:"totalTaxSet"`
+	TotalDiscountsSet        *graphQLMoneyBag `json:"totalDiscountsSet"`
+	LineItems                struct {
+		Nodes []struct {
+			Id                   string           `json:"id"`
+			Title                string           `json:"title"`
+			Name                 string           `json:"name"`
+			Sku                  string           `json:"sku"`
+			Vendor               string           `json:"vendor"`
+			VariantTitle         string           `json:"variantTitle"`
+			Quantity             int              `json:"quantity"`
+			Variant              *gidRef          `json:"variant"`
+			Product              *gidRef          `json:"product"`
+			OriginalUnitPriceSet *graphQLMoneyBag `json:"originalUnitPriceSet"`
+		} `json:"nodes"`
+	} `json:"lineItems"`
+}
+
+// orderFulfillmentStatusFromGraphQL maps displayFulfillmentStatus to the
+// REST fulfillment_status, which is empty for unfulfilled orders
+func orderFulfillmentStatusFromGraphQL(status string) orderFulfillmentStatus {
+	switch status {
+	case "FULFILLED":
+		return OrderFulfillmentStatusFulfilled
+	case "PARTIALLY_FULFILLED":
+		return OrderFulfillmentStatusPartial
+	}
+	return ""
+}
+
+func (n *orderNode) order() *Order {
+	if n == nil {
+		return nil
+	}
+	order := &Order{
+		Id:                  gidToId(n.Id),
+		Name:                n.Name,
+		Email:               n.Email,
+		Phone:               n.Phone,
+		Note:                n.Note,
+		Tags:                strings.Join(n.Tags, ", "),
+		Test:                n.Test,
+		Currency:            n.CurrencyCode,
+		PresentmentCurrency: n.PresentmentCurrencyCode,
+		FinancialStatus:     orderFinancialStatus(strings.ToLower(n.DisplayFinancialStatus)),
+		FulfillmentStatus:   orderFulfillmentStatusFromGraphQL(n.DisplayFulfillmentStatus),
+		CreatedAt:           n.CreatedAt,
+		UpdatedAt:           n.UpdatedAt,
+		ProcessedAt:         n.ProcessedAt,
+		ClosedAt:            n.ClosedAt,
+		CancelledAt:         n.CancelledAt,
+		TotalPrice:          n.TotalPriceSet.shopAmount(),
+		TotalPriceSet:       n.TotalPriceSet.amountSet(),
+		SubtotalPrice:       n.SubtotalPriceSet.shopAmount(),
+		TotalTax:            n.TotalTaxSet.shopAmount(),
+		TotalTaxSet:         n.TotalTaxSet.amountSet(),
+		TotalDiscounts:      n.TotalDiscountsSet.shopAmount(),
+		TotalDiscountSet:    n.TotalDiscountsSet.amountSet(),
+	}
+	if n.Customer != nil {
+		order.Customer = &Customer{Id: n.Customer.id()}
+	}
+	for _, lineItem := range n.LineItems.Nodes {
+		order.LineItems = append(order.LineItems, LineItem{
+			Id:           gidToId(lineItem.Id),
+			ProductId:    lineItem.Product.id(),
+			VariantId:    lineItem.Variant.id(),
+			Quantity:     lineItem.Quantity,
+			Price:        lineItem.OriginalUnitPriceSet.shopAmount(),
+			Title:        lineItem.Title,
+			VariantTitle: lineItem.VariantTitle,
+			Name:         lineItem.Name,
+			SKU:          lineItem.Sku,
+			Vendor:       lineItem.Vendor,
+		})
+	}
+	return order
+}
+
+type customerNode struct {
+	Id             string     `json:"id"`
+	Email          string     `json:"email"`
+	Phone          string     `json:"phone"`
+	FirstName      string     `json:"firstName"`
+	LastName       string     `json:"lastName"`
+	State          string     `json:"state"`
+	Note           string     `json:"note"`
+	Tags           []string   `json:"tags"`
+	VerifiedEmail  bool       `json:"verifiedEmail"`
+	TaxExempt      bool       `json:"taxExempt"`
+	NumberOfOrders int        `json:"numberOfOrders,string"`
+	CreatedAt      *time.Time `json:"createdAt"`
+	UpdatedAt      *time.Time `json:"updatedAt"`
+	AmountSpent    *struct {
+		Amount *decimal.Decimal `json:"amount"`
+	} `json:"amountSpent"`
+}
+
+func (n *customerNode) customer() *Customer {
+	if n == nil {
+		return nil
+	}
+	customer := &Customer{
+		Id:            gidToId(n.Id),
+		Email:         n.Email,
+		Phone:         n.Phone,
+		FirstName:     n.FirstName,
+		LastName:      n.LastName,
+		State:         strings.ToLower(n.State),
+		Note:          n.Note,
+		Tags:          strings.Join(n.Tags, ", "),
+		VerifiedEmail: n.VerifiedEmail,
+		TaxExempt:     n.TaxExempt,
+		OrdersCount:   n.NumberOfOrders,
+		CreatedAt:     n.CreatedAt,
+		UpdatedAt:     n.UpdatedAt,
+	}
+	if n.AmountSpent != nil {
+		customer.TotalSpent = n.AmountSpent.Amount
+	}
+	return customer
+}
+
+// getFromGraphQL fetches a product with graphql, restErr is returned when
+// the product does not exist either
+func (s *ProductServiceOp) getFromGraphQL(ctx context.Context, productId uint64, restErr error) (*Product, error) {
+	resp := struct {
+		Product *productNode `json:"product"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, productFallbackQuery, map[string]interface{}{"id": NewGid("Product", productId)}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Product == nil {
+		return nil, restErr
+	}
+	product := resp.Product.product()
+	if resp.Product.Variants.PageInfo.HasNextPage {
+		if product.Variants, err = s.listVariantsFromGraphQL(ctx, productId); err != nil {
+			return nil, err
+		}
+	}
+	return product, nil
+}
+
+// getFromGraphQL fetches an order with graphql, restErr is returned when the
+// order does not exist either
+func (s *OrderServiceOp) getFromGraphQL(ctx context.Context, orderId uint64, restErr error) (*Order, error) {
+	resp := struct {
+		Order *orderNode `json:"order"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, orderFallbackQuery, map[string]interface{}{"id": NewGid("Order", orderId)}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Order == nil {
+		return nil, restErr
+	}
+	return resp.Order.order(), nil
+}
+
+// getFromGraphQL fetches a customer with graphql, restErr is returned when
+// the customer does not exist either
+func (s *CustomerServiceOp) getFromGraphQL(ctx context.Context, customerId uint64, restErr error) (*Customer, error) {
+	resp := struct {
+		Customer *customerNode `json:"customer"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, customerFallbackQuery, map[string]interface{}{"id": NewGid("Customer", customerId)}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Customer == nil {
+		return nil, restErr
+	}
+	return resp.Customer.customer(), nil
+}
@@ -0,0 +1,124 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func TestProductGetGraphQLFallback(t *testing.T) {
+	setup()
+	defer teardown()
+	WithGraphQLFallbackOnNotFound()(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(404, `{"errors":"Not Found"}`))
+	captured := graphQLTestRequest{}
+	registerGraphQLResponder(`{"data":{"product":{"id":"gid://shopify/Product/1","title":"Shirt","status":"ACTIVE","tags":["a","b"],
+		"options":[{"id":"gid://shopify/ProductOption/3","name":"Size","position":1,"values":["S","M"]}],
+		"variants":{"nodes":[{"id":"gid://shopify/ProductVariant/2","title":"S","price":"10.00","inventoryPolicy":"DENY","inventoryItem":{"id":"gid://shopify/InventoryItem/4"},"selectedOptions":[{"value":"S"}]}],"pageInfo":{"hasNextPage":false}}}}}`, &captured)
+
+	product, err := client.Product.Get(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("Product.Get returned error: %v", err)
+	}
+	if captured.Variables["id"] != "gid://shopify/Product/1" {
+		t.Errorf("Product.Get queried graphql with %v", captured.Variables)
+	}
+	if product.Id != 1 || product.Title != "Shirt" || product.Status != ProductStatusActive || product.Tags != "a, b" {
+		t.Errorf("Product.Get returned %+v", product)
+	}
+	if len(product.Options) != 1 || product.Options[0].Id != 3 || product.Options[0].ProductId != 1 {
+		t.Errorf("Product.Get returned options %+v", product.Options)
+	}
+	if len(product.Variants) != 1 {
+		t.Fatalf("Product.Get returned variants %+v", product.Variants)
+	}
+	variant := product.Variants[0]
+	if variant.Id != 2 || variant.ProductId != 1 || variant.Option1 != "S" || variant.InventoryItemId != 4 ||
+		variant.InventoryPolicy != VariantInventoryPolicyDeny || !variant.Price.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("Product.Get returned variant %+v", variant)
+	}
+}
+
+func TestOrderGetGraphQLFallback(t *testing.T) {
+	setup()
+	defer teardown()
+	WithGraphQLFallbackOnNotFound()(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(410, `{"errors":"Gone"}`))
+	registerGraphQLResponder(`{"data":{"order":{"id":"gid://shopify/Order/1","name":"#1001","currencyCode":"EUR","displayFinancialStatus":"PARTIALLY_REFUNDED","displayFulfillmentStatus":"PARTIALLY_FULFILLED",
+		"customer":{"id":"gid://shopify/Customer/5"},"totalPriceSet":{"shopMoney":{"amount":"25.50","currencyCode":"EUR"},"presentmentMoney":{"amount":"30.00","currencyCode":"USD"}},
+		"lineItems":{"nodes":[{"id":"gid://shopify/LineItem/6","quantity":2,"variant":{"id":"gid://shopify/ProductVariant/7"},"product":null,"originalUnitPriceSet":{"shopMoney":{"amount":"12.75"}}}]}}}}`, nil)
+
+	order, err := client.Order.Get(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("Order.Get returned error: %v", err)
+	}
+	if order.Id != 1 || order.Name != "#1001" || order.Currency != "EUR" || order.Customer == nil || order.Customer.Id != 5 {
+		t.Errorf("Order.Get returned %+v", order)
+	}
+	if order.FinancialStatus != OrderFinancialStatusPartiallyRefunded || order.FulfillmentStatus != OrderFulfillmentStatusPartial {
+		t.Errorf("Order.Get returned statuses %s and %s", order.FinancialStatus, order.FulfillmentStatus)
+	}
+	if !order.TotalPrice.Equal(decimal.RequireFromString("25.50")) || !order.TotalPriceSet.PresentmentMoney.Amount.Equal(decimal.NewFromInt(30)) {
+		t.Errorf("Order.Get returned totals %v and %+v", order.TotalPrice, order.TotalPriceSet)
+	}
+	if len(order.LineItems) != 1 || order.LineItems[0].VariantId != 7 || order.LineItems[0].ProductId != 0 || !order.LineItems[0].Price.Equal(decimal.RequireFromString("12.75")) {
+		t.Errorf("Order.Get returned line items %+v", order.LineItems)
+	}
+}
+
+func TestCustomerGetGraphQLFallbackNotFound(t *testing.T) {
+	setup()
+	defer teardown()
+	WithGraphQLFallbackOnNotFound()(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(404, `{"errors":"Not Found"}`))
+	registerGraphQLResponder(`{"data":{"customer":null}}`, nil)
+
+	_, err := client.Customer.Get(context.Background(), 1, nil)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Customer.Get returned %v, expected ErrNotFound", err)
+	}
+}
+
+func TestCustomerGetGraphQLFallback(t *testing.T) {
+	setup()
+	defer teardown()
+	WithGraphQLFallbackOnNotFound()(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(404, `{"errors":"Not Found"}`))
+	registerGraphQLResponder(`{"data":{"customer":{"id":"gid://shopify/Customer/1","firstName":"Ada","state":"ENABLED","numberOfOrders":"3","amountSpent":{"amount":"99.90"}}}}`, nil)
+
+	customer, err := client.Customer.Get(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("Customer.Get returned error: %v", err)
+	}
+	if customer.Id != 1 || customer.FirstName != "Ada" || customer.State != "enabled" || customer.OrdersCount != 3 || !customer.TotalSpent.Equal(decimal.RequireFromString("99.90")) {
+		t.Errorf("Customer.Get returned %+v", customer)
+	}
+}
+
+func TestGetWithoutGraphQLFallback(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(404, `{"errors":"Not Found"}`))
+
+	_, err := client.Product.Get(context.Background(), 1, nil)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Product.Get returned %v, expected ErrNotFound", err)
+	}
+	if info := httpmock.GetCallCountInfo(); info[fmt.Sprintf("POST https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix)] != 0 {
+		t.Errorf("Product.Get queried graphql without WithGraphQLFallbackOnNotFound")
+	}
+}
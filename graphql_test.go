@@ -2,10 +2,13 @@ package goshopify
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/jarcoal/httpmock"
 )
@@ -105,8 +108,12 @@ func TestGraphQLQueryWithRetries(t *testing.T) {
 				ResponseError: ResponseError{
 					Status:  200,
 					Message: "Throttled",
+					ResponseMetadata: ResponseMetadata{
+						Retries:    maxRetries - 1,
+						RequestURL: fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+					},
 				},
-				RetryAfter: 2,
+				RetryAfter: 2 * time.Second,
 			},
 			retries: maxRetries,
 		},
@@ -174,7 +181,8 @@ func TestGraphQLQueryWithRetries(t *testing.T) {
 				return httpmock.NewStringResponse(http.StatusServiceUnavailable, ""), nil
 			},
 			expected: ResponseError{
-				Status: http.StatusServiceUnavailable,
+				Status:           http.StatusServiceUnavailable,
+				ResponseMetadata: ResponseMetadata{Retries: 2},
 			},
 			retries: maxRetries,
 		},
@@ -215,6 +223,49 @@ func TestGraphQLQueryWithRetries(t *testing.T) {
 	}
 }
 
+func TestGraphQLQueryRetryPolicy(t *testing.T) {
+	var retries []RetryAttempt
+	testClient := MustNewClient(app, "fooshop", "abcd", WithRetryPolicy(RetryPolicy{
+		MaxRetries: 10,
+		MinBackoff: 20 * time.Millisecond,
+		Budget:     50 * time.Millisecond,
+		OnRetry: func(ctx context.Context, retry RetryAttempt) error {
+			retries = append(retries, retry)
+			return nil
+		},
+	}))
+	httpmock.ActivateNonDefault(testClient.Client)
+	defer httpmock.DeactivateAndReset()
+
+	// enough points are available so the waits are the backoff of the policy
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/graphql.json",
+		httpmock.NewStringResponder(200, `{
+			"errors":[{"message":"Throttled","extensions":{"code":"THROTTLED"}}],
+			"extensions":{"cost":{"requestedQueryCost":10,"throttleStatus":{"maximumAvailable":1000,"currentlyAvailable":900,"restoreRate":50}}}
+		}`))
+
+	// waits of 20ms then 40ms, the second one past the budget
+	err := testClient.GraphQL.Query(context.Background(), "query {}", nil, nil)
+	var rateLimitErr RateLimitError
+	if !errors.As(err, &rateLimitErr) || rateLimitErr.Retries != 1 {
+		t.Errorf("GraphQL.Query returned %#v, expected a RateLimitError after 1 retry", err)
+	}
+	if calls := httpmock.GetTotalCallCount(); calls != 2 {
+		t.Errorf("GraphQL.Query sent %d requests, expected 2", calls)
+	}
+	if len(retries) != 1 || retries[0].Retry != 1 || retries[0].Wait != 20*time.Millisecond ||
+		retries[0].Request == nil || !errors.Is(retries[0].Err, ErrRateLimited) {
+		t.Errorf("OnRetry was called with %+v", retries)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	testClient.retryPolicy.MinBackoff, testClient.retryPolicy.Budget = time.Hour, 0
+	if err := testClient.GraphQL.Query(ctx, "query {}", nil, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GraphQL.Query returned %v, expected context.DeadlineExceeded", err)
+	}
+}
+
 func TestGraphQLQueryWithMultipleErrors(t *testing.T) {
 	setup()
 	defer teardown()
@@ -283,17 +334,73 @@ func TestGraphQLQueryWithThrottledError(t *testing.T) {
 		t.Errorf("GraphQL.Query returned error not of type RateLimitError")
 	}
 
-	expectedRetryAfterSeconds := 2.0
-	if rle.RetryAfter != int(expectedRetryAfterSeconds) {
-		t.Errorf("GraphQL.Query rle.RetryAfter is %d but expected %d", rle.RetryAfter, int(expectedRetryAfterSeconds))
+	expectedRetryAfter := 2 * time.Second
+	if rle.RetryAfter != expectedRetryAfter {
+		t.Errorf("GraphQL.Query rle.RetryAfter is %s but expected %s", rle.RetryAfter, expectedRetryAfter)
 	}
 
 	if client.RateLimits.GraphQLCost == nil {
 		t.Errorf("GraphQL.Query should have assigned client.RateLimits.GraphQLCost")
 	}
 
-	if client.RateLimits.RetryAfterSeconds != expectedRetryAfterSeconds {
-		t.Errorf("GraphQL.Query client.RateLimits.RetryAfterSeconds is %f but expected %f", client.RateLimits.RetryAfterSeconds, expectedRetryAfterSeconds)
+	if client.RateLimits.RetryAfterSeconds != expectedRetryAfter.Seconds() {
+		t.Errorf("GraphQL.Query client.RateLimits.RetryAfterSeconds is %f but expected %f", client.RateLimits.RetryAfterSeconds, expectedRetryAfter.Seconds())
+	}
+}
+
+func TestGraphQLForEachPage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var cursors []interface{}
+	httpmock.RegisterResponder(
+		"POST",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			captured := graphQLTestRequest{}
+			if err := json.NewDecoder(req.Body).Decode(&captured); err != nil {
+				return nil, err
+			}
+			if captured.Variables["query"] != "status:active" {
+				t.Errorf("ForEachPage sent variables %v", captured.Variables)
+			}
+			cursors = append(cursors, captured.Variables["after"])
+			if captured.Variables["after"] == nil {
+				return httpmock.NewStringResponse(200, `{"data":{"products":{"nodes":[{"id":"gid://shopify/Product/1"}],"pageInfo":{"hasNextPage":true,"endCursor":"c1"}}}}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"data":{"products":{"nodes":[{"id":"gid://shopify/Product/2"}],"pageInfo":{"hasNextPage":false,"endCursor":"c2"}}}}`), nil
+		},
+	)
+
+	vars := map[string]interface{}{"query": "status:active"}
+	var ids []string
+	err := client.GraphQL.ForEachPage(context.Background(), "query products($query: String, $after: String) { ... }", vars, func(data json.RawMessage) (GraphQLPageInfo, error) {
+		resp := struct {
+			Products struct {
+				Nodes    []graphQLNode   `json:"nodes"`
+				PageInfo GraphQLPageInfo `json:"pageInfo"`
+			} `json:"products"`
+		}{}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return GraphQLPageInfo{}, err
+		}
+		for _, node := range resp.Products.Nodes {
+			ids = append(ids, node.Id)
+		}
+		return resp.Products.PageInfo, nil
+	})
+	if err != nil {
+		t.Fatalf("GraphQL.ForEachPage returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(ids, []string{"gid://shopify/Product/1", "gid://shopify/Product/2"}) {
+		t.Errorf("GraphQL.ForEachPage decoded %v", ids)
+	}
+	if !reflect.DeepEqual(cursors, []interface{}{nil, "c1"}) {
+		t.Errorf("GraphQL.ForEachPage sent cursors %v", cursors)
+	}
+	if _, ok := vars["after"]; ok {
+		t.Errorf("GraphQL.ForEachPage modified the variables of the caller")
 	}
 }
 
@@ -371,3 +478,44 @@ func TestGraphQLCostRetryAfterSeconds(t *testing.T) {
 func makeIntPointer(v int) *int {
 	return &v
 }
+
+func TestGid(t *testing.T) {
+	gid := NewGid("Product", 632910392)
+	if gid != "gid://shopify/Product/632910392" {
+		t.Errorf("NewGid returned %s", gid)
+	}
+
+	resourceType, id, err := ParseGid("gid://shopify/LineItem/1?foo=bar")
+	if err != nil || resourceType != "LineItem" || id != 1 {
+		t.Errorf("ParseGid returned %s, %d, %v, expected LineItem, 1, nil", resourceType, id, err)
+	}
+
+	for _, invalid := range []string{"632910392", "gid://shopify/Product", "gid://shopify/Product/abc"} {
+		if _, _, err := ParseGid(invalid); err == nil {
+			t.Errorf("ParseGid(%s) expected error", invalid)
+		}
+	}
+}
+
+// graphQLTestRequest is the decoded body of a graphql request
+type graphQLTestRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+// registerGraphQLResponder responds to graphql requests with body and
+// records the last request in captured when it is not nil
+func registerGraphQLResponder(body string, captured *graphQLTestRequest) {
+	httpmock.RegisterResponder(
+		"POST",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			if captured != nil {
+				if err := json.NewDecoder(req.Body).Decode(captured); err != nil {
+					return nil, err
+				}
+			}
+			return httpmock.NewStringResponse(200, body), nil
+		},
+	)
+}
@@ -0,0 +1,175 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Sentinel errors matched by the GuardError returned for mutating requests
+// refused by WithProductionGuard and WithReadOnly
+var (
+	ErrProductionGuard = errors.New("shopify: shop not allowed by production guard")
+	ErrReadOnly        = errors.New("shopify: read-only client")
+)
+
+// GuardError is returned without sending the request when a client guard
+// refuses a request. Err is the sentinel error of the guard.
+type GuardError struct {
+	Err    error
+	Shop   string
+	Method string
+	Path   string
+}
+
+func (e GuardError) Error() string {
+	return fmt.Sprintf("%v: %s %s on %s", e.Err, e.Method, e.Path, e.Shop)
+}
+
+func (e GuardError) Unwrap() error {
+	return e.Err
+}
+
+// isMutatingRequest reports whether a request may change the store. Graphql
+// requests are sent with POST and only mutate when their document contains a
+// mutation, or an operation whose type cannot be determined.
+func isMutatingRequest(req *http.Request, body []byte) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	isGraphQL := strings.HasSuffix(req.URL.Path, "/graphql.json") || req.URL.String() == MockShopURL
+	if req.Method != http.MethodPost || !isGraphQL {
+		return true
+	}
+
+	document := struct {
+		Query string `json:"query"`
+	}{}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return true
+	}
+	return graphQLDocumentMutates(document.Query)
+}
+
+// graphQLDocumentMutates reports whether a graphql document may mutate the
+// store: it has a mutation, a subscription, or cannot be tokenized. Tokens are
+// read as graphql does, ignoring whitespace, commas and comments, so a
+// mutation cannot hide from the guards, e.g. in "query q{a},mutation m{b}".
+// Only query and fragment definitions, and the shorthand query, are read.
+func graphQLDocumentMutates(document string) bool {
+	depth := 0
+	expectDefinition := true
+	definitions := 0
+	for i := 0; i < len(document); {
+		c := document[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			i++
+		case c == '#':
+			for i < len(document) && document[i] != '\n' && document[i] != '\r' {
+				i++
+			}
+		case c == '"':
+			end, ok := skipGraphQLString(document, i)
+			if !ok || (depth == 0 && expectDefinition) {
+				return true
+			}
+			i = end
+		case c == '{' || c == '(' || c == '[':
+			if depth == 0 && expectDefinition {
+				if c != '{' {
+					return true
+				}
+				// the shorthand of a query
+				expectDefinition = false
+				definitions++
+			}
+			depth++
+			i++
+		case c == '}' || c == ')' || c == ']':
+			depth--
+			if depth < 0 {
+				return true
+			}
+			if depth == 0 && c == '}' {
+				expectDefinition = true
+			}
+			i++
+		case isGraphQLNameStart(c):
+			start := i
+			for i < len(document) && (isGraphQLNameStart(document[i]) || ('0' <= document[i] && document[i] <= '9')) {
+				i++
+			}
+			if depth == 0 && expectDefinition {
+				switch document[start:i] {
+				case "query", "fragment":
+					expectDefinition = false
+					definitions++
+				default:
+					return true
+				}
+			}
+		default:
+			if depth == 0 && expectDefinition {
+				return true
+			}
+			i++
+		}
+	}
+	return depth != 0 || !expectDefinition || definitions == 0
+}
+
+// skipGraphQLString returns the index following the string or block string
+// starting at start, ok is false when it is not terminated
+func skipGraphQLString(document string, start int) (end int, ok bool) {
+	if strings.HasPrefix(document[start:], `"""`) {
+		for i := start + 3; i+3 <= len(document); i++ {
+			if document[i] == '\\' && strings.HasPrefix(document[i+1:], `"""`) {
+				i += 3
+				continue
+			}
+			if strings.HasPrefix(document[i:], `"""`) {
+				return i + 3, true
+			}
+		}
+		return 0, false
+	}
+	for i := start + 1; i < len(document); i++ {
+		switch document[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1, true
+		case '\n', '\r':
+			return 0, false
+		}
+	}
+	return 0, false
+}
+
+func isGraphQLNameStart(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// checkGuards returns a GuardError when a guard configured on the client
+// refuses the request
+func (c *Client) checkGuards(req *http.Request, body []byte) error {
+	if (!c.readOnly && c.productionGuard == nil) || !isMutatingRequest(req, body) {
+		return nil
+	}
+
+	shop := c.shop
+	if c.readOnly {
+		return GuardError{Err: ErrReadOnly, Shop: shop, Method: req.Method, Path: req.URL.Path}
+	}
+	for _, pattern := range c.productionGuard {
+		if ok, _ := path.Match(pattern, shop); ok {
+			return nil
+		}
+	}
+	return GuardError{Err: ErrProductionGuard, Shop: shop, Method: req.Method, Path: req.URL.Path}
+}
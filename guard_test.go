@@ -0,0 +1,113 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestProductionGuard(t *testing.T) {
+	setup()
+	defer teardown()
+	WithProductionGuard("*-dev.myshopify.com")(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"product":{"id":1}}`))
+	registerGraphQLResponder(`{"data":{"shop":{"name":"foo"}}}`, nil)
+
+	if _, err := client.Product.Get(context.Background(), 1, nil); err != nil {
+		t.Errorf("Product.Get returned error: %v", err)
+	}
+	resp := struct{}{}
+	if err := client.GraphQL.Query(context.Background(), "query { shop { name } }", nil, &resp); err != nil {
+		t.Errorf("GraphQL.Query returned error: %v", err)
+	}
+
+	err := client.Product.Delete(context.Background(), 1)
+	var guardError GuardError
+	if !errors.Is(err, ErrProductionGuard) || !errors.As(err, &guardError) || guardError.Shop != "fooshop.myshopify.com" {
+		t.Errorf("Product.Delete returned error %v, expected ErrProductionGuard", err)
+	}
+
+	err = client.GraphQL.Query(context.Background(), "mutation { productDelete(input: {id: \"1\"}) { deletedProductId } }", nil, &resp)
+	if !errors.Is(err, ErrProductionGuard) {
+		t.Errorf("GraphQL.Query returned error %v for a mutation, expected ErrProductionGuard", err)
+	}
+
+	if httpmock.GetTotalCallCount() != 2 {
+		t.Errorf("expected 2 requests to be sent, got %d", httpmock.GetTotalCallCount())
+	}
+}
+
+func TestProductionGuardAllowedShop(t *testing.T) {
+	setup()
+	defer teardown()
+	WithProductionGuard("foo*.myshopify.com")(client)
+
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{}`))
+
+	if err := client.Product.Delete(context.Background(), 1); err != nil {
+		t.Errorf("Product.Delete returned error: %v", err)
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	setup()
+	defer teardown()
+	WithReadOnly()(client)
+
+	registerGraphQLResponder(`{"data":{"shop":{"name":"foo"}}}`, nil)
+
+	resp := struct{}{}
+	if err := client.GraphQL.Query(context.Background(), "{ shop { name } }", nil, &resp); err != nil {
+		t.Errorf("GraphQL.Query returned error: %v", err)
+	}
+
+	for _, err := range []error{
+		client.Product.Delete(context.Background(), 1),
+		client.GraphQL.Query(context.Background(), "query a { shop { name } }\nmutation b { productDelete(input: {id: \"1\"}) { deletedProductId } }", nil, &resp),
+	} {
+		if !errors.Is(err, ErrReadOnly) {
+			t.Errorf("expected ErrReadOnly, got %v", err)
+		}
+	}
+	if _, err := client.Product.Create(context.Background(), Product{Title: "foo"}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Product.Create returned %v, expected ErrReadOnly", err)
+	}
+
+	if httpmock.GetTotalCallCount() != 1 {
+		t.Errorf("expected 1 request to be sent, got %d", httpmock.GetTotalCallCount())
+	}
+}
+
+func TestGraphQLDocumentMutates(t *testing.T) {
+	cases := []struct {
+		document string
+		expected bool
+	}{
+		{"{ shop { name } }", false},
+		{"query { shop { name } }", false},
+		{"query q($id: ID! = \"mutation\") @cached(ttl: 60) { product(id: $id) { title } }", false},
+		{"query q($f: Filter = {a: [1, 2]}) { products(filter: $f) { ...F } }\nfragment F on Product { id }", false},
+		{"# mutation m { a }\nquery { \"\"\"mutation\"\"\" shop { name } }", false},
+		{"mutation { productDelete(input: {id: \"1\"}) { deletedProductId } }", true},
+		{"query q{a},mutation m{b}", true},
+		{"query q{a}\tmutation m{b}", true},
+		{"query q{a}#comment\nmutation m{b}", true},
+		{"subscription { a }", true},
+		{"query { shop { name }", true},
+		{"query { shop(name: \"unterminated) { name } }", true},
+		{"\"description\" query { a }", true},
+		{"", true},
+	}
+
+	for _, c := range cases {
+		if mutates := graphQLDocumentMutates(c.document); mutates != c.expected {
+			t.Errorf("graphQLDocumentMutates(%q) returned %t, expected %t", c.document, mutates, c.expected)
+		}
+	}
+}
@@ -0,0 +1,345 @@
+package goshopify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// HTTPExchange is an attempt of a request sent to Shopify and its response,
+// with the access token and the fields of the redactor redacted. Bodies are
+// uncompressed.
+type HTTPExchange struct {
+	Started        time.Time
+	Duration       time.Duration
+	Method         string
+	URL            string
+	RequestHeader  http.Header
+	RequestBody    []byte
+	StatusCode     int
+	ResponseHeader http.Header
+	ResponseBody   []byte
+
+	// error of the http client, the response fields are then empty
+	Err error
+}
+
+// HTTPDumper records the exchanges of a client with Shopify, e.g. to
+// reproduce a bug of the API, see WithHTTPDump and RequestHTTPDump
+type HTTPDumper interface {
+	DumpHTTP(exchange HTTPExchange) error
+}
+
+// RequestHTTPDump records the exchanges of the requests sent with the
+// context to dumper, in addition to the dumper of WithHTTPDump
+func RequestHTTPDump(dumper HTTPDumper) RequestOption {
+	return func(o *requestOptions) {
+		o.dumper = dumper
+	}
+}
+
+// requestHTTPDumper returns the dumper of the request options of ctx
+func requestHTTPDumper(ctx context.Context) HTTPDumper {
+	if options, ok := ctx.Value(requestOptionsKey{}).(*requestOptions); ok {
+		return options.dumper
+	}
+	return nil
+}
+
+// dumpAttempt records an attempt of req with the uncompressed body and its
+// response to the dumpers of the client and of the context of req
+func (c *Client) dumpAttempt(req *http.Request, body []byte, resp *http.Response, err error, start time.Time) {
+	dumpers := make([]HTTPDumper, 0, 2)
+	for _, dumper := range []HTTPDumper{c.httpDumper, requestHTTPDumper(req.Context())} {
+		if dumper != nil {
+			dumpers = append(dumpers, dumper)
+		}
+	}
+	if len(dumpers) == 0 {
+		return
+	}
+
+	exchange := HTTPExchange{
+		Started:       start,
+		Duration:      time.Since(start),
+		Method:        req.Method,
+		URL:           c.redactedURL(req),
+		RequestHeader: redactedHeader(req.Header),
+		RequestBody:   body,
+		Err:           err,
+	}
+	// the body is dumped uncompressed, see WithGzipRequests
+	exchange.RequestHeader.Del("Content-Encoding")
+	if len(body) > 0 && c.redactor != nil {
+		exchange.RequestBody = c.redactor.RedactBody(body)
+	}
+	if resp != nil {
+		exchange.StatusCode = resp.StatusCode
+		exchange.ResponseHeader = resp.Header.Clone()
+		exchange.ResponseBody = c.redactedBody(&resp.Body)
+	}
+
+	for _, dumper := range dumpers {
+		if err := dumper.DumpHTTP(exchange); err != nil {
+			c.log.Errorf("dump %s %s: %v", exchange.Method, exchange.URL, err)
+		}
+	}
+}
+
+// redactedHeader returns a copy of header without the credentials of the
+// client
+func redactedHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, key := range redactedHeaders {
+		if redacted.Get(key) != "" {
+			redacted.Set(key, redactedValue)
+		}
+	}
+	return redacted
+}
+
+// textDumper writes exchanges in the wire format of http/1.1
+type textDumper struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewHTTPDumpWriter returns an HTTPDumper writing every exchange to w as the
+// raw request followed by the raw response, e.g. to os.Stderr
+func NewHTTPDumpWriter(w io.Writer) HTTPDumper {
+	return &textDumper{w: w}
+}
+
+func (d *textDumper) DumpHTTP(exchange HTTPExchange) error {
+	var buf bytes.Buffer
+	requestURI := exchange.URL
+	host := ""
+	if u, err := url.Parse(exchange.URL); err == nil {
+		requestURI, host = u.RequestURI(), u.Host
+	}
+	fmt.Fprintf(&buf, "%s %s HTTP/1.1\r\nHost: %s\r\n", exchange.Method, requestURI, host)
+	exchange.RequestHeader.Write(&buf)
+	fmt.Fprintf(&buf, "\r\n%s\r\n\r\n", exchange.RequestBody)
+
+	if exchange.Err != nil {
+		fmt.Fprintf(&buf, "# error after %s: %v\r\n\r\n", exchange.Duration, exchange.Err)
+	} else {
+		fmt.Fprintf(&buf, "HTTP/1.1 %d %s\r\n", exchange.StatusCode, http.StatusText(exchange.StatusCode))
+		exchange.ResponseHeader.Write(&buf)
+		fmt.Fprintf(&buf, "\r\n%s\r\n\r\n", exchange.ResponseBody)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, err := d.w.Write(buf.Bytes())
+	return err
+}
+
+// HARRecorder is an HTTPDumper keeping the exchanges in memory to export them
+// as an HTTP Archive, which browsers and proxies import:
+//
+//	har := goshopify.NewHARRecorder()
+//	ctx = goshopify.WithRequestOptions(ctx, goshopify.RequestHTTPDump(har))
+//	_, err := client.Product.Update(ctx, product)
+//	err = har.WriteFile("product-update.har")
+type HARRecorder struct {
+	mu      sync.Mutex
+	entries []harEntry
+}
+
+// NewHARRecorder returns an empty HARRecorder
+func NewHARRecorder() *HARRecorder {
+	return &HARRecorder{entries: []harEntry{}}
+}
+
+// har is an HTTP Archive, see http://www.softwareishard.com/blog/har-12-spec
+type har struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// DumpHTTP adds the exchange to the archive
+func (h *HARRecorder) DumpHTTP(exchange HTTPExchange) error {
+	duration := float64(exchange.Duration) / float64(time.Millisecond)
+	entry := harEntry{
+		StartedDateTime: exchange.Started.Format("2006-01-02T15:04:05.000Z07:00"),
+		Time:            duration,
+		Request: harRequest{
+			Method:      exchange.Method,
+			URL:         exchange.URL,
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(exchange.RequestHeader),
+			QueryString: []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(exchange.RequestBody),
+		},
+		Response: harResponse{
+			Status:      exchange.StatusCode,
+			StatusText:  http.StatusText(exchange.StatusCode),
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(exchange.ResponseHeader),
+			Content: harContent{
+				Size:     len(exchange.ResponseBody),
+				MimeType: exchange.ResponseHeader.Get("Content-Type"),
+				Text:     string(exchange.ResponseBody),
+			},
+			HeadersSize: -1,
+			BodySize:    len(exchange.ResponseBody),
+		},
+		Timings: harTimings{Wait: duration},
+	}
+	if u, err := url.Parse(exchange.URL); err == nil {
+		for key, values := range u.Query() {
+			for _, value := range values {
+				entry.Request.QueryString = append(entry.Request.QueryString, harNameValue{Name: key, Value: value})
+			}
+		}
+		sort.Slice(entry.Request.QueryString, func(i, j int) bool {
+			return entry.Request.QueryString[i].Name < entry.Request.QueryString[j].Name
+		})
+	}
+	if len(exchange.RequestBody) > 0 {
+		entry.Request.PostData = &harPostData{
+			MimeType: exchange.RequestHeader.Get("Content-Type"),
+			Text:     string(exchange.RequestBody),
+		}
+	}
+	if exchange.Err != nil {
+		entry.Comment = exchange.Err.Error()
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = append(h.entries, entry)
+	return nil
+}
+
+// Len returns the number of exchanges recorded
+func (h *HARRecorder) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.entries)
+}
+
+// WriteTo writes the archive of the exchanges recorded so far to w
+func (h *HARRecorder) WriteTo(w io.Writer) (int64, error) {
+	h.mu.Lock()
+	archive := har{Log: harLog{
+		Version: "1.2",
+		Creator: harCreator{Name: "go-shopify", Version: UserAgent},
+		Entries: append([]harEntry{}, h.entries...),
+	}}
+	h.mu.Unlock()
+
+	b, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// WriteFile writes the archive of the exchanges recorded so far to the file
+// at path, usually with the .har extension
+func (h *HARRecorder) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := h.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// harHeaders returns header as HAR name value pairs sorted by name
+func harHeaders(header http.Header) []harNameValue {
+	pairs := []harNameValue{}
+	for name, values := range header {
+		for _, value := range values {
+			pairs = append(pairs, harNameValue{Name: name, Value: value})
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].Name < pairs[j].Name
+	})
+	return pairs
+}
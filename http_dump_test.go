@@ -0,0 +1,112 @@
+package goshopify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestWithHTTPDump(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var out bytes.Buffer
+	WithHTTPDump(NewHTTPDumpWriter(&out))(client)
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"product":{"id":1,"title":"Shoes"}}`))
+
+	product, err := client.Product.Update(context.Background(), Product{Id: 1, Title: "Shoes"})
+	if err != nil {
+		t.Fatalf("Product.Update returned error: %v", err)
+	}
+	if product.Title != "Shoes" {
+		t.Errorf("Product.Update returned %+v, the dump consumed the response", product)
+	}
+
+	dump := out.String()
+	for _, expected := range []string{
+		fmt.Sprintf("PUT /%s/products/1.json HTTP/1.1\r\nHost: fooshop.myshopify.com\r\n", client.pathPrefix),
+		"X-Shopify-Access-Token: " + redactedValue + "\r\n",
+		`{"product":{"id":1,"title":"Shoes"`,
+		"HTTP/1.1 200 OK\r\n",
+		`{"product":{"id":1,"title":"Shoes"}}`,
+	} {
+		if !strings.Contains(dump, expected) {
+			t.Errorf("dump %q does not contain %q", dump, expected)
+		}
+	}
+	if strings.Contains(dump, "abcd") {
+		t.Errorf("dump %q contains the access token", dump)
+	}
+}
+
+func TestRequestHTTPDumpHAR(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("page_info") == "" {
+				return httpmock.NewStringResponse(503, `{"errors":"Unavailable"}`), nil
+			}
+			resp := httpmock.NewStringResponse(200, `{"products":[{"id":1}]}`)
+			resp.Header.Set("Content-Type", "application/json")
+			return resp, nil
+		})
+
+	recorder := NewHARRecorder()
+	ctx := WithRequestOptions(context.Background(), RequestHTTPDump(recorder))
+	if _, err := client.Product.List(ctx, ListOptions{PageInfo: "abc"}); err != nil {
+		t.Fatalf("Product.List returned error: %v", err)
+	}
+	if _, err := client.Product.List(ctx, nil); err == nil {
+		t.Fatalf("Product.List returned no error, expected the 503")
+	}
+	if _, err := client.Product.List(context.Background(), ListOptions{PageInfo: "abc"}); err != nil {
+		t.Fatalf("Product.List returned error: %v", err)
+	}
+	if recorder.Len() != 1+maxRetries {
+		t.Fatalf("HARRecorder recorded %d exchanges, expected %d", recorder.Len(), 1+maxRetries)
+	}
+
+	path := filepath.Join(t.TempDir(), "products.har")
+	if err := recorder.WriteFile(path); err != nil {
+		t.Fatalf("HARRecorder.WriteFile returned error: %v", err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var archive har
+	if err := json.Unmarshal(b, &archive); err != nil {
+		t.Fatalf("HARRecorder.WriteFile wrote invalid json: %v", err)
+	}
+
+	entry := archive.Log.Entries[0]
+	if archive.Log.Version != "1.2" || entry.Request.Method != "GET" || entry.Response.Status != 200 {
+		t.Errorf("HARRecorder.WriteFile wrote entry %+v", entry)
+	}
+	if len(entry.Request.QueryString) != 1 || entry.Request.QueryString[0] != (harNameValue{Name: "page_info", Value: "abc"}) {
+		t.Errorf("HARRecorder.WriteFile wrote query string %+v", entry.Request.QueryString)
+	}
+	if entry.Response.Content.Text != `{"products":[{"id":1}]}` || entry.Response.Content.MimeType != "application/json" {
+		t.Errorf("HARRecorder.WriteFile wrote content %+v", entry.Response.Content)
+	}
+	for _, header := range entry.Request.Headers {
+		if header.Name == "X-Shopify-Access-Token" && header.Value != redactedValue {
+			t.Errorf("HARRecorder.WriteFile wrote access token %s", header.Value)
+		}
+	}
+	if archive.Log.Entries[1].Response.Status != 503 {
+		t.Errorf("HARRecorder.WriteFile wrote entry %+v, expected the failed attempt", archive.Log.Entries[1])
+	}
+}
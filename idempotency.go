@@ -0,0 +1,49 @@
+package goshopify
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+// IdempotencyKeyHeader is the header carrying the idempotency key of a
+// mutating request
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// RequestIdempotencyKey sets the idempotency key header of the requests, e.g.
+// to send a retried create with the key of the first attempt. The header is
+// only sent: the REST Admin API does not document honoring it, so it does not
+// prevent duplicates on its own.
+func RequestIdempotencyKey(key string) RequestOption {
+	return RequestHeader(IdempotencyKeyHeader, key)
+}
+
+// setIdempotencyKey sets a random idempotency key on the POST and PUT
+// requests without one when WithIdempotencyKeys is set. The key is kept by
+// the retries of the request.
+func (c *Client) setIdempotencyKey(req *http.Request) error {
+	if !c.idempotencyKeys || req.Header.Get(IdempotencyKeyHeader) != "" {
+		return nil
+	}
+	if req.Method != http.MethodPost && req.Method != http.MethodPut {
+		return nil
+	}
+
+	key, err := newIdempotencyKey()
+	if err != nil {
+		return err
+	}
+	req.Header.Set(IdempotencyKeyHeader, key)
+	return nil
+}
+
+// newIdempotencyKey returns a random version 4 uuid
+func newIdempotencyKey() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("generate idempotency key: %w", err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
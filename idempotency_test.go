@@ -0,0 +1,65 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestWithIdempotencyKeys(t *testing.T) {
+	setup()
+	defer teardown()
+	WithIdempotencyKeys()(client)
+
+	var keys []string
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			keys = append(keys, req.Header.Get(IdempotencyKeyHeader))
+			if len(keys) == 1 {
+				return httpmock.NewStringResponse(503, `{"errors":"Unavailable"}`), nil
+			}
+			return httpmock.NewStringResponse(201, `{"order":{"id":1}}`), nil
+		})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			keys = append(keys, req.Header.Get(IdempotencyKeyHeader))
+			return httpmock.NewStringResponse(200, `{"order":{"id":1}}`), nil
+		})
+
+	if _, err := client.Order.Create(context.Background(), Order{}); err != nil {
+		t.Fatalf("Order.Create returned error: %v", err)
+	}
+	if _, err := client.Order.Get(context.Background(), 1, nil); err != nil {
+		t.Fatalf("Order.Get returned error: %v", err)
+	}
+
+	uuidRegex := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	if len(keys) != 3 || !uuidRegex.MatchString(keys[0]) || keys[1] != keys[0] || keys[2] != "" {
+		t.Errorf("requests were sent with idempotency keys %q, expected the same uuid for the retried create only", keys)
+	}
+}
+
+func TestRequestIdempotencyKey(t *testing.T) {
+	setup()
+	defer teardown()
+	WithIdempotencyKeys()(client)
+
+	var key string
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			key = req.Header.Get(IdempotencyKeyHeader)
+			return httpmock.NewStringResponse(201, `{"order":{"id":1}}`), nil
+		})
+
+	ctx := WithRequestOptions(context.Background(), RequestIdempotencyKey("order-42"))
+	if _, err := client.Order.Create(ctx, Order{}); err != nil {
+		t.Fatalf("Order.Create returned error: %v", err)
+	}
+	if key != "order-42" {
+		t.Errorf("Order.Create was sent with idempotency key %q, expected order-42", key)
+	}
+}
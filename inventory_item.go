@@ -17,6 +17,7 @@ type InventoryItemService interface {
 	List(context.Context, interface{}) ([]InventoryItem, error)
 	Get(context.Context, uint64, interface{}) (*InventoryItem, error)
 	Update(context.Context, InventoryItem) (*InventoryItem, error)
+	UpdateCost(context.Context, uint64, decimal.Decimal) (*InventoryItem, error)
 }
 
 // InventoryItemServiceOp is the default implementation of the InventoryItemService interface
@@ -43,6 +44,7 @@ type InventoryItem struct {
 	Tracked                      *bool                   `json:"tracked,omitempty"`
 	CountryHarmonizedSystemCodes []HarmonizedCountryCode `json:"country_harmonized_system_codes"`
 	AdminGraphqlApiId            string                  `json:"admin_graphql_api_id,omitempty"`
+	UnknownJSONFields
 }
 
 // InventoryItemResource is used for handling single item requests and responses
@@ -79,3 +81,27 @@ func (s *InventoryItemServiceOp) Update(ctx context.Context, item InventoryItem)
 	err := s.client.Put(ctx, path, wrappedData, resource)
 	return resource.InventoryItem, err
 }
+
+// UpdateCost updates the cost of an inventory item. The cost is expressed in
+// the shop currency, which is fetched once and cached, and is rounded to the
+// decimals of that currency before being sent.
+func (s *InventoryItemServiceOp) UpdateCost(ctx context.Context, id uint64, cost decimal.Decimal) (*InventoryItem, error) {
+	currency, err := s.client.shopCurrency(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rounded := cost.Round(CurrencyDecimals(currency))
+	// only id and cost are sent so nil fields of InventoryItem are not reset
+	wrappedData := map[string]interface{}{
+		"inventory_item": struct {
+			Id   uint64          `json:"id"`
+			Cost decimal.Decimal `json:"cost"`
+		}{Id: id, Cost: rounded},
+	}
+
+	path := fmt.Sprintf("%s/%d.json", inventoryItemsBasePath, id)
+	resource := new(InventoryItemResource)
+	err = s.client.Put(ctx, path, wrappedData, resource)
+	return resource.InventoryItem, err
+}
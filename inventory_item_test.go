@@ -3,10 +3,13 @@ package goshopify
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"strings"
 	"testing"
 
 	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
 )
 
 func inventoryItemTests(t *testing.T, item *InventoryItem) {
@@ -44,7 +47,11 @@ func inventoryItemTests(t *testing.T, item *InventoryItem) {
 	// strings.Join is used to compare slices since package's go.mod is set to 1.13
 	// which predates the experimental slices package that has a Compare() func.
 	expectedCountryHSCodes := strings.Join([]string{"8471.70.40.35", "8471.70.50.35"}, ",")
-	if strings.Join(item.CountryHarmonizedSystemCodes, ",") != expectedCountryHSCodes {
+	countryHSCodes := make([]string, 0, len(item.CountryHarmonizedSystemCodes))
+	for _, code := range item.CountryHarmonizedSystemCodes {
+		countryHSCodes = append(countryHSCodes, *code.HarmonizedSystemCode)
+	}
+	if strings.Join(countryHSCodes, ",") != expectedCountryHSCodes {
 		t.Errorf("InventoryItem.CountryHarmonizedSystemCodes returned %+v, expected %+v", item.CountryHarmonizedSystemCodes, expectedCountryHSCodes)
 	}
 
@@ -140,3 +147,38 @@ func TestInventoryItemUpdate(t *testing.T) {
 
 	inventoryItemTests(t, updatedItem)
 }
+
+func TestInventoryItemUpdateCost(t *testing.T) {
+	setup()
+	defer teardown()
+
+	shopURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix)
+	httpmock.RegisterResponderWithQuery("GET", shopURL, map[string]string{"fields": "currency"},
+		httpmock.NewStringResponder(200, `{"shop":{"currency":"JPY"}}`))
+
+	var body string
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/inventory_items/1.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := io.ReadAll(req.Body)
+			body = string(b)
+			return httpmock.NewBytesResponse(200, loadFixture("inventory_item.json")), nil
+		})
+
+	for _, cost := range []string{"1250.4", "1249.6"} {
+		item, err := client.InventoryItem.UpdateCost(context.Background(), 1, decimal.RequireFromString(cost))
+		if err != nil {
+			t.Fatalf("InventoryItem.UpdateCost returned error: %v", err)
+		}
+		inventoryItemTests(t, item)
+
+		expected := `{"inventory_item":{"id":1,"cost":"1250"}}`
+		if body != expected {
+			t.Errorf("InventoryItem.UpdateCost sent %s, expected %s", body, expected)
+		}
+	}
+
+	// the shop currency is only fetched once
+	if count := httpmock.GetCallCountInfo()["GET "+shopURL+"?fields=currency"]; count != 1 {
+		t.Errorf("InventoryItem.UpdateCost fetched the shop currency %d times, expected 1", count)
+	}
+}
@@ -2,6 +2,7 @@ package goshopify
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 )
@@ -18,6 +19,8 @@ type LocationService interface {
 	Get(ctx context.Context, id uint64, options interface{}) (*Location, error)
 	// Retrieves a count of locations
 	Count(ctx context.Context, options interface{}) (int, error)
+	// Retrieves a single location by its Id through the client cache store
+	GetCached(ctx context.Context, id uint64) (*Location, error)
 }
 
 type Location struct {
@@ -44,7 +47,7 @@ type Location struct {
 	CountryName string `json:"country_name"`
 
 	// The date and time (ISO 8601 format) when the location was created.
-	CreatedAt time.Time `json:"created_at"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
 
 	// The Id for the location.
 	Id uint64 `json:"id"`
@@ -67,7 +70,7 @@ type Location struct {
 	ProvinceCode string `json:"province_code"`
 
 	// The date and time (ISO 8601 format) when the location was last updated.
-	UpdatedAt time.Time `json:"updated_at"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 
 	// The zip or postal code.
 	Zip string `json:"zip"`
@@ -100,6 +103,48 @@ func (s *LocationServiceOp) Count(ctx context.Context, options interface{}) (int
 	return s.client.Count(ctx, path, options)
 }
 
+// locationCacheTTL is how long locations are kept in the client cache store
+const locationCacheTTL = time.Hour
+
+// GetCached gets a location from the client cache store, fetching it on a
+// miss, e.g. to resolve the location of every fulfillment order of a sync
+func (s *LocationServiceOp) GetCached(ctx context.Context, id uint64) (*Location, error) {
+	key := fmt.Sprintf("location:%s:%d", s.client.shop, id)
+	if cached, found, err := s.client.cache.Get(ctx, key); err == nil && found {
+		location := new(Location)
+		if err := json.Unmarshal(cached, location); err == nil {
+			return location, nil
+		}
+	}
+
+	location, err := s.Get(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if b, err := json.Marshal(location); err == nil {
+		if err := s.client.cache.Set(ctx, key, b, locationCacheTTL); err != nil {
+			s.client.log.Warnf("could not cache location %d: %s", id, err)
+		}
+	}
+	return location, nil
+}
+
+// ShippingRateAddress returns the address of the location as the origin of a
+// shipping rate request, with its country and province codes
+func (l Location) ShippingRateAddress() ShippingRateAddress {
+	return ShippingRateAddress{
+		Country:    l.CountryCode,
+		PostalCode: l.Zip,
+		Province:   l.ProvinceCode,
+		City:       l.City,
+		Name:       l.Name,
+		Address1:   l.Address1,
+		Address2:   l.Address2,
+		Phone:      l.Phone,
+	}
+}
+
 // Represents the result from the locations/X.json endpoint
 type LocationResource struct {
 	Location *Location `json:"location"`
@@ -34,8 +34,8 @@ func TestLocationServiceOp_List(t *testing.T) {
 		Zip:               "10-001",
 		Country:           "PL",
 		Phone:             "12312312",
-		CreatedAt:         created,
-		UpdatedAt:         updated,
+		CreatedAt:         &created,
+		UpdatedAt:         &updated,
 		CountryCode:       "PL",
 		CountryName:       "Poland",
 		Legacy:            false,
@@ -72,8 +72,8 @@ func TestLocationServiceOp_Get(t *testing.T) {
 		Zip:               "10-001",
 		Country:           "PL",
 		Phone:             "12312312",
-		CreatedAt:         created,
-		UpdatedAt:         updated,
+		CreatedAt:         &created,
+		UpdatedAt:         &updated,
 		CountryCode:       "PL",
 		CountryName:       "Poland",
 		Legacy:            false,
@@ -103,3 +103,25 @@ func TestLocationServiceOp_Count(t *testing.T) {
 		t.Errorf("Location.Count returned %d, expected %d", cnt, expected)
 	}
 }
+
+func TestLocationServiceOp_GetCached(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/locations/4688969785.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("location.json")))
+
+	for i := 0; i < 2; i++ {
+		location, err := client.Location.GetCached(context.Background(), 4688969785)
+		if err != nil {
+			t.Fatalf("Location.GetCached returned error: %v", err)
+		}
+		if location.Id != 4688969785 || location.City != "Olsztyn" {
+			t.Errorf("Location.GetCached returned %+v", location)
+		}
+	}
+
+	if calls := httpmock.GetTotalCallCount(); calls != 1 {
+		t.Errorf("Location.GetCached sent %d requests, expected 1", calls)
+	}
+}
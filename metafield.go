@@ -11,6 +11,7 @@ import (
 // https://help.shopify.com/api/reference/metafield
 type MetafieldService interface {
 	List(context.Context, interface{}) ([]Metafield, error)
+	ListByNamespace(context.Context, string) ([]Metafield, error)
 	Count(context.Context, interface{}) (int, error)
 	Get(context.Context, uint64, interface{}) (*Metafield, error)
 	Create(context.Context, Metafield) (*Metafield, error)
@@ -23,6 +24,7 @@ type MetafieldService interface {
 // https://help.shopify.com/api/reference/metafield
 type MetafieldsService interface {
 	ListMetafields(context.Context, uint64, interface{}) ([]Metafield, error)
+	ListMetafieldsByNamespace(context.Context, uint64, string) ([]Metafield, error)
 	CountMetafields(context.Context, uint64, interface{}) (int, error)
 	GetMetafield(context.Context, uint64, uint64, interface{}) (*Metafield, error)
 	CreateMetafield(context.Context, uint64, Metafield) (*Metafield, error)
@@ -119,6 +121,15 @@ type MetafieldsResource struct {
 	Metafields []Metafield `json:"metafields"`
 }
 
+// MetafieldListOptions represents the filters of the metafields.json
+// endpoints, filtering by namespace and key is done by shopify
+type MetafieldListOptions struct {
+	ListOptions
+	Namespace string        `url:"namespace,omitempty"`
+	Key       string        `url:"key,omitempty"`
+	Type      MetafieldType `url:"type,omitempty"`
+}
+
 // List metafields
 func (s *MetafieldServiceOp) List(ctx context.Context, options interface{}) ([]Metafield, error) {
 	prefix := MetafieldPathPrefix(s.resource, s.resourceId)
@@ -128,6 +139,32 @@ func (s *MetafieldServiceOp) List(ctx context.Context, options interface{}) ([]M
 	return resource.Metafields, err
 }
 
+// ListByNamespace lists all metafields of a namespace, iterating over pages
+func (s *MetafieldServiceOp) ListByNamespace(ctx context.Context, namespace string) ([]Metafield, error) {
+	prefix := MetafieldPathPrefix(s.resource, s.resourceId)
+	path := fmt.Sprintf("%s.json", prefix)
+	collector := []Metafield{}
+
+	var options interface{} = MetafieldListOptions{ListOptions: ListOptions{Limit: 250}, Namespace: namespace}
+	for {
+		resource := new(MetafieldsResource)
+		pagination, err := s.client.ListWithPagination(ctx, path, resource, options)
+		if err != nil {
+			return collector, err
+		}
+
+		collector = append(collector, resource.Metafields...)
+
+		if pagination.NextPageOptions == nil {
+			break
+		}
+
+		options = pagination.NextPageOptions
+	}
+
+	return collector, nil
+}
+
 // Count metafields
 func (s *MetafieldServiceOp) Count(ctx context.Context, options interface{}) (int, error) {
 	prefix := MetafieldPathPrefix(s.resource, s.resourceId)
@@ -3,6 +3,7 @@ package goshopify
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"reflect"
 	"testing"
 	"time"
@@ -36,6 +37,51 @@ func TestMetafieldList(t *testing.T) {
 	}
 }
 
+func TestMetafieldListByNamespace(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/metafields.json", client.pathPrefix)
+	httpmock.RegisterResponderWithQuery("GET", listURL, map[string]string{"limit": "250", "namespace": "loyalty"},
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(200, `{"metafields": [{"id":1},{"id":2}]}`)
+			resp.Header.Set("Link", fmt.Sprintf(`<%s?limit=250&page_info=pg2>; rel="next"`, listURL))
+			return resp, nil
+		})
+	httpmock.RegisterResponderWithQuery("GET", listURL, map[string]string{"limit": "250", "page_info": "pg2"},
+		httpmock.NewStringResponder(200, `{"metafields": [{"id":3}]}`))
+
+	metafields, err := client.Metafield.ListByNamespace(context.Background(), "loyalty")
+	if err != nil {
+		t.Errorf("Metafield.ListByNamespace returned error: %v", err)
+	}
+
+	expected := []Metafield{{Id: 1}, {Id: 2}, {Id: 3}}
+	if !reflect.DeepEqual(metafields, expected) {
+		t.Errorf("Metafield.ListByNamespace returned %+v, expected %+v", metafields, expected)
+	}
+}
+
+func TestMetafieldListOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{"namespace": "loyalty", "key": "points", "type": "number_integer"}
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/metafields.json", client.pathPrefix),
+		params, httpmock.NewStringResponder(200, `{"metafields": [{"id":1}]}`))
+
+	options := MetafieldListOptions{Namespace: "loyalty", Key: "points", Type: MetafieldTypeNumberInteger}
+	metafields, err := client.Metafield.List(context.Background(), options)
+	if err != nil {
+		t.Errorf("Metafield.List returned error: %v", err)
+	}
+
+	expected := []Metafield{{Id: 1}}
+	if !reflect.DeepEqual(metafields, expected) {
+		t.Errorf("Metafield.List returned %+v, expected %+v", metafields, expected)
+	}
+}
+
 func TestMetafieldCount(t *testing.T) {
 	setup()
 	defer teardown()
@@ -0,0 +1,104 @@
+package goshopify
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// MetricsCollector observes the requests of a client, e.g. to export per
+// endpoint latencies and the rate limit headroom to Prometheus:
+//
+//	func (p *promCollector) OnRequestDone(ctx context.Context, m goshopify.RequestMetrics) {
+//		p.latency.WithLabelValues(m.Method, m.Endpoint, strconv.Itoa(m.Status)).Observe(m.Duration.Seconds())
+//		if m.BucketSize > 0 {
+//			p.remaining.WithLabelValues(m.Shop).Set(float64(m.RemainingCalls))
+//		}
+//	}
+//
+// A retried request is reported once, with the number of retries.
+type MetricsCollector interface {
+	// OnRequestStart is called before the request is sent, only Shop,
+	// Method, Path and Endpoint are set
+	OnRequestStart(ctx context.Context, metrics RequestMetrics)
+
+	// OnRequestDone is called once the request succeeded or failed
+	OnRequestDone(ctx context.Context, metrics RequestMetrics)
+}
+
+// RequestMetrics describes a request sent to shopify, see MetricsCollector
+type RequestMetrics struct {
+	Shop   string
+	Method string
+	Path   string
+
+	// Endpoint is the path relative to the api version with the ids replaced
+	// by ":id", e.g. "products/:id/variants.json", to be used as a label
+	Endpoint string
+
+	// Status of the last response, 0 when no response was received
+	Status   int
+	Duration time.Duration
+	Retries  int
+
+	// RemainingCalls and BucketSize are read from the call limit header of
+	// the last response, BucketSize is 0 when shopify did not send it, e.g.
+	// for GraphQL requests
+	RemainingCalls int
+	BucketSize     int
+
+	Err error
+}
+
+// startMetrics notifies the metrics collector of the client that req is sent
+func (c *Client) startMetrics(req *http.Request) RequestMetrics {
+	if c.metrics == nil {
+		return RequestMetrics{}
+	}
+
+	metrics := RequestMetrics{
+		Shop:     c.shop,
+		Method:   req.Method,
+		Path:     req.URL.Path,
+		Endpoint: c.endpoint(req.URL.Path),
+	}
+	c.metrics.OnRequestStart(req.Context(), metrics)
+	return metrics
+}
+
+// doneMetrics notifies the metrics collector of the client of the outcome of
+// the request started at start
+func (c *Client) doneMetrics(req *http.Request, metrics RequestMetrics, start time.Time, resp *http.Response, err error) {
+	if c.metrics == nil {
+		return
+	}
+
+	metrics.Duration = time.Since(start)
+	metrics.Err = err
+	if attempts := requestAttempts(req); attempts > 0 {
+		metrics.Retries = attempts - 1
+	}
+	if resp != nil {
+		metrics.Status = resp.StatusCode
+		if used, size, ok := parseCallLimit(resp.Header); ok {
+			metrics.RemainingCalls, metrics.BucketSize = size-used, size
+		}
+	}
+	c.metrics.OnRequestDone(req.Context(), metrics)
+}
+
+// endpoint trims the api prefix from path and replaces the ids
+func (c *Client) endpoint(path string) string {
+	segments := strings.Split(strings.TrimPrefix(path, "/"+c.pathPrefix+"/"), "/")
+	for i, segment := range segments {
+		id, ext, _ := strings.Cut(segment, ".")
+		if id != "" && strings.Trim(id, "0123456789") == "" {
+			segments[i] = ":id"
+			if ext != "" {
+				segments[i] += "." + ext
+			}
+		}
+	}
+	return strings.Join(segments, "/")
+}
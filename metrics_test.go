@@ -0,0 +1,101 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+type recordingCollector struct {
+	started []RequestMetrics
+	done    []RequestMetrics
+}
+
+func (r *recordingCollector) OnRequestStart(_ context.Context, metrics RequestMetrics) {
+	r.started = append(r.started, metrics)
+}
+
+func (r *recordingCollector) OnRequestDone(_ context.Context, metrics RequestMetrics) {
+	r.done = append(r.done, metrics)
+}
+
+func TestWithMetricsCollector(t *testing.T) {
+	setup()
+	defer teardown()
+
+	collector := &recordingCollector{}
+	WithMetricsCollector(collector)(client)
+	client.retries = 2
+
+	attempts := 0
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1/variants.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts == 1 {
+				return httpmock.NewStringResponse(http.StatusServiceUnavailable, ""), nil
+			}
+			resp := httpmock.NewStringResponse(200, `{"variants":[]}`)
+			resp.Header.Set("X-Shopify-Shop-Api-Call-Limit", "10/40")
+			return resp, nil
+		})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/2.json", client.pathPrefix),
+		httpmock.NewStringResponder(404, `{"errors":"Not Found"}`))
+
+	if _, err := client.Variant.List(context.Background(), 1, nil); err != nil {
+		t.Fatalf("Variant.List returned error: %v", err)
+	}
+	if _, err := client.Product.Get(context.Background(), 2, nil); err == nil {
+		t.Fatal("Product.Get returned no error")
+	}
+
+	if len(collector.started) != 2 || len(collector.done) != 2 {
+		t.Fatalf("collector was notified of %d started and %d done requests, expected 2", len(collector.started), len(collector.done))
+	}
+
+	started := collector.started[0]
+	expectedStarted := RequestMetrics{
+		Shop:     "fooshop.myshopify.com",
+		Method:   "GET",
+		Path:     fmt.Sprintf("/%s/products/1/variants.json", client.pathPrefix),
+		Endpoint: "products/:id/variants.json",
+	}
+	if started != expectedStarted {
+		t.Errorf("OnRequestStart was called with %+v, expected %+v", started, expectedStarted)
+	}
+
+	listed := collector.done[0]
+	if listed.Endpoint != "products/:id/variants.json" || listed.Status != 200 || listed.Retries != 1 ||
+		listed.RemainingCalls != 30 || listed.BucketSize != 40 || listed.Err != nil || listed.Duration <= 0 {
+		t.Errorf("OnRequestDone was called with %+v", listed)
+	}
+
+	failed := collector.done[1]
+	if failed.Endpoint != "products/:id.json" || failed.Status != 404 || failed.BucketSize != 0 || !errors.Is(failed.Err, ErrNotFound) {
+		t.Errorf("OnRequestDone was called with %+v for a failed request", failed)
+	}
+}
+
+func TestClientEndpoint(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cases := []struct {
+		path     string
+		expected string
+	}{
+		{fmt.Sprintf("/%s/products.json", client.pathPrefix), "products.json"},
+		{fmt.Sprintf("/%s/products/123.json", client.pathPrefix), "products/:id.json"},
+		{fmt.Sprintf("/%s/orders/1/fulfillments/2/events.json", client.pathPrefix), "orders/:id/fulfillments/:id/events.json"},
+		{fmt.Sprintf("/%s/graphql.json", client.pathPrefix), "graphql.json"},
+	}
+
+	for _, c := range cases {
+		if actual := client.endpoint(c.path); actual != c.expected {
+			t.Errorf("endpoint(%q) returned %q, expected %q", c.path, actual, c.expected)
+		}
+	}
+}
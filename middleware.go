@@ -0,0 +1,41 @@
+package goshopify
+
+import "net/http"
+
+// RoundTripFunc sends a request to Shopify and returns its response
+type RoundTripFunc func(*http.Request) (*http.Response, error)
+
+// Middleware wraps the RoundTripFunc sending the requests of a client, e.g.
+// to log, measure or mutate them:
+//
+//	client.Use(func(next goshopify.RoundTripFunc) goshopify.RoundTripFunc {
+//		return func(req *http.Request) (*http.Response, error) {
+//			start := time.Now()
+//			resp, err := next(req)
+//			metrics.Observe(req.URL.Path, time.Since(start))
+//			return resp, err
+//		}
+//	})
+//
+// Every attempt of a retried request goes through the middlewares.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// Use adds middlewares to every request the services of the client send to
+// Shopify, the first middleware added is the outermost. Use is not safe to
+// call while the client sends requests, add the middlewares before, e.g. with
+// WithMiddleware.
+func (c *Client) Use(middlewares ...Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+// roundTrip sends req through the middlewares of the client
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	next := c.Client.Do
+	if c.compressedResponses {
+		next = decompress(next)
+	}
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		next = c.middlewares[i](next)
+	}
+	return next(req)
+}
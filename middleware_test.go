@@ -0,0 +1,91 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestClientUse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var calls []string
+	tracing := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" "+req.URL.Path)
+				req.Header.Set("X-Trace", name)
+				return next(req)
+			}
+		}
+	}
+	client.Use(tracing("outer"), tracing("inner"))
+
+	var traced string
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shopify_payments/payouts.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			traced = req.Header.Get("X-Trace")
+			return httpmock.NewStringResponse(200, `{"payouts":[]}`), nil
+		})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/smart_collections/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"smart_collection":{"id":1}}`))
+
+	if _, err := client.Payouts.List(context.Background(), nil); err != nil {
+		t.Fatalf("Payouts.List returned error: %v", err)
+	}
+	if _, err := client.SmartCollection.Get(context.Background(), 1, nil); err != nil {
+		t.Fatalf("SmartCollection.Get returned error: %v", err)
+	}
+
+	expected := []string{
+		fmt.Sprintf("outer /%s/shopify_payments/payouts.json", client.pathPrefix),
+		fmt.Sprintf("inner /%s/shopify_payments/payouts.json", client.pathPrefix),
+		fmt.Sprintf("outer /%s/smart_collections/1.json", client.pathPrefix),
+		fmt.Sprintf("inner /%s/smart_collections/1.json", client.pathPrefix),
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("middlewares were called with %v, expected %v", calls, expected)
+	}
+	if traced != "inner" {
+		t.Errorf("request was sent with X-Trace %q, expected the header set by the inner middleware", traced)
+	}
+}
+
+func TestWithMiddlewareSeesRetries(t *testing.T) {
+	attempts := 0
+	counting := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			attempts++
+			return next(req)
+		}
+	}
+	testClient := MustNewClient(app, "fooshop", "abcd", WithRetry(3), WithMiddleware(counting))
+	httpmock.ActivateNonDefault(testClient.Client)
+	defer httpmock.DeactivateAndReset()
+
+	responses := 0
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1",
+		func(req *http.Request) (*http.Response, error) {
+			responses++
+			if responses == 1 {
+				return httpmock.NewStringResponse(http.StatusServiceUnavailable, ""), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, `{}`), nil
+		})
+
+	req, err := testClient.NewRequest(context.Background(), "GET", "foo/1", nil, nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	if err := testClient.Do(req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("middleware saw %d attempts, expected 2", attempts)
+	}
+}
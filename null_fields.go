@@ -0,0 +1,71 @@
+package goshopify
+
+import "encoding/json"
+
+// The NullFields of a resource list the JSON fields sent as null on create and
+// update. Most fields are tagged omitempty so an empty value is not sent and
+// cannot clear the field, e.g. to remove the description of a product:
+//
+//	product := goshopify.Product{Id: 1, NullFields: []string{"body_html"}}
+//	client.Product.Update(ctx, product)
+//
+// A field listed in NullFields is sent as null whatever its value.
+
+// marshalWithNullFields marshals v and sets the nullFields to null
+func marshalWithNullFields(v interface{}, nullFields []string) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(nullFields) == 0 {
+		return data, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for _, field := range nullFields {
+		fields[field] = json.RawMessage("null")
+	}
+	return json.Marshal(fields)
+}
+
+// MarshalJSON sends the NullFields of the product as null
+func (p Product) MarshalJSON() ([]byte, error) {
+	type alias Product
+	return marshalWithNullFields(alias(p), p.NullFields)
+}
+
+// MarshalJSON sends the NullFields of the variant as null
+func (v Variant) MarshalJSON() ([]byte, error) {
+	type alias Variant
+	return marshalWithNullFields(alias(v), v.NullFields)
+}
+
+// MarshalJSON sends the NullFields of the collection as null
+func (c CustomCollection) MarshalJSON() ([]byte, error) {
+	type alias CustomCollection
+	return marshalWithNullFields(alias(c), c.NullFields)
+}
+
+// MarshalJSON sends the NullFields of the collection as null
+func (c SmartCollection) MarshalJSON() ([]byte, error) {
+	type alias SmartCollection
+	return marshalWithNullFields(alias(c), c.NullFields)
+}
+
+// MarshalJSON sends the NullFields of the customer as null
+func (c Customer) MarshalJSON() ([]byte, error) {
+	type alias Customer
+	return marshalWithNullFields(alias(c), c.NullFields)
+}
+
+// MarshalJSON sends the NullFields of the order as null
+func (o Order) MarshalJSON() ([]byte, error) {
+	type alias Order
+	return marshalWithNullFields(alias(o), o.NullFields)
+}
+
+// MarshalJSON sends the NullFields of the draft order as null
+func (d DraftOrder) MarshalJSON() ([]byte, error) {
+	type alias DraftOrder
+	return marshalWithNullFields(alias(d), d.NullFields)
+}
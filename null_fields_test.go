@@ -0,0 +1,72 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestNullFieldsMarshal(t *testing.T) {
+	cases := []struct {
+		description string
+		resource    interface{}
+		expected    string
+	}{
+		{"without null fields", Product{Id: 1, Title: "Shirt"}, `{"id":1,"title":"Shirt","image":{}}`},
+		{"product", Product{Id: 1, NullFields: []string{"body_html", "template_suffix"}}, `{"body_html":null,"id":1,"image":{},"template_suffix":null}`},
+		{"variant", &Variant{Id: 2, NullFields: []string{"compare_at_price"}}, `{"compare_at_price":null,"id":2,"requires_shipping":false}`},
+		{"custom collection", CustomCollection{Id: 3, NullFields: []string{"body_html"}}, `{"body_html":null,"id":3,"image":{}}`},
+		{"smart collection", SmartCollection{Id: 4, NullFields: []string{"template_suffix"}}, `{"id":4,"image":{},"template_suffix":null}`},
+		{"customer", Customer{Id: 5, NullFields: []string{"note"}}, `{"email_marketing_consent":null,"id":5,"note":null,"sms_marketing_consent":null}`},
+		{"order", Order{Id: 6, NullFields: []string{"tags"}}, `{"id":6,"tags":null}`},
+		{"draft order", DraftOrder{Id: 7, NullFields: []string{"note"}}, `{"id":7,"note":null}`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			data, err := json.Marshal(c.resource)
+			if err != nil {
+				t.Fatalf("json.Marshal returned error: %v", err)
+			}
+
+			var actual, expected interface{}
+			json.Unmarshal(data, &actual)
+			json.Unmarshal([]byte(c.expected), &expected)
+			if !reflect.DeepEqual(actual, expected) {
+				t.Errorf("json.Marshal returned %s, expected %s", data, c.expected)
+			}
+		})
+	}
+}
+
+func TestProductUpdateNullFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent string
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			sent = string(body)
+			return httpmock.NewStringResponse(200, `{"product":{"id":1}}`), nil
+		})
+
+	_, err := client.Product.Update(context.Background(), Product{Id: 1, NullFields: []string{"body_html"}})
+	if err != nil {
+		t.Fatalf("Product.Update returned error: %v", err)
+	}
+
+	expected := `{"product":{"body_html":null,"id":1,"image":{}}}`
+	if sent != expected {
+		t.Errorf("Product.Update sent %s, expected %s", sent, expected)
+	}
+}
@@ -69,16 +69,31 @@ func (app App) GetAccessToken(ctx context.Context, shopName string, code string)
 	return token.Token, err
 }
 
+// Secrets returns the secrets accepted by the verification helpers, ApiSecret
+// followed by PreviousApiSecrets
+func (app App) Secrets() []string {
+	secrets := []string{}
+	for _, secret := range append([]string{app.ApiSecret}, app.PreviousApiSecrets...) {
+		if secret != "" {
+			secrets = append(secrets, secret)
+		}
+	}
+	return secrets
+}
+
 // Verify a message against a message HMAC
 func (app App) VerifyMessage(message, messageMAC string) bool {
-	mac := hmac.New(sha256.New, []byte(app.ApiSecret))
-	mac.Write([]byte(message))
-	expectedMAC := mac.Sum(nil)
-
 	// shopify HMAC is in hex so it needs to be decoded
 	actualMac, _ := hex.DecodeString(messageMAC)
 
-	return hmac.Equal(actualMac, expectedMAC)
+	for _, secret := range app.Secrets() {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(message))
+		if hmac.Equal(actualMac, mac.Sum(nil)) {
+			return true
+		}
+	}
+	return false
 }
 
 // Verifying URL callback parameters.
@@ -101,58 +116,77 @@ func (app App) VerifyWebhookRequest(httpRequest *http.Request) bool {
 	shopifySha256 := httpRequest.Header.Get(shopifyChecksumHeader)
 	actualMac := []byte(shopifySha256)
 
-	mac := hmac.New(sha256.New, []byte(app.ApiSecret))
 	requestBody, _ := ioutil.ReadAll(httpRequest.Body)
 	httpRequest.Body = ioutil.NopCloser(bytes.NewBuffer(requestBody))
-	mac.Write(requestBody)
-	macSum := mac.Sum(nil)
-	expectedMac := []byte(base64.StdEncoding.EncodeToString(macSum))
 
-	return hmac.Equal(actualMac, expectedMac)
+	for _, secret := range app.Secrets() {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(requestBody)
+		expectedMac := []byte(base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+		if hmac.Equal(actualMac, expectedMac) {
+			return true
+		}
+	}
+	return false
 }
 
 // Verifies a webhook http request, sent by Shopify.
 // The body of the request is still readable after invoking the method.
 // This method has more verbose error output which is useful for debugging.
 func (app App) VerifyWebhookRequestVerbose(httpRequest *http.Request) (bool, error) {
-	if app.ApiSecret == "" {
-		return false, errors.New("ApiSecret is empty")
+	_, err := app.VerifyWebhookRequestSecret(httpRequest)
+	return err == nil, err
+}
+
+// VerifyWebhookRequestSecret verifies a webhook http request like
+// VerifyWebhookRequestVerbose and returns the index in Secrets of the secret
+// it was signed with, e.g. to tell when Shopify stopped using a previous
+// secret. The index is -1 when the request is not verified.
+func (app App) VerifyWebhookRequestSecret(httpRequest *http.Request) (int, error) {
+	secrets := app.Secrets()
+	if len(secrets) == 0 {
+		return -1, errors.New("ApiSecret is empty")
 	}
 
 	shopifySha256 := httpRequest.Header.Get(shopifyChecksumHeader)
 	if shopifySha256 == "" {
-		return false, fmt.Errorf("header %s not set", shopifyChecksumHeader)
+		return -1, fmt.Errorf("header %s not set", shopifyChecksumHeader)
 	}
 
 	decodedReceivedHMAC, err := base64.StdEncoding.DecodeString(shopifySha256)
 	if err != nil {
-		return false, err
+		return -1, err
 	}
 	if len(decodedReceivedHMAC) != 32 {
-		return false, fmt.Errorf("received HMAC is not of length 32, it is of length %d", len(decodedReceivedHMAC))
+		return -1, fmt.Errorf("received HMAC is not of length 32, it is of length %d", len(decodedReceivedHMAC))
 	}
 
-	mac := hmac.New(sha256.New, []byte(app.ApiSecret))
 	requestBody, err := ioutil.ReadAll(httpRequest.Body)
 	if err != nil {
-		return false, err
+		return -1, err
 	}
 
 	httpRequest.Body = ioutil.NopCloser(bytes.NewBuffer(requestBody))
 	if len(requestBody) == 0 {
-		return false, errors.New("request body is empty")
+		return -1, errors.New("request body is empty")
 	}
 
-	// Sha256 write doesn't actually return an error
-	mac.Write(requestBody)
-
-	computedHMAC := mac.Sum(nil)
-	HMACSame := hmac.Equal(decodedReceivedHMAC, computedHMAC)
-	if !HMACSame {
-		return HMACSame, fmt.Errorf("expected hash %x does not equal %x", computedHMAC, decodedReceivedHMAC)
+	var expectedHMAC []byte
+	for i, secret := range secrets {
+		mac := hmac.New(sha256.New, []byte(secret))
+		// Sha256 write doesn't actually return an error
+		mac.Write(requestBody)
+
+		computedHMAC := mac.Sum(nil)
+		if hmac.Equal(decodedReceivedHMAC, computedHMAC) {
+			return i, nil
+		}
+		if i == 0 {
+			expectedHMAC = computedHMAC
+		}
 	}
 
-	return HMACSame, nil
+	return -1, fmt.Errorf("expected hash %x does not equal %x", expectedHMAC, decodedReceivedHMAC)
 }
 
 // Verifies an app proxy request, sent by Shopify.
@@ -172,7 +206,12 @@ func (app App) VerifySignature(u *url.URL) bool {
 
 	joined := strings.Join(keys, "")
 
-	return hmacSHA256([]byte(app.ApiSecret), []byte(joined), []byte(sig))
+	for _, secret := range app.Secrets() {
+		if hmacSHA256([]byte(secret), []byte(joined), []byte(sig)) {
+			return true
+		}
+	}
+	return false
 }
 
 func hmacSHA256(key, body, expected []byte) bool {
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/jarcoal/httpmock"
 )
@@ -274,3 +275,40 @@ func TestVerifyWebhookRequestVerbose(t *testing.T) {
 		t.Errorf("Expected error %s got %s", errors.New("test-error"), err)
 	}
 }
+
+func TestVerifyWebhookRequestSecretRotation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	rotating := App{ApiSecret: "new", PreviousApiSecrets: []string{"", "old"}}
+	if secrets := rotating.Secrets(); len(secrets) != 2 || secrets[0] != "new" || secrets[1] != "old" {
+		t.Errorf("App.Secrets returned %v", secrets)
+	}
+
+	cases := []struct {
+		secret   string
+		expected int
+	}{
+		{"new", 0},
+		{"old", 1},
+		{"other", -1},
+	}
+	for _, c := range cases {
+		req := newWebhookTestRequest(c.secret, `{"id":1}`, "a", time.Now())
+		index, err := rotating.VerifyWebhookRequestSecret(req)
+		if index != c.expected || (err == nil) != (c.expected >= 0) {
+			t.Errorf("App.VerifyWebhookRequestSecret with secret %s returned %d, %v, expected %d", c.secret, index, err, c.expected)
+		}
+
+		req = newWebhookTestRequest(c.secret, `{"id":1}`, "a", time.Now())
+		if valid := rotating.VerifyWebhookRequest(req); valid != (c.expected >= 0) {
+			t.Errorf("App.VerifyWebhookRequest with secret %s returned %v", c.secret, valid)
+		}
+	}
+
+	// the authorization url of the Shopify example is signed with "hush"
+	u, _ := url.Parse("http://example.com/callback?code=0907a61c0c8d55e99db179b68161bc00&hmac=4712bf92ffc2917d15a2f5a273e39f0116667419aa4b6ac0b3baaf26fa3c4d20&shop=some-shop.myshopify.com&signature=11813d1e7bbf4629edcda0628a3f7a20&timestamp=1337178173")
+	if ok, _ := (App{ApiSecret: "new", PreviousApiSecrets: []string{"hush"}}).VerifyAuthorizationURL(u); !ok {
+		t.Errorf("App.VerifyAuthorizationURL did not accept the previous secret")
+	}
+}
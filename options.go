@@ -1,18 +1,32 @@
 package goshopify
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Option is used to configure client with options
 type Option func(c *Client)
 
-// WithVersion optionally sets the api-version if the passed string is valid
+// WithVersion optionally sets the api-version, NewClient returns an error when
+// the version is neither "unstable" nor a quarterly release formatted as
+// YYYY-MM. See ApiVersion.
 func WithVersion(apiVersion string) Option {
 	return func(c *Client) {
 		pathPrefix := defaultApiPathPrefix
-		if len(apiVersion) > 0 && (apiVersionRegex.MatchString(apiVersion) || apiVersion == UnstableApiVersion) {
+		if len(apiVersion) > 0 {
+			if !ApiVersion(apiVersion).Valid() {
+				c.optionErr = fmt.Errorf("invalid api version %q, expected a quarterly YYYY-MM release or %s", apiVersion, UnstableApiVersion)
+				return
+			}
 			pathPrefix = fmt.Sprintf("admin/api/%s", apiVersion)
 		}
 		c.apiVersion = apiVersion
@@ -20,6 +34,24 @@ func WithVersion(apiVersion string) Option {
 	}
 }
 
+// WithBaseURL sends the requests to baseURL instead of the myshopify domain
+// of the shop, e.g. an api gateway or a local mock server. The api paths are
+// appended to the path of baseURL. The shop still identifies the client in
+// rate limits, guards, metrics and traces.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		u, err := url.Parse(baseURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			c.optionErr = fmt.Errorf("invalid base url %q, expected an absolute url", baseURL)
+			return
+		}
+		if !strings.HasSuffix(u.Path, "/") {
+			u.Path += "/"
+		}
+		c.baseURL = u
+	}
+}
+
 // WithRetry sets the number of times a request will be retried if a rate limit or service unavailable error is returned.
 // Rate limiting can be either REST API limits or GraphQL Cost limits
 func WithRetry(retries int) Option {
@@ -28,6 +60,48 @@ func WithRetry(retries int) Option {
 	}
 }
 
+// WithRetryPolicy retries requests failing with a rate limit or a transient
+// server error with exponential backoff and jitter, honoring the Retry-After
+// header, e.g. WithRetryPolicy(DefaultRetryPolicy()). A negative MaxRetries,
+// a zero MinBackoff and MaxBackoff and a nil Statuses take their default
+// value, a zero MaxRetries disables retries and a zero Jitter disables
+// proportional jitter. GraphQL throttling is retried up to MaxRetries times
+// too, within the Budget and calling OnRetry.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		defaults := DefaultRetryPolicy()
+		if policy.MaxRetries < 0 {
+			policy.MaxRetries = defaults.MaxRetries
+		}
+		if policy.MinBackoff == 0 {
+			policy.MinBackoff = defaults.MinBackoff
+		}
+		if policy.MaxBackoff == 0 {
+			policy.MaxBackoff = defaults.MaxBackoff
+		}
+		if policy.Statuses == nil {
+			policy.Statuses = defaults.Statuses
+		}
+		c.retryPolicy = &policy
+		c.retries = policy.MaxRetries + 1
+	}
+}
+
+// WithSlogLogger logs with logger, every attempt of a request is logged with
+// its method, url, status, attempt number and duration, failed attempts as
+// warnings. Request and response bodies are logged at debug level without the
+// access token header and, unless WithRedactor sets another redactor, without
+// the DefaultRedactedFields.
+func WithSlogLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.slog = logger
+		c.log = slogLogger{logger: logger}
+		if c.redactor == nil {
+			c.redactor = NewFieldRedactor()
+		}
+	}
+}
+
 func WithLogger(logger LeveledLoggerInterface) Option {
 	return func(c *Client) {
 		c.log = logger
@@ -40,3 +114,222 @@ func WithHTTPClient(client *http.Client) Option {
 		c.Client = client
 	}
 }
+
+// UnauthorizedHandler is called with the shop domain and the response error
+// when shopify rejects the access token of a request
+type UnauthorizedHandler func(ctx context.Context, shop string, err error)
+
+// WithUnauthorizedHandler sets a handler invoked on 401 or "Invalid API key or
+// access token" responses, e.g. to mark a shop as uninstalled and stop
+// queuing work for it. The request error is still returned to the caller.
+func WithUnauthorizedHandler(handler UnauthorizedHandler) Option {
+	return func(c *Client) {
+		c.onUnauthorized = handler
+	}
+}
+
+// WithCacheStore sets the store used for cached shop state and the buckets of
+// the LeakyBucket of a ClientPool, e.g. a redisstore.Store shared by all
+// clients of a cluster
+func WithCacheStore(store CacheStore) Option {
+	return func(c *Client) {
+		c.cache = store
+	}
+}
+
+// WithUnknownFieldCapture keeps the JSON fields of a response which the
+// resource structs do not declare in their UnknownFields map, so fields added
+// by Shopify are not dropped before the package supports them
+func WithUnknownFieldCapture() Option {
+	return func(c *Client) {
+		c.captureUnknownFields = true
+	}
+}
+
+// WithDecodeHook registers a hook called with every T decoded from a response,
+// at any depth, and the raw JSON it was decoded from. Hooks run in the order
+// they are registered, e.g. to normalize values some stores return in a
+// locale-specific format:
+//
+//	goshopify.WithDecodeHook(func(v *goshopify.Variant, raw json.RawMessage) error {
+//		v.Barcode = strings.TrimSpace(v.Barcode)
+//		return nil
+//	})
+//
+// An error returned by a hook is returned by the request.
+func WithDecodeHook[T any](hook func(resource *T, raw json.RawMessage) error) Option {
+	return func(c *Client) {
+		if c.decodeHooks == nil {
+			c.decodeHooks = map[reflect.Type][]decodeHook{}
+		}
+		t := reflect.TypeOf((*T)(nil)).Elem()
+		c.decodeHooks[t] = append(c.decodeHooks[t], func(v reflect.Value, raw json.RawMessage) error {
+			return hook(v.Interface().(*T), raw)
+		})
+	}
+}
+
+// WithProductionGuard refuses requests which may mutate a shop whose domain
+// does not match one of patterns, e.g. "*-dev.myshopify.com", so integration
+// tests cannot write to a live store. Patterns use the path.Match syntax and
+// every mutating request is refused when none are given. Refused requests
+// return a GuardError matching ErrProductionGuard.
+func WithProductionGuard(patterns ...string) Option {
+	return func(c *Client) {
+		c.productionGuard = append([]string{}, patterns...)
+	}
+}
+
+// WithReadOnly refuses every request which may mutate the shop, including
+// graphql mutations, whatever the scopes of the access token. Refused
+// requests return a GuardError matching ErrReadOnly.
+func WithReadOnly() Option {
+	return func(c *Client) {
+		c.readOnly = true
+	}
+}
+
+// WithRedactor sets the Redactor applied to request urls and bodies before
+// they are logged, e.g. NewFieldRedactor() to keep customer PII and tokens out
+// of debug logs. The requests sent to Shopify are not changed.
+func WithRedactor(redactor Redactor) Option {
+	return func(c *Client) {
+		c.redactor = redactor
+	}
+}
+
+// WithGraphQLFallbackOnNotFound makes Product.Get, Order.Get and Customer.Get
+// fetch the resource with graphql when the REST endpoint responds 404 or 410,
+// e.g. once it is removed from the api version, and return it as the usual
+// struct. Only the fields graphql and REST have in common are set and the
+// REST options are ignored. The REST error is returned when graphql does not
+// find the resource either. Fields missing from a REST response found are not
+// fetched with graphql, except the variants REST truncates, which Product.Get
+// always pages with graphql.
+func WithGraphQLFallbackOnNotFound() Option {
+	return func(c *Client) {
+		c.graphQLFallbackOnNotFound = true
+	}
+}
+
+// WithEnqueuer hands every request which may mutate the shop, including
+// graphql mutations, to enqueuer instead of sending it, so writes go through
+// a durable queue of the app. These requests return ErrEnqueued once the job
+// is queued and the worker sends the jobs with Client.Replay.
+func WithEnqueuer(enqueuer Enqueuer) Option {
+	return func(c *Client) {
+		c.enqueuer = enqueuer
+	}
+}
+
+// WithRateLimiter throttles the REST requests of the client with limiter
+// before they are sent, e.g. a NewLeakyBucket(store) shared by every client of
+// the app so concurrent jobs for the same shop do not overflow its bucket.
+func WithRateLimiter(limiter RateLimiter) Option {
+	return func(c *Client) {
+		c.rateLimiter = limiter
+	}
+}
+
+// WithRateLimitCallback calls callback when the utilization of the REST or
+// GraphQL bucket of the shop crosses one of thresholds, from 0 to 1, or of
+// the DefaultRateLimitThresholds when none are given, e.g. to shed
+// non-critical work before being throttled. The callback is called from the
+// goroutine sending the request.
+func WithRateLimitCallback(callback func(state BucketState), thresholds ...float64) Option {
+	return func(c *Client) {
+		c.rateLimitAnnouncer = newRateLimitAnnouncer(callback, thresholds)
+	}
+}
+
+// WithMiddleware adds middlewares to the requests of the client, see
+// Client.Use
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *Client) {
+		c.Use(middlewares...)
+	}
+}
+
+// WithTracerProvider starts an OpenTelemetry span from provider for every
+// request of the client, see Client.Use to trace the requests differently
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *Client) {
+		c.tracer = provider.Tracer(tracerName)
+	}
+}
+
+// WithMetricsCollector reports the requests of the client to collector
+func WithMetricsCollector(collector MetricsCollector) Option {
+	return func(c *Client) {
+		c.metrics = collector
+	}
+}
+
+// WithUpdatedAtPrecondition fetches the updated_at of a resource before an
+// update sent with its updated_at, e.g. a product fetched then edited, and
+// returns a ConflictError instead of sending the update when the resource was
+// updated since. Updates without updated_at are sent as is.
+func WithUpdatedAtPrecondition() Option {
+	return func(c *Client) {
+		c.updatedAtPrecondition = true
+	}
+}
+
+// WithIdempotencyKeys sets a random Idempotency-Key header on the POST and
+// PUT requests without one, kept by their retries and by the jobs of an
+// Enqueuer. A single call sets its own key with RequestIdempotencyKey. The
+// header is only sent, the REST Admin API does not document deduplicating
+// requests on it, so retried writes may still create duplicates.
+func WithIdempotencyKeys() Option {
+	return func(c *Client) {
+		c.idempotencyKeys = true
+	}
+}
+
+// WithCompressedResponses sends requests with an Accept-Encoding of
+// AcceptEncoding, so shopify may deflate responses as well as gzip them, and
+// decompresses the responses in those encodings before the middlewares. The
+// http transport already negotiates gzip on its own when it is not set.
+func WithCompressedResponses() Option {
+	return func(c *Client) {
+		c.compressedResponses = true
+	}
+}
+
+// WithGzipRequests gzips the request bodies of at least minSize bytes, e.g.
+// products with hundreds of variants, and sends them with a Content-Encoding
+// header. Only set it for the endpoints accepting compressed bodies.
+func WithGzipRequests(minSize int) Option {
+	return func(c *Client) {
+		c.gzipRequestsMinSize = minSize
+		if minSize < 1 {
+			c.gzipRequestsMinSize = 1
+		}
+	}
+}
+
+// WithHTTPDump records every attempt of the requests of the client and its
+// response to dumper, e.g. NewHTTPDumpWriter(os.Stderr) or a HARRecorder.
+// Credentials and the fields of the redactor are redacted. A single call is
+// recorded with RequestHTTPDump.
+func WithHTTPDump(dumper HTTPDumper) Option {
+	return func(c *Client) {
+		c.httpDumper = dumper
+	}
+}
+
+// WithStorefrontAccessToken sets the token the Storefront service sends to
+// the storefront api of the shop
+func WithStorefrontAccessToken(token string) Option {
+	return func(c *Client) {
+		c.storefrontToken = token
+	}
+}
+
+// WithMockShop points the Storefront service at the storefront api of
+// shopify's public mock shop, which needs no credentials, see NewMockShopClient
+func WithMockShop() Option {
+	return func(c *Client) {
+		c.storefrontURL = MockShopURL
+	}
+}
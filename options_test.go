@@ -1,10 +1,15 @@
 package goshopify
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
 	"testing"
 	"time"
+
+	"github.com/jarcoal/httpmock"
 )
 
 func TestWithVersion(t *testing.T) {
@@ -32,10 +37,47 @@ func TestWithoutVersionInInitiation(t *testing.T) {
 }
 
 func TestWithVersionInvalidVersion(t *testing.T) {
-	c := MustNewClient(app, "fooshop", "abcd", WithVersion("9999-99b"))
-	expected := "admin"
-	if c.pathPrefix != expected {
-		t.Errorf("WithVersion client.pathPrefix = %s, expected %s", c.pathPrefix, expected)
+	_, err := NewClient(app, "fooshop", "abcd", WithVersion("9999-99b"))
+	expected := `invalid api version "9999-99b", expected a quarterly YYYY-MM release or unstable`
+	if err == nil || err.Error() != expected {
+		t.Errorf("NewClient returned error %v, expected %s", err, expected)
+	}
+}
+
+func TestWithVersionNotReleased(t *testing.T) {
+	for _, version := range []string{"2024-05", "2024-13"} {
+		_, err := NewClient(app, "fooshop", "abcd", WithVersion(version))
+		if err == nil {
+			t.Errorf("NewClient with version %s returned no error", version)
+		}
+	}
+}
+
+func TestWithBaseURL(t *testing.T) {
+	setup()
+	defer teardown()
+	WithBaseURL("http://localhost:8080/shopify/fooshop")(client)
+	WithReadOnly()(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("http://localhost:8080/shopify/fooshop/%s/shop.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"shop":{"id":1}}`))
+
+	if _, err := client.Shop.Get(context.Background(), nil); err != nil {
+		t.Fatalf("Shop.Get returned error: %v", err)
+	}
+
+	var guardErr GuardError
+	_, err := client.Product.Create(context.Background(), Product{Title: "foo"})
+	if !errors.As(err, &guardErr) || guardErr.Shop != "fooshop.myshopify.com" {
+		t.Errorf("Product.Create returned %v, expected a GuardError of fooshop.myshopify.com", err)
+	}
+}
+
+func TestWithBaseURLInvalid(t *testing.T) {
+	_, err := NewClient(app, "fooshop", "abcd", WithBaseURL("localhost:8080"))
+	expected := `invalid base url "localhost:8080", expected an absolute url`
+	if err == nil || err.Error() != expected {
+		t.Errorf("NewClient returned error %v, expected %s", err, expected)
 	}
 }
 
@@ -72,3 +114,62 @@ func TestWithHTTPClient(t *testing.T) {
 		t.Errorf("WithVersion client.Client = %s, expected %s", c.Client.Timeout, expected)
 	}
 }
+
+func TestWithUnauthorizedHandler(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var calledShop string
+	var calledErr error
+	WithUnauthorizedHandler(func(_ context.Context, shop string, err error) {
+		calledShop = shop
+		calledErr = err
+	})(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		httpmock.NewStringResponder(401, `{"errors":"[API] Invalid API key or access token (unrecognized login or wrong password)"}`))
+
+	_, err := client.Shop.Get(context.Background(), nil)
+	if err == nil {
+		t.Fatal("Shop.Get expected error")
+	}
+
+	expectedShop := "fooshop.myshopify.com"
+	if calledShop != expectedShop {
+		t.Errorf("WithUnauthorizedHandler handler called with shop %s, expected %s", calledShop, expectedShop)
+	}
+	if !reflect.DeepEqual(calledErr, err) {
+		t.Errorf("WithUnauthorizedHandler handler called with error %v, expected %v", calledErr, err)
+	}
+}
+
+func TestWithUnauthorizedHandlerNotCalled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	called := false
+	WithUnauthorizedHandler(func(context.Context, string, error) {
+		called = true
+	})(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		httpmock.NewStringResponder(404, `{"errors":"Not Found"}`))
+
+	_, _ = client.Shop.Get(context.Background(), nil)
+	if called {
+		t.Error("WithUnauthorizedHandler handler should not be called on 404")
+	}
+}
+
+func TestWithCacheStore(t *testing.T) {
+	store := NewMemoryCacheStore()
+	c := MustNewClient(app, "fooshop", "abcd", WithCacheStore(store))
+	if c.cache != store {
+		t.Errorf("WithCacheStore client.cache = %v, expected %v", c.cache, store)
+	}
+
+	c = MustNewClient(app, "fooshop", "abcd")
+	if _, ok := c.cache.(*MemoryCacheStore); !ok {
+		t.Errorf("NewClient client.cache = %T, expected *MemoryCacheStore", c.cache)
+	}
+}
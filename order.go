@@ -24,11 +24,15 @@ type OrderService interface {
 	Count(context.Context, interface{}) (int, error)
 	Get(context.Context, uint64, interface{}) (*Order, error)
 	Create(context.Context, Order) (*Order, error)
+	CreateWithOptions(context.Context, Order, OrderCreateOptions) (*Order, error)
 	Update(context.Context, Order) (*Order, error)
 	Cancel(context.Context, uint64, interface{}) (*Order, error)
 	Close(context.Context, uint64) (*Order, error)
 	Open(context.Context, uint64) (*Order, error)
 	Delete(context.Context, uint64) error
+	MarkAsPaid(context.Context, uint64) (*Transaction, error)
+	OpenClose(context.Context, uint64, bool) (*Order, error)
+	Scope(uint64) *OrderScope
 
 	// MetafieldsService used for Order resource to communicate with Metafields resource
 	MetafieldsService
@@ -251,6 +255,27 @@ const (
 	OrderInventoryBehaviourDecrementObeyingPolicy orderInventoryBehaviour = "decrement_obeying_policy"
 )
 
+// OrderCreateOptions represents the options of the create order endpoint
+// which are sent along with the order and not returned by Shopify
+type OrderCreateOptions struct {
+	InventoryBehaviour     orderInventoryBehaviour
+	SendReceipt            bool
+	SendFulfillmentReceipt bool
+}
+
+// Validate checks the inventory behaviour is one of the
+// OrderInventoryBehaviour constants
+func (o OrderCreateOptions) Validate() error {
+	switch o.InventoryBehaviour {
+	case "", OrderInventoryBehaviourBypass, OrderInventoryBehaviourDecrementIgnoringPolicy, OrderInventoryBehaviourDecrementObeyingPolicy:
+		return nil
+	}
+	return ValidationError{
+		Field:   "inventory_behaviour",
+		Message: fmt.Sprintf("unknown value %q", o.InventoryBehaviour),
+	}
+}
+
 // Order represents a Shopify order
 type Order struct {
 	Id                       uint64                  `json:"id,omitempty"`
@@ -266,6 +291,7 @@ type Order struct {
 	ShippingAddress          *Address                `json:"shipping_address,omitempty"`
 	Currency                 string                  `json:"currency,omitempty"`
 	TotalPrice               *decimal.Decimal        `json:"total_price,omitempty"`
+	TotalOutstanding         *decimal.Decimal        `json:"total_outstanding,omitempty"`
 	TotalPriceSet            *AmountSet              `json:"total_price_set,omitempty"`
 	TotalShippingPriceSet    *AmountSet              `json:"total_shipping_price_set,omitempty"`
 	CurrentTotalPrice        *decimal.Decimal        `json:"current_total_price,omitempty"`
@@ -301,7 +327,7 @@ type Order struct {
 	LineItems                []LineItem              `json:"line_items,omitempty"`
 	ShippingLines            []ShippingLines         `json:"shipping_lines,omitempty"`
 	Transactions             []Transaction           `json:"transactions,omitempty"`
-	AppId                    int                     `json:"app_id,omitempty"`
+	AppId                    uint64                  `json:"app_id,omitempty"`
 	CustomerLocale           string                  `json:"customer_locale,omitempty"`
 	LandingSite              string                  `json:"landing_site,omitempty"`
 	ReferringSite            string                  `json:"referring_site,omitempty"`
@@ -330,6 +356,8 @@ type Order struct {
 	SendFulfillmentReceipt   bool                    `json:"send_fulfillment_receipt,omitempty"`
 	PresentmentCurrency      string                  `json:"presentment_currency,omitempty"`
 	InventoryBehaviour       orderInventoryBehaviour `json:"inventory_behaviour,omitempty"`
+	NullFields               []string                `json:"-"`
+	UnknownJSONFields
 }
 
 type Address struct {
@@ -402,6 +430,7 @@ type LineItem struct {
 
 	AppliedDiscount     *AppliedDiscount      `json:"applied_discount,omitempty"`
 	DiscountAllocations []DiscountAllocations `json:"discount_allocations,omitempty"`
+	UnknownJSONFields
 }
 
 type DiscountAllocations struct {
@@ -546,10 +575,10 @@ type Transaction struct {
 	Test           bool             `json:"test,omitempty"`
 	Authorization  string           `json:"authorization,omitempty"`
 	Currency       string           `json:"currency,omitempty"`
-	LocationId     *int64           `json:"location_id,omitempty"`
-	UserId         *int64           `json:"user_id,omitempty"`
-	ParentId       *int64           `json:"parent_id,omitempty"`
-	DeviceId       *int64           `json:"device_id,omitempty"`
+	LocationId     *uint64          `json:"location_id,omitempty"`
+	UserId         *uint64          `json:"user_id,omitempty"`
+	ParentId       *uint64          `json:"parent_id,omitempty"`
+	DeviceId       *uint64          `json:"device_id,omitempty"`
 	ErrorCode      string           `json:"error_code,omitempty"`
 	SourceName     string           `json:"source_name,omitempty"`
 	Source         string           `json:"source,omitempty"`
@@ -571,7 +600,10 @@ type Refund struct {
 	CreatedAt        *time.Time        `json:"created_at,omitempty"`
 	Note             string            `json:"note,omitempty"`
 	Restock          bool              `json:"restock,omitempty"`
+	Notify           bool              `json:"notify,omitempty"`
+	Currency         string            `json:"currency,omitempty"`
 	UserId           uint64            `json:"user_id,omitempty"`
+	Shipping         *RefundShipping   `json:"shipping,omitempty"`
 	RefundLineItems  []RefundLineItem  `json:"refund_line_items,omitempty"`
 	Transactions     []Transaction     `json:"transactions,omitempty"`
 	OrderAdjustments []OrderAdjustment `json:"order_adjustments,omitempty"`
@@ -597,12 +629,20 @@ const (
 )
 
 type RefundLineItem struct {
-	Id         uint64           `json:"id,omitempty"`
-	Quantity   int              `json:"quantity,omitempty"`
-	LineItemId uint64           `json:"line_item_id,omitempty"`
-	LineItem   *LineItem        `json:"line_item,omitempty"`
-	Subtotal   *decimal.Decimal `json:"subtotal,omitempty"`
-	TotalTax   *decimal.Decimal `json:"total_tax,omitempty"`
+	Id          uint64            `json:"id,omitempty"`
+	Quantity    int               `json:"quantity,omitempty"`
+	LineItemId  uint64            `json:"line_item_id,omitempty"`
+	LineItem    *LineItem         `json:"line_item,omitempty"`
+	RestockType RefundRestockType `json:"restock_type,omitempty"`
+	LocationId  uint64            `json:"location_id,omitempty"`
+	Subtotal    *decimal.Decimal  `json:"subtotal,omitempty"`
+	TotalTax    *decimal.Decimal  `json:"total_tax,omitempty"`
+}
+
+// RefundShipping represents the shipping refunded, either in full or an amount
+type RefundShipping struct {
+	FullRefund bool             `json:"full_refund,omitempty"`
+	Amount     *decimal.Decimal `json:"amount,omitempty"`
 }
 
 // List orders
@@ -660,6 +700,9 @@ func (s *OrderServiceOp) Get(ctx context.Context, orderId uint64, options interf
 	path := fmt.Sprintf("%s/%d.json", ordersBasePath, orderId)
 	resource := new(OrderResource)
 	err := s.client.Get(ctx, path, resource, options)
+	if s.client.needsGraphQLFallback(err) {
+		return s.getFromGraphQL(ctx, orderId, err)
+	}
 	return resource.Order, err
 }
 
@@ -672,6 +715,18 @@ func (s *OrderServiceOp) Create(ctx context.Context, order Order) (*Order, error
 	return resource.Order, err
 }
 
+// CreateWithOptions creates an order with the inventory and receipt options
+// set, overriding those of the order
+func (s *OrderServiceOp) CreateWithOptions(ctx context.Context, order Order, options OrderCreateOptions) (*Order, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	order.InventoryBehaviour = options.InventoryBehaviour
+	order.SendReceipt = options.SendReceipt
+	order.SendFulfillmentReceipt = options.SendFulfillmentReceipt
+	return s.Create(ctx, order)
+}
+
 // Update order
 func (s *OrderServiceOp) Update(ctx context.Context, order Order) (*Order, error) {
 	path := fmt.Sprintf("%s/%d.json", ordersBasePath, order.Id)
@@ -718,6 +773,12 @@ func (s *OrderServiceOp) ListMetafields(ctx context.Context, orderId uint64, opt
 	return metafieldService.List(ctx, options)
 }
 
+// ListMetafieldsByNamespace lists all metafields of a namespace for an order
+func (s *OrderServiceOp) ListMetafieldsByNamespace(ctx context.Context, orderId uint64, namespace string) ([]Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersResourceName, resourceId: orderId}
+	return metafieldService.ListByNamespace(ctx, namespace)
+}
+
 // Count metafields for an order
 func (s *OrderServiceOp) CountMetafields(ctx context.Context, orderId uint64, options interface{}) (int, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersResourceName, resourceId: orderId}
@@ -0,0 +1,85 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+)
+
+// MarkAsPaid records the outstanding amount of an order as paid, like the
+// "Mark as paid" button of the admin. An authorized payment is captured, any
+// other unpaid order gets an external sale transaction. The created
+// transaction is returned, nil when the order is already paid.
+func (s *OrderServiceOp) MarkAsPaid(ctx context.Context, orderId uint64) (*Transaction, error) {
+	order, err := s.Get(ctx, orderId, struct {
+		Fields string `url:"fields"`
+	}{"id,financial_status,total_outstanding,total_price,currency"})
+	if err != nil {
+		return nil, err
+	}
+
+	switch order.FinancialStatus {
+	case OrderFinancialStatusPaid, OrderFinancialStatusPartiallyRefunded, OrderFinancialStatusRefunded:
+		return nil, nil
+	case OrderFinancialStatusVoided:
+		return nil, ValidationError{Field: "financial_status", Message: fmt.Sprintf("order %d is voided", orderId)}
+	}
+
+	transaction := Transaction{
+		Kind:     "sale",
+		Source:   "external",
+		Amount:   order.TotalOutstanding,
+		Currency: order.Currency,
+	}
+	if transaction.Amount == nil {
+		transaction.Amount = order.TotalPrice
+	}
+
+	if order.FinancialStatus == OrderFinancialStatusAuthorized {
+		transactions, err := s.client.Transaction.List(ctx, orderId, nil)
+		if err != nil {
+			return nil, err
+		}
+		authorization := lastAuthorization(transactions)
+		if authorization == nil {
+			return nil, ValidationError{Field: "transactions", Message: fmt.Sprintf("order %d has no successful authorization", orderId)}
+		}
+		transaction = Transaction{
+			Kind:     "capture",
+			ParentId: &authorization.Id,
+			Amount:   transaction.Amount,
+			Currency: order.Currency,
+		}
+	}
+
+	return s.client.Transaction.Create(ctx, orderId, transaction)
+}
+
+// lastAuthorization returns the latest successful authorization
+func lastAuthorization(transactions []Transaction) *Transaction {
+	for i := len(transactions) - 1; i >= 0; i-- {
+		if transactions[i].Kind == "authorization" && transactions[i].Status == "success" {
+			return &transactions[i]
+		}
+	}
+	return nil
+}
+
+// OpenClose closes or reopens an order unless it already is in that state,
+// so retried jobs do not fail on orders they closed before
+func (s *OrderServiceOp) OpenClose(ctx context.Context, orderId uint64, closed bool) (*Order, error) {
+	order, err := s.Get(ctx, orderId, struct {
+		Fields string `url:"fields"`
+	}{"id,closed_at"})
+	if err != nil {
+		return nil, err
+	}
+
+	isClosed := order.ClosedAt != nil
+	switch {
+	case closed && !isClosed:
+		return s.Close(ctx, orderId)
+	case !closed && isClosed:
+		return s.Open(ctx, orderId)
+	}
+	return order, nil
+}
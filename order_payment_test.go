@@ -0,0 +1,140 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+// registerMarkAsPaidResponders serves the order and its transactions and
+// records the transactions created
+func registerMarkAsPaidResponders(order string, transactions string, created *[]Transaction) {
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/123.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, order))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/123/transactions.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, transactions))
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/123/transactions.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			resource := TransactionResource{}
+			if err := json.NewDecoder(req.Body).Decode(&resource); err != nil {
+				return nil, err
+			}
+			*created = append(*created, *resource.Transaction)
+			return httpmock.NewJsonResponse(201, resource)
+		})
+}
+
+func TestOrderMarkAsPaid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	created := []Transaction{}
+	registerMarkAsPaidResponders(
+		`{"order":{"id":123,"financial_status":"pending","total_outstanding":"25.50","total_price":"30.00","currency":"EUR"}}`,
+		`{"transactions":[]}`,
+		&created,
+	)
+
+	transaction, err := client.Order.MarkAsPaid(context.Background(), 123)
+	if err != nil {
+		t.Fatalf("Order.MarkAsPaid returned error: %v", err)
+	}
+	if transaction == nil || len(created) != 1 {
+		t.Fatalf("Order.MarkAsPaid created %d transactions, expected 1", len(created))
+	}
+	sent := created[0]
+	if sent.Kind != "sale" || sent.Source != "external" || sent.Currency != "EUR" || !sent.Amount.Equal(decimal.RequireFromString("25.50")) {
+		t.Errorf("Order.MarkAsPaid sent %+v, expected an external sale of 25.50 EUR", sent)
+	}
+}
+
+func TestOrderMarkAsPaidCapturesAuthorization(t *testing.T) {
+	setup()
+	defer teardown()
+
+	created := []Transaction{}
+	registerMarkAsPaidResponders(
+		`{"order":{"id":123,"financial_status":"authorized","total_outstanding":"30.00","currency":"EUR"}}`,
+		`{"transactions":[
+			{"id":1,"kind":"authorization","status":"failure"},
+			{"id":2,"kind":"authorization","status":"success"}
+		]}`,
+		&created,
+	)
+
+	_, err := client.Order.MarkAsPaid(context.Background(), 123)
+	if err != nil {
+		t.Fatalf("Order.MarkAsPaid returned error: %v", err)
+	}
+	if len(created) != 1 {
+		t.Fatalf("Order.MarkAsPaid created %d transactions, expected 1", len(created))
+	}
+	sent := created[0]
+	if sent.Kind != "capture" || sent.ParentId == nil || *sent.ParentId != 2 || !sent.Amount.Equal(decimal.RequireFromString("30")) {
+		t.Errorf("Order.MarkAsPaid sent %+v, expected a capture of authorization 2", sent)
+	}
+}
+
+func TestOrderMarkAsPaidAlreadyPaid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	created := []Transaction{}
+	registerMarkAsPaidResponders(`{"order":{"id":123,"financial_status":"paid"}}`, `{"transactions":[]}`, &created)
+
+	transaction, err := client.Order.MarkAsPaid(context.Background(), 123)
+	if err != nil || transaction != nil || len(created) != 0 {
+		t.Errorf("Order.MarkAsPaid returned %+v, %v and created %d transactions, expected nothing", transaction, err, len(created))
+	}
+
+	registerMarkAsPaidResponders(`{"order":{"id":123,"financial_status":"voided"}}`, `{"transactions":[]}`, &created)
+	_, err = client.Order.MarkAsPaid(context.Background(), 123)
+	var validationError ValidationError
+	if !errors.As(err, &validationError) {
+		t.Errorf("Order.MarkAsPaid returned %v for a voided order, expected a ValidationError", err)
+	}
+}
+
+func TestOrderOpenClose(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cases := []struct {
+		order    string
+		closed   bool
+		expected string
+	}{
+		{`{"order":{"id":123}}`, true, "close"},
+		{`{"order":{"id":123}}`, false, ""},
+		{`{"order":{"id":123,"closed_at":"2024-01-02T10:00:00Z"}}`, true, ""},
+		{`{"order":{"id":123,"closed_at":"2024-01-02T10:00:00Z"}}`, false, "open"},
+	}
+
+	for _, c := range cases {
+		var called string
+		httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/123.json", client.pathPrefix),
+			httpmock.NewStringResponder(200, c.order))
+		for _, action := range []string{"close", "open"} {
+			action := action
+			httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/123/%s.json", client.pathPrefix, action),
+				func(req *http.Request) (*http.Response, error) {
+					called = action
+					return httpmock.NewStringResponse(200, `{"order":{"id":123}}`), nil
+				})
+		}
+
+		order, err := client.Order.OpenClose(context.Background(), 123, c.closed)
+		if err != nil || order == nil || order.Id != 123 {
+			t.Errorf("Order.OpenClose(%v) returned %+v, %v", c.closed, order, err)
+		}
+		if called != c.expected {
+			t.Errorf("Order.OpenClose(%v) of %s called %q, expected %q", c.closed, c.order, called, c.expected)
+		}
+	}
+}
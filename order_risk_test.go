@@ -19,7 +19,7 @@ func TestOrderRiskListError(t *testing.T) {
 	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/risks.json", client.pathPrefix),
 		httpmock.NewStringResponder(500, ""))
 
-	expectedErrMessage := "Unknown Error"
+	expectedErrMessage := "500 Internal Server Error"
 
 	orders, err := client.OrderRisk.List(context.Background(), 450789469, nil)
 	if orders != nil {
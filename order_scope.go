@@ -0,0 +1,139 @@
+package goshopify
+
+import "context"
+
+// OrderScope binds the order sub-resources to a single order so the order id
+// does not need to be passed to every call, e.g.
+//
+//	order := client.Order.Scope(orderId)
+//	risks, err := order.Risks().List(ctx, nil)
+type OrderScope struct {
+	client  *Client
+	orderId uint64
+}
+
+// Scope returns an OrderScope for the given order
+func (s *OrderServiceOp) Scope(orderId uint64) *OrderScope {
+	return &OrderScope{client: s.client, orderId: orderId}
+}
+
+// Id returns the id of the scoped order
+func (o *OrderScope) Id() uint64 {
+	return o.orderId
+}
+
+// Fulfillments returns a FulfillmentService bound to the order
+func (o *OrderScope) Fulfillments() FulfillmentService {
+	return &FulfillmentServiceOp{client: o.client, resource: ordersResourceName, resourceId: o.orderId}
+}
+
+// Metafields returns a MetafieldService bound to the order
+func (o *OrderScope) Metafields() MetafieldService {
+	return &MetafieldServiceOp{client: o.client, resource: ordersResourceName, resourceId: o.orderId}
+}
+
+// Risks returns the order risks methods bound to the order
+func (o *OrderScope) Risks() *OrderScopeRisks {
+	return &OrderScopeRisks{service: o.client.OrderRisk, orderId: o.orderId}
+}
+
+// Transactions returns the transaction methods bound to the order
+func (o *OrderScope) Transactions() *OrderScopeTransactions {
+	return &OrderScopeTransactions{service: o.client.Transaction, orderId: o.orderId}
+}
+
+// Refunds returns the refund methods bound to the order
+func (o *OrderScope) Refunds() *OrderScopeRefunds {
+	return &OrderScopeRefunds{service: o.client.Refund, orderId: o.orderId}
+}
+
+// OrderScopeRisks forwards to the OrderRiskService of the client for a
+// single order
+type OrderScopeRisks struct {
+	service OrderRiskService
+	orderId uint64
+}
+
+// List risks of the order
+func (r *OrderScopeRisks) List(ctx context.Context, options interface{}) ([]OrderRisk, error) {
+	return r.service.List(ctx, r.orderId, options)
+}
+
+// ListAll risks of the order, iterating over pages
+func (r *OrderScopeRisks) ListAll(ctx context.Context, options interface{}) ([]OrderRisk, error) {
+	return r.service.ListAll(ctx, r.orderId, options)
+}
+
+// Get individual risk of the order
+func (r *OrderScopeRisks) Get(ctx context.Context, riskId uint64, options interface{}) (*OrderRisk, error) {
+	return r.service.Get(ctx, r.orderId, riskId, options)
+}
+
+// Create a risk on the order
+func (r *OrderScopeRisks) Create(ctx context.Context, risk OrderRisk) (*OrderRisk, error) {
+	return r.service.Create(ctx, r.orderId, risk)
+}
+
+// Update a risk of the order
+func (r *OrderScopeRisks) Update(ctx context.Context, riskId uint64, risk OrderRisk) (*OrderRisk, error) {
+	return r.service.Update(ctx, r.orderId, riskId, risk)
+}
+
+// Delete a risk of the order
+func (r *OrderScopeRisks) Delete(ctx context.Context, riskId uint64) error {
+	return r.service.Delete(ctx, r.orderId, riskId)
+}
+
+// OrderScopeTransactions forwards to the TransactionService of the client
+// for a single order
+type OrderScopeTransactions struct {
+	service TransactionService
+	orderId uint64
+}
+
+// List transactions of the order
+func (t *OrderScopeTransactions) List(ctx context.Context, options interface{}) ([]Transaction, error) {
+	return t.service.List(ctx, t.orderId, options)
+}
+
+// Count transactions of the order
+func (t *OrderScopeTransactions) Count(ctx context.Context, options interface{}) (int, error) {
+	return t.service.Count(ctx, t.orderId, options)
+}
+
+// Get individual transaction of the order
+func (t *OrderScopeTransactions) Get(ctx context.Context, transactionId uint64, options interface{}) (*Transaction, error) {
+	return t.service.Get(ctx, t.orderId, transactionId, options)
+}
+
+// Create a transaction on the order
+func (t *OrderScopeTransactions) Create(ctx context.Context, transaction Transaction) (*Transaction, error) {
+	return t.service.Create(ctx, t.orderId, transaction)
+}
+
+// OrderScopeRefunds forwards to the RefundService of the client for a single
+// order
+type OrderScopeRefunds struct {
+	service RefundService
+	orderId uint64
+}
+
+// List refunds of the order
+func (r *OrderScopeRefunds) List(ctx context.Context, options interface{}) ([]Refund, error) {
+	return r.service.List(ctx, r.orderId, options)
+}
+
+// Get individual refund of the order
+func (r *OrderScopeRefunds) Get(ctx context.Context, refundId uint64, options interface{}) (*Refund, error) {
+	return r.service.Get(ctx, r.orderId, refundId, options)
+}
+
+// Create a refund on the order
+func (r *OrderScopeRefunds) Create(ctx context.Context, refund Refund) (*Refund, error) {
+	return r.service.Create(ctx, r.orderId, refund)
+}
+
+// Calculate a refund on the order
+func (r *OrderScopeRefunds) Calculate(ctx context.Context, refund Refund) (*Refund, error) {
+	return r.service.Calculate(ctx, r.orderId, refund)
+}
@@ -0,0 +1,58 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestOrderScope(t *testing.T) {
+	setup()
+	defer teardown()
+
+	prefix := fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1", client.pathPrefix)
+	httpmock.RegisterResponder("GET", prefix+"/fulfillments.json",
+		httpmock.NewStringResponder(200, `{"fulfillments": [{"id":1}]}`))
+	httpmock.RegisterResponder("GET", prefix+"/risks.json",
+		httpmock.NewStringResponder(200, `{"risks": [{"id":2}]}`))
+	httpmock.RegisterResponder("GET", prefix+"/transactions.json",
+		httpmock.NewStringResponder(200, `{"transactions": [{"id":3}]}`))
+	httpmock.RegisterResponder("GET", prefix+"/refunds.json",
+		httpmock.NewStringResponder(200, `{"refunds": [{"id":4}]}`))
+	httpmock.RegisterResponder("GET", prefix+"/metafields.json",
+		httpmock.NewStringResponder(200, `{"metafields": [{"id":5}]}`))
+
+	ctx := context.Background()
+	order := client.Order.Scope(1)
+
+	if order.Id() != 1 {
+		t.Errorf("OrderScope.Id returned %d, expected 1", order.Id())
+	}
+
+	fulfillments, err := order.Fulfillments().List(ctx, nil)
+	if err != nil || len(fulfillments) != 1 || fulfillments[0].Id != 1 {
+		t.Errorf("OrderScope.Fulfillments().List returned %+v, %v", fulfillments, err)
+	}
+
+	risks, err := order.Risks().List(ctx, nil)
+	if err != nil || len(risks) != 1 || risks[0].Id != 2 {
+		t.Errorf("OrderScope.Risks().List returned %+v, %v", risks, err)
+	}
+
+	transactions, err := order.Transactions().List(ctx, nil)
+	if err != nil || len(transactions) != 1 || transactions[0].Id != 3 {
+		t.Errorf("OrderScope.Transactions().List returned %+v, %v", transactions, err)
+	}
+
+	refunds, err := order.Refunds().List(ctx, nil)
+	if err != nil || len(refunds) != 1 || refunds[0].Id != 4 {
+		t.Errorf("OrderScope.Refunds().List returned %+v, %v", refunds, err)
+	}
+
+	metafields, err := order.Metafields().List(ctx, nil)
+	if err != nil || len(metafields) != 1 || metafields[0].Id != 5 {
+		t.Errorf("OrderScope.Metafields().List returned %+v, %v", metafields, err)
+	}
+}
@@ -2,6 +2,7 @@ package goshopify
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -22,7 +23,7 @@ func TestOrderListError(t *testing.T) {
 	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix),
 		httpmock.NewStringResponder(500, ""))
 
-	expectedErrMessage := "Unknown Error"
+	expectedErrMessage := "500 Internal Server Error"
 
 	orders, err := client.Order.List(context.Background(), nil)
 	if orders != nil {
@@ -501,6 +502,41 @@ func TestOrderCreate(t *testing.T) {
 	}
 }
 
+func TestOrderCreateWithOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body map[string]map[string]interface{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			return httpmock.NewStringResponse(201, `{"order":{"id": 1}}`), nil
+		})
+
+	options := OrderCreateOptions{
+		InventoryBehaviour: OrderInventoryBehaviourDecrementObeyingPolicy,
+		SendReceipt:        true,
+	}
+	_, err := client.Order.CreateWithOptions(context.Background(), Order{LineItems: []LineItem{{VariantId: 1, Quantity: 1}}}, options)
+	if err != nil {
+		t.Fatalf("Order.CreateWithOptions returned error: %v", err)
+	}
+
+	if body["order"]["inventory_behaviour"] != "decrement_obeying_policy" || body["order"]["send_receipt"] != true {
+		t.Errorf("Order.CreateWithOptions sent %v", body["order"])
+	}
+	if _, ok := body["order"]["send_fulfillment_receipt"]; ok {
+		t.Errorf("Order.CreateWithOptions sent send_fulfillment_receipt")
+	}
+
+	_, err = client.Order.CreateWithOptions(context.Background(), Order{}, OrderCreateOptions{InventoryBehaviour: "decrement"})
+	if _, ok := err.(ValidationError); !ok {
+		t.Errorf("Order.CreateWithOptions returned error %v, expected ValidationError", err)
+	}
+}
+
 func TestOrderUpdate(t *testing.T) {
 	setup()
 	defer teardown()
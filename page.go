@@ -109,6 +109,12 @@ func (s *PageServiceOp) ListMetafields(ctx context.Context, pageId uint64, optio
 	return metafieldService.List(ctx, options)
 }
 
+// ListMetafieldsByNamespace lists all metafields of a namespace for a page
+func (s *PageServiceOp) ListMetafieldsByNamespace(ctx context.Context, pageId uint64, namespace string) ([]Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: pagesResourceName, resourceId: pageId}
+	return metafieldService.ListByNamespace(ctx, namespace)
+}
+
 // Count metafields for a page
 func (s *PageServiceOp) CountMetafields(ctx context.Context, pageId uint64, options interface{}) (int, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: pagesResourceName, resourceId: pageId}
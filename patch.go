@@ -0,0 +1,63 @@
+package goshopify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// PatchOf returns the JSON fields of new which differ from old, fields of
+// old missing from new are set to nil to clear them. Send the patch instead
+// of the whole resource so fields changed concurrently are not overwritten:
+//
+//	patch, err := goshopify.PatchOf(fetched, edited)
+//	if err == nil && len(patch) > 0 {
+//		patch["id"] = edited.Id
+//		path := fmt.Sprintf("products/%d.json", edited.Id)
+//		err = client.Put(ctx, path, map[string]interface{}{"product": patch}, nil)
+//	}
+//
+// Fields are compared by their JSON value, nested objects and lists are sent
+// whole when any of their fields changed. See DiffProduct to also tell apart
+// the variants of a product.
+func PatchOf[T any](old, new T) (map[string]interface{}, error) {
+	oldFields, err := jsonFields(old)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := jsonFields(new)
+	if err != nil {
+		return nil, err
+	}
+
+	patch := map[string]interface{}{}
+	for name, value := range newFields {
+		if oldValue, ok := oldFields[name]; !ok || !reflect.DeepEqual(oldValue, value) {
+			patch[name] = value
+		}
+	}
+	for name, value := range oldFields {
+		if _, ok := newFields[name]; !ok && value != nil {
+			patch[name] = nil
+		}
+	}
+	return patch, nil
+}
+
+// jsonFields decodes the JSON object v is marshalled to, numbers are kept as
+// json.Number so they are compared and sent as is
+func jsonFields(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, fmt.Errorf("patch of %T: %w", v, err)
+	}
+	return fields, nil
+}
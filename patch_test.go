@@ -0,0 +1,81 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func TestPatchOf(t *testing.T) {
+	price := decimal.RequireFromString("10.00")
+	old := Variant{Id: 1, Title: "S", Sku: "S-1", Price: &price, Barcode: "123", RequireShipping: true}
+	edited := old
+	edited.Sku = "S-2"
+	edited.Barcode = ""
+	edited.RequireShipping = false
+
+	patch, err := PatchOf(old, edited)
+	if err != nil {
+		t.Fatalf("PatchOf returned error: %v", err)
+	}
+
+	expected := map[string]interface{}{"sku": "S-2", "barcode": nil, "requires_shipping": false}
+	if !reflect.DeepEqual(patch, expected) {
+		t.Errorf("PatchOf returned %v, expected %v", patch, expected)
+	}
+}
+
+func TestPatchOfUnchanged(t *testing.T) {
+	old := Customer{Id: 1, Email: "jon@example.com", Tags: "vip"}
+
+	patch, err := PatchOf(old, old)
+	if err != nil {
+		t.Fatalf("PatchOf returned error: %v", err)
+	}
+	if len(patch) != 0 {
+		t.Errorf("PatchOf returned %v, expected no fields", patch)
+	}
+}
+
+func TestPatchOfNotAnObject(t *testing.T) {
+	if _, err := PatchOf([]string{"a"}, []string{"b"}); err == nil {
+		t.Error("PatchOf returned no error for a list")
+	}
+}
+
+func TestPatchOfPut(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]map[string]interface{}
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&sent); err != nil {
+				t.Errorf("request body was not JSON: %v", err)
+			}
+			return httpmock.NewStringResponse(200, `{"product":{"id":1}}`), nil
+		})
+
+	old := Product{Id: 1, Title: "Shirt", Vendor: "Acme", Tags: "summer"}
+	edited := old
+	edited.Vendor = "Acme Apparel"
+
+	patch, err := PatchOf(old, edited)
+	if err != nil {
+		t.Fatalf("PatchOf returned error: %v", err)
+	}
+	if err := client.Put(context.Background(), "products/1.json", map[string]interface{}{"product": patch}, nil); err != nil {
+		t.Fatalf("Client.Put returned error: %v", err)
+	}
+
+	expected := map[string]map[string]interface{}{"product": {"vendor": "Acme Apparel"}}
+	if !reflect.DeepEqual(sent, expected) {
+		t.Errorf("patch was sent as %v, expected %v", sent, expected)
+	}
+}
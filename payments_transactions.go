@@ -3,6 +3,8 @@ package goshopify
 import (
 	"context"
 	"fmt"
+
+	"github.com/shopspring/decimal"
 )
 
 const paymentsTransactionsBasePath = "shopify_payments/balance/transactions"
@@ -42,17 +44,17 @@ type PaymentsTransactions struct {
 	Id                       uint64                    `json:"id,omitempty"`
 	Type                     PaymentsTransactionsTypes `json:"type,omitempty"`
 	Test                     bool                      `json:"test,omitempty"`
-	PayoutId                 int                       `json:"payout_id,omitempty"`
+	PayoutId                 uint64                    `json:"payout_id,omitempty"`
 	PayoutStatus             PayoutStatus              `json:"payout_status,omitempty"`
 	Currency                 string                    `json:"currency,omitempty"`
-	Amount                   string                    `json:"amount,omitempty"`
-	Fee                      string                    `json:"fee,omitempty"`
-	Net                      string                    `json:"net,omitempty"`
-	SourceId                 int                       `json:"source_id,omitempty"`
+	Amount                   decimal.Decimal           `json:"amount,omitempty"`
+	Fee                      decimal.Decimal           `json:"fee,omitempty"`
+	Net                      decimal.Decimal           `json:"net,omitempty"`
+	SourceId                 uint64                    `json:"source_id,omitempty"`
 	SourceType               string                    `json:"source_type,omitempty"`
-	SourceOrderTransactionId int                       `json:"source_order_transaction_id,omitempty"`
-	SourceOrderId            int                       `json:"source_order_id,omitempty"`
-	ProcessedAt              OnlyDate                  `json:"processed_at,omitempty"`
+	SourceOrderTransactionId uint64                    `json:"source_order_transaction_id,omitempty"`
+	SourceOrderId            uint64                    `json:"source_order_id,omitempty"`
+	ProcessedAt              *OnlyDate                 `json:"processed_at,omitempty"`
 }
 
 type PaymentsTransactionsTypes string
@@ -70,6 +72,20 @@ const (
 	PaymentsTransactionsPayoutCancellation PaymentsTransactionsTypes = "payout_cancellation"
 )
 
+// IsKnown reports whether the type is one of the PaymentsTransactionsTypes
+// constants. Types added by Shopify after this version are kept as is when
+// decoding.
+func (t PaymentsTransactionsTypes) IsKnown() bool {
+	switch t {
+	case PaymentsTransactionsCharge, PaymentsTransactionsRefund, PaymentsTransactionsDispute,
+		PaymentsTransactionsReserve, PaymentsTransactionsAdjustment, PaymentsTransactionsCredit,
+		PaymentsTransactionsDebit, PaymentsTransactionsPayout, PaymentsTransactionsPayoutFailure,
+		PaymentsTransactionsPayoutCancellation:
+		return true
+	}
+	return false
+}
+
 // Represents the result from the PaymentsTransactions/X.json endpoint
 type PaymentsTransactionResource struct {
 	PaymentsTransaction *PaymentsTransactions `json:"transaction"`
@@ -2,6 +2,7 @@ package goshopify
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
 )
 
 func TestPaymentsTransactionsList(t *testing.T) {
@@ -32,14 +34,14 @@ func TestPaymentsTransactionsList(t *testing.T) {
 			PayoutId:                 623721858,
 			PayoutStatus:             PayoutStatusPaid,
 			Currency:                 "USD",
-			Amount:                   "-50.00",
-			Fee:                      "0.00",
-			Net:                      "-50.00",
+			Amount:                   decimal.RequireFromString("-50.00"),
+			Fee:                      decimal.RequireFromString("0.00"),
+			Net:                      decimal.RequireFromString("-50.00"),
 			SourceId:                 460709370,
 			SourceType:               "adjustment",
 			SourceOrderId:            0,
 			SourceOrderTransactionId: 0,
-			ProcessedAt:              date1,
+			ProcessedAt:              &date1,
 		},
 		{
 			Id:                       77412310,
@@ -48,14 +50,14 @@ func TestPaymentsTransactionsList(t *testing.T) {
 			PayoutId:                 623721858,
 			PayoutStatus:             PayoutStatusPaid,
 			Currency:                 "USD",
-			Amount:                   "50.00",
-			Fee:                      "0.00",
-			Net:                      "50.00",
+			Amount:                   decimal.RequireFromString("50.00"),
+			Fee:                      decimal.RequireFromString("0.00"),
+			Net:                      decimal.RequireFromString("50.00"),
 			SourceId:                 374511569,
 			SourceType:               "Payments::Balance::AdjustmentReversal",
 			SourceOrderId:            0,
 			SourceOrderTransactionId: 0,
-			ProcessedAt:              date1,
+			ProcessedAt:              &date1,
 		},
 		{
 			Id:                       1006917261,
@@ -64,14 +66,14 @@ func TestPaymentsTransactionsList(t *testing.T) {
 			PayoutId:                 623721858,
 			PayoutStatus:             PayoutStatusPaid,
 			Currency:                 "USD",
-			Amount:                   "-3.45",
-			Fee:                      "0.00",
-			Net:                      "-3.45",
+			Amount:                   decimal.RequireFromString("-3.45"),
+			Fee:                      decimal.RequireFromString("0.00"),
+			Net:                      decimal.RequireFromString("-3.45"),
 			SourceId:                 1006917261,
 			SourceType:               "Payments::Refund",
 			SourceOrderId:            217130470,
 			SourceOrderTransactionId: 1006917261,
-			ProcessedAt:              date1,
+			ProcessedAt:              &date1,
 		},
 	}
 	if !reflect.DeepEqual(paymentsTransactions, expected) {
@@ -100,7 +102,7 @@ func TestPaymentsTransactionsListError(t *testing.T) {
 	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shopify_payments/balance/transactions.json", client.pathPrefix),
 		httpmock.NewStringResponder(500, ""))
 
-	expectedErrMessage := "Unknown Error"
+	expectedErrMessage := "500 Internal Server Error"
 
 	paymentsTransactions, err := client.PaymentsTransactions.List(context.Background(), nil)
 	if paymentsTransactions != nil {
@@ -250,14 +252,14 @@ func TestPaymentsTransactionsListWithPagination(t *testing.T) {
 					PayoutId:                 623721858,
 					PayoutStatus:             PayoutStatusPaid,
 					Currency:                 "USD",
-					Amount:                   "-50.00",
-					Fee:                      "0.00",
-					Net:                      "-50.00",
+					Amount:                   decimal.RequireFromString("-50.00"),
+					Fee:                      decimal.RequireFromString("0.00"),
+					Net:                      decimal.RequireFromString("-50.00"),
 					SourceId:                 460709370,
 					SourceType:               "adjustment",
 					SourceOrderId:            0,
 					SourceOrderTransactionId: 0,
-					ProcessedAt:              date1,
+					ProcessedAt:              &date1,
 				},
 				{
 					Id:                       77412310,
@@ -266,14 +268,14 @@ func TestPaymentsTransactionsListWithPagination(t *testing.T) {
 					PayoutId:                 623721858,
 					PayoutStatus:             PayoutStatusPaid,
 					Currency:                 "USD",
-					Amount:                   "50.00",
-					Fee:                      "0.00",
-					Net:                      "50.00",
+					Amount:                   decimal.RequireFromString("50.00"),
+					Fee:                      decimal.RequireFromString("0.00"),
+					Net:                      decimal.RequireFromString("50.00"),
 					SourceId:                 374511569,
 					SourceType:               "Payments::Balance::AdjustmentReversal",
 					SourceOrderId:            0,
 					SourceOrderTransactionId: 0,
-					ProcessedAt:              date1,
+					ProcessedAt:              &date1,
 				},
 				{
 					Id:                       1006917261,
@@ -282,14 +284,14 @@ func TestPaymentsTransactionsListWithPagination(t *testing.T) {
 					PayoutId:                 623721858,
 					PayoutStatus:             PayoutStatusPaid,
 					Currency:                 "USD",
-					Amount:                   "-3.45",
-					Fee:                      "0.00",
-					Net:                      "-3.45",
+					Amount:                   decimal.RequireFromString("-3.45"),
+					Fee:                      decimal.RequireFromString("0.00"),
+					Net:                      decimal.RequireFromString("-3.45"),
 					SourceId:                 1006917261,
 					SourceType:               "Payments::Refund",
 					SourceOrderId:            217130470,
 					SourceOrderTransactionId: 1006917261,
-					ProcessedAt:              date1,
+					ProcessedAt:              &date1,
 				},
 			},
 			new(Pagination),
@@ -408,16 +410,30 @@ func TestPaymentsTransactionsGet(t *testing.T) {
 		PayoutId:                 623721858,
 		PayoutStatus:             PayoutStatusPaid,
 		Currency:                 "USD",
-		Amount:                   "-50.00",
-		Fee:                      "0.00",
-		Net:                      "-50.00",
+		Amount:                   decimal.RequireFromString("-50.00"),
+		Fee:                      decimal.RequireFromString("0.00"),
+		Net:                      decimal.RequireFromString("-50.00"),
 		SourceId:                 460709370,
 		SourceType:               "adjustment",
 		SourceOrderId:            0,
 		SourceOrderTransactionId: 0,
-		ProcessedAt:              date1,
+		ProcessedAt:              &date1,
 	}
 	if !reflect.DeepEqual(paymentsTransactions, expected) {
 		t.Errorf("PaymentsTransactions.Get returned %+v, expected %+v", paymentsTransactions, expected)
 	}
 }
+
+func TestPaymentsTransactionsTypesIsKnown(t *testing.T) {
+	transaction := PaymentsTransactions{}
+	if err := json.Unmarshal([]byte(`{"id":1,"type":"anomaly_credit"}`), &transaction); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+
+	if transaction.Type != "anomaly_credit" || transaction.Type.IsKnown() {
+		t.Errorf("PaymentsTransactions.Type decoded to %q, expected the unknown anomaly_credit type", transaction.Type)
+	}
+	if !PaymentsTransactionsPayoutCancellation.IsKnown() {
+		t.Errorf("PaymentsTransactionsPayoutCancellation.IsKnown returned false")
+	}
+}
@@ -41,10 +41,25 @@ type PayoutsListOptions struct {
 // Payout represents a Shopify payout
 type Payout struct {
 	Id       uint64          `json:"id,omitempty"`
-	Date     OnlyDate        `json:"date,omitempty"`
+	Date     *OnlyDate       `json:"date,omitempty"`
 	Currency string          `json:"currency,omitempty"`
 	Amount   decimal.Decimal `json:"amount,omitempty"`
 	Status   PayoutStatus    `json:"status,omitempty"`
+	Summary  *PayoutSummary  `json:"summary,omitempty"`
+}
+
+// PayoutSummary breaks a payout amount down by kind of balance transaction
+type PayoutSummary struct {
+	AdjustmentsFeeAmount      decimal.Decimal `json:"adjustments_fee_amount,omitempty"`
+	AdjustmentsGrossAmount    decimal.Decimal `json:"adjustments_gross_amount,omitempty"`
+	ChargesFeeAmount          decimal.Decimal `json:"charges_fee_amount,omitempty"`
+	ChargesGrossAmount        decimal.Decimal `json:"charges_gross_amount,omitempty"`
+	RefundsFeeAmount          decimal.Decimal `json:"refunds_fee_amount,omitempty"`
+	RefundsGrossAmount        decimal.Decimal `json:"refunds_gross_amount,omitempty"`
+	ReservedFundsFeeAmount    decimal.Decimal `json:"reserved_funds_fee_amount,omitempty"`
+	ReservedFundsGrossAmount  decimal.Decimal `json:"reserved_funds_gross_amount,omitempty"`
+	RetriedPayoutsFeeAmount   decimal.Decimal `json:"retried_payouts_fee_amount,omitempty"`
+	RetriedPayoutsGrossAmount decimal.Decimal `json:"retried_payouts_gross_amount,omitempty"`
 }
 
 type PayoutStatus string
@@ -57,6 +72,16 @@ const (
 	PayoutStatusCancelled PayoutStatus = "canceled"
 )
 
+// IsKnown reports whether the status is one of the PayoutStatus constants.
+// Statuses added by Shopify after this version are kept as is when decoding.
+func (s PayoutStatus) IsKnown() bool {
+	switch s {
+	case PayoutStatusScheduled, PayoutStatusInTransit, PayoutStatusPaid, PayoutStatusFailed, PayoutStatusCancelled:
+		return true
+	}
+	return false
+}
+
 // Represents the result from the payouts/X.json endpoint
 type PayoutResource struct {
 	Payout *Payout `json:"payout"`
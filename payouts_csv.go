@@ -0,0 +1,124 @@
+package goshopify
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// PayoutsCSVHeader returns the header written by WritePayoutsCSV, the column
+// names of the payouts export of the Shopify admin
+func PayoutsCSVHeader() []string {
+	return []string{
+		"Payout Date", "Status", "Charges", "Refunds", "Adjustments", "Reserved Funds", "Fees", "Retried Amount", "Total", "Currency",
+	}
+}
+
+// PaymentsTransactionsCSVHeader returns the header written by
+// WritePaymentsTransactionsCSV, the column names of the payout transactions
+// export of the Shopify admin
+func PaymentsTransactionsCSVHeader() []string {
+	return []string{
+		"Transaction Date", "Type", "Order", "Card Brand", "Card Source", "Payout Status", "Payout Date", "Payout ID",
+		"Available On", "Amount", "Fee", "Net", "Checkout", "Payment Method Name", "Presentment Amount", "Presentment Currency", "Currency",
+	}
+}
+
+const csvDateFormat = "2006-01-02"
+
+// WritePayoutsCSV writes payouts under the column names of the Shopify admin
+// export. The output is not byte-compatible with the export: dates are written
+// as YYYY-MM-DD and the breakdown columns are left empty for payouts fetched
+// without a summary.
+func WritePayoutsCSV(w io.Writer, payouts []Payout) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(PayoutsCSVHeader()); err != nil {
+		return err
+	}
+
+	for _, payout := range payouts {
+		record := []string{formatCSVDate(payout.Date), string(payout.Status), "", "", "", "", "", "", payout.Amount.StringFixed(2), payout.Currency}
+		if summary := payout.Summary; summary != nil {
+			fees := summary.ChargesFeeAmount.
+				Add(summary.RefundsFeeAmount).
+				Add(summary.AdjustmentsFeeAmount).
+				Add(summary.ReservedFundsFeeAmount).
+				Add(summary.RetriedPayoutsFeeAmount)
+			record[2] = summary.ChargesGrossAmount.StringFixed(2)
+			record[3] = summary.RefundsGrossAmount.StringFixed(2)
+			record[4] = summary.AdjustmentsGrossAmount.StringFixed(2)
+			record[5] = summary.ReservedFundsGrossAmount.StringFixed(2)
+			record[6] = fees.Neg().StringFixed(2)
+			record[7] = summary.RetriedPayoutsGrossAmount.StringFixed(2)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// WritePaymentsTransactionsCSV writes balance transactions under the column
+// names of the Shopify admin export, payouts is used to fill the payout dates.
+// The output is not byte-compatible with the export: dates are written as
+// YYYY-MM-DD and the card, checkout and presentment columns, which the API
+// does not return, are left empty.
+func WritePaymentsTransactionsCSV(w io.Writer, transactions []PaymentsTransactions, payouts []Payout) error {
+	payoutDates := make(map[uint64]*OnlyDate, len(payouts))
+	for _, payout := range payouts {
+		payoutDates[payout.Id] = payout.Date
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(PaymentsTransactionsCSVHeader()); err != nil {
+		return err
+	}
+
+	for _, transaction := range transactions {
+		payoutId := ""
+		payoutDate := ""
+		if transaction.PayoutId != 0 {
+			payoutId = strconv.FormatUint(transaction.PayoutId, 10)
+			payoutDate = formatCSVDate(payoutDates[transaction.PayoutId])
+		}
+		order := ""
+		if transaction.SourceOrderId != 0 {
+			order = strconv.FormatUint(transaction.SourceOrderId, 10)
+		}
+
+		record := []string{
+			formatCSVDate(transaction.ProcessedAt),
+			string(transaction.Type),
+			order,
+			"",
+			"",
+			string(transaction.PayoutStatus),
+			payoutDate,
+			payoutId,
+			"",
+			transaction.Amount.StringFixed(2),
+			transaction.Fee.StringFixed(2),
+			transaction.Net.StringFixed(2),
+			"",
+			"",
+			"",
+			"",
+			transaction.Currency,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatCSVDate(date *OnlyDate) string {
+	if date == nil || date.IsZero() {
+		return ""
+	}
+	return date.Format(csvDateFormat)
+}
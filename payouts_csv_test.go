@@ -0,0 +1,85 @@
+package goshopify
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestWritePayoutsCSV(t *testing.T) {
+	payouts := []Payout{
+		{
+			Id:       1,
+			Date:     &OnlyDate{time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+			Status:   PayoutStatusPaid,
+			Currency: "USD",
+			Amount:   decimal.RequireFromString("90.5"),
+			Summary: &PayoutSummary{
+				ChargesGrossAmount: decimal.RequireFromString("100"),
+				ChargesFeeAmount:   decimal.RequireFromString("3.2"),
+				RefundsGrossAmount: decimal.RequireFromString("-6"),
+				RefundsFeeAmount:   decimal.RequireFromString("0.3"),
+			},
+		},
+		{
+			Id:       2,
+			Date:     &OnlyDate{time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)},
+			Status:   PayoutStatusScheduled,
+			Currency: "USD",
+			Amount:   decimal.RequireFromString("12"),
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WritePayoutsCSV(&buf, payouts); err != nil {
+		t.Fatalf("WritePayoutsCSV returned error: %v", err)
+	}
+
+	expected := "Payout Date,Status,Charges,Refunds,Adjustments,Reserved Funds,Fees,Retried Amount,Total,Currency\n" +
+		"2024-01-05,paid,100.00,-6.00,0.00,0.00,-3.50,0.00,90.50,USD\n" +
+		"2024-01-06,scheduled,,,,,,,12.00,USD\n"
+	if buf.String() != expected {
+		t.Errorf("WritePayoutsCSV wrote\n%s\nexpected\n%s", buf.String(), expected)
+	}
+}
+
+func TestWritePaymentsTransactionsCSV(t *testing.T) {
+	payouts := []Payout{{Id: 623721858, Date: &OnlyDate{time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)}}}
+	transactions := []PaymentsTransactions{
+		{
+			Id:            1,
+			Type:          PaymentsTransactionsCharge,
+			PayoutId:      623721858,
+			PayoutStatus:  PayoutStatusPaid,
+			Currency:      "USD",
+			Amount:        decimal.RequireFromString("10.5"),
+			Fee:           decimal.RequireFromString("0.6"),
+			Net:           decimal.RequireFromString("9.9"),
+			SourceOrderId: 450789469,
+			ProcessedAt:   &OnlyDate{time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			Id:          2,
+			Type:        PaymentsTransactionsAdjustment,
+			Currency:    "USD",
+			Amount:      decimal.RequireFromString("-1"),
+			Fee:         decimal.RequireFromString("0"),
+			Net:         decimal.RequireFromString("-1"),
+			ProcessedAt: &OnlyDate{time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WritePaymentsTransactionsCSV(&buf, transactions, payouts); err != nil {
+		t.Fatalf("WritePaymentsTransactionsCSV returned error: %v", err)
+	}
+
+	expected := "Transaction Date,Type,Order,Card Brand,Card Source,Payout Status,Payout Date,Payout ID,Available On,Amount,Fee,Net,Checkout,Payment Method Name,Presentment Amount,Presentment Currency,Currency\n" +
+		"2024-01-03,charge,450789469,,,paid,2024-01-05,623721858,,10.50,0.60,9.90,,,,,USD\n" +
+		"2024-01-04,adjustment,,,,,,,,-1.00,0.00,-1.00,,,,,USD\n"
+	if buf.String() != expected {
+		t.Errorf("WritePaymentsTransactionsCSV wrote\n%s\nexpected\n%s", buf.String(), expected)
+	}
+}
@@ -26,7 +26,7 @@ func TestPayoutsList(t *testing.T) {
 		t.Errorf("Payouts.List returned error: %v", err)
 	}
 
-	expected := []Payout{{Id: 854088011, Date: date1, Currency: "USD", Amount: decimal.NewFromFloat(43.12), Status: PayoutStatusScheduled}}
+	expected := []Payout{{Id: 854088011, Date: &date1, Currency: "USD", Amount: decimal.NewFromFloat(43.12), Status: PayoutStatusScheduled}}
 	if !reflect.DeepEqual(payouts, expected) {
 		t.Errorf("Payouts.List returned %+v, expected %+v", payouts, expected)
 	}
@@ -53,7 +53,7 @@ func TestPayoutsListError(t *testing.T) {
 	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shopify_payments/payouts.json", client.pathPrefix),
 		httpmock.NewStringResponder(500, ""))
 
-	expectedErrMessage := "Unknown Error"
+	expectedErrMessage := "500 Internal Server Error"
 
 	payouts, err := client.Payouts.List(context.Background(), nil)
 	if payouts != nil {
@@ -195,8 +195,8 @@ func TestPayoutsListWithPagination(t *testing.T) {
 			string(loadFixture("payouts.json")),
 			"",
 			[]Payout{
-				{Id: 854088011, Date: OnlyDate{time.Date(2013, 11, 1, 0, 0, 0, 0, time.UTC)}, Currency: "USD", Amount: decimal.NewFromFloat(43.12), Status: PayoutStatusScheduled},
-				{Id: 512467833, Date: OnlyDate{time.Date(2013, 11, 1, 0, 0, 0, 0, time.UTC)}, Currency: "USD", Amount: decimal.NewFromFloat(43.12), Status: PayoutStatusFailed},
+				{Id: 854088011, Date: &OnlyDate{time.Date(2013, 11, 1, 0, 0, 0, 0, time.UTC)}, Currency: "USD", Amount: decimal.NewFromFloat(43.12), Status: PayoutStatusScheduled},
+				{Id: 512467833, Date: &OnlyDate{time.Date(2013, 11, 1, 0, 0, 0, 0, time.UTC)}, Currency: "USD", Amount: decimal.NewFromFloat(43.12), Status: PayoutStatusFailed},
 			},
 			new(Pagination),
 			nil,
@@ -308,7 +308,7 @@ func TestPayoutsGet(t *testing.T) {
 
 	expected := &Payout{
 		Id:       623721858,
-		Date:     OnlyDate{time.Date(2012, 11, 12, 0, 0, 0, 0, time.UTC)},
+		Date:     &OnlyDate{time.Date(2012, 11, 12, 0, 0, 0, 0, time.UTC)},
 		Status:   PayoutStatusPaid,
 		Currency: "USD",
 		Amount:   decimal.NewFromFloat(41.9),
@@ -317,3 +317,23 @@ func TestPayoutsGet(t *testing.T) {
 		t.Errorf("Payouts.Get returned %+v, expected %+v", payout, expected)
 	}
 }
+
+func TestPayoutsUnknownEnumValues(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shopify_payments/payouts.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"payouts":[{"id":1,"status":"paid"},{"id":2,"status":"action_required"}]}`))
+
+	payouts, err := client.Payouts.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Payouts.List returned error: %v", err)
+	}
+
+	if !payouts[0].Status.IsKnown() {
+		t.Errorf("PayoutStatus(%s).IsKnown returned false", payouts[0].Status)
+	}
+	if payouts[1].Status != "action_required" || payouts[1].Status.IsKnown() {
+		t.Errorf("Payouts.List returned status %q, expected the unknown action_required status", payouts[1].Status)
+	}
+}
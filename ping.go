@@ -0,0 +1,45 @@
+package goshopify
+
+import (
+	"context"
+	"time"
+)
+
+// defaultPingTimeout bounds the duration of Ping when the context has no
+// earlier deadline
+const defaultPingTimeout = 5 * time.Second
+
+// PingResult is the outcome of a successful Ping
+type PingResult struct {
+	// Latency is the round trip duration of the request
+	Latency time.Duration
+
+	// RateLimits is the rate limit state reported by shopify on the response
+	RateLimits RateLimitInfo
+}
+
+// Ping performs a minimal shop.json?fields=id request to check that the shop
+// is reachable and the access token is valid, e.g. for readiness probes.
+// The request is bounded to 5 seconds unless ctx has an earlier deadline.
+func (c *Client) Ping(ctx context.Context) (*PingResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultPingTimeout)
+	defer cancel()
+
+	options := struct {
+		Fields string `url:"fields"`
+	}{
+		Fields: "id",
+	}
+	resource := new(ShopResource)
+
+	start := time.Now()
+	err := c.Get(ctx, "shop.json", resource, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PingResult{
+		Latency:    time.Since(start),
+		RateLimits: c.CurrentRateLimits(),
+	}, nil
+}
@@ -0,0 +1,49 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestPing(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{"fields": "id"}
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		params, createResponderWithHeaders(200, `{"shop":{"id":1}}`, map[string]string{
+			"X-Shopify-Shop-Api-Call-Limit": "3/40",
+		}))
+
+	result, err := client.Ping(context.Background())
+	if err != nil {
+		t.Fatalf("Client.Ping returned error: %v", err)
+	}
+
+	if result.RateLimits.RequestCount != 3 || result.RateLimits.BucketSize != 40 {
+		t.Errorf("Client.Ping returned rate limits %+v, expected 3/40", result.RateLimits)
+	}
+	if result.Latency <= 0 {
+		t.Errorf("Client.Ping returned latency %v, expected a positive duration", result.Latency)
+	}
+}
+
+func TestPingUnauthorized(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		httpmock.NewStringResponder(401, `{"errors":"[API] Invalid API key or access token"}`))
+
+	result, err := client.Ping(context.Background())
+	if result != nil {
+		t.Errorf("Client.Ping returned %+v, expected nil", result)
+	}
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Client.Ping returned error %v, expected ErrUnauthorized", err)
+	}
+}
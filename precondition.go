@@ -0,0 +1,77 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrConflict is matched by the ConflictError returned when the resource was
+// updated since it was fetched, see WithUpdatedAtPrecondition
+var ErrConflict = errors.New("shopify: conflict")
+
+// ConflictError is returned without sending an update when the updated_at of
+// the resource differs from the one of the update
+type ConflictError struct {
+	Path string
+	// Expected is the updated_at of the update
+	Expected time.Time
+	// Actual is the updated_at of the resource in shopify
+	Actual time.Time
+}
+
+func (e ConflictError) Error() string {
+	return fmt.Sprintf("%v: %s was updated at %s, expected %s", ErrConflict, e.Path,
+		e.Actual.Format(time.RFC3339), e.Expected.Format(time.RFC3339))
+}
+
+func (e ConflictError) Unwrap() error {
+	return ErrConflict
+}
+
+// checkUpdatedAt returns a ConflictError when the updated_at sent with a PUT
+// request differs from the one of the resource in shopify
+func (c *Client) checkUpdatedAt(req *http.Request, body []byte) error {
+	if !c.updatedAtPrecondition || req.Method != http.MethodPut || isGraphQLRequest(req) {
+		return nil
+	}
+
+	sent := map[string]struct {
+		UpdatedAt *time.Time `json:"updated_at"`
+	}{}
+	if err := json.Unmarshal(body, &sent); err != nil || len(sent) != 1 {
+		return nil
+	}
+	var root string
+	var expected *time.Time
+	for root = range sent {
+		expected = sent[root].UpdatedAt
+	}
+	if expected == nil {
+		return nil
+	}
+
+	u := *req.URL
+	query := u.Query()
+	query.Set("fields", "updated_at")
+	u.RawQuery = query.Encode()
+	get, err := http.NewRequestWithContext(req.Context(), http.MethodGet, u.String(), http.NoBody)
+	if err != nil {
+		return err
+	}
+	get.Header = req.Header.Clone()
+
+	remote := map[string]struct {
+		UpdatedAt *time.Time `json:"updated_at"`
+	}{}
+	if _, err := c.doGetHeaders(get, &remote); err != nil {
+		return err
+	}
+	actual := remote[root].UpdatedAt
+	if actual == nil || actual.Equal(*expected) {
+		return nil
+	}
+	return ConflictError{Path: req.URL.Path, Expected: *expected, Actual: *actual}
+}
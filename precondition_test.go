@@ -0,0 +1,59 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestWithUpdatedAtPrecondition(t *testing.T) {
+	setup()
+	defer teardown()
+	WithUpdatedAtPrecondition()(client)
+
+	productURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix)
+	httpmock.RegisterResponderWithQuery("GET", productURL, "fields=updated_at",
+		httpmock.NewStringResponder(200, `{"product":{"updated_at":"2024-01-02T12:00:00+02:00"}}`))
+	httpmock.RegisterResponder("PUT", productURL,
+		httpmock.NewStringResponder(200, `{"product":{"id":1}}`))
+
+	fetchedAt := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	staleAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	cases := []struct {
+		description string
+		updatedAt   *time.Time
+		conflict    bool
+		gets, puts  int
+	}{
+		{"unchanged", &fetchedAt, false, 1, 1},
+		{"updated since", &staleAt, true, 1, 0},
+		{"without updated_at", nil, false, 0, 1},
+	}
+
+	for _, c := range cases {
+		httpmock.ZeroCallCounters()
+		_, err := client.Product.Update(context.Background(), Product{Id: 1, Title: "Shirt", UpdatedAt: c.updatedAt})
+
+		var conflictErr ConflictError
+		if c.conflict {
+			if !errors.Is(err, ErrConflict) || !errors.As(err, &conflictErr) {
+				t.Fatalf("%s: Product.Update returned %v, expected a ConflictError", c.description, err)
+			}
+			if !conflictErr.Actual.Equal(fetchedAt) || !conflictErr.Expected.Equal(staleAt) {
+				t.Errorf("%s: Product.Update returned %+v", c.description, conflictErr)
+			}
+		} else if err != nil {
+			t.Fatalf("%s: Product.Update returned error: %v", c.description, err)
+		}
+
+		info := httpmock.GetCallCountInfo()
+		gets, puts := info["GET "+productURL+"?fields=updated_at"], info["PUT "+productURL]
+		if gets != c.gets || puts != c.puts {
+			t.Errorf("%s: Product.Update sent %d GET and %d PUT requests, expected %d and %d", c.description, gets, puts, c.gets, c.puts)
+		}
+	}
+}
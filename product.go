@@ -21,12 +21,16 @@ var linkRegex = regexp.MustCompile(`^ *<([^>]+)>; rel="(previous|next)" *$`)
 type ProductService interface {
 	List(context.Context, interface{}) ([]Product, error)
 	ListAll(context.Context, interface{}) ([]Product, error)
+	ListEach(context.Context, interface{}, func(Product) error) error
 	ListWithPagination(context.Context, interface{}) ([]Product, *Pagination, error)
 	Count(context.Context, interface{}) (int, error)
 	Get(context.Context, uint64, interface{}) (*Product, error)
 	Create(context.Context, Product) (*Product, error)
 	Update(context.Context, Product) (*Product, error)
 	Delete(context.Context, uint64) error
+	SetStatusBulk(context.Context, []uint64, ProductStatus, BulkProgressFunc) error
+	SetStatusBulkGraphQL(context.Context, []uint64, ProductStatus, BulkProgressFunc) (*BulkOperation, error)
+	ListSummaries(context.Context, ProductListOptions) ([]ProductSummary, *Pagination, error)
 
 	// MetafieldsService used for Product resource to communicate with Metafields resource
 	MetafieldsService
@@ -80,6 +84,8 @@ type Product struct {
 	MetafieldsGlobalDescriptionTag string          `json:"metafields_global_description_tag,omitempty"`
 	Metafields                     []Metafield     `json:"metafields,omitempty"`
 	AdminGraphqlApiId              string          `json:"admin_graphql_api_id,omitempty"`
+	NullFields                     []string        `json:"-"`
+	UnknownJSONFields
 }
 
 // The options provided by Shopify
@@ -91,6 +97,15 @@ type ProductOption struct {
 	Values    []string `json:"values,omitempty"`
 }
 
+// Values accepted by the published_status filter of products and collections
+const (
+	PublishedStatusPublished   = "published"
+	PublishedStatusUnpublished = "unpublished"
+	PublishedStatusAny         = "any"
+)
+
+// ProductListOptions represents the filters of the products.json endpoint
+// See: https://shopify.dev/docs/api/admin-rest/latest/resources/product#get-products
 type ProductListOptions struct {
 	ListOptions
 	CollectionId          uint64          `url:"collection_id,omitempty"`
@@ -103,6 +118,22 @@ type ProductListOptions struct {
 	PresentmentCurrencies string          `url:"presentment_currencies,omitempty"`
 	Status                []ProductStatus `url:"status,omitempty,comma"`
 	Title                 string          `url:"title,omitempty"`
+
+	// PresentmentCurrencyCodes is PresentmentCurrencies as a list, encoded
+	// comma separated like VariantListOptions.PresentmentCurrencies. Set
+	// one of them.
+	PresentmentCurrencyCodes []string `url:"presentment_currencies,omitempty,comma"`
+}
+
+// ProductCountOptions represents the filters of the products/count.json endpoint
+type ProductCountOptions struct {
+	CountOptions
+	CollectionId    uint64    `url:"collection_id,omitempty"`
+	ProductType     string    `url:"product_type,omitempty"`
+	Vendor          string    `url:"vendor,omitempty"`
+	PublishedAtMin  time.Time `url:"published_at_min,omitempty"`
+	PublishedAtMax  time.Time `url:"published_at_max,omitempty"`
+	PublishedStatus string    `url:"published_status,omitempty"`
 }
 
 // Represents the result from the products/X.json endpoint
@@ -153,6 +184,25 @@ func (s *ProductServiceOp) ListAll(ctx context.Context, options interface{}) ([]
 	return collector, nil
 }
 
+// ListEach calls each with all products, iterating over pages and decoding
+// them one at a time instead of buffering the pages, see ListEach
+func (s *ProductServiceOp) ListEach(ctx context.Context, options interface{}, each func(Product) error) error {
+	path := fmt.Sprintf("%s.json", productsBasePath)
+
+	for {
+		pagination, err := ListEach(ctx, s.client, path, "products", options, each)
+		if err != nil {
+			return err
+		}
+
+		if pagination.NextPageOptions == nil {
+			return nil
+		}
+
+		options = pagination.NextPageOptions
+	}
+}
+
 // ListWithPagination lists products and return pagination to retrieve next/previous results.
 func (s *ProductServiceOp) ListWithPagination(ctx context.Context, options interface{}) ([]Product, *Pagination, error) {
 	path := fmt.Sprintf("%s.json", productsBasePath)
@@ -172,11 +222,22 @@ func (s *ProductServiceOp) Count(ctx context.Context, options interface{}) (int,
 	return s.client.Count(ctx, path, options)
 }
 
-// Get individual product
+// Get individual product, the variants REST truncates are paged with graphql
+// when the product has more than REST returns
 func (s *ProductServiceOp) Get(ctx context.Context, productId uint64, options interface{}) (*Product, error) {
 	path := fmt.Sprintf("%s/%d.json", productsBasePath, productId)
 	resource := new(ProductResource)
 	err := s.client.Get(ctx, path, resource, options)
+	if s.client.needsGraphQLFallback(err) {
+		return s.getFromGraphQL(ctx, productId, err)
+	}
+	if err == nil && resource.Product != nil && len(resource.Product.Variants) >= restVariantsLimit {
+		// REST may have truncated the variants
+		resource.Product.Variants, err = s.completeVariants(ctx, productId, resource.Product.Variants)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return resource.Product, err
 }
 
@@ -209,6 +270,12 @@ func (s *ProductServiceOp) ListMetafields(ctx context.Context, productId uint64,
 	return metafieldService.List(ctx, options)
 }
 
+// ListMetafieldsByNamespace lists all metafields of a namespace for a product
+func (s *ProductServiceOp) ListMetafieldsByNamespace(ctx context.Context, productId uint64, namespace string) ([]Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceId: productId}
+	return metafieldService.ListByNamespace(ctx, namespace)
+}
+
 // Count metafields for a product
 func (s *ProductServiceOp) CountMetafields(ctx context.Context, productId uint64, options interface{}) (int, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceId: productId}
@@ -0,0 +1,151 @@
+package goshopify
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// productDiffSkipped are the product fields DiffProduct does not compare,
+// they are read-only, sent through other endpoints or carry ids a local
+// snapshot does not have
+var productDiffSkipped = map[string]bool{
+	"Id": true, "CreatedAt": true, "UpdatedAt": true, "PublishedAt": true, "PublishedScope": true,
+	"Options": true, "Variants": true, "Image": true, "Images": true, "Metafields": true,
+	"AdminGraphqlApiId": true, "NullFields": true, "UnknownJSONFields": true,
+}
+
+// variantDiffSkipped are the variant fields DiffProduct does not compare,
+// inventory is set with InventoryLevelService
+var variantDiffSkipped = map[string]bool{
+	"Id": true, "ProductId": true, "CreatedAt": true, "UpdatedAt": true, "InventoryItemId": true,
+	"InventoryQuantity": true, "OldInventoryQuantity": true, "Metafields": true, "PresentmentPrices": true,
+	"AdminGraphqlApiId": true, "NullFields": true, "UnknownJSONFields": true,
+}
+
+// ProductDiff holds the sparse updates turning a product into the desired
+// snapshot, see DiffProduct
+type ProductDiff struct {
+	// Product has the id and the changed fields of the product, nil when no
+	// product field changed. Cleared fields are listed in its NullFields.
+	Product *Product
+
+	// Variants have the id and the changed fields of every changed variant
+	Variants []Variant
+
+	// NewVariants are the desired variants without an id
+	NewVariants []Variant
+
+	// DeletedVariantIds are the variants of the product missing from the
+	// desired snapshot
+	DeletedVariantIds []uint64
+}
+
+// Empty reports whether the product is already in the desired state
+func (d ProductDiff) Empty() bool {
+	return d.Product == nil && len(d.Variants) == 0 && len(d.NewVariants) == 0 && len(d.DeletedVariantIds) == 0
+}
+
+// DiffProduct compares a product freshly fetched from shopify with the
+// desired snapshot, e.g. built from a local catalog, and returns the changed
+// fields only so sync jobs send sparse updates:
+//
+//	diff := goshopify.DiffProduct(*current, desired)
+//	if diff.Product != nil {
+//		_, err = client.Product.Update(ctx, *diff.Product)
+//	}
+//	for _, variant := range diff.Variants {
+//		_, err = client.Variant.Update(ctx, variant)
+//	}
+//
+// Empty desired fields clear the field. Variants are matched by id. Options,
+// images, metafields and inventory quantities are not compared.
+func DiffProduct(current, desired Product) ProductDiff {
+	diff := ProductDiff{}
+
+	product := Product{Id: current.Id}
+	if diffFields(reflect.ValueOf(current), reflect.ValueOf(desired), reflect.ValueOf(&product).Elem(), &product.NullFields, productDiffSkipped) {
+		diff.Product = &product
+	}
+
+	currentVariants := make(map[uint64]Variant, len(current.Variants))
+	for _, variant := range current.Variants {
+		currentVariants[variant.Id] = variant
+	}
+	desiredIds := make(map[uint64]bool, len(desired.Variants))
+	for _, variant := range desired.Variants {
+		existing, ok := currentVariants[variant.Id]
+		if variant.Id == 0 || !ok {
+			variant.Id = 0
+			diff.NewVariants = append(diff.NewVariants, variant)
+			continue
+		}
+		desiredIds[variant.Id] = true
+
+		update := Variant{Id: variant.Id}
+		if diffFields(reflect.ValueOf(existing), reflect.ValueOf(variant), reflect.ValueOf(&update).Elem(), &update.NullFields, variantDiffSkipped) {
+			diff.Variants = append(diff.Variants, update)
+		}
+	}
+	for _, variant := range current.Variants {
+		if !desiredIds[variant.Id] {
+			diff.DeletedVariantIds = append(diff.DeletedVariantIds, variant.Id)
+		}
+	}
+
+	return diff
+}
+
+// diffFields copies the fields of desired which differ from current to
+// sparse and lists the cleared ones in nullFields. Fields sent even when
+// empty, i.e. without omitempty, are always copied.
+func diffFields(current, desired, sparse reflect.Value, nullFields *[]string, skipped map[string]bool) bool {
+	changed := false
+	for i := 0; i < desired.NumField(); i++ {
+		field := desired.Type().Field(i)
+		if !field.IsExported() || skipped[field.Name] {
+			continue
+		}
+		name, _ := jsonFieldName(field)
+		omitEmpty := strings.Contains(field.Tag.Get("json"), ",omitempty")
+
+		if !omitEmpty {
+			sparse.Field(i).Set(desired.Field(i))
+		}
+		if equalFieldValues(current.Field(i), desired.Field(i)) {
+			continue
+		}
+
+		changed = true
+		if omitEmpty && desired.Field(i).IsZero() {
+			*nullFields = append(*nullFields, name)
+			continue
+		}
+		sparse.Field(i).Set(desired.Field(i))
+	}
+	return changed
+}
+
+var (
+	decimalType = reflect.TypeOf(decimal.Decimal{})
+	timeType    = reflect.TypeOf(time.Time{})
+)
+
+// equalFieldValues compares decimals and times by value, "10.0" equals "10"
+func equalFieldValues(a, b reflect.Value) bool {
+	if a.Kind() == reflect.Ptr {
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		a, b = a.Elem(), b.Elem()
+	}
+	switch a.Type() {
+	case decimalType:
+		return a.Interface().(decimal.Decimal).Equal(b.Interface().(decimal.Decimal))
+	case timeType:
+		return a.Interface().(time.Time).Equal(b.Interface().(time.Time))
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
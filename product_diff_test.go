@@ -0,0 +1,98 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestDiffProduct(t *testing.T) {
+	updatedAt := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	price := decimal.RequireFromString("10.00")
+	samePrice := decimal.RequireFromString("10")
+	newPrice := decimal.RequireFromString("12.50")
+
+	current := Product{
+		Id:        1,
+		Title:     "Shirt",
+		BodyHTML:  "<p>Cotton</p>",
+		Vendor:    "Acme",
+		Tags:      "summer",
+		UpdatedAt: &updatedAt,
+		Status:    ProductStatusActive,
+		Images:    []Image{{Id: 5}},
+		Variants: []Variant{
+			{Id: 11, ProductId: 1, Title: "S", Sku: "S-1", Price: &price, InventoryQuantity: 4, RequireShipping: true},
+			{Id: 12, ProductId: 1, Title: "M", Sku: "M-1", Price: &price, RequireShipping: true},
+			{Id: 13, ProductId: 1, Title: "L", Sku: "L-1", Price: &price, RequireShipping: true},
+		},
+	}
+	desired := Product{
+		Title:  "Shirt",
+		Vendor: "Acme Apparel",
+		Tags:   "summer",
+		Status: ProductStatusActive,
+		Variants: []Variant{
+			{Id: 11, Title: "S", Sku: "S-1", Price: &samePrice, RequireShipping: true},
+			{Id: 12, Title: "M", Sku: "M-2", Price: &newPrice, RequireShipping: true},
+			{Title: "XL", Sku: "XL-1", Price: &price},
+		},
+	}
+
+	diff := DiffProduct(current, desired)
+
+	expectedProduct := &Product{Id: 1, Vendor: "Acme Apparel", NullFields: []string{"body_html"}}
+	if !reflect.DeepEqual(diff.Product, expectedProduct) {
+		t.Errorf("DiffProduct returned product %+v, expected %+v", diff.Product, expectedProduct)
+	}
+	expectedVariants := []Variant{{Id: 12, Sku: "M-2", Price: &newPrice, RequireShipping: true}}
+	if !reflect.DeepEqual(diff.Variants, expectedVariants) {
+		t.Errorf("DiffProduct returned variants %+v, expected %+v", diff.Variants, expectedVariants)
+	}
+	if len(diff.NewVariants) != 1 || diff.NewVariants[0].Title != "XL" {
+		t.Errorf("DiffProduct returned new variants %+v", diff.NewVariants)
+	}
+	if !reflect.DeepEqual(diff.DeletedVariantIds, []uint64{13}) {
+		t.Errorf("DiffProduct returned deleted variants %v, expected [13]", diff.DeletedVariantIds)
+	}
+	if diff.Empty() {
+		t.Error("DiffProduct returned an empty diff")
+	}
+
+	data, err := json.Marshal(diff.Product)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+	sent := map[string]interface{}{}
+	if err := json.Unmarshal(data, &sent); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+	if sent["vendor"] != "Acme Apparel" || sent["body_html"] != nil || sent["title"] != nil {
+		t.Errorf("sparse product was marshalled to %s", data)
+	}
+	if _, ok := sent["body_html"]; !ok {
+		t.Errorf("sparse product was marshalled to %s, expected body_html to be null", data)
+	}
+}
+
+func TestDiffProductUnchanged(t *testing.T) {
+	price := decimal.RequireFromString("10.00")
+	samePrice := decimal.RequireFromString("10")
+	current := Product{
+		Id:       1,
+		Title:    "Shirt",
+		Variants: []Variant{{Id: 11, ProductId: 1, Sku: "S-1", Price: &price, InventoryQuantity: 3}},
+	}
+	desired := Product{
+		Title:    "Shirt",
+		Variants: []Variant{{Id: 11, Sku: "S-1", Price: &samePrice}},
+	}
+
+	diff := DiffProduct(current, desired)
+	if !diff.Empty() {
+		t.Errorf("DiffProduct returned %+v, expected an empty diff", diff)
+	}
+}
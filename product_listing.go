@@ -20,6 +20,7 @@ type ProductListingService interface {
 	GetProductIds(context.Context, interface{}) ([]uint64, error)
 	Publish(context.Context, uint64) (*ProductListing, error)
 	Delete(context.Context, uint64) error
+	Sync(context.Context, []uint64, ProductListingSyncOptions) (*ProductListingSyncResult, error)
 }
 
 // ProductListingServiceOp handles communication with the product related methods of
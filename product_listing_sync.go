@@ -0,0 +1,136 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// productListingIdsPageSize is the maximum limit of the product_ids.json endpoint
+const productListingIdsPageSize = 1000
+
+// ProductListingSyncOptions configures ProductListing.Sync
+type ProductListingSyncOptions struct {
+	// DryRun computes the listings to publish and delete without changing them
+	DryRun bool
+
+	// Progress is called after each listing published or deleted
+	Progress BulkProgressFunc
+}
+
+// ProductListingSyncResult lists the product ids Sync published and deleted
+type ProductListingSyncResult struct {
+	Published []uint64
+	Deleted   []uint64
+}
+
+// Sync converges the listings of the sales channel to productIds: products
+// missing from the channel are published and listings of other products are
+// deleted. The published ids are fetched by pages of 1000 and the changes are
+// paced to keep the REST bucket from overflowing. When a change fails the
+// changes made so far are returned along with the error.
+func (s *ProductListingServiceOp) Sync(ctx context.Context, productIds []uint64, options ProductListingSyncOptions) (*ProductListingSyncResult, error) {
+	last := &Response{}
+	ctx = context.WithValue(ctx, responseKey{}, last)
+	listed, err := s.allProductIds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	toPublish, toDelete := diffProductIds(productIds, listed)
+	result := &ProductListingSyncResult{Published: []uint64{}, Deleted: []uint64{}}
+	if options.DryRun {
+		result.Published, result.Deleted = toPublish, toDelete
+		return result, nil
+	}
+
+	total := len(toPublish) + len(toDelete)
+	report := func() {
+		if options.Progress != nil {
+			options.Progress(len(result.Published)+len(result.Deleted), total)
+		}
+	}
+
+	for _, productId := range toPublish {
+		if err := s.client.paceREST(ctx, last); err != nil {
+			return result, err
+		}
+		if _, err := s.Publish(ctx, productId); err != nil {
+			return result, fmt.Errorf("publish product %d: %w", productId, err)
+		}
+		result.Published = append(result.Published, productId)
+		report()
+	}
+
+	for _, productId := range toDelete {
+		if err := s.client.paceREST(ctx, last); err != nil {
+			return result, err
+		}
+		// a listing removed since the ids were fetched is already converged
+		if err := s.Delete(ctx, productId); err != nil && !errors.Is(err, ErrNotFound) {
+			return result, fmt.Errorf("delete product listing %d: %w", productId, err)
+		}
+		result.Deleted = append(result.Deleted, productId)
+		report()
+	}
+
+	return result, nil
+}
+
+// allProductIds fetches every page of product ids published to the channel
+func (s *ProductListingServiceOp) allProductIds(ctx context.Context) ([]uint64, error) {
+	path := fmt.Sprintf("%s/product_ids.json", productListingBasePath)
+	options := productIdsPageOptions{Limit: productListingIdsPageSize}
+	ids := []uint64{}
+
+	for {
+		resource := new(ProductListingIdsResource)
+		pagination, err := s.client.ListWithPagination(ctx, path, resource, options)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, resource.ProductIds...)
+
+		if pagination == nil || pagination.NextPageOptions == nil {
+			return ids, nil
+		}
+		options.PageInfo = pagination.NextPageOptions.PageInfo
+	}
+}
+
+// productIdsPageOptions pages the product ids, which allows a larger limit
+// than ListOptions
+type productIdsPageOptions struct {
+	PageInfo string `url:"page_info,omitempty"`
+	Limit    int    `url:"limit,omitempty"`
+}
+
+// diffProductIds returns the desired ids which are not listed and the listed
+// ids which are not desired, sorted
+func diffProductIds(desired, listed []uint64) (toPublish, toDelete []uint64) {
+	desiredSet := make(map[uint64]bool, len(desired))
+	for _, id := range desired {
+		desiredSet[id] = true
+	}
+	listedSet := make(map[uint64]bool, len(listed))
+	for _, id := range listed {
+		listedSet[id] = true
+	}
+
+	toPublish, toDelete = []uint64{}, []uint64{}
+	for id := range desiredSet {
+		if !listedSet[id] {
+			toPublish = append(toPublish, id)
+		}
+	}
+	for id := range listedSet {
+		if !desiredSet[id] {
+			toDelete = append(toDelete, id)
+		}
+	}
+
+	sort.Slice(toPublish, func(i, j int) bool { return toPublish[i] < toPublish[j] })
+	sort.Slice(toDelete, func(i, j int) bool { return toDelete[i] < toDelete[j] })
+	return toPublish, toDelete
+}
@@ -0,0 +1,83 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestProductListingSync(t *testing.T) {
+	setup()
+	defer teardown()
+
+	idsURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/product_listings/product_ids.json", client.pathPrefix)
+	httpmock.RegisterResponder("GET", idsURL,
+		func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("page_info") == "next" {
+				return httpmock.NewStringResponse(200, `{"product_ids":[3,4]}`), nil
+			}
+			resp := httpmock.NewStringResponse(200, `{"product_ids":[1,2]}`)
+			resp.Header.Set("Link", fmt.Sprintf(`<%s?page_info=next&limit=1000>; rel="next"`, idsURL))
+			return resp, nil
+		})
+
+	published := []uint64{}
+	for _, id := range []uint64{5, 6} {
+		id := id
+		httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/product_listings/%d.json", client.pathPrefix, id),
+			func(req *http.Request) (*http.Response, error) {
+				published = append(published, id)
+				return httpmock.NewStringResponse(200, fmt.Sprintf(`{"product_listing":{"product_id":%d}}`, id)), nil
+			})
+	}
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/product_listings/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{}`))
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/product_listings/3.json", client.pathPrefix),
+		httpmock.NewStringResponder(404, `{"errors":"Not Found"}`))
+
+	var progress [][2]int
+	result, err := client.ProductListing.Sync(context.Background(), []uint64{2, 4, 5, 6}, ProductListingSyncOptions{
+		Progress: func(done, total int) { progress = append(progress, [2]int{done, total}) },
+	})
+	if err != nil {
+		t.Fatalf("ProductListing.Sync returned error: %v", err)
+	}
+
+	expected := &ProductListingSyncResult{Published: []uint64{5, 6}, Deleted: []uint64{1, 3}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ProductListing.Sync returned %+v, expected %+v", result, expected)
+	}
+	if !reflect.DeepEqual(published, []uint64{5, 6}) {
+		t.Errorf("ProductListing.Sync published %v, expected [5 6]", published)
+	}
+
+	expectedProgress := [][2]int{{1, 4}, {2, 4}, {3, 4}, {4, 4}}
+	if !reflect.DeepEqual(progress, expectedProgress) {
+		t.Errorf("ProductListing.Sync reported progress %v, expected %v", progress, expectedProgress)
+	}
+}
+
+func TestProductListingSyncDryRun(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/product_listings/product_ids.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"product_ids":[1,2]}`))
+
+	result, err := client.ProductListing.Sync(context.Background(), []uint64{2, 3}, ProductListingSyncOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("ProductListing.Sync returned error: %v", err)
+	}
+
+	expected := &ProductListingSyncResult{Published: []uint64{3}, Deleted: []uint64{1}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ProductListing.Sync returned %+v, expected %+v", result, expected)
+	}
+	if calls := httpmock.GetTotalCallCount(); calls != 1 {
+		t.Errorf("ProductListing.Sync made %d calls in dry run, expected 1", calls)
+	}
+}
@@ -46,7 +46,7 @@ func TestProductListingListError(t *testing.T) {
 	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/product_listings.json", client.pathPrefix),
 		httpmock.NewStringResponder(500, ""))
 
-	expectedErrMessage := "Unknown Error"
+	expectedErrMessage := "500 Internal Server Error"
 
 	products, err := client.ProductListing.List(context.Background(), nil)
 	if products != nil {
@@ -0,0 +1,221 @@
+package goshopify
+
+import (
+	"context"
+)
+
+const productOptionFields = `
+      options {
+        id
+        name
+        position
+        optionValues {
+          id
+          name
+          hasVariants
+        }
+      }`
+
+const productOptionsCreateMutation = `mutation productOptionsCreate($productId: ID!, $options: [OptionCreateInput!]!) {
+  productOptionsCreate(productId: $productId, options: $options) {
+    userErrors {
+      field
+      message
+      code
+    }
+    product {` + productOptionFields + `
+    }
+  }
+}`
+
+const productOptionUpdateMutation = `mutation productOptionUpdate($productId: ID!, $option: OptionUpdateInput!, $optionValuesToAdd: [OptionValueCreateInput!], $optionValuesToUpdate: [OptionValueUpdateInput!], $optionValuesToDelete: [ID!]) {
+  productOptionUpdate(productId: $productId, option: $option, optionValuesToAdd: $optionValuesToAdd, optionValuesToUpdate: $optionValuesToUpdate, optionValuesToDelete: $optionValuesToDelete) {
+    userErrors {
+      field
+      message
+      code
+    }
+    product {` + productOptionFields + `
+    }
+  }
+}`
+
+const productOptionsReorderMutation = `mutation productOptionsReorder($productId: ID!, $options: [OptionReorderInput!]!) {
+  productOptionsReorder(productId: $productId, options: $options) {
+    userErrors {
+      field
+      message
+      code
+    }
+    product {` + productOptionFields + `
+    }
+  }
+}`
+
+// ProductOptionService is an interface for managing product options with the
+// graphql options model of the Shopify API, which allows restructuring
+// options without deleting and recreating variants.
+// See: https://shopify.dev/docs/apps/build/product-merchandising/products-and-collections/manage-variants
+type ProductOptionService interface {
+	Create(context.Context, uint64, []ProductOptionCreate) ([]ProductOptionNode, error)
+	Update(context.Context, uint64, ProductOptionUpdate) ([]ProductOptionNode, error)
+	Reorder(context.Context, uint64, []ProductOptionReorder) ([]ProductOptionNode, error)
+	RenameValue(context.Context, uint64, string, string, string) ([]ProductOptionNode, error)
+}
+
+// ProductOptionServiceOp handles communication with the product option
+// mutations of the Shopify API.
+type ProductOptionServiceOp struct {
+	client *Client
+}
+
+// ProductOptionNode represents a product option as returned by graphql, ids
+// are graphql global ids
+type ProductOptionNode struct {
+	Id           string                   `json:"id"`
+	Name         string                   `json:"name"`
+	Position     int                      `json:"position"`
+	OptionValues []ProductOptionValueNode `json:"optionValues"`
+}
+
+// ProductOptionValueNode represents a value of a product option
+type ProductOptionValueNode struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	HasVariants bool   `json:"hasVariants"`
+}
+
+// ProductOptionCreate represents an OptionCreateInput
+type ProductOptionCreate struct {
+	Name     string                     `json:"name"`
+	Position int                        `json:"position,omitempty"`
+	Values   []ProductOptionValueCreate `json:"values,omitempty"`
+}
+
+// ProductOptionValueCreate represents an OptionValueCreateInput
+type ProductOptionValueCreate struct {
+	Name string `json:"name"`
+}
+
+// ProductOptionValueUpdate represents an OptionValueUpdateInput
+type ProductOptionValueUpdate struct {
+	Id   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// ProductOptionUpdate represents the arguments of the productOptionUpdate
+// mutation. Option.Id is required, the value lists are optional.
+type ProductOptionUpdate struct {
+	Option         ProductOptionUpdateOption
+	ValuesToAdd    []ProductOptionValueCreate
+	ValuesToUpdate []ProductOptionValueUpdate
+	ValuesToDelete []string
+}
+
+// ProductOptionUpdateOption represents an OptionUpdateInput
+type ProductOptionUpdateOption struct {
+	Id       string `json:"id"`
+	Name     string `json:"name,omitempty"`
+	Position int    `json:"position,omitempty"`
+}
+
+// ProductOptionReorder represents an OptionReorderInput, options and values
+// are identified by either id or name and take the position of their index
+type ProductOptionReorder struct {
+	Id     string                      `json:"id,omitempty"`
+	Name   string                      `json:"name,omitempty"`
+	Values []ProductOptionValueReorder `json:"values,omitempty"`
+}
+
+// ProductOptionValueReorder represents an OptionValueReorderInput
+type ProductOptionValueReorder struct {
+	Id   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// productOptionsPayload is the common payload of the product option mutations
+type productOptionsPayload struct {
+	UserErrors []GraphQLUserError `json:"userErrors"`
+	Product    *struct {
+		Options []ProductOptionNode `json:"options"`
+	} `json:"product"`
+}
+
+func (p productOptionsPayload) result() ([]ProductOptionNode, error) {
+	if err := userErrorsToResponseError(p.UserErrors); err != nil {
+		return nil, err
+	}
+	if p.Product == nil {
+		return nil, nil
+	}
+	return p.Product.Options, nil
+}
+
+// Create adds options to a product
+func (s *ProductOptionServiceOp) Create(ctx context.Context, productId uint64, options []ProductOptionCreate) ([]ProductOptionNode, error) {
+	vars := map[string]interface{}{
+		"productId": NewGid("Product", productId),
+		"options":   options,
+	}
+	resp := struct {
+		ProductOptionsCreate productOptionsPayload `json:"productOptionsCreate"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, productOptionsCreateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.ProductOptionsCreate.result()
+}
+
+// Update renames or moves an option and adds, renames or deletes its values
+func (s *ProductOptionServiceOp) Update(ctx context.Context, productId uint64, update ProductOptionUpdate) ([]ProductOptionNode, error) {
+	vars := map[string]interface{}{
+		"productId": NewGid("Product", productId),
+		"option":    update.Option,
+	}
+	if len(update.ValuesToAdd) > 0 {
+		vars["optionValuesToAdd"] = update.ValuesToAdd
+	}
+	if len(update.ValuesToUpdate) > 0 {
+		vars["optionValuesToUpdate"] = update.ValuesToUpdate
+	}
+	if len(update.ValuesToDelete) > 0 {
+		vars["optionValuesToDelete"] = update.ValuesToDelete
+	}
+	resp := struct {
+		ProductOptionUpdate productOptionsPayload `json:"productOptionUpdate"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, productOptionUpdateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.ProductOptionUpdate.result()
+}
+
+// Reorder changes the position of options and their values
+func (s *ProductOptionServiceOp) Reorder(ctx context.Context, productId uint64, options []ProductOptionReorder) ([]ProductOptionNode, error) {
+	vars := map[string]interface{}{
+		"productId": NewGid("Product", productId),
+		"options":   options,
+	}
+	resp := struct {
+		ProductOptionsReorder productOptionsPayload `json:"productOptionsReorder"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, productOptionsReorderMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.ProductOptionsReorder.result()
+}
+
+// RenameValue renames a single option value, the variants using the value
+// are kept
+func (s *ProductOptionServiceOp) RenameValue(ctx context.Context, productId uint64, optionId, valueId, name string) ([]ProductOptionNode, error) {
+	return s.Update(ctx, productId, ProductOptionUpdate{
+		Option:         ProductOptionUpdateOption{Id: optionId},
+		ValuesToUpdate: []ProductOptionValueUpdate{{Id: valueId, Name: name}},
+	})
+}
@@ -0,0 +1,115 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const productOptionsResponse = `{
+	"options": [{
+		"id": "gid://shopify/ProductOption/1",
+		"name": "Color",
+		"position": 1,
+		"optionValues": [
+			{"id": "gid://shopify/ProductOptionValue/11", "name": "Blue", "hasVariants": true},
+			{"id": "gid://shopify/ProductOptionValue/12", "name": "Red", "hasVariants": false}
+		]
+	}]
+}`
+
+var expectedProductOptions = []ProductOptionNode{{
+	Id:       "gid://shopify/ProductOption/1",
+	Name:     "Color",
+	Position: 1,
+	OptionValues: []ProductOptionValueNode{
+		{Id: "gid://shopify/ProductOptionValue/11", Name: "Blue", HasVariants: true},
+		{Id: "gid://shopify/ProductOptionValue/12", Name: "Red"},
+	},
+}}
+
+func TestProductOptionCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var req graphQLTestRequest
+	registerGraphQLResponder(`{"data":{"productOptionsCreate":{"userErrors":[],"product":`+productOptionsResponse+`}}}`, &req)
+
+	options, err := client.ProductOption.Create(context.Background(), 1, []ProductOptionCreate{{
+		Name:   "Color",
+		Values: []ProductOptionValueCreate{{Name: "Blue"}, {Name: "Red"}},
+	}})
+	if err != nil {
+		t.Fatalf("ProductOption.Create returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(options, expectedProductOptions) {
+		t.Errorf("ProductOption.Create returned %+v, expected %+v", options, expectedProductOptions)
+	}
+	if !strings.Contains(req.Query, "productOptionsCreate(") {
+		t.Errorf("ProductOption.Create sent query %s", req.Query)
+	}
+	if req.Variables["productId"] != "gid://shopify/Product/1" {
+		t.Errorf("ProductOption.Create sent productId %v", req.Variables["productId"])
+	}
+}
+
+func TestProductOptionRenameValue(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var req graphQLTestRequest
+	registerGraphQLResponder(`{"data":{"productOptionUpdate":{"userErrors":[],"product":`+productOptionsResponse+`}}}`, &req)
+
+	_, err := client.ProductOption.RenameValue(context.Background(), 1, "gid://shopify/ProductOption/1", "gid://shopify/ProductOptionValue/11", "Navy")
+	if err != nil {
+		t.Fatalf("ProductOption.RenameValue returned error: %v", err)
+	}
+
+	expectedOption := map[string]interface{}{"id": "gid://shopify/ProductOption/1"}
+	if !reflect.DeepEqual(req.Variables["option"], expectedOption) {
+		t.Errorf("ProductOption.RenameValue sent option %v, expected %v", req.Variables["option"], expectedOption)
+	}
+
+	expectedValues := []interface{}{map[string]interface{}{"id": "gid://shopify/ProductOptionValue/11", "name": "Navy"}}
+	if !reflect.DeepEqual(req.Variables["optionValuesToUpdate"], expectedValues) {
+		t.Errorf("ProductOption.RenameValue sent values %v, expected %v", req.Variables["optionValuesToUpdate"], expectedValues)
+	}
+	if _, ok := req.Variables["optionValuesToAdd"]; ok {
+		t.Error("ProductOption.RenameValue should not send optionValuesToAdd")
+	}
+}
+
+func TestProductOptionReorder(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var req graphQLTestRequest
+	registerGraphQLResponder(`{"data":{"productOptionsReorder":{"userErrors":[],"product":`+productOptionsResponse+`}}}`, &req)
+
+	options, err := client.ProductOption.Reorder(context.Background(), 1, []ProductOptionReorder{
+		{Name: "Color", Values: []ProductOptionValueReorder{{Name: "Red"}, {Name: "Blue"}}},
+	})
+	if err != nil {
+		t.Fatalf("ProductOption.Reorder returned error: %v", err)
+	}
+	if !reflect.DeepEqual(options, expectedProductOptions) {
+		t.Errorf("ProductOption.Reorder returned %+v, expected %+v", options, expectedProductOptions)
+	}
+}
+
+func TestProductOptionUpdateUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerGraphQLResponder(`{"data":{"productOptionUpdate":{"userErrors":[{"field":["option","name"],"message":"Option name already exists","code":"OPTION_ALREADY_EXISTS"}],"product":null}}}`, nil)
+
+	_, err := client.ProductOption.Update(context.Background(), 1, ProductOptionUpdate{
+		Option: ProductOptionUpdateOption{Id: "gid://shopify/ProductOption/1", Name: "Size"},
+	})
+	expected := "option.name: Option name already exists"
+	if err == nil || err.Error() != expected {
+		t.Errorf("ProductOption.Update returned error %v, expected %s", err, expected)
+	}
+}
@@ -0,0 +1,107 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// productStatusUpdateMutation is run by SetStatusBulkGraphQL for each product
+const productStatusUpdateMutation = `mutation productStatusUpdate($input: ProductInput!) {
+  productUpdate(input: $input) {
+    product {
+      id
+    }
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+// BulkProgressFunc is called after each item of a bulk operation with the
+// number of items done and the total number of items
+type BulkProgressFunc func(done, total int)
+
+// SetStatusBulk sets the status of every product, e.g. to archive or publish
+// thousands of products. Products are updated one at a time and the calls are
+// paced from the call limit of the previous update to keep the REST bucket
+// from overflowing. progress is optional. The first error stops the operation
+// and names the product which failed. See SetStatusBulkGraphQL to update the
+// products in a single bulk mutation instead.
+func (s *ProductServiceOp) SetStatusBulk(ctx context.Context, productIds []uint64, status ProductStatus, progress BulkProgressFunc) error {
+	last := &Response{}
+	ctx = context.WithValue(ctx, responseKey{}, last)
+	for i, productId := range productIds {
+		if err := s.client.paceREST(ctx, last); err != nil {
+			return err
+		}
+
+		path := fmt.Sprintf("%s/%d.json", productsBasePath, productId)
+		wrappedData := ProductResource{Product: &Product{Id: productId, Status: status}}
+		if err := s.client.Put(ctx, path, wrappedData, nil); err != nil {
+			return fmt.Errorf("product %d: %w", productId, err)
+		}
+
+		if progress != nil {
+			progress(i+1, len(productIds))
+		}
+	}
+	return nil
+}
+
+// SetStatusBulkGraphQL sets the status of every product with a productUpdate
+// bulk mutation and waits for it to stop running. progress is optional and
+// called after each poll of the operation. Mutations failing with user errors
+// do not stop the operation, they are reported in the JSONL file at the Url
+// of the returned operation. An error is returned when the operation did not
+// complete.
+func (s *ProductServiceOp) SetStatusBulkGraphQL(ctx context.Context, productIds []uint64, status ProductStatus, progress BulkProgressFunc) (*BulkOperation, error) {
+	w := s.client.BulkOperation.NewMutation(productStatusUpdateMutation)
+	for _, productId := range productIds {
+		input := map[string]interface{}{"id": NewGid("Product", productId), "status": strings.ToUpper(string(status))}
+		if err := w.Add(map[string]interface{}{"input": input}); err != nil {
+			return nil, err
+		}
+	}
+	operation, err := w.Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pollProgress BulkProgressFunc
+	if progress != nil {
+		pollProgress = func(done, _ int) {
+			progress(done, len(productIds))
+		}
+	}
+	operation, err = s.client.BulkOperation.Wait(ctx, operation.Id, 0, pollProgress)
+	if err != nil {
+		return operation, err
+	}
+	if operation.Status != BulkOperationStatusCompleted {
+		return operation, fmt.Errorf("bulk operation %s %s: %s", operation.Id, strings.ToLower(operation.Status), operation.ErrorCode)
+	}
+	return operation, nil
+}
+
+// paceREST waits for the REST bucket to leak when the last response to the
+// caller reported it almost full. The call limit of the caller's own response
+// is used rather than the RateLimits of the client, which other goroutines
+// update concurrently.
+func (c *Client) paceREST(ctx context.Context, last *Response) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	used, size, ok := last.CallLimit()
+	limit := size - restPacingReserve
+	if !ok || used < limit {
+		return nil
+	}
+
+	wait := time.Duration(used-limit+1) * time.Second / restLeakRate
+	c.log.Debugf("rest bucket at %d/%d, waiting %s", used, size, wait)
+	return sleepContext(ctx, wait)
+}
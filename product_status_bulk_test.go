@@ -0,0 +1,138 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestProductSetStatusBulk(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var statuses []ProductStatus
+	for _, id := range []uint64{1, 2, 3} {
+		httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/%d.json", client.pathPrefix, id),
+			func(req *http.Request) (*http.Response, error) {
+				resource := ProductResource{}
+				if err := json.NewDecoder(req.Body).Decode(&resource); err != nil {
+					return nil, err
+				}
+				statuses = append(statuses, resource.Product.Status)
+				resp := httpmock.NewStringResponse(200, `{"product":{"id":1}}`)
+				resp.Header.Set("X-Shopify-Shop-Api-Call-Limit", "38/40")
+				return resp, nil
+			})
+	}
+
+	var progress [][2]int
+	start := time.Now()
+	err := client.Product.SetStatusBulk(context.Background(), []uint64{1, 2, 3}, ProductStatusArchived, func(done, total int) {
+		progress = append(progress, [2]int{done, total})
+	})
+	if err != nil {
+		t.Fatalf("Product.SetStatusBulk returned error: %v", err)
+	}
+
+	expectedStatuses := []ProductStatus{ProductStatusArchived, ProductStatusArchived, ProductStatusArchived}
+	if !reflect.DeepEqual(statuses, expectedStatuses) {
+		t.Errorf("Product.SetStatusBulk sent %v, expected %v", statuses, expectedStatuses)
+	}
+
+	expectedProgress := [][2]int{{1, 3}, {2, 3}, {3, 3}}
+	if !reflect.DeepEqual(progress, expectedProgress) {
+		t.Errorf("Product.SetStatusBulk reported progress %v, expected %v", progress, expectedProgress)
+	}
+
+	// the bucket is reported almost full after the first call, the next two wait half a second each
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Product.SetStatusBulk took %s, expected the calls to be paced", elapsed)
+	}
+}
+
+func TestProductSetStatusBulkError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"product":{"id":1}}`))
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/2.json", client.pathPrefix),
+		httpmock.NewStringResponder(404, `{"errors":"Not Found"}`))
+
+	done := 0
+	err := client.Product.SetStatusBulk(context.Background(), []uint64{1, 2, 3}, ProductStatusActive, func(d, total int) {
+		done = d
+	})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Product.SetStatusBulk returned %v, expected ErrNotFound", err)
+	}
+	if done != 1 {
+		t.Errorf("Product.SetStatusBulk reported %d products done, expected 1", done)
+	}
+}
+
+func TestProductSetStatusBulkGraphQL(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var uploaded string
+	httpmock.RegisterResponder("POST", "https://shopify-staged-uploads.storage.googleapis.com/",
+		func(req *http.Request) (*http.Response, error) {
+			file, _, err := req.FormFile("file")
+			if err != nil {
+				return nil, err
+			}
+			content, _ := io.ReadAll(file)
+			uploaded = string(content)
+			return httpmock.NewStringResponse(201, ""), nil
+		})
+
+	var mutation string
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			captured := graphQLTestRequest{}
+			if err := json.NewDecoder(req.Body).Decode(&captured); err != nil {
+				return nil, err
+			}
+			switch {
+			case strings.HasPrefix(captured.Query, "mutation stagedUploadsCreate"):
+				return httpmock.NewStringResponse(200, `{"data":{"stagedUploadsCreate":{"stagedTargets":[{"url":"https://shopify-staged-uploads.storage.googleapis.com/","parameters":[{"name":"key","value":"tmp/1/bulk/vars.jsonl"}]}],"userErrors":[]}}}`), nil
+			case strings.HasPrefix(captured.Query, "mutation bulkOperationRunMutation"):
+				mutation, _ = captured.Variables["mutation"].(string)
+				return httpmock.NewStringResponse(200, `{"data":{"bulkOperationRunMutation":{"bulkOperation":{"id":"gid://shopify/BulkOperation/1","status":"CREATED","objectCount":"0"},"userErrors":[]}}}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"data":{"node":{"id":"gid://shopify/BulkOperation/1","status":"COMPLETED","objectCount":"2","url":"https://storage.example.com/result.jsonl"}}}`), nil
+		})
+
+	var progress [][2]int
+	operation, err := client.Product.SetStatusBulkGraphQL(context.Background(), []uint64{1, 2}, ProductStatusArchived, func(done, total int) {
+		progress = append(progress, [2]int{done, total})
+	})
+	if err != nil {
+		t.Fatalf("Product.SetStatusBulkGraphQL returned error: %v", err)
+	}
+	if operation.Url != "https://storage.example.com/result.jsonl" {
+		t.Errorf("Product.SetStatusBulkGraphQL returned %+v", operation)
+	}
+	if mutation != productStatusUpdateMutation {
+		t.Errorf("bulkOperationRunMutation got mutation %q", mutation)
+	}
+
+	expected := `{"input":{"id":"gid://shopify/Product/1","status":"ARCHIVED"}}` + "\n" +
+		`{"input":{"id":"gid://shopify/Product/2","status":"ARCHIVED"}}` + "\n"
+	if uploaded != expected {
+		t.Errorf("staged upload sent %q, expected %q", uploaded, expected)
+	}
+	if !reflect.DeepEqual(progress, [][2]int{{2, 2}}) {
+		t.Errorf("Product.SetStatusBulkGraphQL reported progress %v", progress)
+	}
+}
@@ -0,0 +1,61 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// productSummaryFields are the fields requested by ListSummaries, variants
+// are only requested to count them
+const productSummaryFields = "id,title,handle,status,updated_at,variants"
+
+// ProductSummary is a trimmed product for catalog-wide scans, see
+// ProductService.ListSummaries
+type ProductSummary struct {
+	Id           uint64        `json:"id,omitempty"`
+	Title        string        `json:"title,omitempty"`
+	Handle       string        `json:"handle,omitempty"`
+	Status       ProductStatus `json:"status,omitempty"`
+	UpdatedAt    *time.Time    `json:"updated_at,omitempty"`
+	VariantCount int           `json:"-"`
+}
+
+// UnmarshalJSON counts the variants of the product without keeping them
+func (p *ProductSummary) UnmarshalJSON(data []byte) error {
+	type summary ProductSummary
+	decoded := struct {
+		*summary
+		Variants []struct{} `json:"variants"`
+	}{summary: (*summary)(p)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	p.VariantCount = len(decoded.Variants)
+	return nil
+}
+
+// ProductSummariesResource represents the result from the products.json
+// endpoint when requested by ListSummaries
+type ProductSummariesResource struct {
+	Products []ProductSummary `json:"products"`
+}
+
+// ListSummaries lists products requesting only the fields of a
+// ProductSummary, the Fields of options are replaced. The variants are still
+// sent by shopify to be counted but are not kept in memory. Use
+// ProductListOptions{ListOptions: *pagination.NextPageOptions} to request the
+// next page.
+func (s *ProductServiceOp) ListSummaries(ctx context.Context, options ProductListOptions) ([]ProductSummary, *Pagination, error) {
+	options.Fields = productSummaryFields
+	path := fmt.Sprintf("%s.json", productsBasePath)
+	resource := new(ProductSummariesResource)
+
+	pagination, err := s.client.ListWithPagination(ctx, path, resource, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resource.Products, pagination, nil
+}
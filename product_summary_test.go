@@ -0,0 +1,43 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestProductListSummaries(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
+		"fields=id%2Ctitle%2Chandle%2Cstatus%2Cupdated_at%2Cvariants&limit=50&vendor=Acme",
+		httpmock.NewStringResponder(200, `{"products":[
+			{"id":1,"title":"Shirt","handle":"shirt","status":"active","updated_at":"2024-01-02T10:00:00Z","variants":[{"id":11,"title":"S"},{"id":12,"title":"M"}]},
+			{"id":2,"title":"Hat","handle":"hat","status":"draft","variants":[]}
+		]}`))
+
+	summaries, _, err := client.Product.ListSummaries(context.Background(), ProductListOptions{
+		ListOptions: ListOptions{Limit: 50, Fields: "id"},
+		Vendor:      "Acme",
+	})
+	if err != nil {
+		t.Fatalf("Product.ListSummaries returned error: %v", err)
+	}
+
+	updatedAt := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	if len(summaries) != 2 {
+		t.Fatalf("Product.ListSummaries returned %d summaries, expected 2", len(summaries))
+	}
+	first := summaries[0]
+	if first.Id != 1 || first.Title != "Shirt" || first.Handle != "shirt" || first.Status != ProductStatusActive ||
+		first.UpdatedAt == nil || !first.UpdatedAt.Equal(updatedAt) || first.VariantCount != 2 {
+		t.Errorf("Product.ListSummaries returned %+v", first)
+	}
+	if summaries[1].VariantCount != 0 || summaries[1].Status != ProductStatusDraft {
+		t.Errorf("Product.ListSummaries returned %+v", summaries[1])
+	}
+}
@@ -70,7 +70,7 @@ func TestProductListError(t *testing.T) {
 	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
 		httpmock.NewStringResponder(500, ""))
 
-	expectedErrMessage := "Unknown Error"
+	expectedErrMessage := "500 Internal Server Error"
 
 	products, err := client.Product.List(context.Background(), nil)
 	if products != nil {
@@ -194,6 +194,35 @@ func TestProductListAll(t *testing.T) {
 	}
 }
 
+func TestProductListEach(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix)
+	httpmock.RegisterResponderWithQuery("GET", listURL, map[string]string{"limit": "250"},
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(200, `{"products": [{"id":1},{"id":2}]}`)
+			resp.Header.Set("Link", fmt.Sprintf(`<%s?page_info=pg2>; rel="next"`, listURL))
+			return resp, nil
+		})
+	httpmock.RegisterResponderWithQuery("GET", listURL, map[string]string{"page_info": "pg2"},
+		httpmock.NewStringResponder(200, `{"products": [{"id":3}]}`))
+
+	var ids []uint64
+	err := client.Product.ListEach(context.Background(), ListOptions{Limit: 250}, func(product Product) error {
+		ids = append(ids, product.Id)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Product.ListEach returned error: %v", err)
+	}
+
+	expected := []uint64{1, 2, 3}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Product.ListEach decoded products %v, expected %v", ids, expected)
+	}
+}
+
 func TestProductListWithPagination(t *testing.T) {
 	setup()
 	defer teardown()
@@ -314,6 +343,82 @@ func TestProductListWithPagination(t *testing.T) {
 	}
 }
 
+func TestProductListWithOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{
+		"collection_id":          "1",
+		"product_type":           "shoes",
+		"vendor":                 "acme",
+		"handle":                 "runner",
+		"published_status":       "any",
+		"presentment_currencies": "USD,CAD",
+		"status":                 "active,draft",
+		"title":                  "Runner",
+	}
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
+		params, httpmock.NewStringResponder(200, `{"products": [{"id":1}]}`))
+
+	options := ProductListOptions{
+		CollectionId:          1,
+		ProductType:           "shoes",
+		Vendor:                "acme",
+		Handle:                "runner",
+		PublishedStatus:       PublishedStatusAny,
+		PresentmentCurrencies: "USD,CAD",
+		Status:                []ProductStatus{ProductStatusActive, ProductStatusDraft},
+		Title:                 "Runner",
+	}
+	products, err := client.Product.List(context.Background(), options)
+	if err != nil {
+		t.Errorf("Product.List returned error: %v", err)
+	}
+
+	expected := []Product{{Id: 1}}
+	if !reflect.DeepEqual(products, expected) {
+		t.Errorf("Product.List returned %+v, expected %+v", products, expected)
+	}
+}
+
+func TestProductListWithPresentmentCurrencyCodes(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{"presentment_currencies": "USD,CAD"}
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
+		params, httpmock.NewStringResponder(200, `{"products": [{"id":1}]}`))
+
+	products, err := client.Product.List(context.Background(), ProductListOptions{PresentmentCurrencyCodes: []string{"USD", "CAD"}})
+	if err != nil {
+		t.Errorf("Product.List returned error: %v", err)
+	}
+	if len(products) != 1 {
+		t.Errorf("Product.List returned %+v, expected product 1", products)
+	}
+}
+
+func TestProductCountWithOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{"vendor": "acme", "published_status": "unpublished"}
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/count.json", client.pathPrefix),
+		params, httpmock.NewStringResponder(200, `{"count": 4}`))
+
+	cnt, err := client.Product.Count(context.Background(), ProductCountOptions{
+		Vendor:          "acme",
+		PublishedStatus: PublishedStatusUnpublished,
+	})
+	if err != nil {
+		t.Errorf("Product.Count returned error: %v", err)
+	}
+
+	if cnt != 4 {
+		t.Errorf("Product.Count returned %d, expected 4", cnt)
+	}
+}
+
 func TestProductCount(t *testing.T) {
 	setup()
 	defer teardown()
@@ -0,0 +1,82 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// restVariantsLimit is the most variants a REST product is returned with,
+// products of the new product model can have up to 2048
+const restVariantsLimit = 100
+
+const productVariantsQuery = `query productVariants($id: ID!, $after: String) {
+  product(id: $id) {
+    variants(first: 250, after: $after) {
+      nodes {` + productVariantFields + `
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+// completeVariants appends to the variants REST returned for a product the
+// ones it truncated, paged with graphql once the variants count confirms
+// the truncation. The REST variants are kept as they are, graphql does not
+// return all their fields.
+func (s *ProductServiceOp) completeVariants(ctx context.Context, productId uint64, variants []Variant) ([]Variant, error) {
+	count, err := s.client.Variant.Count(ctx, productId, nil)
+	if err != nil {
+		return nil, err
+	}
+	if count <= len(variants) {
+		return variants, nil
+	}
+
+	paged, err := s.listVariantsFromGraphQL(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	returned := make(map[uint64]bool, len(variants))
+	for _, variant := range variants {
+		returned[variant.Id] = true
+	}
+	for _, variant := range paged {
+		if !returned[variant.Id] {
+			variants = append(variants, variant)
+		}
+	}
+	return variants, nil
+}
+
+// listVariantsFromGraphQL pages all the variants of a product with graphql
+func (s *ProductServiceOp) listVariantsFromGraphQL(ctx context.Context, productId uint64) ([]Variant, error) {
+	variants := []Variant{}
+	vars := map[string]interface{}{"id": NewGid("Product", productId)}
+	err := s.client.GraphQL.ForEachPage(ctx, productVariantsQuery, vars, func(data json.RawMessage) (GraphQLPageInfo, error) {
+		resp := struct {
+			Product *struct {
+				Variants struct {
+					Nodes    []variantNode   `json:"nodes"`
+					PageInfo GraphQLPageInfo `json:"pageInfo"`
+				} `json:"variants"`
+			} `json:"product"`
+		}{}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return GraphQLPageInfo{}, err
+		}
+		if resp.Product == nil {
+			return GraphQLPageInfo{}, nil
+		}
+		for i := range resp.Product.Variants.Nodes {
+			variants = append(variants, resp.Product.Variants.Nodes[i].variant(productId))
+		}
+		return resp.Product.Variants.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return variants, nil
+}
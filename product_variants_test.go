@@ -0,0 +1,103 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func variantsJSON(from, to int, format string) string {
+	variants := []string{}
+	for i := from; i <= to; i++ {
+		variants = append(variants, fmt.Sprintf(format, i))
+	}
+	return strings.Join(variants, ",")
+}
+
+func TestProductGetMoreThan100Variants(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"product":{"id":1,"variants":[`+variantsJSON(1, 100, `{"id":%d,"weight_unit":"kg"}`)+`]}}`))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1/variants/count.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"count":300}`))
+
+	var cursors []interface{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			captured := graphQLTestRequest{}
+			if err := json.NewDecoder(req.Body).Decode(&captured); err != nil {
+				return nil, err
+			}
+			cursors = append(cursors, captured.Variables["after"])
+			if captured.Variables["after"] == nil {
+				return httpmock.NewStringResponse(200, `{"data":{"product":{"variants":{"nodes":[`+
+					variantsJSON(1, 250, `{"id":"gid://shopify/ProductVariant/%d"}`)+`],"pageInfo":{"hasNextPage":true,"endCursor":"c1"}}}}}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"data":{"product":{"variants":{"nodes":[`+
+				variantsJSON(251, 300, `{"id":"gid://shopify/ProductVariant/%d"}`)+`],"pageInfo":{"hasNextPage":false}}}}}`), nil
+		})
+
+	product, err := client.Product.Get(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("Product.Get returned error: %v", err)
+	}
+	if len(product.Variants) != 300 {
+		t.Fatalf("Product.Get returned %d variants, expected 300", len(product.Variants))
+	}
+	if product.Variants[299].Id != 300 || product.Variants[299].ProductId != 1 {
+		t.Errorf("Product.Get returned last variant %+v", product.Variants[299])
+	}
+	if product.Variants[0].Id != 1 || product.Variants[99].Id != 100 || product.Variants[99].WeightUnit != "kg" || product.Variants[100].Id != 101 {
+		t.Errorf("Product.Get did not keep the REST variants, returned %+v and %+v", product.Variants[0], product.Variants[99])
+	}
+	if len(cursors) != 2 || cursors[1] != "c1" {
+		t.Errorf("Product.Get paged variants with cursors %v", cursors)
+	}
+}
+
+func TestProductGetExactly100Variants(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"product":{"id":1,"variants":[`+variantsJSON(1, 100, `{"id":%d}`)+`]}}`))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1/variants/count.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"count":100}`))
+
+	product, err := client.Product.Get(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("Product.Get returned error: %v", err)
+	}
+	if len(product.Variants) != 100 {
+		t.Errorf("Product.Get returned %d variants, expected 100", len(product.Variants))
+	}
+	if info := httpmock.GetCallCountInfo(); info[fmt.Sprintf("POST https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix)] != 0 {
+		t.Errorf("Product.Get queried graphql for a product with 100 variants")
+	}
+}
+
+func TestProductGetFewVariants(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"product":{"id":1,"variants":[`+variantsJSON(1, 99, `{"id":%d}`)+`]}}`))
+
+	product, err := client.Product.Get(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("Product.Get returned error: %v", err)
+	}
+	if len(product.Variants) != 99 {
+		t.Errorf("Product.Get returned %d variants, expected 99", len(product.Variants))
+	}
+	if info := httpmock.GetCallCountInfo(); info[fmt.Sprintf("POST https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix)] != 0 {
+		t.Errorf("Product.Get queried graphql for a product with 99 variants")
+	}
+}
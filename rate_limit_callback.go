@@ -0,0 +1,101 @@
+package goshopify
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// DefaultRateLimitThresholds are the bucket utilizations announced by
+// WithRateLimitCallback when no thresholds are given
+var DefaultRateLimitThresholds = []float64{0.5, 0.8, 0.95}
+
+// BucketState is the state of a rate limit bucket of a shop announced by
+// WithRateLimitCallback
+type BucketState struct {
+	Shop string
+
+	// GraphQL tells the query cost bucket from the REST call bucket
+	GraphQL bool
+
+	// Used and Size are the calls, or query cost points, used and available
+	// in the bucket
+	Used float64
+	Size float64
+
+	// Utilization is Used over Size, from 0 to 1
+	Utilization float64
+
+	// Threshold is the highest threshold the utilization reached, 0 once it
+	// dropped below every threshold
+	Threshold float64
+}
+
+// rateLimitAnnouncer calls back when the utilization of the buckets of the
+// client crosses a threshold
+type rateLimitAnnouncer struct {
+	callback   func(BucketState)
+	thresholds []float64
+
+	mu sync.Mutex
+	// reached are the thresholds reached by the REST and GraphQL buckets
+	reached map[bool]float64
+}
+
+// announce calls back when the threshold reached by the utilization of state
+// changed since the last response
+func (a *rateLimitAnnouncer) announce(state BucketState) {
+	if state.Size <= 0 {
+		return
+	}
+	state.Utilization = state.Used / state.Size
+	for _, threshold := range a.thresholds {
+		if state.Utilization >= threshold {
+			state.Threshold = threshold
+		}
+	}
+
+	a.mu.Lock()
+	changed := a.reached[state.GraphQL] != state.Threshold
+	a.reached[state.GraphQL] = state.Threshold
+	a.mu.Unlock()
+
+	if changed {
+		a.callback(state)
+	}
+}
+
+// newRateLimitAnnouncer returns an announcer calling callback at thresholds
+func newRateLimitAnnouncer(callback func(BucketState), thresholds []float64) *rateLimitAnnouncer {
+	if len(thresholds) == 0 {
+		thresholds = DefaultRateLimitThresholds
+	}
+	sorted := append([]float64(nil), thresholds...)
+	sort.Float64s(sorted)
+	return &rateLimitAnnouncer{callback: callback, thresholds: sorted, reached: map[bool]float64{}}
+}
+
+// announceCallLimit announces the REST bucket of the call limit header of a
+// response
+func (c *Client) announceCallLimit(req *http.Request, resp *http.Response) {
+	if c.rateLimitAnnouncer == nil {
+		return
+	}
+	if used, size, ok := parseCallLimit(resp.Header); ok {
+		c.rateLimitAnnouncer.announce(BucketState{Shop: c.shop, Used: float64(used), Size: float64(size)})
+	}
+}
+
+// announceQueryCost announces the GraphQL bucket of the cost of a query
+func (c *Client) announceQueryCost(cost GraphQLCost) {
+	if c.rateLimitAnnouncer == nil {
+		return
+	}
+	status := cost.ThrottleStatus
+	c.rateLimitAnnouncer.announce(BucketState{
+		Shop:    c.shop,
+		GraphQL: true,
+		Used:    status.MaximumAvailable - status.CurrentlyAvailable,
+		Size:    status.MaximumAvailable,
+	})
+}
@@ -0,0 +1,68 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestWithRateLimitCallback(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var states []BucketState
+	WithRateLimitCallback(func(state BucketState) { states = append(states, state) })(client)
+
+	callLimits := []string{"10/40", "20/40", "25/40", "33/40", "39/40", "12/40"}
+	calls := 0
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(200, `{"product":{"id":1}}`)
+			resp.Header.Set("X-Shopify-Shop-Api-Call-Limit", callLimits[calls])
+			calls++
+			return resp, nil
+		})
+
+	for range callLimits {
+		if _, err := client.Product.Get(context.Background(), 1, nil); err != nil {
+			t.Fatalf("Product.Get returned error: %v", err)
+		}
+	}
+
+	expected := []BucketState{
+		{Shop: "fooshop.myshopify.com", Used: 20, Size: 40, Utilization: 0.5, Threshold: 0.5},
+		{Shop: "fooshop.myshopify.com", Used: 33, Size: 40, Utilization: 0.825, Threshold: 0.8},
+		{Shop: "fooshop.myshopify.com", Used: 39, Size: 40, Utilization: 0.975, Threshold: 0.95},
+		{Shop: "fooshop.myshopify.com", Used: 12, Size: 40, Utilization: 0.3, Threshold: 0},
+	}
+	if !reflect.DeepEqual(states, expected) {
+		t.Errorf("callback was called with %+v, expected %+v", states, expected)
+	}
+}
+
+func TestWithRateLimitCallbackGraphQL(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var states []BucketState
+	WithRateLimitCallback(func(state BucketState) { states = append(states, state) }, 0.9)(client)
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{},"extensions":{"cost":{"requestedQueryCost":10,
+			"throttleStatus":{"maximumAvailable":1000.0,"currentlyAvailable":50,"restoreRate":50.0}}}}`))
+
+	if err := client.GraphQL.Query(context.Background(), "query {}", nil, nil); err != nil {
+		t.Fatalf("GraphQL.Query returned error: %v", err)
+	}
+
+	expected := []BucketState{
+		{Shop: "fooshop.myshopify.com", GraphQL: true, Used: 950, Size: 1000, Utilization: 0.95, Threshold: 0.9},
+	}
+	if !reflect.DeepEqual(states, expected) {
+		t.Errorf("callback was called with %+v, expected %+v", states, expected)
+	}
+}
@@ -0,0 +1,238 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// restLeakRate is the number of REST calls the bucket of a standard shop
+	// leaks per second
+	restLeakRate = 2
+
+	// restPacingReserve is the number of calls left free in the bucket for
+	// other requests of the app while pacing bulk operations
+	restPacingReserve = 2
+)
+
+// RateLimiter throttles the REST requests of clients before they are sent,
+// see WithRateLimiter. A limiter may be shared by the clients of many shops,
+// requests are keyed by shop domain.
+type RateLimiter interface {
+	// Wait blocks until a request to shop may be sent or ctx is done
+	Wait(ctx context.Context, shop string) error
+
+	// Update records the X-Shopify-Shop-Api-Call-Limit of a response, used
+	// calls out of a bucket of size
+	Update(shop string, used, size int)
+}
+
+// LeakyBucket is a RateLimiter modelling the REST bucket of every shop from
+// the X-Shopify-Shop-Api-Call-Limit header of the responses. Requests wait
+// for the bucket to leak instead of overflowing it, so bursts of calls do not
+// get 429 responses. The buckets are kept in a CacheStore, the replicas of an
+// app sharing a store, e.g. a redisstore.Store, pace their calls together.
+// The zero value is not usable, see NewLeakyBucket.
+type LeakyBucket struct {
+	// Reserve is the number of calls left free in the bucket, e.g. for
+	// other processes using the same shop
+	Reserve int
+
+	store CacheStore
+	mu    sync.Mutex
+	now   func() time.Time
+}
+
+const (
+	leakyBucketKeyPrefix = "ratelimit:"
+
+	// leakyBucketLockTTL bounds how long a process which died holding the
+	// lock of a bucket blocks the others
+	leakyBucketLockTTL   = time.Second
+	leakyBucketLockRetry = 5 * time.Millisecond
+)
+
+// shopBucket is the modelled level of the bucket of a shop at a time
+type shopBucket struct {
+	Level float64   `json:"level"`
+	Size  int       `json:"size"`
+	At    time.Time `json:"at"`
+}
+
+// NewLeakyBucket returns a LeakyBucket keeping the buckets in store, an
+// in-process store when nil, and assuming the bucket of a standard shop until
+// a response reports its size. The bucket of a shop is read and written under
+// a lock, taken with SetNX among the processes sharing the store when it is an
+// AtomicCacheStore, so their calls are not lost.
+func NewLeakyBucket(store CacheStore) *LeakyBucket {
+	if store == nil {
+		store = NewMemoryCacheStore()
+	}
+	return &LeakyBucket{
+		Reserve: restPacingReserve,
+		store:   store,
+		now:     time.Now,
+	}
+}
+
+// Wait reserves a call in the bucket of shop, waiting for it to leak when it
+// is full
+func (b *LeakyBucket) Wait(ctx context.Context, shop string) error {
+	for {
+		wait, err := b.reserve(ctx, shop)
+		if err != nil || wait == 0 {
+			return err
+		}
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve reserves a call in the bucket of shop, or returns the wait for the
+// bucket to leak enough when it is full
+func (b *LeakyBucket) reserve(ctx context.Context, shop string) (time.Duration, error) {
+	unlock, err := b.lock(ctx, shop)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	bucket, err := b.load(ctx, shop)
+	if err != nil {
+		return 0, err
+	}
+	limit := math.Max(float64(bucket.Size-b.Reserve), 1)
+	if bucket.Level+1 <= limit {
+		bucket.Level++
+		return 0, b.save(ctx, shop, bucket)
+	}
+	return time.Duration((bucket.Level + 1 - limit) / bucket.leakRate() * float64(time.Second)), nil
+}
+
+// Update sets the bucket of shop to the reported level. Calls reserved by
+// requests still in flight are kept when the bucket is modelled fuller. The
+// errors of the store are dropped, the next response reports the level again.
+func (b *LeakyBucket) Update(shop string, used, size int) {
+	ctx := context.Background()
+	unlock, err := b.lock(ctx, shop)
+	if err != nil {
+		return
+	}
+	defer unlock()
+
+	bucket, err := b.load(ctx, shop)
+	if err != nil {
+		return
+	}
+	bucket.Level = math.Max(bucket.Level, float64(used))
+	if size > 0 {
+		bucket.Size = size
+	}
+	_ = b.save(ctx, shop, bucket)
+}
+
+// current returns the bucket of shop leaked up to now
+func (b *LeakyBucket) current(ctx context.Context, shop string) (*shopBucket, error) {
+	unlock, err := b.lock(ctx, shop)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return b.load(ctx, shop)
+}
+
+// lock serializes the reads and writes of the bucket of shop, among the
+// processes sharing the store when it is an AtomicCacheStore
+func (b *LeakyBucket) lock(ctx context.Context, shop string) (func(), error) {
+	b.mu.Lock()
+	store, ok := b.store.(AtomicCacheStore)
+	if !ok {
+		return b.mu.Unlock, nil
+	}
+
+	key := leakyBucketKeyPrefix + shop + ":lock"
+	for {
+		locked, err := store.SetNX(ctx, key, []byte{1}, leakyBucketLockTTL)
+		if err != nil {
+			b.mu.Unlock()
+			return nil, err
+		}
+		if locked {
+			return func() {
+				_ = store.Delete(context.Background(), key)
+				b.mu.Unlock()
+			}, nil
+		}
+		if err := sleepContext(ctx, leakyBucketLockRetry); err != nil {
+			b.mu.Unlock()
+			return nil, err
+		}
+	}
+}
+
+// load returns the bucket of shop leaked up to now
+func (b *LeakyBucket) load(ctx context.Context, shop string) (*shopBucket, error) {
+	now := b.now()
+	bucket := &shopBucket{Size: defaultRESTBucketSize, At: now}
+	value, found, err := b.store.Get(ctx, leakyBucketKeyPrefix+shop)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		if err := json.Unmarshal(value, bucket); err != nil {
+			return nil, err
+		}
+	}
+	bucket.Level = math.Max(bucket.Level-now.Sub(bucket.At).Seconds()*bucket.leakRate(), 0)
+	bucket.At = now
+	return bucket, nil
+}
+
+// save stores the bucket of shop until it leaked empty, a missing bucket is
+// an empty one
+func (b *LeakyBucket) save(ctx context.Context, shop string, bucket *shopBucket) error {
+	value, err := json.Marshal(bucket)
+	if err != nil {
+		return err
+	}
+	ttl := time.Duration(bucket.Level/bucket.leakRate()*float64(time.Second)) + time.Minute
+	return b.store.Set(ctx, leakyBucketKeyPrefix+shop, value, ttl)
+}
+
+// leakRate scales the leak rate of a standard shop with the bucket size, a
+// bucket of 80 calls leaks 4 calls per second
+func (s *shopBucket) leakRate() float64 {
+	return restLeakRate * float64(s.Size) / defaultRESTBucketSize
+}
+
+// waitRateLimiter waits for the rate limiter of the client before a REST
+// request is sent
+func (c *Client) waitRateLimiter(req *http.Request) error {
+	if c.rateLimiter == nil || isGraphQLRequest(req) {
+		return nil
+	}
+	return c.rateLimiter.Wait(req.Context(), c.shop)
+}
+
+// updateRateLimiter passes the call limit of a response to the rate limiter
+// of the client
+func (c *Client) updateRateLimiter(req *http.Request, resp *http.Response) {
+	if c.rateLimiter == nil {
+		return
+	}
+	if used, size, ok := parseCallLimit(resp.Header); ok {
+		c.rateLimiter.Update(c.shop, used, size)
+	}
+}
+
+// isGraphQLRequest reports whether req is sent to the graphql endpoint,
+// which is limited by query cost instead of the REST bucket
+func isGraphQLRequest(req *http.Request) bool {
+	return strings.HasSuffix(req.URL.Path, "/graphql.json")
+}
@@ -0,0 +1,104 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestLeakyBucketWait(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	bucket := NewLeakyBucket(nil)
+	bucket.now = func() time.Time { return now }
+
+	for i := 0; i < 38; i++ {
+		if err := bucket.Wait(context.Background(), "fooshop.myshopify.com"); err != nil {
+			t.Fatalf("LeakyBucket.Wait returned error on call %d: %v", i+1, err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := bucket.Wait(ctx, "fooshop.myshopify.com"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("LeakyBucket.Wait on a full bucket returned %v, expected context.DeadlineExceeded", err)
+	}
+
+	// the bucket of other shops is not shared
+	if err := bucket.Wait(ctx, "barshop.myshopify.com"); err != nil {
+		t.Errorf("LeakyBucket.Wait for another shop returned error: %v", err)
+	}
+
+	// a standard bucket leaks 2 calls per second
+	now = now.Add(time.Second)
+	for i := 0; i < 2; i++ {
+		if err := bucket.Wait(context.Background(), "fooshop.myshopify.com"); err != nil {
+			t.Fatalf("LeakyBucket.Wait returned error after leaking: %v", err)
+		}
+	}
+}
+
+func TestLeakyBucketUpdate(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	bucket := NewLeakyBucket(nil)
+	bucket.now = func() time.Time { return now }
+
+	bucket.Update("fooshop.myshopify.com", 78, 80)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := bucket.Wait(ctx, "fooshop.myshopify.com"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("LeakyBucket.Wait at 78/80 returned %v, expected context.DeadlineExceeded", err)
+	}
+
+	// an 80 calls bucket leaks 4 calls per second
+	now = now.Add(250 * time.Millisecond)
+	if err := bucket.Wait(context.Background(), "fooshop.myshopify.com"); err != nil {
+		t.Errorf("LeakyBucket.Wait returned error after leaking: %v", err)
+	}
+}
+
+func TestLeakyBucketSharedStore(t *testing.T) {
+	store := NewMemoryCacheStore()
+	first, second := NewLeakyBucket(store), NewLeakyBucket(store)
+
+	for i := 0; i < 38; i++ {
+		if err := first.Wait(context.Background(), "fooshop.myshopify.com"); err != nil {
+			t.Fatalf("LeakyBucket.Wait returned error on call %d: %v", i+1, err)
+		}
+	}
+
+	// the calls of another process sharing the store fill the same bucket
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := second.Wait(ctx, "fooshop.myshopify.com"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("LeakyBucket.Wait on a bucket filled by another limiter returned %v, expected context.DeadlineExceeded", err)
+	}
+}
+
+func TestClientRateLimiter(t *testing.T) {
+	bucket := NewLeakyBucket(nil)
+	testClient := MustNewClient(app, "fooshop", "abcd", WithRateLimiter(bucket))
+	httpmock.ActivateNonDefault(testClient.Client)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", testClient.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(200, `{"shop":{"id":1}}`)
+			resp.Header.Set("X-Shopify-Shop-Api-Call-Limit", "39/40")
+			return resp, nil
+		})
+
+	if _, err := testClient.Shop.Get(context.Background(), nil); err != nil {
+		t.Fatalf("Shop.Get returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := testClient.Shop.Get(ctx, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shop.Get with a full bucket returned %v, expected context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,104 @@
+package goshopify
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// redactedValue replaces redacted values
+const redactedValue = "[REDACTED]"
+
+// DefaultRedactedFields are the customer PII, address and credential fields
+// redacted by NewFieldRedactor when no fields are given
+var DefaultRedactedFields = []string{
+	"email", "phone", "first_name", "last_name", "firstName", "lastName",
+	"address1", "address2", "city", "zip", "company", "latitude", "longitude",
+	"customer_locale", "browser_ip", "client_details", "note",
+	"access_token", "accessToken", "client_secret", "password", "token",
+}
+
+// Redactor removes sensitive data from requests and responses before they are
+// logged
+type Redactor interface {
+	RedactURL(*url.URL) *url.URL
+	RedactBody([]byte) []byte
+}
+
+// FieldRedactor is a Redactor replacing the values of JSON fields and query
+// parameters by name, at any depth
+type FieldRedactor struct {
+	fields map[string]bool
+}
+
+// NewFieldRedactor returns a FieldRedactor for fields, or for
+// DefaultRedactedFields when none are given. Names are matched exactly.
+func NewFieldRedactor(fields ...string) *FieldRedactor {
+	if len(fields) == 0 {
+		fields = DefaultRedactedFields
+	}
+	r := &FieldRedactor{fields: map[string]bool{}}
+	for _, field := range fields {
+		r.fields[field] = true
+	}
+	return r
+}
+
+// RedactURL returns a copy of u with the redacted query parameters replaced
+func (r *FieldRedactor) RedactURL(u *url.URL) *url.URL {
+	query := u.Query()
+	redacted := false
+	for key := range query {
+		if r.fields[key] {
+			query.Set(key, redactedValue)
+			redacted = true
+		}
+	}
+	if !redacted {
+		return u
+	}
+	copied := *u
+	copied.RawQuery = query.Encode()
+	return &copied
+}
+
+// RedactBody returns body with the redacted fields replaced. Bodies which are
+// not JSON are returned as is.
+func (r *FieldRedactor) RedactBody(body []byte) []byte {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return body
+	}
+
+	redacted, err := json.Marshal(r.redact(v))
+	if err != nil {
+		return body
+	}
+	return redacted
+}
+
+func (r *FieldRedactor) redact(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if r.fields[key] && value != nil {
+				v[key] = redactedValue
+			} else {
+				v[key] = r.redact(value)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = r.redact(value)
+		}
+	case string:
+		// graphql variables and webhook payloads may be nested JSON strings
+		if strings.HasPrefix(v, "{") {
+			return string(r.RedactBody([]byte(v)))
+		}
+	}
+	return v
+}
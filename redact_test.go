@@ -0,0 +1,59 @@
+package goshopify
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestFieldRedactorRedactBody(t *testing.T) {
+	r := NewFieldRedactor()
+
+	body := `{"customer":{"id":1,"email":"bob@example.com","addresses":[{"address1":"1 Main St","country":"CA"}],"note":null}}`
+	expected := `{"customer":{"addresses":[{"address1":"[REDACTED]","country":"CA"}],"email":"[REDACTED]","id":1,"note":null}}`
+	if redacted := string(r.RedactBody([]byte(body))); redacted != expected {
+		t.Errorf("RedactBody returned %s, expected %s", redacted, expected)
+	}
+
+	if redacted := string(r.RedactBody([]byte("not json"))); redacted != "not json" {
+		t.Errorf("RedactBody returned %s for a non JSON body", redacted)
+	}
+}
+
+func TestFieldRedactorRedactURL(t *testing.T) {
+	r := NewFieldRedactor("email")
+
+	u, _ := url.Parse("https://fooshop.myshopify.com/admin/customers.json?email=bob%40example.com&limit=1")
+	redacted := r.RedactURL(u)
+	if redacted.Query().Get("email") != redactedValue || redacted.Query().Get("limit") != "1" {
+		t.Errorf("RedactURL returned %s", redacted)
+	}
+	if u.Query().Get("email") != "bob@example.com" {
+		t.Errorf("RedactURL modified the request url")
+	}
+}
+
+func TestLogRequestRedacted(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger := &LeveledLogger{Level: LevelDebug, stdoutOverride: out}
+	client := MustNewClient(app, "fooshop", "abcd", WithLogger(logger), WithRedactor(NewFieldRedactor()))
+
+	body := `{"customer":{"email":"bob@example.com"}}`
+	req := &http.Request{
+		Method: "POST",
+		URL:    &url.URL{Scheme: "https", Host: "fooshop.myshopify.com", Path: "/admin/customers.json"},
+		Body:   ioutil.NopCloser(strings.NewReader(body)),
+	}
+	client.logRequest(req)
+
+	if strings.Contains(out.String(), "bob@example.com") {
+		t.Errorf("logRequest logged %s", out.String())
+	}
+	sent, _ := ioutil.ReadAll(req.Body)
+	if string(sent) != body {
+		t.Errorf("logRequest changed the request body to %s", sent)
+	}
+}
@@ -0,0 +1,22 @@
+module github.com/influxer-Engineering/go-shopify-influxer/redisstore
+
+go 1.21
+
+require (
+	github.com/alicebob/miniredis/v2 v2.33.0
+	github.com/influxer-Engineering/go-shopify-influxer v1.0.5
+	github.com/redis/go-redis/v9 v9.7.3
+)
+
+require (
+	github.com/alicebob/gopher-json v0.0.0-20200520072559-a9ecdc9d1d3a // indirect
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/google/go-querystring v1.0.0 // indirect
+	github.com/shopspring/decimal v0.0.0-20200105231215-408a2507e114 // indirect
+	github.com/yuin/gopher-lua v1.1.1 // indirect
+	go.opentelemetry.io/otel v1.28.0 // indirect
+	go.opentelemetry.io/otel/trace v1.28.0 // indirect
+)
+
+replace github.com/influxer-Engineering/go-shopify-influxer => ../
@@ -0,0 +1,70 @@
+// Package redisstore provides a goshopify.AtomicCacheStore backed by a
+// go-redis client, so the cached state and the rate limiter buckets are
+// shared by every process of an app. It is a module of its own so only the
+// apps using it depend on go-redis:
+//
+//	go get github.com/influxer-Engineering/go-shopify-influxer/redisstore
+package redisstore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	goshopify "github.com/influxer-Engineering/go-shopify-influxer"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ goshopify.AtomicCacheStore = (*Store)(nil)
+
+// Store is a goshopify.CacheStore keeping its values in redis. Connection
+// pooling, reconnects, TLS and authentication are the ones of the client.
+type Store struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// New returns a Store using client, a *redis.Client, *redis.ClusterClient or
+// any other redis.UniversalClient. keyPrefix is prepended to every key, e.g.
+// "goshopify:".
+//
+//	store := redisstore.New(redis.NewClient(&redis.Options{Addr: "localhost:6379"}), "goshopify:")
+//	client, err := goshopify.NewClient(app, shop, token, goshopify.WithCacheStore(store))
+func New(client redis.UniversalClient, keyPrefix string) *Store {
+	return &Store{client: client, prefix: keyPrefix}
+}
+
+// Get returns the value stored for key and whether it was found
+func (s *Store) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := s.client.Get(ctx, s.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Set stores value for key, a ttl <= 0 means the value never expires
+func (s *Store) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if ttl < 0 {
+		// a negative expiration keeps the ttl of the previous value in go-redis
+		ttl = 0
+	}
+	return s.client.Set(ctx, s.prefix+key, value, ttl).Err()
+}
+
+// SetNX stores value for key unless key is already set and reports whether
+// it stored it, a ttl <= 0 means the value never expires
+func (s *Store) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	if ttl < 0 {
+		ttl = 0
+	}
+	return s.client.SetNX(ctx, s.prefix+key, value, ttl).Result()
+}
+
+// Delete removes key, deleting a missing key is not an error
+func (s *Store) Delete(ctx context.Context, key string) error {
+	return s.client.Del(ctx, s.prefix+key).Err()
+}
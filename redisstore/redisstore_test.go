@@ -0,0 +1,68 @@
+package redisstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestStore(t *testing.T) {
+	server := miniredis.RunT(t)
+	ctx := context.Background()
+	store := New(redis.NewClient(&redis.Options{Addr: server.Addr()}), "goshopify:")
+
+	if _, found, err := store.Get(ctx, "currency"); err != nil || found {
+		t.Errorf("Store.Get returned %v, %v, expected false, nil", found, err)
+	}
+
+	if err := store.Set(ctx, "currency", []byte("CAD"), 90*time.Second); err != nil {
+		t.Fatalf("Store.Set returned error: %v", err)
+	}
+	if ttl := server.TTL("goshopify:currency"); ttl != 90*time.Second {
+		t.Errorf("Store.Set stored goshopify:currency with a ttl of %s, expected 90s", ttl)
+	}
+
+	value, found, err := store.Get(ctx, "currency")
+	if err != nil || !found || string(value) != "CAD" {
+		t.Errorf("Store.Get returned %s, %v, %v, expected CAD, true, nil", value, found, err)
+	}
+
+	if err := store.Set(ctx, "forever", []byte("a"), -1); err != nil {
+		t.Fatalf("Store.Set returned error: %v", err)
+	}
+	if ttl := server.TTL("goshopify:forever"); ttl != 0 {
+		t.Errorf("Store.Set stored goshopify:forever with a ttl of %s, expected none", ttl)
+	}
+
+	if stored, err := store.SetNX(ctx, "currency", []byte("USD"), time.Minute); err != nil || stored {
+		t.Errorf("Store.SetNX returned %v, %v for a key set, expected false, nil", stored, err)
+	}
+	if stored, err := store.SetNX(ctx, "webhook:a", []byte{0}, time.Minute); err != nil || !stored {
+		t.Errorf("Store.SetNX returned %v, %v for a missing key, expected true, nil", stored, err)
+	}
+	if ttl := server.TTL("goshopify:webhook:a"); ttl != time.Minute {
+		t.Errorf("Store.SetNX stored goshopify:webhook:a with a ttl of %s, expected 1m", ttl)
+	}
+
+	if err := store.Delete(ctx, "currency"); err != nil {
+		t.Errorf("Store.Delete returned error: %v", err)
+	}
+	if server.Exists("goshopify:currency") {
+		t.Error("Store.Delete kept goshopify:currency")
+	}
+}
+
+func TestStoreError(t *testing.T) {
+	server := miniredis.RunT(t)
+	server.RequireAuth("secret")
+	store := New(redis.NewClient(&redis.Options{Addr: server.Addr(), Password: "wrong"}), "")
+
+	_, _, err := store.Get(context.Background(), "currency")
+	if err == nil || errors.Is(err, redis.Nil) {
+		t.Errorf("Store.Get returned error %v, expected the authentication error", err)
+	}
+}
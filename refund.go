@@ -0,0 +1,68 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+)
+
+// RefundService is an interface for interfacing with the refund endpoints
+// of the Shopify API.
+// See: https://shopify.dev/docs/api/admin-rest/latest/resources/refund
+type RefundService interface {
+	List(context.Context, uint64, interface{}) ([]Refund, error)
+	Get(context.Context, uint64, uint64, interface{}) (*Refund, error)
+	Create(context.Context, uint64, Refund) (*Refund, error)
+	Calculate(context.Context, uint64, Refund) (*Refund, error)
+	CalculateAndCreate(context.Context, uint64, Refund) (*Refund, error)
+}
+
+// RefundServiceOp handles communication with the refund related methods of
+// the Shopify API.
+type RefundServiceOp struct {
+	client *Client
+}
+
+// RefundResource represents the result from the orders/X/refunds/Y.json endpoint
+type RefundResource struct {
+	Refund *Refund `json:"refund"`
+}
+
+// RefundsResource represents the result from the orders/X/refunds.json endpoint
+type RefundsResource struct {
+	Refunds []Refund `json:"refunds"`
+}
+
+// List refunds of an order
+func (s *RefundServiceOp) List(ctx context.Context, orderId uint64, options interface{}) ([]Refund, error) {
+	path := fmt.Sprintf("%s/%d/refunds.json", ordersBasePath, orderId)
+	resource := new(RefundsResource)
+	err := s.client.Get(ctx, path, resource, options)
+	return resource.Refunds, err
+}
+
+// Get individual refund
+func (s *RefundServiceOp) Get(ctx context.Context, orderId uint64, refundId uint64, options interface{}) (*Refund, error) {
+	path := fmt.Sprintf("%s/%d/refunds/%d.json", ordersBasePath, orderId, refundId)
+	resource := new(RefundResource)
+	err := s.client.Get(ctx, path, resource, options)
+	return resource.Refund, err
+}
+
+// Create a new refund
+func (s *RefundServiceOp) Create(ctx context.Context, orderId uint64, refund Refund) (*Refund, error) {
+	path := fmt.Sprintf("%s/%d/refunds.json", ordersBasePath, orderId)
+	wrappedData := RefundResource{Refund: &refund}
+	resource := new(RefundResource)
+	err := s.client.Post(ctx, path, wrappedData, resource)
+	return resource.Refund, err
+}
+
+// Calculate a refund, the returned refund holds the suggested transactions
+// which can be used to create the refund
+func (s *RefundServiceOp) Calculate(ctx context.Context, orderId uint64, refund Refund) (*Refund, error) {
+	path := fmt.Sprintf("%s/%d/refunds/calculate.json", ordersBasePath, orderId)
+	wrappedData := RefundResource{Refund: &refund}
+	resource := new(RefundResource)
+	err := s.client.Post(ctx, path, wrappedData, resource)
+	return resource.Refund, err
+}
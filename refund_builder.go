@@ -0,0 +1,199 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// RefundRestockType is what happens to the inventory of a refunded line item
+type RefundRestockType string
+
+const (
+	// The items are not restocked
+	RefundRestockTypeNoRestock RefundRestockType = "no_restock"
+
+	// The items were not fulfilled and are returned to stock
+	RefundRestockTypeCancel RefundRestockType = "cancel"
+
+	// The items were fulfilled and returned to the location
+	RefundRestockTypeReturn RefundRestockType = "return"
+)
+
+// refundTransactionKind is the kind of the transactions of a refund, the
+// calculate endpoint suggests transactions of kind suggested_refund
+const refundTransactionKind = "refund"
+
+// RefundBuilder assembles the line items and shipping of a refund, e.g.
+//
+//	refund, err := goshopify.NewRefundBuilder().
+//		Return(lineItemId, 1, locationId).
+//		Cancel(otherLineItemId, 2, locationId).
+//		SetNote("Damaged in transit").
+//		Build()
+//	created, err := client.Refund.CalculateAndCreate(ctx, orderId, refund)
+type RefundBuilder struct {
+	refund Refund
+}
+
+// NewRefundBuilder returns an empty RefundBuilder
+func NewRefundBuilder() *RefundBuilder {
+	return &RefundBuilder{}
+}
+
+// AddLineItem refunds a quantity of a line item, locationId is where the
+// items are restocked and is ignored for no_restock
+func (b *RefundBuilder) AddLineItem(lineItemId uint64, quantity int, restockType RefundRestockType, locationId uint64) *RefundBuilder {
+	if restockType == RefundRestockTypeNoRestock {
+		locationId = 0
+	}
+	b.refund.RefundLineItems = append(b.refund.RefundLineItems, RefundLineItem{
+		LineItemId:  lineItemId,
+		Quantity:    quantity,
+		RestockType: restockType,
+		LocationId:  locationId,
+	})
+	return b
+}
+
+// NoRestock refunds a quantity of a line item without restocking it
+func (b *RefundBuilder) NoRestock(lineItemId uint64, quantity int) *RefundBuilder {
+	return b.AddLineItem(lineItemId, quantity, RefundRestockTypeNoRestock, 0)
+}
+
+// Cancel refunds an unfulfilled quantity of a line item and restocks it at
+// the location
+func (b *RefundBuilder) Cancel(lineItemId uint64, quantity int, locationId uint64) *RefundBuilder {
+	return b.AddLineItem(lineItemId, quantity, RefundRestockTypeCancel, locationId)
+}
+
+// Return refunds a fulfilled quantity of a line item returned to the
+// location
+func (b *RefundBuilder) Return(lineItemId uint64, quantity int, locationId uint64) *RefundBuilder {
+	return b.AddLineItem(lineItemId, quantity, RefundRestockTypeReturn, locationId)
+}
+
+// RefundFullShipping refunds all the remaining shipping
+func (b *RefundBuilder) RefundFullShipping() *RefundBuilder {
+	b.refund.Shipping = &RefundShipping{FullRefund: true}
+	return b
+}
+
+// RefundShippingAmount refunds an amount of the shipping
+func (b *RefundBuilder) RefundShippingAmount(amount decimal.Decimal) *RefundBuilder {
+	b.refund.Shipping = &RefundShipping{Amount: &amount}
+	return b
+}
+
+// SetNote sets the reason of the refund
+func (b *RefundBuilder) SetNote(note string) *RefundBuilder {
+	b.refund.Note = note
+	return b
+}
+
+// SetNotify sets whether the customer is notified of the refund
+func (b *RefundBuilder) SetNotify(notify bool) *RefundBuilder {
+	b.refund.Notify = notify
+	return b
+}
+
+// Build validates and returns the refund, the returned error is a
+// ValidationError naming the offending field
+func (b *RefundBuilder) Build() (Refund, error) {
+	if err := validateRefund(b.refund); err != nil {
+		return Refund{}, err
+	}
+	return b.refund, nil
+}
+
+func validateRefund(refund Refund) error {
+	if len(refund.RefundLineItems) == 0 && refund.Shipping == nil {
+		return ValidationError{Field: "refund_line_items", Message: "a line item or shipping is required"}
+	}
+
+	refunded := map[uint64]bool{}
+	for i, lineItem := range refund.RefundLineItems {
+		field := fmt.Sprintf("refund_line_items[%d]", i)
+		switch {
+		case lineItem.LineItemId == 0:
+			return ValidationError{Field: field + ".line_item_id", Message: "is required"}
+		case refunded[lineItem.LineItemId]:
+			return ValidationError{Field: field + ".line_item_id", Message: fmt.Sprintf("line item %d is refunded twice", lineItem.LineItemId)}
+		case lineItem.Quantity <= 0:
+			return ValidationError{Field: field + ".quantity", Message: "must be positive"}
+		}
+		switch lineItem.RestockType {
+		case "", RefundRestockTypeNoRestock, RefundRestockTypeCancel, RefundRestockTypeReturn:
+		default:
+			return ValidationError{Field: field + ".restock_type", Message: fmt.Sprintf("unknown restock type %q", lineItem.RestockType)}
+		}
+		refunded[lineItem.LineItemId] = true
+	}
+
+	if shipping := refund.Shipping; shipping != nil && !shipping.FullRefund {
+		if shipping.Amount == nil || !shipping.Amount.IsPositive() {
+			return ValidationError{Field: "shipping.amount", Message: "must be positive"}
+		}
+	}
+	return nil
+}
+
+// CalculateAndCreate validates a refund against the calculate endpoint, then
+// creates it with the transactions suggested by Shopify. A ValidationError is
+// returned when Shopify cannot refund or restock the requested quantity of a
+// line item, e.g. when it was already refunded or cancelled items were
+// fulfilled. Refunds with transactions are created as is.
+func (s *RefundServiceOp) CalculateAndCreate(ctx context.Context, orderId uint64, refund Refund) (*Refund, error) {
+	if err := validateRefund(refund); err != nil {
+		return nil, err
+	}
+
+	calculated, err := s.Calculate(ctx, orderId, refund)
+	if err != nil {
+		return nil, err
+	}
+	if err := compareCalculatedRefund(refund, calculated); err != nil {
+		return nil, err
+	}
+
+	if len(refund.Transactions) == 0 {
+		refund.Currency = calculated.Currency
+		for _, transaction := range calculated.Transactions {
+			if transaction.Amount == nil || transaction.Amount.IsZero() {
+				continue
+			}
+			refund.Transactions = append(refund.Transactions, Transaction{
+				ParentId: transaction.ParentId,
+				Amount:   transaction.Amount,
+				Kind:     refundTransactionKind,
+				Gateway:  transaction.Gateway,
+			})
+		}
+	}
+	return s.Create(ctx, orderId, refund)
+}
+
+// compareCalculatedRefund checks Shopify kept the quantities and restock
+// types requested, the calculate endpoint lowers them instead of failing
+func compareCalculatedRefund(refund Refund, calculated *Refund) error {
+	if calculated == nil {
+		return fmt.Errorf("refund calculation returned no refund")
+	}
+	suggested := map[uint64]RefundLineItem{}
+	for _, lineItem := range calculated.RefundLineItems {
+		suggested[lineItem.LineItemId] = lineItem
+	}
+
+	for i, lineItem := range refund.RefundLineItems {
+		field := fmt.Sprintf("refund_line_items[%d]", i)
+		got, ok := suggested[lineItem.LineItemId]
+		if !ok || got.Quantity < lineItem.Quantity {
+			return ValidationError{Field: field + ".quantity", Message: fmt.Sprintf("only %d of line item %d can be refunded", got.Quantity, lineItem.LineItemId)}
+		}
+		if lineItem.RestockType != "" && got.RestockType != "" && got.RestockType != lineItem.RestockType {
+			return ValidationError{Field: field + ".restock_type", Message: fmt.Sprintf("line item %d can only be refunded with restock type %s", lineItem.LineItemId, got.RestockType)}
+		}
+	}
+	return nil
+}
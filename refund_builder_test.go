@@ -0,0 +1,100 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func TestRefundBuilderBuild(t *testing.T) {
+	refund, err := NewRefundBuilder().
+		Return(1, 2, 10).
+		NoRestock(2, 1).
+		RefundShippingAmount(decimal.NewFromInt(5)).
+		SetNote("damaged").
+		Build()
+	if err != nil {
+		t.Fatalf("RefundBuilder.Build returned error: %v", err)
+	}
+	if len(refund.RefundLineItems) != 2 || refund.RefundLineItems[0].RestockType != RefundRestockTypeReturn || refund.RefundLineItems[0].LocationId != 10 {
+		t.Errorf("RefundBuilder.Build returned %+v", refund)
+	}
+
+	cases := []struct {
+		builder *RefundBuilder
+		field   string
+	}{
+		{NewRefundBuilder(), "refund_line_items"},
+		{NewRefundBuilder().Cancel(1, 0, 10), "refund_line_items[0].quantity"},
+		{NewRefundBuilder().Cancel(1, 1, 10).Return(1, 1, 10), "refund_line_items[1].line_item_id"},
+		{NewRefundBuilder().AddLineItem(1, 1, "restock", 10), "refund_line_items[0].restock_type"},
+		{NewRefundBuilder().RefundShippingAmount(decimal.Zero), "shipping.amount"},
+	}
+	for _, c := range cases {
+		_, err := c.builder.Build()
+		var validationError ValidationError
+		if !errors.As(err, &validationError) || validationError.Field != c.field {
+			t.Errorf("RefundBuilder.Build returned %v, expected a ValidationError on %s", err, c.field)
+		}
+	}
+}
+
+func TestRefundCalculateAndCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1/refunds/calculate.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"refund":{"currency":"USD","refund_line_items":[{"line_item_id":5,"quantity":1,"restock_type":"return","location_id":10}],
+			"transactions":[{"parent_id":7,"amount":"10.00","kind":"suggested_refund","gateway":"bogus"}]}}`))
+
+	var sent RefundResource
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1/refunds.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&sent); err != nil {
+				return nil, err
+			}
+			return httpmock.NewStringResponse(201, `{"refund":{"id":3,"order_id":1}}`), nil
+		})
+
+	refund, _ := NewRefundBuilder().Return(5, 1, 10).Build()
+	created, err := client.Refund.CalculateAndCreate(context.Background(), 1, refund)
+	if err != nil {
+		t.Fatalf("Refund.CalculateAndCreate returned error: %v", err)
+	}
+	if created.Id != 3 {
+		t.Errorf("Refund.CalculateAndCreate returned %+v", created)
+	}
+
+	if sent.Refund == nil || len(sent.Refund.Transactions) != 1 {
+		t.Fatalf("Refund.CalculateAndCreate sent %+v", sent.Refund)
+	}
+	transaction := sent.Refund.Transactions[0]
+	if transaction.Kind != "refund" || *transaction.ParentId != 7 || transaction.Amount.String() != "10" || sent.Refund.Currency != "USD" {
+		t.Errorf("Refund.CalculateAndCreate sent transaction %+v", transaction)
+	}
+}
+
+func TestRefundCalculateAndCreateQuantityLowered(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1/refunds/calculate.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"refund":{"refund_line_items":[{"line_item_id":5,"quantity":1,"restock_type":"return"}]}}`))
+
+	refund, _ := NewRefundBuilder().Return(5, 2, 10).Build()
+	_, err := client.Refund.CalculateAndCreate(context.Background(), 1, refund)
+
+	var validationError ValidationError
+	if !errors.As(err, &validationError) || validationError.Field != "refund_line_items[0].quantity" {
+		t.Errorf("Refund.CalculateAndCreate returned %v, expected a quantity ValidationError", err)
+	}
+	if httpmock.GetTotalCallCount() != 1 {
+		t.Errorf("Refund.CalculateAndCreate created the refund")
+	}
+}
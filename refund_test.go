@@ -0,0 +1,84 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestRefundList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1/refunds.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"refunds": [{"id":1},{"id":2}]}`))
+
+	refunds, err := client.Refund.List(context.Background(), 1, nil)
+	if err != nil {
+		t.Errorf("Refund.List returned error: %v", err)
+	}
+
+	expected := []Refund{{Id: 1}, {Id: 2}}
+	if !reflect.DeepEqual(refunds, expected) {
+		t.Errorf("Refund.List returned %+v, expected %+v", refunds, expected)
+	}
+}
+
+func TestRefundGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1/refunds/2.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"refund": {"id":2,"order_id":1}}`))
+
+	refund, err := client.Refund.Get(context.Background(), 1, 2, nil)
+	if err != nil {
+		t.Errorf("Refund.Get returned error: %v", err)
+	}
+
+	expected := &Refund{Id: 2, OrderId: 1}
+	if !reflect.DeepEqual(refund, expected) {
+		t.Errorf("Refund.Get returned %+v, expected %+v", refund, expected)
+	}
+}
+
+func TestRefundCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1/refunds.json", client.pathPrefix),
+		httpmock.NewStringResponder(201, `{"refund": {"id":2,"order_id":1,"note":"wrong size"}}`))
+
+	refund, err := client.Refund.Create(context.Background(), 1, Refund{Note: "wrong size"})
+	if err != nil {
+		t.Errorf("Refund.Create returned error: %v", err)
+	}
+
+	expected := &Refund{Id: 2, OrderId: 1, Note: "wrong size"}
+	if !reflect.DeepEqual(refund, expected) {
+		t.Errorf("Refund.Create returned %+v, expected %+v", refund, expected)
+	}
+}
+
+func TestRefundCalculate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1/refunds/calculate.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"refund": {"refund_line_items":[{"line_item_id":3,"quantity":1}]}}`))
+
+	refund, err := client.Refund.Calculate(context.Background(), 1, Refund{
+		RefundLineItems: []RefundLineItem{{LineItemId: 3, Quantity: 1}},
+	})
+	if err != nil {
+		t.Errorf("Refund.Calculate returned error: %v", err)
+	}
+
+	expected := &Refund{RefundLineItems: []RefundLineItem{{LineItemId: 3, Quantity: 1}}}
+	if !reflect.DeepEqual(refund, expected) {
+		t.Errorf("Refund.Calculate returned %+v, expected %+v", refund, expected)
+	}
+}
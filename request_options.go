@@ -0,0 +1,97 @@
+package goshopify
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RequestOption changes the requests sent with a context, see
+// WithRequestOptions
+type RequestOption func(*requestOptions)
+
+// requestOptions are the request options of a context
+type requestOptions struct {
+	header  http.Header
+	query   map[string]string
+	timeout time.Duration
+	dumper  HTTPDumper
+}
+
+// requestOptionsKey is the context key of the request options
+type requestOptionsKey struct{}
+
+// WithRequestOptions returns a copy of ctx whose requests are changed by
+// options, without changing the client, e.g. to give a single call a shorter
+// timeout:
+//
+//	ctx := goshopify.WithRequestOptions(ctx, goshopify.RequestTimeout(2*time.Second))
+//	product, err := client.Product.Get(ctx, id, nil)
+//
+// Options are added to the ones of ctx, later options win.
+func WithRequestOptions(ctx context.Context, options ...RequestOption) context.Context {
+	merged := &requestOptions{header: http.Header{}, query: map[string]string{}}
+	if current, ok := ctx.Value(requestOptionsKey{}).(*requestOptions); ok {
+		merged.header = current.header.Clone()
+		for key, value := range current.query {
+			merged.query[key] = value
+		}
+		merged.timeout = current.timeout
+		merged.dumper = current.dumper
+	}
+	for _, option := range options {
+		option(merged)
+	}
+	return context.WithValue(ctx, requestOptionsKey{}, merged)
+}
+
+// RequestHeader sets a header of the requests
+func RequestHeader(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		o.header.Set(key, value)
+	}
+}
+
+// RequestQuery sets a query parameter of the requests, replacing the one set
+// by the options of the service method
+func RequestQuery(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		o.query[key] = value
+	}
+}
+
+// RequestTimeout limits the time a request takes, retries and response
+// decoding included
+func RequestTimeout(timeout time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.timeout = timeout
+	}
+}
+
+// applyRequestOptions sets the headers and query parameters of the request
+// options of ctx on req
+func applyRequestOptions(ctx context.Context, req *http.Request) {
+	options, ok := ctx.Value(requestOptionsKey{}).(*requestOptions)
+	if !ok {
+		return
+	}
+	for key, values := range options.header {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	if len(options.query) > 0 {
+		query := req.URL.Query()
+		for key, value := range options.query {
+			query.Set(key, value)
+		}
+		req.URL.RawQuery = query.Encode()
+	}
+}
+
+// requestTimeout returns the timeout of the request options of ctx, 0 when
+// none is set
+func requestTimeout(ctx context.Context) time.Duration {
+	if options, ok := ctx.Value(requestOptionsKey{}).(*requestOptions); ok {
+		return options.timeout
+	}
+	return 0
+}
@@ -0,0 +1,65 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestWithRequestOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var header http.Header
+	var query string
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			header, query = req.Header, req.URL.RawQuery
+			return httpmock.NewStringResponse(200, `{"products":[]}`), nil
+		})
+
+	ctx := WithRequestOptions(context.Background(), RequestHeader("X-Trace", "a"), RequestQuery("limit", "5"))
+	ctx = WithRequestOptions(ctx, RequestHeader("X-Trace", "b"), RequestQuery("fields", "id"))
+	if _, err := client.Product.List(ctx, ListOptions{Limit: 50}); err != nil {
+		t.Fatalf("Product.List returned error: %v", err)
+	}
+
+	if header.Get("X-Trace") != "b" || header.Get("X-Shopify-Access-Token") != "abcd" {
+		t.Errorf("request was sent with headers %v", header)
+	}
+	if query != "fields=id&limit=5" {
+		t.Errorf("request was sent with query %q, expected fields=id&limit=5", query)
+	}
+
+	if _, err := client.Product.List(context.Background(), ListOptions{Limit: 50}); err != nil {
+		t.Fatalf("Product.List returned error: %v", err)
+	}
+	if header.Get("X-Trace") != "" || query != "limit=50" {
+		t.Errorf("request without options was sent with headers %v and query %q", header, query)
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			if _, ok := req.Context().Deadline(); !ok {
+				t.Error("request was sent without a deadline")
+			}
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		})
+
+	ctx := WithRequestOptions(context.Background(), RequestTimeout(10*time.Millisecond))
+	_, err := client.Product.Get(ctx, 1, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Product.Get returned %v, expected context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,89 @@
+package goshopify
+
+import (
+	"context"
+	"net/http"
+)
+
+// deprecatedReasonHeader is sent by shopify with responses to calls using a
+// deprecated endpoint or field
+const deprecatedReasonHeader = "X-Shopify-API-Deprecated-Reason"
+
+// Response describes the last response to a request sent with one of the
+// WithResponse methods of the client, successful or not
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	ResponseMetadata
+}
+
+// responseKey is the context key of the Response recorded for the
+// WithResponse methods
+type responseKey struct{}
+
+// ApiVersion returns the api version shopify served the request with
+func (r *Response) ApiVersion() string {
+	return r.Header.Get("X-Shopify-API-Version")
+}
+
+// DeprecatedReason returns the reason shopify gave for the request using a
+// deprecated endpoint or field, "" when it did not
+func (r *Response) DeprecatedReason() string {
+	return r.Header.Get(deprecatedReasonHeader)
+}
+
+// CallLimit returns the calls used and the size of the REST bucket of the
+// shop, ok is false when the response had no call limit header
+func (r *Response) CallLimit() (used, size int, ok bool) {
+	return parseCallLimit(r.Header)
+}
+
+// Pagination returns the pagination of the Link header of the response
+func (r *Response) Pagination() (*Pagination, error) {
+	return extractPagination(r.Header.Get("Link"))
+}
+
+// DoWithResponse sends an API request like Do and returns the last response
+// to it, along with the error of the request. The response is nil when none
+// was received.
+func (c *Client) DoWithResponse(req *http.Request, v interface{}) (*Response, error) {
+	response := &Response{}
+	req = req.WithContext(context.WithValue(req.Context(), responseKey{}, response))
+	_, err := c.doGetHeaders(req, v)
+	if response.StatusCode == 0 {
+		return nil, err
+	}
+	return response, err
+}
+
+// createAndDoWithResponse creates and sends a request like CreateAndDo and
+// returns the last response to it
+func (c *Client) createAndDoWithResponse(ctx context.Context, method, relPath string, data, options, resource interface{}) (*Response, error) {
+	response := &Response{}
+	_, err := c.createAndDoGetHeaders(context.WithValue(ctx, responseKey{}, response), method, relPath, data, options, resource)
+	if response.StatusCode == 0 {
+		return nil, err
+	}
+	return response, err
+}
+
+// GetWithResponse performs a GET request like Get and returns the response
+func (c *Client) GetWithResponse(ctx context.Context, path string, resource, options interface{}) (*Response, error) {
+	return c.createAndDoWithResponse(ctx, "GET", path, nil, options, resource)
+}
+
+// PostWithResponse performs a POST request like Post and returns the response
+func (c *Client) PostWithResponse(ctx context.Context, path string, data, resource interface{}) (*Response, error) {
+	return c.createAndDoWithResponse(ctx, "POST", path, data, nil, resource)
+}
+
+// PutWithResponse performs a PUT request like Put and returns the response
+func (c *Client) PutWithResponse(ctx context.Context, path string, data, resource interface{}) (*Response, error) {
+	return c.createAndDoWithResponse(ctx, "PUT", path, data, nil, resource)
+}
+
+// DeleteWithResponse performs a DELETE request like DeleteWithOptions and
+// returns the response
+func (c *Client) DeleteWithResponse(ctx context.Context, path string, options interface{}) (*Response, error) {
+	return c.createAndDoWithResponse(ctx, "DELETE", path, nil, options, nil)
+}
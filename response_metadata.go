@@ -0,0 +1,50 @@
+package goshopify
+
+import (
+	"context"
+	"net/http"
+)
+
+// responseMetadataKey is the context key of the ResponseMetadata recorded by
+// CaptureResponseMetadata
+type responseMetadataKey struct{}
+
+// CaptureResponseMetadata returns a copy of ctx recording in metadata the
+// metadata of the last response to the requests sent with it, successful or
+// not, e.g. to log the X-Request-Id of a call:
+//
+//	var metadata goshopify.ResponseMetadata
+//	product, err := client.Product.Get(goshopify.CaptureResponseMetadata(ctx, &metadata), id, nil)
+//	log.Printf("shopify request id %s", metadata.RequestId)
+//
+// The errors returned for non-2xx responses carry the same metadata.
+func CaptureResponseMetadata(ctx context.Context, metadata *ResponseMetadata) context.Context {
+	return context.WithValue(ctx, responseMetadataKey{}, metadata)
+}
+
+// responseMetadataCapture returns the ResponseMetadata recorded with ctx,
+// capturing it in a new one when ctx does not record it
+func responseMetadataCapture(ctx context.Context) (context.Context, *ResponseMetadata) {
+	if metadata, ok := ctx.Value(responseMetadataKey{}).(*ResponseMetadata); ok {
+		return ctx, metadata
+	}
+	metadata := &ResponseMetadata{}
+	return CaptureResponseMetadata(ctx, metadata), metadata
+}
+
+// captureResponseMetadata records the metadata of resp in the context of req,
+// and resp itself for the WithResponse methods
+func (c *Client) captureResponseMetadata(req *http.Request, resp *http.Response) {
+	ctx := req.Context()
+	metadata := ResponseMetadata{
+		Retries:    requestAttempts(req) - 1,
+		RequestURL: req.URL.String(),
+		RequestId:  resp.Header.Get("X-Request-Id"),
+	}
+	if captured, ok := ctx.Value(responseMetadataKey{}).(*ResponseMetadata); ok {
+		*captured = metadata
+	}
+	if response, ok := ctx.Value(responseKey{}).(*Response); ok {
+		*response = Response{StatusCode: resp.StatusCode, Header: resp.Header, ResponseMetadata: metadata}
+	}
+}
@@ -0,0 +1,63 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestCaptureResponseMetadata(t *testing.T) {
+	setup()
+	defer teardown()
+	client.retries = 2
+
+	productURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix)
+	attempts := 0
+	httpmock.RegisterResponder("GET", productURL,
+		func(req *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts == 1 {
+				return httpmock.NewStringResponse(http.StatusServiceUnavailable, ""), nil
+			}
+			resp := httpmock.NewStringResponse(200, `{"product":{"id":1}}`)
+			resp.Header.Set("X-Request-Id", "request-2")
+			return resp, nil
+		})
+
+	var metadata ResponseMetadata
+	if _, err := client.Product.Get(CaptureResponseMetadata(context.Background(), &metadata), 1, nil); err != nil {
+		t.Fatalf("Product.Get returned error: %v", err)
+	}
+
+	expected := ResponseMetadata{Retries: 1, RequestURL: productURL, RequestId: "request-2"}
+	if metadata != expected {
+		t.Errorf("CaptureResponseMetadata recorded %+v, expected %+v", metadata, expected)
+	}
+}
+
+func TestGraphQLErrorRequestId(t *testing.T) {
+	setup()
+	defer teardown()
+
+	graphQLURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix)
+	httpmock.RegisterResponder("POST", graphQLURL,
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(200, `{"errors":[{"message":"Field 'foo' doesn't exist on type 'Shop'"}]}`)
+			resp.Header.Set("X-Request-Id", "request-1")
+			return resp, nil
+		})
+
+	err := client.GraphQL.Query(context.Background(), "query { shop { foo } }", nil, nil)
+
+	var responseError ResponseError
+	if !errors.As(err, &responseError) {
+		t.Fatalf("GraphQL.Query returned %v, expected a ResponseError", err)
+	}
+	if responseError.RequestId != "request-1" || responseError.RequestURL != graphQLURL {
+		t.Errorf("GraphQL.Query returned metadata %+v", responseError.ResponseMetadata)
+	}
+}
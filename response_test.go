@@ -0,0 +1,80 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestClientGetWithResponse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	productsURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix)
+	httpmock.RegisterResponder("GET", productsURL,
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(200, `{"products":[{"id":1}]}`)
+			resp.Header.Set("X-Request-Id", "request-1")
+			resp.Header.Set("X-Shopify-Shop-Api-Call-Limit", "3/40")
+			resp.Header.Set("X-Shopify-API-Version", "2024-01")
+			resp.Header.Set("X-Shopify-API-Deprecated-Reason", "https://shopify.dev/changelog")
+			resp.Header.Set("Link", `<https://fooshop.myshopify.com/admin/products.json?page_info=abc&limit=1>; rel="next"`)
+			return resp, nil
+		})
+
+	resource := ProductsResource{}
+	response, err := client.GetWithResponse(context.Background(), "products.json", &resource, nil)
+	if err != nil {
+		t.Fatalf("Client.GetWithResponse returned error: %v", err)
+	}
+
+	if len(resource.Products) != 1 {
+		t.Errorf("Client.GetWithResponse decoded %+v", resource)
+	}
+	if response.StatusCode != 200 || response.RequestId != "request-1" || response.RequestURL != productsURL {
+		t.Errorf("Client.GetWithResponse returned %+v", response)
+	}
+	if response.ApiVersion() != "2024-01" || response.DeprecatedReason() != "https://shopify.dev/changelog" {
+		t.Errorf("Client.GetWithResponse returned headers %v", response.Header)
+	}
+	if used, size, ok := response.CallLimit(); !ok || used != 3 || size != 40 {
+		t.Errorf("Response.CallLimit returned %d, %d, %t", used, size, ok)
+	}
+	pagination, err := response.Pagination()
+	if err != nil || pagination.NextPageOptions == nil || pagination.NextPageOptions.PageInfo != "abc" {
+		t.Errorf("Response.Pagination returned %+v, %v", pagination, err)
+	}
+}
+
+func TestClientPutWithResponseError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(422, `{"errors":{"title":["can't be blank"]}}`))
+
+	response, err := client.PutWithResponse(context.Background(), "products/1.json", ProductResource{Product: &Product{Id: 1}}, nil)
+	if err == nil {
+		t.Fatal("Client.PutWithResponse returned no error")
+	}
+	if response == nil || response.StatusCode != 422 {
+		t.Errorf("Client.PutWithResponse returned %+v, expected the 422 response", response)
+	}
+}
+
+func TestClientDoWithResponseNoResponse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewErrorResponder(errors.New("connection reset")))
+
+	response, err := client.DeleteWithResponse(context.Background(), "products/1.json", nil)
+	if err == nil || response != nil {
+		t.Errorf("Client.DeleteWithResponse returned %+v, %v, expected no response and an error", response, err)
+	}
+}
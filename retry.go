@@ -0,0 +1,238 @@
+package goshopify
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how requests failing with a rate limit or a
+// transient server error are retried, see WithRetryPolicy
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt, 0 sends
+	// the request once and a negative value retries 3 times
+	MaxRetries int
+
+	// MinBackoff is the wait before the first retry, doubled on every retry
+	// up to MaxBackoff
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction of the backoff which is randomized, e.g. 0.5
+	// waits between 50% and 150% of the backoff so clients of many shops do
+	// not retry at once
+	Jitter float64
+
+	// JitterStrategy is how the backoff is randomized, JitterProportional
+	// applies Jitter, JitterFull and JitterEqual ignore it
+	JitterStrategy JitterStrategy
+
+	// Statuses are the response statuses retried, defaults to 429, 502, 503
+	// and 504. A 500 may be returned after a write was applied so it is not
+	// retried unless listed. See WithStatus.
+	Statuses []int
+
+	// RetryWrites retries POST, PUT, PATCH and DELETE requests failing with a
+	// status other than 429 too. Shopify may have applied a write before a
+	// gateway responded with a 502 or 504, retrying it can create a duplicate
+	// order, refund or fulfillment, so only GET, HEAD and OPTIONS requests are
+	// retried by default. A 429 is retried for every method, shopify rejected
+	// the request without applying it.
+	RetryWrites bool
+
+	// Budget is the total time a request may take, retries and their waits
+	// included. The error of the last attempt is returned instead of waiting
+	// past it. Zero means no budget.
+	Budget time.Duration
+
+	// OnRetry is called before waiting for every retry, e.g. to log it or to
+	// give up when the deadline of ctx would pass during the wait. Its error
+	// is returned instead of retrying.
+	OnRetry func(ctx context.Context, retry RetryAttempt) error
+}
+
+// JitterStrategy randomizes the backoff between retries
+type JitterStrategy int
+
+const (
+	// JitterProportional waits the backoff plus or minus Jitter of it
+	JitterProportional JitterStrategy = iota
+
+	// JitterFull waits between zero and the backoff
+	JitterFull
+
+	// JitterEqual waits between half the backoff and the backoff
+	JitterEqual
+)
+
+// RetryAttempt is a retry about to be waited for, see RetryPolicy.OnRetry
+type RetryAttempt struct {
+	Request *http.Request
+
+	// Retry is the number of the retry, starting at 1
+	Retry int
+
+	// Wait is the backoff before the retry is sent
+	Wait time.Duration
+
+	// Elapsed is the time the request took so far
+	Elapsed time.Duration
+
+	// Err is the error of the failed attempt
+	Err error
+}
+
+// DefaultRetryPolicy returns a policy of 3 retries waiting about 1s, 2s and
+// 4s with 50% jitter. Writes are only retried after a 429, see RetryWrites.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 3,
+		MinBackoff: time.Second,
+		MaxBackoff: 30 * time.Second,
+		Jitter:     0.5,
+		Statuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// legacyRetryPolicy is the policy of clients created WithRetry but without a
+// RetryPolicy: a 429 waits for the Retry-After shopify asks for and a 503 is
+// retried at once, whatever the method of the request
+func legacyRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		Statuses:    []int{http.StatusTooManyRequests, http.StatusServiceUnavailable},
+		RetryWrites: true,
+	}
+}
+
+// currentRetryPolicy returns the policy retrying the requests of the client
+func (c *Client) currentRetryPolicy() RetryPolicy {
+	if c.retryPolicy != nil {
+		return *c.retryPolicy
+	}
+	return legacyRetryPolicy()
+}
+
+// WithStatus returns a copy of the policy retrying, or not, the responses
+// with status, e.g. DefaultRetryPolicy().WithStatus(http.StatusInternalServerError, true)
+func (p RetryPolicy) WithStatus(status int, retry bool) RetryPolicy {
+	statuses := p.Statuses
+	if statuses == nil {
+		statuses = DefaultRetryPolicy().Statuses
+	}
+	p.Statuses = []int{}
+	for _, s := range statuses {
+		if s != status {
+			p.Statuses = append(p.Statuses, s)
+		}
+	}
+	if retry {
+		p.Statuses = append(p.Statuses, status)
+	}
+	return p
+}
+
+// retries reports whether a response with status to a request with method is
+// retried
+func (p RetryPolicy) retries(method string, status int) bool {
+	if status != http.StatusTooManyRequests && !p.RetryWrites && !isSafeMethod(method) {
+		return false
+	}
+	for _, s := range p.Statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// isSafeMethod reports whether a request with method does not change the shop
+func isSafeMethod(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// backoff returns the wait before retry number retry, starting at 1. The
+// Retry-After header of the response is honored when it is set.
+func (p RetryPolicy) backoff(retry int, retryAfter string) time.Duration {
+	if seconds, err := strconv.ParseFloat(retryAfter, 64); err == nil && seconds > 0 {
+		return time.Duration(seconds * float64(time.Second))
+	}
+
+	wait := p.MinBackoff
+	for i := 1; i < retry && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	switch p.JitterStrategy {
+	case JitterFull:
+		wait = time.Duration(rand.Int63n(int64(wait) + 1))
+	case JitterEqual:
+		half := wait / 2
+		wait = half + time.Duration(rand.Int63n(int64(wait-half)+1))
+	default:
+		if p.Jitter > 0 {
+			wait += time.Duration((rand.Float64()*2 - 1) * p.Jitter * float64(wait))
+		}
+	}
+	return wait
+}
+
+// beforeRetry reports whether the retry fits in the budget and calls OnRetry
+func (p RetryPolicy) beforeRetry(ctx context.Context, retry RetryAttempt) (bool, error) {
+	if p.Budget > 0 && retry.Elapsed+retry.Wait > p.Budget {
+		return false, nil
+	}
+	if p.OnRetry != nil {
+		if err := p.OnRetry(ctx, retry); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// requestAttemptsKey is the context key of the attempts of a request
+type requestAttemptsKey struct{}
+
+// withRequestAttempts returns a copy of req counting its attempts in the
+// returned counter. The counter is per request so concurrent calls on a
+// client do not see each other's attempts.
+func withRequestAttempts(req *http.Request) (*http.Request, *int) {
+	attempts := new(int)
+	return req.WithContext(context.WithValue(req.Context(), requestAttemptsKey{}, attempts)), attempts
+}
+
+// requestAttempts returns the attempts made so far of req, 0 when req was
+// not sent by the client
+func requestAttempts(req *http.Request) int {
+	if req == nil {
+		return 0
+	}
+	if attempts, ok := req.Context().Value(requestAttemptsKey{}).(*int); ok {
+		return *attempts
+	}
+	return 0
+}
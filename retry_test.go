@@ -0,0 +1,302 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	cases := []struct {
+		retry      int
+		retryAfter string
+		expected   time.Duration
+	}{
+		{1, "", time.Second},
+		{2, "", 2 * time.Second},
+		{3, "", 4 * time.Second},
+		{4, "", 5 * time.Second},
+		{40, "", 5 * time.Second},
+		{1, "2.5", 2500 * time.Millisecond},
+		{3, "10", 10 * time.Second},
+		{1, "soon", time.Second},
+	}
+
+	for _, c := range cases {
+		if actual := policy.backoff(c.retry, c.retryAfter); actual != c.expected {
+			t.Errorf("backoff(%d, %q) returned %s, expected %s", c.retry, c.retryAfter, actual, c.expected)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		wait := policy.backoff(2, "")
+		if wait < time.Second || wait > 3*time.Second {
+			t.Fatalf("backoff with jitter returned %s, expected between 1s and 3s", wait)
+		}
+	}
+}
+
+func TestRetryPolicyRetriesTransientErrors(t *testing.T) {
+	testClient := MustNewClient(app, "fooshop", "abcd", WithRetryPolicy(RetryPolicy{MaxRetries: 3, MinBackoff: time.Millisecond}))
+	httpmock.ActivateNonDefault(testClient.Client)
+	defer httpmock.DeactivateAndReset()
+
+	statuses := []int{http.StatusBadGateway, http.StatusTooManyRequests, http.StatusOK}
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1",
+		func(req *http.Request) (*http.Response, error) {
+			status := statuses[0]
+			statuses = statuses[1:]
+			return httpmock.NewStringResponse(status, `{"foo":"bar"}`), nil
+		})
+
+	var metadata ResponseMetadata
+	req, err := testClient.NewRequest(CaptureResponseMetadata(context.Background(), &metadata), "GET", "foo/1", nil, nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	if err := testClient.Do(req, nil); err != nil {
+		t.Errorf("Do returned error: %v", err)
+	}
+	if metadata.Retries != 2 {
+		t.Errorf("Do made %d retries, expected 2", metadata.Retries)
+	}
+}
+
+func TestRetryPolicyGivesUp(t *testing.T) {
+	testClient := MustNewClient(app, "fooshop", "abcd", WithRetryPolicy(RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond}))
+	httpmock.ActivateNonDefault(testClient.Client)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1", httpmock.NewStringResponder(http.StatusServiceUnavailable, ""))
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/2", httpmock.NewStringResponder(http.StatusInternalServerError, ""))
+
+	cases := []struct {
+		path     string
+		attempts int
+	}{
+		{"foo/1", 3},
+		// 500 is not retried by default
+		{"foo/2", 1},
+	}
+
+	for _, c := range cases {
+		req, err := testClient.NewRequest(context.Background(), "GET", c.path, nil, nil)
+		if err != nil {
+			t.Fatalf("NewRequest returned error: %v", err)
+		}
+
+		err = testClient.Do(req, nil)
+		var responseError ResponseError
+		if !errors.As(err, &responseError) || responseError.Retries != c.attempts-1 {
+			t.Errorf("Do(%s) returned %#v, expected a ResponseError after %d retries", c.path, err, c.attempts-1)
+		}
+	}
+}
+
+func TestRetryPolicyMaxRetries(t *testing.T) {
+	cases := []struct {
+		maxRetries int
+		attempts   int
+	}{
+		{0, 1},
+		{-1, 4},
+	}
+
+	for _, c := range cases {
+		testClient := MustNewClient(app, "fooshop", "abcd", WithRetryPolicy(RetryPolicy{MaxRetries: c.maxRetries, MinBackoff: time.Millisecond}))
+		httpmock.ActivateNonDefault(testClient.Client)
+		httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1", httpmock.NewStringResponder(http.StatusServiceUnavailable, ""))
+
+		req, err := testClient.NewRequest(context.Background(), "GET", "foo/1", nil, nil)
+		if err != nil {
+			t.Fatalf("NewRequest returned error: %v", err)
+		}
+		_ = testClient.Do(req, nil)
+		if calls := httpmock.GetTotalCallCount(); calls != c.attempts {
+			t.Errorf("Do with MaxRetries %d sent the request %d times, expected %d", c.maxRetries, calls, c.attempts)
+		}
+		httpmock.DeactivateAndReset()
+	}
+}
+
+func TestRetryPolicyContextCanceled(t *testing.T) {
+	testClient := MustNewClient(app, "fooshop", "abcd", WithRetryPolicy(RetryPolicy{MaxRetries: 3, MinBackoff: time.Hour}))
+	httpmock.ActivateNonDefault(testClient.Client)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1", httpmock.NewStringResponder(http.StatusServiceUnavailable, ""))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, err := testClient.NewRequest(ctx, "GET", "foo/1", nil, nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+
+	if err := testClient.Do(req, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Do returned %v, expected context.DeadlineExceeded", err)
+	}
+}
+
+func TestRetryPolicyJitterStrategy(t *testing.T) {
+	cases := []struct {
+		strategy JitterStrategy
+		min, max time.Duration
+	}{
+		{JitterFull, 0, 2 * time.Second},
+		{JitterEqual, time.Second, 2 * time.Second},
+	}
+
+	for _, c := range cases {
+		// Jitter is ignored by full and equal jitter
+		policy := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second, Jitter: 0.9, JitterStrategy: c.strategy}
+		for i := 0; i < 100; i++ {
+			wait := policy.backoff(2, "")
+			if wait < c.min || wait > c.max {
+				t.Fatalf("backoff with jitter strategy %d returned %s, expected between %s and %s", c.strategy, wait, c.min, c.max)
+			}
+		}
+		if wait := policy.backoff(2, "3"); wait != 3*time.Second {
+			t.Errorf("backoff with jitter strategy %d returned %s, expected the Retry-After of 3s", c.strategy, wait)
+		}
+	}
+}
+
+func TestRetryPolicyWithStatus(t *testing.T) {
+	policy := DefaultRetryPolicy().
+		WithStatus(http.StatusInternalServerError, true).
+		WithStatus(http.StatusTooManyRequests, false)
+
+	for status, expected := range map[int]bool{
+		http.StatusInternalServerError: true,
+		http.StatusTooManyRequests:     false,
+		http.StatusBadGateway:          true,
+	} {
+		if actual := policy.retries(http.MethodGet, status); actual != expected {
+			t.Errorf("retries(%d) returned %t, expected %t", status, actual, expected)
+		}
+	}
+
+	none := RetryPolicy{}.WithStatus(http.StatusServiceUnavailable, false).
+		WithStatus(http.StatusTooManyRequests, false).
+		WithStatus(http.StatusBadGateway, false).
+		WithStatus(http.StatusGatewayTimeout, false)
+	testClient := MustNewClient(app, "fooshop", "abcd", WithRetryPolicy(none))
+	if len(testClient.retryPolicy.Statuses) != 0 {
+		t.Errorf("WithRetryPolicy retries statuses %v, expected none", testClient.retryPolicy.Statuses)
+	}
+}
+
+func TestRetryPolicyWrites(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	cases := []struct {
+		method   string
+		status   int
+		writes   bool
+		expected bool
+	}{
+		{http.MethodGet, http.StatusBadGateway, false, true},
+		{http.MethodHead, http.StatusGatewayTimeout, false, true},
+		{http.MethodPost, http.StatusTooManyRequests, false, true},
+		{http.MethodPost, http.StatusBadGateway, false, false},
+		{http.MethodPut, http.StatusServiceUnavailable, false, false},
+		{http.MethodDelete, http.StatusGatewayTimeout, false, false},
+		{http.MethodPost, http.StatusBadGateway, true, true},
+		{http.MethodPost, http.StatusInternalServerError, true, false},
+	}
+
+	for _, c := range cases {
+		policy.RetryWrites = c.writes
+		if actual := policy.retries(c.method, c.status); actual != c.expected {
+			t.Errorf("retries(%s, %d) with RetryWrites %t returned %t, expected %t", c.method, c.status, c.writes, actual, c.expected)
+		}
+	}
+
+	testClient := MustNewClient(app, "fooshop", "abcd", WithRetryPolicy(RetryPolicy{MaxRetries: 3, MinBackoff: time.Millisecond}))
+	httpmock.ActivateNonDefault(testClient.Client)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/foo/1", httpmock.NewStringResponder(http.StatusBadGateway, ""))
+
+	req, err := testClient.NewRequest(context.Background(), "POST", "foo/1", map[string]string{"foo": "bar"}, nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	var responseError ResponseError
+	if err := testClient.Do(req, nil); !errors.As(err, &responseError) || responseError.Retries != 0 {
+		t.Errorf("Do returned %#v, expected the 502 without retries", err)
+	}
+	if calls := httpmock.GetTotalCallCount(); calls != 1 {
+		t.Errorf("Do sent the POST %d times, expected once", calls)
+	}
+}
+
+func TestRetryPolicyBudget(t *testing.T) {
+	testClient := MustNewClient(app, "fooshop", "abcd", WithRetryPolicy(RetryPolicy{
+		MaxRetries: 10,
+		MinBackoff: 20 * time.Millisecond,
+		Budget:     50 * time.Millisecond,
+	}))
+	httpmock.ActivateNonDefault(testClient.Client)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1", httpmock.NewStringResponder(http.StatusServiceUnavailable, ""))
+
+	req, err := testClient.NewRequest(context.Background(), "GET", "foo/1", nil, nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+
+	// waits of 20ms then 40ms, the second one past the budget
+	err = testClient.Do(req, nil)
+	var responseError ResponseError
+	if !errors.As(err, &responseError) || responseError.Status != http.StatusServiceUnavailable {
+		t.Errorf("Do returned %#v, expected the 503", err)
+	}
+	if responseError.Retries != 1 {
+		t.Errorf("Do made %d retries, expected 1", responseError.Retries)
+	}
+}
+
+func TestRetryPolicyOnRetry(t *testing.T) {
+	errAbort := errors.New("abort")
+	var retries []RetryAttempt
+	testClient := MustNewClient(app, "fooshop", "abcd", WithRetryPolicy(RetryPolicy{
+		MaxRetries: 3,
+		MinBackoff: time.Millisecond,
+		OnRetry: func(ctx context.Context, retry RetryAttempt) error {
+			retries = append(retries, retry)
+			if retry.Retry == 2 {
+				return errAbort
+			}
+			return nil
+		},
+	}))
+	httpmock.ActivateNonDefault(testClient.Client)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1", httpmock.NewStringResponder(http.StatusBadGateway, ""))
+
+	req, err := testClient.NewRequest(context.Background(), "GET", "foo/1", nil, nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+
+	if err := testClient.Do(req, nil); !errors.Is(err, errAbort) {
+		t.Errorf("Do returned %v, expected the error of OnRetry", err)
+	}
+	if len(retries) != 2 || retries[0].Retry != 1 || retries[0].Wait != time.Millisecond || retries[0].Request.URL.Path != "/foo/1" {
+		t.Errorf("OnRetry was called with %+v", retries)
+	}
+	var responseError ResponseError
+	if len(retries) > 0 && (!errors.As(retries[0].Err, &responseError) || responseError.Status != http.StatusBadGateway) {
+		t.Errorf("OnRetry was called with error %v, expected the 502", retries[0].Err)
+	}
+}
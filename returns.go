@@ -0,0 +1,509 @@
+package goshopify
+
+import (
+	"context"
+	"time"
+)
+
+const returnFields = `
+      id
+      name
+      status
+      totalQuantity
+      order {
+        id
+      }
+      returnLineItems(first: 50) {
+        nodes {
+          id
+          quantity
+          returnReason
+          returnReasonNote
+          ... on ReturnLineItem {
+            fulfillmentLineItem {
+              id
+            }
+          }
+        }
+      }
+      reverseFulfillmentOrders(first: 10) {
+        nodes {
+          id
+          status
+          lineItems(first: 50) {
+            nodes {
+              id
+              totalQuantity
+              fulfillmentLineItem {
+                id
+              }
+            }
+          }
+          reverseDeliveries(first: 10) {
+            nodes {` + reverseDeliveryFields + `
+            }
+          }
+        }
+      }`
+
+const reverseDeliveryFields = `
+              id
+              deliverable {
+                ... on ReverseDeliveryShippingDeliverable {
+                  tracking {
+                    number
+                    url
+                  }
+                  label {
+                    publicFileUrl
+                  }
+                }
+              }`
+
+const returnQuery = `query return($id: ID!) {
+  return(id: $id) {` + returnFields + `
+  }
+}`
+
+const returnCreateMutation = `mutation returnCreate($returnInput: ReturnInput!) {
+  returnCreate(returnInput: $returnInput) {
+    return {` + returnFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const returnApproveRequestMutation = `mutation returnApproveRequest($input: ReturnApproveRequestInput!) {
+  returnApproveRequest(input: $input) {
+    return {` + returnFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const returnDeclineRequestMutation = `mutation returnDeclineRequest($input: ReturnDeclineRequestInput!) {
+  returnDeclineRequest(input: $input) {
+    return {` + returnFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const reverseDeliveryCreateWithShippingMutation = `mutation reverseDeliveryCreateWithShipping($reverseFulfillmentOrderId: ID!, $reverseDeliveryLineItems: [ReverseDeliveryLineItemInput!]!, $trackingInput: ReverseDeliveryTrackingInput, $labelInput: ReverseDeliveryLabelInput, $notifyCustomer: Boolean) {
+  reverseDeliveryCreateWithShipping(reverseFulfillmentOrderId: $reverseFulfillmentOrderId, reverseDeliveryLineItems: $reverseDeliveryLineItems, trackingInput: $trackingInput, labelInput: $labelInput, notifyCustomer: $notifyCustomer) {
+    reverseDelivery {` + reverseDeliveryFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const reverseFulfillmentOrderDisposeMutation = `mutation reverseFulfillmentOrderDispose($dispositionInputs: [ReverseFulfillmentOrderDisposeInput!]!) {
+  reverseFulfillmentOrderDispose(dispositionInputs: $dispositionInputs) {
+    reverseFulfillmentOrderLineItems {
+      id
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+// Statuses of a return
+const (
+	ReturnStatusRequested = "REQUESTED"
+	ReturnStatusOpen      = "OPEN"
+	ReturnStatusClosed    = "CLOSED"
+	ReturnStatusDeclined  = "DECLINED"
+	ReturnStatusCanceled  = "CANCELED"
+)
+
+// Reasons of a returned line item
+const (
+	ReturnReasonColor          = "COLOR"
+	ReturnReasonDefective      = "DEFECTIVE"
+	ReturnReasonNotAsDescribed = "NOT_AS_DESCRIBED"
+	ReturnReasonOther          = "OTHER"
+	ReturnReasonSizeTooLarge   = "SIZE_TOO_LARGE"
+	ReturnReasonSizeTooSmall   = "SIZE_TOO_SMALL"
+	ReturnReasonStyle          = "STYLE"
+	ReturnReasonUnwanted       = "UNWANTED"
+	ReturnReasonWrongItem      = "WRONG_ITEM"
+	ReturnReasonUnknown        = "UNKNOWN"
+)
+
+// Dispositions of the items received with a reverse delivery
+const (
+	ReverseDispositionRestocked          = "RESTOCKED"
+	ReverseDispositionProcessingRequired = "PROCESSING_REQUIRED"
+	ReverseDispositionNotRestocked       = "NOT_RESTOCKED"
+	ReverseDispositionMissing            = "MISSING"
+)
+
+// ReturnService is an interface for managing returns and their reverse
+// fulfillment with the graphql API. Returns, their line items and reverse
+// fulfillment orders are graphql only and identified by graphql global ids.
+// See: https://shopify.dev/docs/apps/build/orders-fulfillment/returns-apps
+type ReturnService interface {
+	Get(context.Context, string) (*Return, error)
+	Create(context.Context, ReturnCreate) (*Return, error)
+	ApproveRequest(context.Context, string) (*Return, error)
+	DeclineRequest(context.Context, string, string) (*Return, error)
+	CreateReverseDelivery(context.Context, ReverseDeliveryCreate) (*ReverseDelivery, error)
+	Dispose(context.Context, []ReverseDisposition) error
+}
+
+// ReturnServiceOp handles communication with the return related methods of
+// the Shopify API.
+type ReturnServiceOp struct {
+	client *Client
+}
+
+// Return represents a return of items of an order
+type Return struct {
+	Id                       string
+	Name                     string
+	Status                   string
+	TotalQuantity            int
+	OrderId                  uint64
+	LineItems                []ReturnLineItem
+	ReverseFulfillmentOrders []ReverseFulfillmentOrder
+}
+
+// ReturnLineItem represents a fulfilled line item being returned
+type ReturnLineItem struct {
+	Id                    string
+	FulfillmentLineItemId string
+	Quantity              int
+	ReturnReason          string
+	ReturnReasonNote      string
+}
+
+// ReverseFulfillmentOrder represents the items of a return to receive
+type ReverseFulfillmentOrder struct {
+	Id                string
+	Status            string
+	LineItems         []ReverseFulfillmentOrderLineItem
+	ReverseDeliveries []ReverseDelivery
+}
+
+// ReverseFulfillmentOrderLineItem represents an item to receive
+type ReverseFulfillmentOrderLineItem struct {
+	Id                    string
+	FulfillmentLineItemId string
+	TotalQuantity         int
+}
+
+// ReverseDelivery represents the shipment of returned items back to the shop
+type ReverseDelivery struct {
+	Id             string
+	TrackingNumber string
+	TrackingUrl    string
+	LabelUrl       string
+}
+
+// ReturnCreate represents a ReturnInput, the line items are fulfillment line
+// items of the order
+type ReturnCreate struct {
+	OrderId        uint64
+	LineItems      []ReturnLineItemCreate
+	NotifyCustomer bool
+	RequestedAt    *time.Time
+}
+
+// ReturnLineItemCreate represents a ReturnLineItemInput
+type ReturnLineItemCreate struct {
+	FulfillmentLineItemId string `json:"fulfillmentLineItemId"`
+	Quantity              int    `json:"quantity"`
+	ReturnReason          string `json:"returnReason"`
+	ReturnReasonNote      string `json:"returnReasonNote,omitempty"`
+}
+
+// ReverseDeliveryCreate represents the arguments of the
+// reverseDeliveryCreateWithShipping mutation, the tracking and label are
+// optional
+type ReverseDeliveryCreate struct {
+	ReverseFulfillmentOrderId string
+	LineItems                 []ReverseDeliveryLineItem
+	TrackingNumber            string
+	TrackingUrl               string
+	LabelUrl                  string
+	NotifyCustomer            bool
+}
+
+// ReverseDeliveryLineItem represents a ReverseDeliveryLineItemInput
+type ReverseDeliveryLineItem struct {
+	ReverseFulfillmentOrderLineItemId string `json:"reverseFulfillmentOrderLineItemId"`
+	Quantity                          int    `json:"quantity"`
+}
+
+// ReverseDisposition represents a ReverseFulfillmentOrderDisposeInput,
+// LocationId is required when restocking
+type ReverseDisposition struct {
+	ReverseFulfillmentOrderLineItemId string `json:"reverseFulfillmentOrderLineItemId"`
+	Quantity                          int    `json:"quantity"`
+	DispositionType                   string `json:"dispositionType"`
+	LocationId                        string `json:"locationId,omitempty"`
+}
+
+type returnNode struct {
+	Id            string `json:"id"`
+	Name          string `json:"name"`
+	Status        string `json:"status"`
+	TotalQuantity int    `json:"totalQuantity"`
+	Order         *struct {
+		Id string `json:"id"`
+	} `json:"order"`
+	ReturnLineItems struct {
+		Nodes []struct {
+			Id                  string       `json:"id"`
+			Quantity            int          `json:"quantity"`
+			ReturnReason        string       `json:"returnReason"`
+			ReturnReasonNote    string       `json:"returnReasonNote"`
+			FulfillmentLineItem *graphQLNode `json:"fulfillmentLineItem"`
+		} `json:"nodes"`
+	} `json:"returnLineItems"`
+	ReverseFulfillmentOrders struct {
+		Nodes []struct {
+			Id        string `json:"id"`
+			Status    string `json:"status"`
+			LineItems struct {
+				Nodes []struct {
+					Id                  string       `json:"id"`
+					TotalQuantity       int          `json:"totalQuantity"`
+					FulfillmentLineItem *graphQLNode `json:"fulfillmentLineItem"`
+				} `json:"nodes"`
+			} `json:"lineItems"`
+			ReverseDeliveries struct {
+				Nodes []reverseDeliveryNode `json:"nodes"`
+			} `json:"reverseDeliveries"`
+		} `json:"nodes"`
+	} `json:"reverseFulfillmentOrders"`
+}
+
+// graphQLNode is a graphql object of which only the id is queried
+type graphQLNode struct {
+	Id string `json:"id"`
+}
+
+func (n *graphQLNode) id() string {
+	if n == nil {
+		return ""
+	}
+	return n.Id
+}
+
+type reverseDeliveryNode struct {
+	Id          string `json:"id"`
+	Deliverable *struct {
+		Tracking *struct {
+			Number string `json:"number"`
+			Url    string `json:"url"`
+		} `json:"tracking"`
+		Label *struct {
+			PublicFileUrl string `json:"publicFileUrl"`
+		} `json:"label"`
+	} `json:"deliverable"`
+}
+
+func (n *reverseDeliveryNode) reverseDelivery() *ReverseDelivery {
+	if n == nil {
+		return nil
+	}
+	delivery := &ReverseDelivery{Id: n.Id}
+	if n.Deliverable != nil {
+		if n.Deliverable.Tracking != nil {
+			delivery.TrackingNumber = n.Deliverable.Tracking.Number
+			delivery.TrackingUrl = n.Deliverable.Tracking.Url
+		}
+		if n.Deliverable.Label != nil {
+			delivery.LabelUrl = n.Deliverable.Label.PublicFileUrl
+		}
+	}
+	return delivery
+}
+
+func (n *returnNode) toReturn() (*Return, error) {
+	if n == nil {
+		return nil, nil
+	}
+	r := &Return{
+		Id:            n.Id,
+		Name:          n.Name,
+		Status:        n.Status,
+		TotalQuantity: n.TotalQuantity,
+	}
+	if n.Order != nil {
+		_, orderId, err := ParseGid(n.Order.Id)
+		if err != nil {
+			return nil, err
+		}
+		r.OrderId = orderId
+	}
+	for _, lineItem := range n.ReturnLineItems.Nodes {
+		r.LineItems = append(r.LineItems, ReturnLineItem{
+			Id:                    lineItem.Id,
+			FulfillmentLineItemId: lineItem.FulfillmentLineItem.id(),
+			Quantity:              lineItem.Quantity,
+			ReturnReason:          lineItem.ReturnReason,
+			ReturnReasonNote:      lineItem.ReturnReasonNote,
+		})
+	}
+	for _, node := range n.ReverseFulfillmentOrders.Nodes {
+		order := ReverseFulfillmentOrder{Id: node.Id, Status: node.Status}
+		for _, lineItem := range node.LineItems.Nodes {
+			order.LineItems = append(order.LineItems, ReverseFulfillmentOrderLineItem{
+				Id:                    lineItem.Id,
+				FulfillmentLineItemId: lineItem.FulfillmentLineItem.id(),
+				TotalQuantity:         lineItem.TotalQuantity,
+			})
+		}
+		for i := range node.ReverseDeliveries.Nodes {
+			order.ReverseDeliveries = append(order.ReverseDeliveries, *node.ReverseDeliveries.Nodes[i].reverseDelivery())
+		}
+		r.ReverseFulfillmentOrders = append(r.ReverseFulfillmentOrders, order)
+	}
+	return r, nil
+}
+
+// returnPayload is the common payload of the return mutations
+type returnPayload struct {
+	Return     *returnNode        `json:"return"`
+	UserErrors []GraphQLUserError `json:"userErrors"`
+}
+
+func (p returnPayload) result() (*Return, error) {
+	if err := userErrorsToResponseError(p.UserErrors); err != nil {
+		return nil, err
+	}
+	return p.Return.toReturn()
+}
+
+// Get individual return, nil when it does not exist
+func (s *ReturnServiceOp) Get(ctx context.Context, returnId string) (*Return, error) {
+	resp := struct {
+		Return *returnNode `json:"return"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, returnQuery, map[string]interface{}{"id": returnId}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Return.toReturn()
+}
+
+// Create opens a return for fulfilled items of an order, its reverse
+// fulfillment orders are created along with it
+func (s *ReturnServiceOp) Create(ctx context.Context, create ReturnCreate) (*Return, error) {
+	input := map[string]interface{}{
+		"orderId":         NewGid("Order", create.OrderId),
+		"returnLineItems": create.LineItems,
+		"notifyCustomer":  create.NotifyCustomer,
+	}
+	if create.RequestedAt != nil {
+		input["requestedAt"] = create.RequestedAt
+	}
+	resp := struct {
+		ReturnCreate returnPayload `json:"returnCreate"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, returnCreateMutation, map[string]interface{}{"returnInput": input}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.ReturnCreate.result()
+}
+
+// ApproveRequest approves a return requested by the customer, which opens it
+func (s *ReturnServiceOp) ApproveRequest(ctx context.Context, returnId string) (*Return, error) {
+	resp := struct {
+		ReturnApproveRequest returnPayload `json:"returnApproveRequest"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, returnApproveRequestMutation, map[string]interface{}{"input": map[string]interface{}{"id": returnId}}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.ReturnApproveRequest.result()
+}
+
+// DeclineRequest declines a return requested by the customer, reason is a
+// ReturnDeclineReason e.g. FINAL_SALE, RETURN_PERIOD_ENDED or OTHER
+func (s *ReturnServiceOp) DeclineRequest(ctx context.Context, returnId, reason string) (*Return, error) {
+	input := map[string]interface{}{"id": returnId, "declineReason": reason}
+	resp := struct {
+		ReturnDeclineRequest returnPayload `json:"returnDeclineRequest"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, returnDeclineRequestMutation, map[string]interface{}{"input": input}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.ReturnDeclineRequest.result()
+}
+
+// CreateReverseDelivery creates the shipment of items of a reverse
+// fulfillment order back to the shop, with an optional tracking and label
+func (s *ReturnServiceOp) CreateReverseDelivery(ctx context.Context, create ReverseDeliveryCreate) (*ReverseDelivery, error) {
+	vars := map[string]interface{}{
+		"reverseFulfillmentOrderId": create.ReverseFulfillmentOrderId,
+		"reverseDeliveryLineItems":  create.LineItems,
+		"notifyCustomer":            create.NotifyCustomer,
+	}
+	if create.TrackingNumber != "" || create.TrackingUrl != "" {
+		tracking := map[string]interface{}{}
+		setIfNotEmpty(tracking, "number", create.TrackingNumber)
+		setIfNotEmpty(tracking, "url", create.TrackingUrl)
+		vars["trackingInput"] = tracking
+	}
+	if create.LabelUrl != "" {
+		vars["labelInput"] = map[string]interface{}{"fileUrl": create.LabelUrl}
+	}
+	resp := struct {
+		ReverseDeliveryCreateWithShipping struct {
+			ReverseDelivery *reverseDeliveryNode `json:"reverseDelivery"`
+			UserErrors      []GraphQLUserError   `json:"userErrors"`
+		} `json:"reverseDeliveryCreateWithShipping"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, reverseDeliveryCreateWithShippingMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := userErrorsToResponseError(resp.ReverseDeliveryCreateWithShipping.UserErrors); err != nil {
+		return nil, err
+	}
+	return resp.ReverseDeliveryCreateWithShipping.ReverseDelivery.reverseDelivery(), nil
+}
+
+// Dispose records what happened to received items of reverse fulfillment
+// orders, e.g. restocked at a location
+func (s *ReturnServiceOp) Dispose(ctx context.Context, dispositions []ReverseDisposition) error {
+	resp := struct {
+		ReverseFulfillmentOrderDispose struct {
+			UserErrors []GraphQLUserError `json:"userErrors"`
+		} `json:"reverseFulfillmentOrderDispose"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, reverseFulfillmentOrderDisposeMutation, map[string]interface{}{"dispositionInputs": dispositions}, &resp)
+	if err != nil {
+		return err
+	}
+	return userErrorsToResponseError(resp.ReverseFulfillmentOrderDispose.UserErrors)
+}
@@ -0,0 +1,97 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestReturnCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	captured := graphQLTestRequest{}
+	registerGraphQLResponder(`{"data":{"returnCreate":{"return":{
+		"id":"gid://shopify/Return/1","name":"#1001-R1","status":"OPEN","totalQuantity":1,
+		"order":{"id":"gid://shopify/Order/2"},
+		"returnLineItems":{"nodes":[{"id":"gid://shopify/ReturnLineItem/3","quantity":1,"returnReason":"DEFECTIVE","fulfillmentLineItem":{"id":"gid://shopify/FulfillmentLineItem/4"}}]},
+		"reverseFulfillmentOrders":{"nodes":[{"id":"gid://shopify/ReverseFulfillmentOrder/5","status":"OPEN",
+			"lineItems":{"nodes":[{"id":"gid://shopify/ReverseFulfillmentOrderLineItem/6","totalQuantity":1,"fulfillmentLineItem":{"id":"gid://shopify/FulfillmentLineItem/4"}}]},
+			"reverseDeliveries":{"nodes":[]}}]}
+	},"userErrors":[]}}}`, &captured)
+
+	r, err := client.Return.Create(context.Background(), ReturnCreate{
+		OrderId: 2,
+		LineItems: []ReturnLineItemCreate{
+			{FulfillmentLineItemId: "gid://shopify/FulfillmentLineItem/4", Quantity: 1, ReturnReason: ReturnReasonDefective},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Return.Create returned error: %v", err)
+	}
+
+	expected := &Return{
+		Id:            "gid://shopify/Return/1",
+		Name:          "#1001-R1",
+		Status:        ReturnStatusOpen,
+		TotalQuantity: 1,
+		OrderId:       2,
+		LineItems: []ReturnLineItem{
+			{Id: "gid://shopify/ReturnLineItem/3", FulfillmentLineItemId: "gid://shopify/FulfillmentLineItem/4", Quantity: 1, ReturnReason: ReturnReasonDefective},
+		},
+		ReverseFulfillmentOrders: []ReverseFulfillmentOrder{
+			{
+				Id:     "gid://shopify/ReverseFulfillmentOrder/5",
+				Status: "OPEN",
+				LineItems: []ReverseFulfillmentOrderLineItem{
+					{Id: "gid://shopify/ReverseFulfillmentOrderLineItem/6", FulfillmentLineItemId: "gid://shopify/FulfillmentLineItem/4", TotalQuantity: 1},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(r, expected) {
+		t.Errorf("Return.Create returned %+v, expected %+v", r, expected)
+	}
+
+	input := captured.Variables["returnInput"].(map[string]interface{})
+	if input["orderId"] != "gid://shopify/Order/2" {
+		t.Errorf("Return.Create sent orderId %v", input["orderId"])
+	}
+}
+
+func TestReturnApproveRequestUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerGraphQLResponder(`{"data":{"returnApproveRequest":{"return":null,"userErrors":[{"field":["input","id"],"message":"Return is not requested"}]}}}`, nil)
+
+	_, err := client.Return.ApproveRequest(context.Background(), "gid://shopify/Return/1")
+	if err == nil || err.Error() != "input.id: Return is not requested" {
+		t.Errorf("Return.ApproveRequest returned error %v", err)
+	}
+}
+
+func TestReturnCreateReverseDelivery(t *testing.T) {
+	setup()
+	defer teardown()
+
+	captured := graphQLTestRequest{}
+	registerGraphQLResponder(`{"data":{"reverseDeliveryCreateWithShipping":{"reverseDelivery":{"id":"gid://shopify/ReverseDelivery/7","deliverable":{"tracking":{"number":"1Z","url":"https://example.com/1Z"},"label":null}},"userErrors":[]}}}`, &captured)
+
+	delivery, err := client.Return.CreateReverseDelivery(context.Background(), ReverseDeliveryCreate{
+		ReverseFulfillmentOrderId: "gid://shopify/ReverseFulfillmentOrder/5",
+		LineItems:                 []ReverseDeliveryLineItem{{ReverseFulfillmentOrderLineItemId: "gid://shopify/ReverseFulfillmentOrderLineItem/6", Quantity: 1}},
+		TrackingNumber:            "1Z",
+	})
+	if err != nil {
+		t.Fatalf("Return.CreateReverseDelivery returned error: %v", err)
+	}
+
+	expected := &ReverseDelivery{Id: "gid://shopify/ReverseDelivery/7", TrackingNumber: "1Z", TrackingUrl: "https://example.com/1Z"}
+	if !reflect.DeepEqual(delivery, expected) {
+		t.Errorf("Return.CreateReverseDelivery returned %+v, expected %+v", delivery, expected)
+	}
+	if _, ok := captured.Variables["labelInput"]; ok {
+		t.Errorf("Return.CreateReverseDelivery sent a labelInput without label")
+	}
+}
@@ -0,0 +1,187 @@
+package goshopify
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// resourceScopes maps REST resources to the name of their access scope,
+// without the read_ or write_ prefix. Resources mapped to "" need no scope.
+// Nested resources missing from the map, e.g. the images of a product,
+// require the scope of their parent.
+var resourceScopes = map[string]string{
+	"products":                      "products",
+	"variants":                      "products",
+	"custom_collections":            "products",
+	"smart_collections":             "products",
+	"collects":                      "products",
+	"collections":                   "products",
+	"product_listings":              "product_listings",
+	"customers":                     "customers",
+	"orders":                        "orders",
+	"fulfillments":                  "fulfillments",
+	"fulfillment_services":          "fulfillments",
+	"draft_orders":                  "draft_orders",
+	"checkouts":                     "checkouts",
+	"fulfillment_orders":            "merchant_managed_fulfillment_orders",
+	"assigned_fulfillment_orders":   "assigned_fulfillment_orders",
+	"inventory_items":               "inventory",
+	"inventory_levels":              "inventory",
+	"locations":                     "locations",
+	"price_rules":                   "price_rules",
+	"gift_cards":                    "gift_cards",
+	"themes":                        "themes",
+	"script_tags":                   "script_tags",
+	"blogs":                         "content",
+	"articles":                      "content",
+	"pages":                         "content",
+	"redirects":                     "online_store_navigation",
+	"shipping_zones":                "shipping",
+	"carrier_services":              "shipping",
+	"countries":                     "shipping",
+	"shopify_payments":              "shopify_payments_payouts",
+	"marketing_events":              "marketing_events",
+	"reports":                       "reports",
+	"users":                         "users",
+	"shop":                          "",
+	"webhooks":                      "",
+	"access_scopes":                 "",
+	"api_permissions":               "",
+	"application_charges":           "",
+	"recurring_application_charges": "",
+	"storefront_access_tokens":      "",
+}
+
+// inheritedResources take the scope of the resource they are nested in,
+// top-level metafields and events belong to the shop
+var inheritedResources = map[string]bool{"metafields": true, "events": true, "count": true}
+
+// apiPathPrefixRegex matches the prefix of the paths of the admin api
+var apiPathPrefixRegex = regexp.MustCompile(`^/?admin(/api/([0-9]{4}-[0-9]{2}|unstable))?/`)
+
+// ScopeRecorder is a Middleware recording the endpoints called by a client to
+// suggest the least-privilege access scopes of the app, e.g. during a test
+// run of a sync job:
+//
+//	recorder := goshopify.NewScopeRecorder()
+//	client, err := goshopify.NewClient(app, shop, token, goshopify.WithMiddleware(recorder.Middleware))
+//	// ... run the job
+//	log.Printf("scopes: %s", strings.Join(recorder.Scopes(), ","))
+//
+// GraphQL requests cannot be mapped from their path and are listed by
+// Unmapped.
+type ScopeRecorder struct {
+	mu        sync.Mutex
+	endpoints map[string]bool
+}
+
+// NewScopeRecorder returns a ScopeRecorder which recorded no endpoint
+func NewScopeRecorder() *ScopeRecorder {
+	return &ScopeRecorder{endpoints: map[string]bool{}}
+}
+
+// Middleware records the endpoint of the requests sent through it
+func (r *ScopeRecorder) Middleware(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		r.mu.Lock()
+		r.endpoints[req.Method+" "+endpointResource(req.URL.Path)] = true
+		r.mu.Unlock()
+		return next(req)
+	}
+}
+
+// Endpoints returns the method and resource of the recorded requests, e.g.
+// "GET products/metafields", sorted
+func (r *ScopeRecorder) Endpoints() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	endpoints := make([]string, 0, len(r.endpoints))
+	for endpoint := range r.endpoints {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	return endpoints
+}
+
+// Scopes returns the minimal access scopes of the recorded endpoints, sorted.
+// A read scope is left out when the write scope of the resource is needed
+// since it grants reading too.
+func (r *ScopeRecorder) Scopes() []string {
+	needed := map[string]bool{}
+	for _, endpoint := range r.Endpoints() {
+		if scope, ok := endpointScope(endpoint); ok && scope != "" {
+			needed[scope] = true
+		}
+	}
+
+	scopes := make([]string, 0, len(needed))
+	for scope := range needed {
+		if strings.HasPrefix(scope, "read_") && needed["write_"+strings.TrimPrefix(scope, "read_")] {
+			continue
+		}
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+// Unmapped returns the recorded endpoints whose scope is unknown, sorted
+func (r *ScopeRecorder) Unmapped() []string {
+	var unmapped []string
+	for _, endpoint := range r.Endpoints() {
+		if _, ok := endpointScope(endpoint); !ok {
+			unmapped = append(unmapped, endpoint)
+		}
+	}
+	return unmapped
+}
+
+// endpointResource returns the resources of an api path without the api
+// prefix, the ids and the .json extension, e.g. "products/metafields" for
+// "/admin/api/2024-01/products/1/metafields.json"
+func endpointResource(path string) string {
+	path = strings.TrimSuffix(apiPathPrefixRegex.ReplaceAllString(path, ""), ".json")
+
+	var resources []string
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" && strings.Trim(segment, "0123456789") != "" {
+			resources = append(resources, segment)
+		}
+	}
+	return strings.Join(resources, "/")
+}
+
+// endpointScope returns the scope of an endpoint recorded by a ScopeRecorder,
+// "" when it needs none, ok is false when its scope is unknown
+func endpointScope(endpoint string) (scope string, ok bool) {
+	method, resource, _ := strings.Cut(endpoint, " ")
+	resources := strings.Split(resource, "/")
+
+	for i := len(resources) - 1; i >= 0; i-- {
+		if inheritedResources[resources[i]] {
+			if i == 0 {
+				return "", true
+			}
+			continue
+		}
+		name, known := resourceScopes[resources[i]]
+		if !known {
+			if i > 0 {
+				continue
+			}
+			return "", false
+		}
+		if name == "" {
+			return "", true
+		}
+		if method == http.MethodGet || method == http.MethodHead {
+			return "read_" + name, true
+		}
+		return "write_" + name, true
+	}
+	return "", false
+}
@@ -0,0 +1,81 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestScopeRecorder(t *testing.T) {
+	setup()
+	defer teardown()
+
+	recorder := NewScopeRecorder()
+	client.Use(recorder.Middleware)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"product":{"id":1}}`))
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"product":{"id":1}}`))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1/transactions.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"transactions":[]}`))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"shop":{"id":1}}`))
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{}}`))
+
+	ctx := context.Background()
+	if _, err := client.Product.Get(ctx, 1, nil); err != nil {
+		t.Fatalf("Product.Get returned error: %v", err)
+	}
+	if _, err := client.Product.Update(ctx, Product{Id: 1}); err != nil {
+		t.Fatalf("Product.Update returned error: %v", err)
+	}
+	if _, err := client.Transaction.List(ctx, 1, nil); err != nil {
+		t.Fatalf("Transaction.List returned error: %v", err)
+	}
+	if _, err := client.Shop.Get(ctx, nil); err != nil {
+		t.Fatalf("Shop.Get returned error: %v", err)
+	}
+	if err := client.GraphQL.Query(ctx, "query {}", nil, nil); err != nil {
+		t.Fatalf("GraphQL.Query returned error: %v", err)
+	}
+
+	expectedEndpoints := []string{"GET orders/transactions", "GET products", "GET shop", "POST graphql", "PUT products"}
+	if endpoints := recorder.Endpoints(); !reflect.DeepEqual(endpoints, expectedEndpoints) {
+		t.Errorf("ScopeRecorder.Endpoints returned %v, expected %v", endpoints, expectedEndpoints)
+	}
+	expectedScopes := []string{"read_orders", "write_products"}
+	if scopes := recorder.Scopes(); !reflect.DeepEqual(scopes, expectedScopes) {
+		t.Errorf("ScopeRecorder.Scopes returned %v, expected %v", scopes, expectedScopes)
+	}
+	if unmapped := recorder.Unmapped(); !reflect.DeepEqual(unmapped, []string{"POST graphql"}) {
+		t.Errorf("ScopeRecorder.Unmapped returned %v, expected [POST graphql]", unmapped)
+	}
+}
+
+func TestEndpointScope(t *testing.T) {
+	cases := []struct {
+		method, path string
+		scope        string
+		ok           bool
+	}{
+		{"GET", "/admin/api/2024-01/products/1/metafields.json", "read_products", true},
+		{"POST", "/admin/api/unstable/orders/1/fulfillments.json", "write_fulfillments", true},
+		{"GET", "/admin/shopify_payments/balance/transactions.json", "read_shopify_payments_payouts", true},
+		{"DELETE", "/admin/api/2024-01/price_rules/1/discount_codes/2.json", "write_price_rules", true},
+		{"GET", "/admin/api/2024-01/metafields.json", "", true},
+		{"POST", "/admin/api/2024-01/webhooks.json", "", true},
+		{"GET", "/admin/api/2024-01/unknown_things.json", "", false},
+	}
+
+	for _, c := range cases {
+		scope, ok := endpointScope(c.method + " " + endpointResource(c.path))
+		if scope != c.scope || ok != c.ok {
+			t.Errorf("endpointScope(%s %s) returned %q, %t, expected %q, %t", c.method, c.path, scope, ok, c.scope, c.ok)
+		}
+	}
+}
@@ -51,7 +51,7 @@ func TestShippingZoneListError(t *testing.T) {
 	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shipping_zones.json", client.pathPrefix),
 		httpmock.NewStringResponder(500, ""))
 
-	expectedErrMessage := "Unknown Error"
+	expectedErrMessage := "500 Internal Server Error"
 
 	shippingZones, err := client.ShippingZone.List(context.Background())
 	if shippingZones != nil {
@@ -2,6 +2,8 @@ package goshopify
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -13,6 +15,10 @@ const shopResourceName = ""
 // See: https://help.shopify.com/api/reference/shop
 type ShopService interface {
 	Get(ctx context.Context, options interface{}) (*Shop, error)
+	GetWithFields(ctx context.Context, fields ...string) (*Shop, error)
+	Location(ctx context.Context) (*time.Location, error)
+	LocalTime(ctx context.Context, t time.Time) (time.Time, error)
+	LocalDay(ctx context.Context, t time.Time) (time.Time, error)
 
 	// MetafieldsService used for Shop resource to communicate with Metafields resource
 	MetafieldsService
@@ -26,55 +32,64 @@ type ShopServiceOp struct {
 
 // Shop represents a Shopify shop
 type Shop struct {
-	Id                              uint64     `json:"id"`
-	Name                            string     `json:"name"`
-	ShopOwner                       string     `json:"shop_owner"`
-	Email                           string     `json:"email"`
-	CustomerEmail                   string     `json:"customer_email"`
-	CreatedAt                       *time.Time `json:"created_at"`
-	UpdatedAt                       *time.Time `json:"updated_at"`
-	Address1                        string     `json:"address1"`
-	Address2                        string     `json:"address2"`
-	City                            string     `json:"city"`
-	Country                         string     `json:"country"`
-	CountryCode                     string     `json:"country_code"`
-	CountryName                     string     `json:"country_name"`
-	Currency                        string     `json:"currency"`
-	Domain                          string     `json:"domain"`
-	Latitude                        float64    `json:"latitude"`
-	Longitude                       float64    `json:"longitude"`
-	Phone                           string     `json:"phone"`
-	Province                        string     `json:"province"`
-	ProvinceCode                    string     `json:"province_code"`
-	Zip                             string     `json:"zip"`
-	MoneyFormat                     string     `json:"money_format"`
-	MoneyWithCurrencyFormat         string     `json:"money_with_currency_format"`
-	WeightUnit                      string     `json:"weight_unit"`
-	MyshopifyDomain                 string     `json:"myshopify_domain"`
-	PlanName                        string     `json:"plan_name"`
-	PlanDisplayName                 string     `json:"plan_display_name"`
-	PasswordEnabled                 bool       `json:"password_enabled"`
-	PrimaryLocale                   string     `json:"primary_locale"`
-	PrimaryLocationId               uint64     `json:"primary_location_id"`
-	Timezone                        string     `json:"timezone"`
-	IanaTimezone                    string     `json:"iana_timezone"`
-	ForceSSL                        bool       `json:"force_ssl"`
-	TaxShipping                     bool       `json:"tax_shipping"`
-	TaxesIncluded                   bool       `json:"taxes_included"`
-	HasStorefront                   bool       `json:"has_storefront"`
-	HasDiscounts                    bool       `json:"has_discounts"`
-	HasGiftcards                    bool       `json:"has_gift_cards"`
-	SetupRequire                    bool       `json:"setup_required"`
-	CountyTaxes                     bool       `json:"county_taxes"`
-	CheckoutAPISupported            bool       `json:"checkout_api_supported"`
-	Source                          string     `json:"source"`
-	GoogleAppsDomain                string     `json:"google_apps_domain"`
-	GoogleAppsLoginEnabled          bool       `json:"google_apps_login_enabled"`
-	MoneyInEmailsFormat             string     `json:"money_in_emails_format"`
-	MoneyWithCurrencyInEmailsFormat string     `json:"money_with_currency_in_emails_format"`
-	EligibleForPayments             bool       `json:"eligible_for_payments"`
-	RequiresExtraPaymentsAgreement  bool       `json:"requires_extra_payments_agreement"`
-	PreLaunchEnabled                bool       `json:"pre_launch_enabled"`
+	Id                                   uint64     `json:"id"`
+	Name                                 string     `json:"name"`
+	ShopOwner                            string     `json:"shop_owner"`
+	Email                                string     `json:"email"`
+	CustomerEmail                        string     `json:"customer_email"`
+	CreatedAt                            *time.Time `json:"created_at"`
+	UpdatedAt                            *time.Time `json:"updated_at"`
+	Address1                             string     `json:"address1"`
+	Address2                             string     `json:"address2"`
+	City                                 string     `json:"city"`
+	Country                              string     `json:"country"`
+	CountryCode                          string     `json:"country_code"`
+	CountryName                          string     `json:"country_name"`
+	Currency                             string     `json:"currency"`
+	EnabledPresentmentCurrencies         []string   `json:"enabled_presentment_currencies"`
+	Domain                               string     `json:"domain"`
+	Latitude                             float64    `json:"latitude"`
+	Longitude                            float64    `json:"longitude"`
+	Phone                                string     `json:"phone"`
+	Province                             string     `json:"province"`
+	ProvinceCode                         string     `json:"province_code"`
+	Zip                                  string     `json:"zip"`
+	MoneyFormat                          string     `json:"money_format"`
+	MoneyWithCurrencyFormat              string     `json:"money_with_currency_format"`
+	WeightUnit                           string     `json:"weight_unit"`
+	MyshopifyDomain                      string     `json:"myshopify_domain"`
+	PlanName                             string     `json:"plan_name"`
+	PlanDisplayName                      string     `json:"plan_display_name"`
+	PasswordEnabled                      bool       `json:"password_enabled"`
+	PrimaryLocale                        string     `json:"primary_locale"`
+	PrimaryLocationId                    uint64     `json:"primary_location_id"`
+	Timezone                             string     `json:"timezone"`
+	IanaTimezone                         string     `json:"iana_timezone"`
+	ForceSSL                             bool       `json:"force_ssl"`
+	TaxShipping                          bool       `json:"tax_shipping"`
+	TaxesIncluded                        bool       `json:"taxes_included"`
+	AutoConfigureTaxInclusivity          bool       `json:"auto_configure_tax_inclusivity"`
+	HasStorefront                        bool       `json:"has_storefront"`
+	HasDiscounts                         bool       `json:"has_discounts"`
+	HasGiftcards                         bool       `json:"has_gift_cards"`
+	SetupRequire                         bool       `json:"setup_required"`
+	CountyTaxes                          bool       `json:"county_taxes"`
+	CheckoutAPISupported                 bool       `json:"checkout_api_supported"`
+	MultiLocationEnabled                 bool       `json:"multi_location_enabled"`
+	Source                               string     `json:"source"`
+	GoogleAppsDomain                     string     `json:"google_apps_domain"`
+	GoogleAppsLoginEnabled               bool       `json:"google_apps_login_enabled"`
+	MoneyInEmailsFormat                  string     `json:"money_in_emails_format"`
+	MoneyWithCurrencyInEmailsFormat      string     `json:"money_with_currency_in_emails_format"`
+	EligibleForPayments                  bool       `json:"eligible_for_payments"`
+	EligibleForCardReaderGiveaway        bool       `json:"eligible_for_card_reader_giveaway"`
+	RequiresExtraPaymentsAgreement       bool       `json:"requires_extra_payments_agreement"`
+	PreLaunchEnabled                     bool       `json:"pre_launch_enabled"`
+	TransactionalSmsDisabled             bool       `json:"transactional_sms_disabled"`
+	MarketingSmsConsentEnabledAtCheckout bool       `json:"marketing_sms_consent_enabled_at_checkout"`
+	CookieConsentLevel                   string     `json:"cookie_consent_level"`
+	Finances                             bool       `json:"finances"`
+	UnknownJSONFields
 }
 
 // Represents the result from the admin/shop.json endpoint
@@ -89,12 +104,96 @@ func (s *ShopServiceOp) Get(ctx context.Context, options interface{}) (*Shop, er
 	return resource.Shop, err
 }
 
+// GetWithFields gets the shop limited to the given fields, e.g.
+// GetWithFields(ctx, "plan_name", "multi_location_enabled")
+func (s *ShopServiceOp) GetWithFields(ctx context.Context, fields ...string) (*Shop, error) {
+	options := struct {
+		Fields string `url:"fields,omitempty"`
+	}{
+		Fields: strings.Join(fields, ","),
+	}
+	return s.Get(ctx, options)
+}
+
+// shopCacheTTL is how long shop settings are kept in the client cache store
+const shopCacheTTL = 24 * time.Hour
+
+// cachedShopField returns a shop field from the client cache store, fetching
+// it with shop.json?fields=<field> on a miss
+func (c *Client) cachedShopField(ctx context.Context, field string, value func(*Shop) string) (string, error) {
+	key := fmt.Sprintf("shop:%s:%s", c.shop, field)
+	if cached, found, err := c.cache.Get(ctx, key); err == nil && found {
+		return string(cached), nil
+	}
+
+	shop, err := c.Shop.GetWithFields(ctx, field)
+	if err != nil {
+		return "", err
+	}
+
+	v := value(shop)
+	if err := c.cache.Set(ctx, key, []byte(v), shopCacheTTL); err != nil {
+		c.log.Warnf("could not cache shop %s: %s", field, err)
+	}
+	return v, nil
+}
+
+// shopCurrency returns the cached currency of the shop
+func (c *Client) shopCurrency(ctx context.Context) (string, error) {
+	return c.cachedShopField(ctx, "currency", func(shop *Shop) string {
+		return shop.Currency
+	})
+}
+
+// Location returns the IANA timezone of the shop, cached in the client cache
+// store. Binaries running without the tz database of the system should import
+// time/tzdata.
+func (s *ShopServiceOp) Location(ctx context.Context) (*time.Location, error) {
+	name, err := s.client.cachedShopField(ctx, "iana_timezone", func(shop *Shop) string {
+		return shop.IanaTimezone
+	})
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, fmt.Errorf("shop %s has no iana timezone", s.client.shop)
+	}
+	return time.LoadLocation(name)
+}
+
+// LocalTime converts t, e.g. the created_at of an order, to the local time of
+// the shop
+func (s *ShopServiceOp) LocalTime(ctx context.Context, t time.Time) (time.Time, error) {
+	location, err := s.Location(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.In(location), nil
+}
+
+// LocalDay returns the start of the day of t in the local time of the shop,
+// e.g. to bucket the sales of orders per merchant-local day
+func (s *ShopServiceOp) LocalDay(ctx context.Context, t time.Time) (time.Time, error) {
+	local, err := s.LocalTime(ctx, t)
+	if err != nil {
+		return time.Time{}, err
+	}
+	year, month, day := local.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, local.Location()), nil
+}
+
 // ListMetafields for a shop
 func (s *ShopServiceOp) ListMetafields(ctx context.Context, _ uint64, options interface{}) ([]Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: shopResourceName}
 	return metafieldService.List(ctx, options)
 }
 
+// ListMetafieldsByNamespace lists all metafields of a namespace for a shop
+func (s *ShopServiceOp) ListMetafieldsByNamespace(ctx context.Context, _ uint64, namespace string) ([]Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: shopResourceName}
+	return metafieldService.ListByNamespace(ctx, namespace)
+}
+
 // CountMetafields for a shop
 func (s *ShopServiceOp) CountMetafields(ctx context.Context, _ uint64, options interface{}) (int, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: shopResourceName}
@@ -48,6 +48,13 @@ func TestShopGet(t *testing.T) {
 		{"EligibleForPayments", true, shop.EligibleForPayments},
 		{"RequiresExtraPaymentsAgreement", false, shop.RequiresExtraPaymentsAgreement},
 		{"PreLaunchEnabled", false, shop.PreLaunchEnabled},
+		{"PlanName", "enterprise", shop.PlanName},
+		{"PlanDisplayName", "Shopify Plus", shop.PlanDisplayName},
+		{"CheckoutAPISupported", true, shop.CheckoutAPISupported},
+		{"MultiLocationEnabled", true, shop.MultiLocationEnabled},
+		{"TransactionalSmsDisabled", false, shop.TransactionalSmsDisabled},
+		{"MarketingSmsConsentEnabledAtCheckout", true, shop.MarketingSmsConsentEnabledAtCheckout},
+		{"CookieConsentLevel", "implicit", shop.CookieConsentLevel},
 	}
 
 	for _, c := range cases {
@@ -57,6 +64,68 @@ func TestShopGet(t *testing.T) {
 	}
 }
 
+func TestShopGetWithFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{"fields": "id,plan_name"}
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		params, httpmock.NewStringResponder(200, `{"shop":{"id":1,"plan_name":"basic"}}`))
+
+	shop, err := client.Shop.GetWithFields(context.Background(), "id", "plan_name")
+	if err != nil {
+		t.Errorf("Shop.GetWithFields returned error: %v", err)
+	}
+
+	expected := &Shop{Id: 1, PlanName: "basic"}
+	if !reflect.DeepEqual(shop, expected) {
+		t.Errorf("Shop.GetWithFields returned %+v, expected %+v", shop, expected)
+	}
+}
+
+func TestShopLocalDay(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{"fields": "iana_timezone"}
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		params, httpmock.NewStringResponder(200, `{"shop":{"iana_timezone":"America/New_York"}}`))
+
+	createdAt := time.Date(2024, time.May, 10, 2, 30, 0, 0, time.UTC)
+	local, err := client.Shop.LocalTime(context.Background(), createdAt)
+	if err != nil {
+		t.Fatalf("Shop.LocalTime returned error: %v", err)
+	}
+	if expected := "2024-05-09T22:30:00-04:00"; local.Format(time.RFC3339) != expected {
+		t.Errorf("Shop.LocalTime returned %s, expected %s", local.Format(time.RFC3339), expected)
+	}
+
+	day, err := client.Shop.LocalDay(context.Background(), createdAt)
+	if err != nil {
+		t.Fatalf("Shop.LocalDay returned error: %v", err)
+	}
+	if expected := "2024-05-09T00:00:00-04:00"; day.Format(time.RFC3339) != expected {
+		t.Errorf("Shop.LocalDay returned %s, expected %s", day.Format(time.RFC3339), expected)
+	}
+
+	if calls := httpmock.GetTotalCallCount(); calls != 1 {
+		t.Errorf("shop.json was requested %d times, expected the timezone to be cached", calls)
+	}
+}
+
+func TestShopLocationMissing(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"shop":{}}`))
+
+	_, err := client.Shop.Location(context.Background())
+	if expected := "shop fooshop.myshopify.com has no iana timezone"; err == nil || err.Error() != expected {
+		t.Errorf("Shop.Location returned error %v, expected %s", err, expected)
+	}
+}
+
 func TestShopListMetafields(t *testing.T) {
 	setup()
 	defer teardown()
@@ -0,0 +1,258 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const shopifyFunctionsQuery = `query shopifyFunctions($apiType: String, $after: String) {
+  shopifyFunctions(first: 250, apiType: $apiType, after: $after) {
+    nodes {
+      id
+      title
+      apiType
+      apiVersion
+      app {
+        title
+      }
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+const metafieldsSetMutation = `mutation metafieldsSet($metafields: [MetafieldsSetInput!]!) {
+  metafieldsSet(metafields: $metafields) {
+    metafields {
+      id
+      namespace
+      key
+      type
+      value
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const discountAutomaticAppCreateMutation = `mutation discountAutomaticAppCreate($automaticAppDiscount: DiscountAutomaticAppInput!) {
+  discountAutomaticAppCreate(automaticAppDiscount: $automaticAppDiscount) {
+    automaticAppDiscount {
+      discountId
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+// API types of Shopify Functions
+const (
+	FunctionApiTypeCartTransform          = "cart_transform"
+	FunctionApiTypeDeliveryCustomization  = "delivery_customization"
+	FunctionApiTypeOrderDiscounts         = "order_discounts"
+	FunctionApiTypePaymentCustomization   = "payment_customization"
+	FunctionApiTypeProductDiscounts       = "product_discounts"
+	FunctionApiTypeShippingDiscounts      = "shipping_discounts"
+	FunctionApiTypeFulfillmentConstraints = "fulfillment_constraints"
+)
+
+// ShopifyFunctionService is an interface for installing Shopify Functions of
+// the app with the Shopify API: looking up a function, creating the discount
+// or customization running it and setting its configuration metafield.
+// See: https://shopify.dev/docs/apps/build/functions
+type ShopifyFunctionService interface {
+	List(context.Context, string) ([]ShopifyFunction, error)
+	GetByTitle(context.Context, string, string) (*ShopifyFunction, error)
+	SetConfiguration(context.Context, string, FunctionConfiguration) (*GraphQLMetafield, error)
+	CreateAutomaticDiscount(context.Context, FunctionAutomaticDiscount) (string, error)
+}
+
+// ShopifyFunctionServiceOp handles communication with the Shopify Functions
+// related methods of the Shopify API.
+type ShopifyFunctionServiceOp struct {
+	client *Client
+}
+
+// ShopifyFunction represents a function deployed by the app. Id is the
+// function id used when creating discounts and customizations.
+type ShopifyFunction struct {
+	Id         string `json:"id"`
+	Title      string `json:"title"`
+	ApiType    string `json:"apiType"`
+	ApiVersion string `json:"apiVersion"`
+	App        struct {
+		Title string `json:"title"`
+	} `json:"app"`
+}
+
+// FunctionConfiguration is the json metafield a function reads its
+// configuration from. Value is marshalled to json.
+type FunctionConfiguration struct {
+	Namespace string
+	Key       string
+	Value     interface{}
+}
+
+// GraphQLMetafield represents a metafield returned by graphql
+type GraphQLMetafield struct {
+	Id        string `json:"id"`
+	Namespace string `json:"namespace"`
+	Key       string `json:"key"`
+	Type      string `json:"type"`
+	Value     string `json:"value"`
+}
+
+// FunctionAutomaticDiscount represents a DiscountAutomaticAppInput, the
+// discount runs the function and gets the Configuration metafield when set
+type FunctionAutomaticDiscount struct {
+	Title         string
+	FunctionId    string
+	StartsAt      time.Time
+	EndsAt        *time.Time
+	Configuration *FunctionConfiguration
+}
+
+// metafieldInput represents a MetafieldInput or MetafieldsSetInput
+type metafieldInput struct {
+	OwnerId   string `json:"ownerId,omitempty"`
+	Namespace string `json:"namespace"`
+	Key       string `json:"key"`
+	Type      string `json:"type"`
+	Value     string `json:"value"`
+}
+
+func (c FunctionConfiguration) input(ownerId string) (metafieldInput, error) {
+	value, err := json.Marshal(c.Value)
+	if err != nil {
+		return metafieldInput{}, err
+	}
+	return metafieldInput{OwnerId: ownerId, Namespace: c.Namespace, Key: c.Key, Type: "json", Value: string(value)}, nil
+}
+
+// List returns the functions of the app, apiType is optional
+func (s *ShopifyFunctionServiceOp) List(ctx context.Context, apiType string) ([]ShopifyFunction, error) {
+	functions := []ShopifyFunction{}
+	vars := map[string]interface{}{}
+	if apiType != "" {
+		vars["apiType"] = apiType
+	}
+
+	for {
+		resp := struct {
+			ShopifyFunctions struct {
+				Nodes    []ShopifyFunction `json:"nodes"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"shopifyFunctions"`
+		}{}
+
+		err := s.client.GraphQL.Query(ctx, shopifyFunctionsQuery, vars, &resp)
+		if err != nil {
+			return nil, err
+		}
+		functions = append(functions, resp.ShopifyFunctions.Nodes...)
+
+		if !resp.ShopifyFunctions.PageInfo.HasNextPage {
+			return functions, nil
+		}
+		vars["after"] = resp.ShopifyFunctions.PageInfo.EndCursor
+	}
+}
+
+// GetByTitle looks a function of the app up by api type and title, the error
+// matches ErrNotFound when the function is not deployed
+func (s *ShopifyFunctionServiceOp) GetByTitle(ctx context.Context, apiType, title string) (*ShopifyFunction, error) {
+	functions, err := s.List(ctx, apiType)
+	if err != nil {
+		return nil, err
+	}
+	for i := range functions {
+		if functions[i].Title == title {
+			return &functions[i], nil
+		}
+	}
+	return nil, ResponseError{
+		Status:  http.StatusNotFound,
+		Message: fmt.Sprintf("no %s function titled %q", apiType, title),
+	}
+}
+
+// SetConfiguration sets the configuration metafield of the discount or
+// customization with the graphql id ownerId
+func (s *ShopifyFunctionServiceOp) SetConfiguration(ctx context.Context, ownerId string, configuration FunctionConfiguration) (*GraphQLMetafield, error) {
+	input, err := configuration.input(ownerId)
+	if err != nil {
+		return nil, err
+	}
+	resp := struct {
+		MetafieldsSet struct {
+			Metafields []GraphQLMetafield `json:"metafields"`
+			UserErrors []GraphQLUserError `json:"userErrors"`
+		} `json:"metafieldsSet"`
+	}{}
+
+	err = s.client.GraphQL.Query(ctx, metafieldsSetMutation, map[string]interface{}{"metafields": []metafieldInput{input}}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := userErrorsToResponseError(resp.MetafieldsSet.UserErrors); err != nil {
+		return nil, err
+	}
+	if len(resp.MetafieldsSet.Metafields) == 0 {
+		return nil, nil
+	}
+	return &resp.MetafieldsSet.Metafields[0], nil
+}
+
+// CreateAutomaticDiscount creates an automatic discount running a discount
+// function and returns the graphql id of the discount
+func (s *ShopifyFunctionServiceOp) CreateAutomaticDiscount(ctx context.Context, discount FunctionAutomaticDiscount) (string, error) {
+	input := map[string]interface{}{
+		"title":      discount.Title,
+		"functionId": discount.FunctionId,
+		"startsAt":   discount.StartsAt,
+	}
+	if discount.EndsAt != nil {
+		input["endsAt"] = discount.EndsAt
+	}
+	if discount.Configuration != nil {
+		metafield, err := discount.Configuration.input("")
+		if err != nil {
+			return "", err
+		}
+		input["metafields"] = []metafieldInput{metafield}
+	}
+	resp := struct {
+		DiscountAutomaticAppCreate struct {
+			AutomaticAppDiscount *struct {
+				DiscountId string `json:"discountId"`
+			} `json:"automaticAppDiscount"`
+			UserErrors []GraphQLUserError `json:"userErrors"`
+		} `json:"discountAutomaticAppCreate"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, discountAutomaticAppCreateMutation, map[string]interface{}{"automaticAppDiscount": input}, &resp)
+	if err != nil {
+		return "", err
+	}
+	if err := userErrorsToResponseError(resp.DiscountAutomaticAppCreate.UserErrors); err != nil {
+		return "", err
+	}
+	if resp.DiscountAutomaticAppCreate.AutomaticAppDiscount == nil {
+		return "", nil
+	}
+	return resp.DiscountAutomaticAppCreate.AutomaticAppDiscount.DiscountId, nil
+}
@@ -0,0 +1,137 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestShopifyFunctionGetByTitle(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLTestRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			captured := graphQLTestRequest{}
+			if err := json.NewDecoder(req.Body).Decode(&captured); err != nil {
+				return nil, err
+			}
+			requests = append(requests, captured)
+			if captured.Variables["after"] == nil {
+				return httpmock.NewStringResponse(200, `{"data":{"shopifyFunctions":{
+					"nodes":[{"id":"fn-1","title":"Volume discount","apiType":"product_discounts"}],
+					"pageInfo":{"hasNextPage":true,"endCursor":"c1"}
+				}}}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"data":{"shopifyFunctions":{
+				"nodes":[{"id":"fn-2","title":"Bundle discount","apiType":"product_discounts"}],
+				"pageInfo":{"hasNextPage":false,"endCursor":"c2"}
+			}}}`), nil
+		})
+
+	function, err := client.ShopifyFunction.GetByTitle(context.Background(), FunctionApiTypeProductDiscounts, "Bundle discount")
+	if err != nil {
+		t.Fatalf("ShopifyFunction.GetByTitle returned error: %v", err)
+	}
+	if function.Id != "fn-2" {
+		t.Errorf("ShopifyFunction.GetByTitle returned %+v, expected fn-2", function)
+	}
+	if len(requests) != 2 || requests[0].Variables["apiType"] != "product_discounts" || requests[1].Variables["after"] != "c1" {
+		t.Errorf("ShopifyFunction.GetByTitle sent %+v", requests)
+	}
+
+	_, err = client.ShopifyFunction.GetByTitle(context.Background(), FunctionApiTypeProductDiscounts, "Missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("ShopifyFunction.GetByTitle returned %v, expected ErrNotFound", err)
+	}
+}
+
+func TestShopifyFunctionSetConfiguration(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var captured graphQLTestRequest
+	registerGraphQLResponder(`{"data":{"metafieldsSet":{
+		"metafields":[{"id":"gid://shopify/Metafield/1","namespace":"$app:volume","key":"function-configuration","type":"json","value":"{\"minimum\":3}"}],
+		"userErrors":[]
+	}}}`, &captured)
+
+	metafield, err := client.ShopifyFunction.SetConfiguration(context.Background(), "gid://shopify/DiscountAutomaticNode/1", FunctionConfiguration{
+		Namespace: "$app:volume",
+		Key:       "function-configuration",
+		Value:     map[string]int{"minimum": 3},
+	})
+	if err != nil {
+		t.Fatalf("ShopifyFunction.SetConfiguration returned error: %v", err)
+	}
+	if metafield.Id != "gid://shopify/Metafield/1" {
+		t.Errorf("ShopifyFunction.SetConfiguration returned %+v", metafield)
+	}
+
+	expected := map[string]interface{}{"metafields": []interface{}{map[string]interface{}{
+		"ownerId":   "gid://shopify/DiscountAutomaticNode/1",
+		"namespace": "$app:volume",
+		"key":       "function-configuration",
+		"type":      "json",
+		"value":     `{"minimum":3}`,
+	}}}
+	if !reflect.DeepEqual(captured.Variables, expected) {
+		t.Errorf("ShopifyFunction.SetConfiguration sent %+v, expected %+v", captured.Variables, expected)
+	}
+}
+
+func TestShopifyFunctionCreateAutomaticDiscount(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var captured graphQLTestRequest
+	registerGraphQLResponder(`{"data":{"discountAutomaticAppCreate":{
+		"automaticAppDiscount":{"discountId":"gid://shopify/DiscountAutomaticNode/1"},
+		"userErrors":[]
+	}}}`, &captured)
+
+	discountId, err := client.ShopifyFunction.CreateAutomaticDiscount(context.Background(), FunctionAutomaticDiscount{
+		Title:         "Buy 3, save 10%",
+		FunctionId:    "fn-1",
+		StartsAt:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Configuration: &FunctionConfiguration{Namespace: "$app:volume", Key: "function-configuration", Value: map[string]int{"minimum": 3}},
+	})
+	if err != nil {
+		t.Fatalf("ShopifyFunction.CreateAutomaticDiscount returned error: %v", err)
+	}
+	if discountId != "gid://shopify/DiscountAutomaticNode/1" {
+		t.Errorf("ShopifyFunction.CreateAutomaticDiscount returned %s", discountId)
+	}
+
+	input := captured.Variables["automaticAppDiscount"].(map[string]interface{})
+	if input["functionId"] != "fn-1" || input["startsAt"] != "2024-01-01T00:00:00Z" {
+		t.Errorf("ShopifyFunction.CreateAutomaticDiscount sent %+v", input)
+	}
+	metafields := input["metafields"].([]interface{})
+	if len(metafields) != 1 || metafields[0].(map[string]interface{})["value"] != `{"minimum":3}` {
+		t.Errorf("ShopifyFunction.CreateAutomaticDiscount sent metafields %+v", metafields)
+	}
+}
+
+func TestShopifyFunctionUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerGraphQLResponder(`{"data":{"discountAutomaticAppCreate":{
+		"automaticAppDiscount":null,
+		"userErrors":[{"field":["automaticAppDiscount","functionId"],"message":"Function not found.","code":"INVALID"}]
+	}}}`, nil)
+
+	_, err := client.ShopifyFunction.CreateAutomaticDiscount(context.Background(), FunctionAutomaticDiscount{Title: "x", FunctionId: "missing"})
+	if err == nil || err.Error() != "automaticAppDiscount.functionId: Function not found." {
+		t.Errorf("ShopifyFunction.CreateAutomaticDiscount returned %v", err)
+	}
+}
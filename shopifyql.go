@@ -0,0 +1,151 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const shopifyqlQuery = `query shopifyqlQuery($query: String!) {
+  shopifyqlQuery(query: $query) {
+    __typename
+    ... on TableResponse {
+      tableData {
+        rowData
+        columns {
+          name
+          dataType
+          displayName
+        }
+      }
+    }
+    parseErrors {
+      code
+      message
+      range {
+        start {
+          line
+          character
+        }
+        end {
+          line
+          character
+        }
+      }
+    }
+  }
+}`
+
+// ShopifyQLService is an interface for running ShopifyQL analytics queries
+// with the Shopify API.
+// See: https://shopify.dev/docs/api/shopifyql
+type ShopifyQLService interface {
+	Query(context.Context, string) (*ShopifyQLResult, error)
+}
+
+// ShopifyQLServiceOp handles communication with the ShopifyQL query of the
+// Shopify API.
+type ShopifyQLServiceOp struct {
+	client *Client
+}
+
+// ShopifyQLColumn describes a column of a ShopifyQL table, e.g. a "net_sales"
+// column of type "MONEY"
+type ShopifyQLColumn struct {
+	Name        string `json:"name"`
+	DataType    string `json:"dataType"`
+	DisplayName string `json:"displayName"`
+}
+
+// ShopifyQLPosition is a position in a ShopifyQL query
+type ShopifyQLPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// ShopifyQLParseError is an error in the syntax or semantics of a query
+type ShopifyQLParseError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Range   *struct {
+		Start ShopifyQLPosition `json:"start"`
+		End   ShopifyQLPosition `json:"end"`
+	} `json:"range"`
+}
+
+// ShopifyQLResult is the table returned by a ShopifyQL query. Rows hold the
+// values in the order of Columns, as formatted by Shopify.
+type ShopifyQLResult struct {
+	Columns     []ShopifyQLColumn
+	Rows        [][]string
+	ParseErrors []ShopifyQLParseError
+}
+
+// ColumnIndex returns the index of the named column in the rows, or -1
+func (r *ShopifyQLResult) ColumnIndex(name string) int {
+	for i, column := range r.Columns {
+		if column.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Records returns the rows keyed by column name
+func (r *ShopifyQLResult) Records() []map[string]string {
+	records := make([]map[string]string, 0, len(r.Rows))
+	for _, row := range r.Rows {
+		record := make(map[string]string, len(r.Columns))
+		for i, column := range r.Columns {
+			if i < len(row) {
+				record[column.Name] = row[i]
+			}
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// Query runs a ShopifyQL query, e.g.
+//
+//	FROM sales SHOW net_sales GROUP BY month SINCE -3m
+//
+// When the query does not parse the result holds the ParseErrors and the
+// returned ResponseError lists their messages.
+func (s *ShopifyQLServiceOp) Query(ctx context.Context, query string) (*ShopifyQLResult, error) {
+	resp := struct {
+		ShopifyqlQuery struct {
+			TableData *struct {
+				RowData [][]string        `json:"rowData"`
+				Columns []ShopifyQLColumn `json:"columns"`
+			} `json:"tableData"`
+			ParseErrors []ShopifyQLParseError `json:"parseErrors"`
+		} `json:"shopifyqlQuery"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, shopifyqlQuery, map[string]interface{}{"query": query}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ShopifyQLResult{ParseErrors: resp.ShopifyqlQuery.ParseErrors}
+	if tableData := resp.ShopifyqlQuery.TableData; tableData != nil {
+		result.Columns = tableData.Columns
+		result.Rows = tableData.RowData
+	}
+
+	if len(result.ParseErrors) > 0 {
+		responseError := ResponseError{Status: 200}
+		for _, parseError := range result.ParseErrors {
+			message := parseError.Message
+			if parseError.Range != nil {
+				message = fmt.Sprintf("%d:%d: %s", parseError.Range.Start.Line, parseError.Range.Start.Character, parseError.Message)
+			}
+			responseError.Errors = append(responseError.Errors, message)
+		}
+		responseError.Message = strings.Join(responseError.Errors, ", ")
+		return result, responseError
+	}
+
+	return result, nil
+}
@@ -0,0 +1,63 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestShopifyQLQuery(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var captured graphQLTestRequest
+	registerGraphQLResponder(`{"data":{"shopifyqlQuery":{
+		"__typename":"TableResponse",
+		"tableData":{
+			"rowData":[["2024-01-01","120.50"],["2024-02-01","98.00"]],
+			"columns":[{"name":"month","dataType":"MONTH_TIMESTAMP","displayName":"Month"},{"name":"net_sales","dataType":"MONEY","displayName":"Net sales"}]
+		},
+		"parseErrors":[]
+	}}}`, &captured)
+
+	query := "FROM sales SHOW net_sales GROUP BY month SINCE -2m"
+	result, err := client.ShopifyQL.Query(context.Background(), query)
+	if err != nil {
+		t.Fatalf("ShopifyQL.Query returned error: %v", err)
+	}
+
+	if captured.Variables["query"] != query {
+		t.Errorf("ShopifyQL.Query sent variables %+v", captured.Variables)
+	}
+
+	if index := result.ColumnIndex("net_sales"); index != 1 {
+		t.Errorf("ShopifyQLResult.ColumnIndex returned %d, expected 1", index)
+	}
+
+	expected := []map[string]string{
+		{"month": "2024-01-01", "net_sales": "120.50"},
+		{"month": "2024-02-01", "net_sales": "98.00"},
+	}
+	if records := result.Records(); !reflect.DeepEqual(records, expected) {
+		t.Errorf("ShopifyQLResult.Records returned %+v, expected %+v", records, expected)
+	}
+}
+
+func TestShopifyQLQueryParseErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerGraphQLResponder(`{"data":{"shopifyqlQuery":{
+		"__typename":"TableResponse",
+		"tableData":null,
+		"parseErrors":[{"code":"SYNTAX_NOT_RECOGNIZED","message":"Unknown keyword SHWO","range":{"start":{"line":1,"character":11},"end":{"line":1,"character":15}}}]
+	}}}`, nil)
+
+	result, err := client.ShopifyQL.Query(context.Background(), "FROM sales SHWO net_sales")
+	if err == nil || err.Error() != "1:11: Unknown keyword SHWO" {
+		t.Errorf("ShopifyQL.Query returned error %v", err)
+	}
+	if result == nil || len(result.ParseErrors) != 1 || result.ParseErrors[0].Code != "SYNTAX_NOT_RECOGNIZED" {
+		t.Errorf("ShopifyQL.Query returned %+v", result)
+	}
+}
@@ -0,0 +1,88 @@
+package goshopify
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// redactedHeaders are the request headers never logged by WithSlogLogger
+var redactedHeaders = []string{"X-Shopify-Access-Token", "X-Shopify-Storefront-Access-Token", "Authorization"}
+
+// slogLogger sends the messages of the client to a slog.Logger
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+func (l slogLogger) Debugf(format string, v ...interface{}) {
+	l.logger.Debug(fmt.Sprintf(format, v...))
+}
+
+func (l slogLogger) Errorf(format string, v ...interface{}) {
+	l.logger.Error(fmt.Sprintf(format, v...))
+}
+
+func (l slogLogger) Infof(format string, v ...interface{}) {
+	l.logger.Info(fmt.Sprintf(format, v...))
+}
+
+func (l slogLogger) Warnf(format string, v ...interface{}) {
+	l.logger.Warn(fmt.Sprintf(format, v...))
+}
+
+// slogRequest logs the request about to be sent with its headers and body at
+// debug level
+func (c *Client) slogRequest(req *http.Request) {
+	if !c.slog.Enabled(req.Context(), slog.LevelDebug) {
+		return
+	}
+
+	headers := redactedHeader(req.Header)
+	c.slog.LogAttrs(req.Context(), slog.LevelDebug, "shopify request",
+		slog.String("method", req.Method),
+		slog.String("url", c.redactedURL(req)),
+		slog.Any("headers", headers),
+		slog.String("body", string(c.redactedBody(&req.Body))),
+	)
+}
+
+// slogResponse logs an attempt of req, failed attempts are logged as warnings
+// and bodies only at debug level
+func (c *Client) slogResponse(req *http.Request, resp *http.Response, err error, duration time.Duration) {
+	level := slog.LevelDebug
+	if err != nil || resp.StatusCode >= http.StatusBadRequest {
+		level = slog.LevelWarn
+	}
+	if !c.slog.Enabled(req.Context(), level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", c.redactedURL(req)),
+		slog.Int("attempt", requestAttempts(req)),
+		slog.Duration("duration", duration),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	if resp != nil {
+		attrs = append(attrs,
+			slog.Int("status", resp.StatusCode),
+			slog.String("request_id", resp.Header.Get("X-Request-Id")))
+		if c.slog.Enabled(req.Context(), slog.LevelDebug) {
+			attrs = append(attrs, slog.String("body", string(c.redactedBody(&resp.Body))))
+		}
+	}
+	c.slog.LogAttrs(req.Context(), level, "shopify response", attrs...)
+}
+
+// redactedURL returns the url of req without its redacted query parameters
+func (c *Client) redactedURL(req *http.Request) string {
+	u := req.URL
+	if c.redactor != nil {
+		u = c.redactor.RedactURL(u)
+	}
+	return u.String()
+}
@@ -0,0 +1,77 @@
+package goshopify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestWithSlogLogger(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(out, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	testClient := MustNewClient(app, "fooshop", "abcd", WithRetry(2), WithSlogLogger(logger))
+	httpmock.ActivateNonDefault(testClient.Client)
+	defer httpmock.DeactivateAndReset()
+
+	responses := 0
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/customers.json",
+		func(req *http.Request) (*http.Response, error) {
+			responses++
+			if responses == 1 {
+				return httpmock.NewStringResponse(http.StatusServiceUnavailable, ""), nil
+			}
+			resp := httpmock.NewStringResponse(http.StatusCreated, `{"customer":{"id":1,"email":"bob@example.com"}}`)
+			resp.Header.Set("X-Request-Id", "abc")
+			return resp, nil
+		})
+
+	err := testClient.Post(context.Background(), "customers.json",
+		map[string]interface{}{"customer": map[string]string{"email": "bob@example.com"}}, nil)
+	if err != nil {
+		t.Fatalf("Client.Post returned error: %v", err)
+	}
+
+	if strings.Contains(out.String(), "bob@example.com") || strings.Contains(out.String(), "abcd") {
+		t.Errorf("logger was sent the access token or customer email: %s", out.String())
+	}
+
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		record := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("logger wrote %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 4 {
+		t.Fatalf("logger wrote %d records, expected 4: %s", len(records), out.String())
+	}
+
+	request := records[0]
+	headers, _ := request["headers"].(map[string]interface{})
+	if request["msg"] != "shopify request" || request["method"] != "POST" ||
+		request["url"] != "https://fooshop.myshopify.com/admin/customers.json" ||
+		!strings.Contains(request["body"].(string), redactedValue) ||
+		headers == nil || headers["X-Shopify-Access-Token"].([]interface{})[0] != redactedValue {
+		t.Errorf("logger wrote request %v", request)
+	}
+
+	failed := records[1]
+	if failed["msg"] != "shopify response" || failed["level"] != "WARN" || failed["status"] != 503.0 || failed["attempt"] != 1.0 {
+		t.Errorf("logger wrote failed attempt %v", failed)
+	}
+	if records[2]["msg"] != "503 response, retrying in 0s" || records[2]["level"] != "DEBUG" {
+		t.Errorf("logger wrote %v, expected the retry message", records[2])
+	}
+	succeeded := records[3]
+	if succeeded["level"] != "DEBUG" || succeeded["status"] != 201.0 || succeeded["attempt"] != 2.0 ||
+		succeeded["request_id"] != "abc" || succeeded["duration"] == nil {
+		t.Errorf("logger wrote successful attempt %v", succeeded)
+	}
+}
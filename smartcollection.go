@@ -3,6 +3,7 @@ package goshopify
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 )
 
@@ -21,6 +22,8 @@ type SmartCollectionService interface {
 	Create(context.Context, SmartCollection) (*SmartCollection, error)
 	Update(context.Context, SmartCollection) (*SmartCollection, error)
 	Delete(context.Context, uint64) error
+	SetImage(context.Context, uint64, Image) (*SmartCollection, error)
+	RemoveImage(context.Context, uint64) (*SmartCollection, error)
 
 	// MetafieldsService used for SmartCollection resource to communicate with Metafields resource
 	MetafieldsService
@@ -33,9 +36,39 @@ type SmartCollectionServiceOp struct {
 }
 
 type Rule struct {
-	Column    string `json:"column"`
-	Relation  string `json:"relation"`
-	Condition string `json:"condition"`
+	Column            string `json:"column"`
+	Relation          string `json:"relation"`
+	Condition         string `json:"condition"`
+	ConditionObjectId string `json:"condition_object_id,omitempty"`
+}
+
+// Rule columns matching the value of a metafield, the definition of the
+// metafield is set in the ConditionObjectId of the rule
+const (
+	RuleColumnProductMetafieldDefinition = "product_metafield_definition"
+	RuleColumnVariantMetafieldDefinition = "variant_metafield_definition"
+)
+
+// NewProductMetafieldRule returns a rule matching products whose metafield of
+// the given definition relates to condition, e.g. "equals"
+func NewProductMetafieldRule(definitionId uint64, relation, condition string) Rule {
+	return Rule{
+		Column:            RuleColumnProductMetafieldDefinition,
+		Relation:          relation,
+		Condition:         condition,
+		ConditionObjectId: strconv.FormatUint(definitionId, 10),
+	}
+}
+
+// NewVariantMetafieldRule returns a rule matching products with a variant
+// whose metafield of the given definition relates to condition
+func NewVariantMetafieldRule(definitionId uint64, relation, condition string) Rule {
+	return Rule{
+		Column:            RuleColumnVariantMetafieldDefinition,
+		Relation:          relation,
+		Condition:         condition,
+		ConditionObjectId: strconv.FormatUint(definitionId, 10),
+	}
 }
 
 // SmartCollection represents a Shopify smart collection.
@@ -54,6 +87,7 @@ type SmartCollection struct {
 	Rules          []Rule      `json:"rules,omitempty"`
 	Disjunctive    bool        `json:"disjunctive,omitempty"`
 	Metafields     []Metafield `json:"metafields,omitempty"`
+	NullFields     []string    `json:"-"`
 }
 
 // SmartCollectionResource represents the result from the smart_collections/X.json endpoint
@@ -118,6 +152,12 @@ func (s *SmartCollectionServiceOp) ListMetafields(ctx context.Context, smartColl
 	return metafieldService.List(ctx, options)
 }
 
+// ListMetafieldsByNamespace lists all metafields of a namespace for a smart collection
+func (s *SmartCollectionServiceOp) ListMetafieldsByNamespace(ctx context.Context, smartCollectionId uint64, namespace string) ([]Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: smartCollectionsResourceName, resourceId: smartCollectionId}
+	return metafieldService.ListByNamespace(ctx, namespace)
+}
+
 // Count metafields for a smart collection
 func (s *SmartCollectionServiceOp) CountMetafields(ctx context.Context, smartCollectionId uint64, options interface{}) (int, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: smartCollectionsResourceName, resourceId: smartCollectionId}
@@ -288,3 +288,27 @@ func TestSmartCollectionDeleteMetafield(t *testing.T) {
 		t.Errorf("SmartCollection.DeleteMetafield() returned error: %v", err)
 	}
 }
+
+func TestSmartCollectionMetafieldRule(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/smart_collections.json", client.pathPrefix),
+		httpmock.NewStringResponder(201, `{"smart_collection":{"id":1,"rules":[{"column":"product_metafield_definition","relation":"equals","condition":"cotton","condition_object_id":"4567"}]}}`))
+
+	collection, err := client.SmartCollection.Create(context.Background(), SmartCollection{
+		Title: "Cotton",
+		Rules: []Rule{NewProductMetafieldRule(4567, "equals", "cotton")},
+	})
+	if err != nil {
+		t.Fatalf("SmartCollection.Create returned error: %v", err)
+	}
+
+	expected := []Rule{{Column: RuleColumnProductMetafieldDefinition, Relation: "equals", Condition: "cotton", ConditionObjectId: "4567"}}
+	if !reflect.DeepEqual(collection.Rules, expected) {
+		t.Errorf("SmartCollection.Rules returned %+v, expected %+v", collection.Rules, expected)
+	}
+	if rule := NewVariantMetafieldRule(1, "equals", "red"); rule.Column != RuleColumnVariantMetafieldDefinition || rule.ConditionObjectId != "1" {
+		t.Errorf("NewVariantMetafieldRule returned %+v", rule)
+	}
+}
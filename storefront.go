@@ -0,0 +1,100 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// MockShopURL is the graphql endpoint of the storefront api of shopify's
+// public mock shop, a demo store answering queries without credentials.
+// See https://mock.shop
+const MockShopURL = "https://mock.shop/api"
+
+// StorefrontService is an interface to interact with the graphql endpoint
+// of the Storefront API
+// See https://shopify.dev/docs/api/storefront
+type StorefrontService interface {
+	Query(context.Context, string, interface{}, interface{}) error
+}
+
+// StorefrontServiceOp handles communication with the graphql endpoint of
+// the Storefront API.
+type StorefrontServiceOp struct {
+	client *Client
+}
+
+// NewMockShopClient returns a client whose Storefront service queries
+// shopify's mock shop, so examples and smoke tests run without credentials:
+//
+//	client, err := goshopify.NewMockShopClient()
+//	var resp struct {
+//		Products struct {
+//			Nodes []struct{ Title string } `json:"nodes"`
+//		} `json:"products"`
+//	}
+//	err = client.Storefront.Query(ctx, "{ products(first: 3) { nodes { title } } }", nil, &resp)
+//
+// The mock shop only serves the storefront api, the admin services of the
+// client fail.
+func NewMockShopClient(opts ...Option) (*Client, error) {
+	return NewClient(App{}, "mock", "", append([]Option{WithMockShop()}, opts...)...)
+}
+
+// Query creates a graphql query against the Storefront API
+// the "data" portion of the response is unmarshalled into resp
+func (s *StorefrontServiceOp) Query(ctx context.Context, q string, vars, resp interface{}) error {
+	data := struct {
+		Query     string      `json:"query"`
+		Variables interface{} `json:"variables"`
+	}{
+		Query:     q,
+		Variables: vars,
+	}
+
+	ctx, metadata := responseMetadataCapture(ctx)
+	req, err := s.client.NewRequest(ctx, http.MethodPost, s.client.storefrontEndpoint(), data, nil)
+	if err != nil {
+		return err
+	}
+
+	// the admin credentials must not reach the storefront api
+	req.Header.Del("X-Shopify-Access-Token")
+	req.Header.Del("Authorization")
+	if s.client.storefrontToken != "" {
+		req.Header.Set("X-Shopify-Storefront-Access-Token", s.client.storefrontToken)
+	}
+
+	gr := graphQLResponse{
+		Data: resp,
+	}
+	if _, err := s.client.doGetHeaders(req, &gr); err != nil {
+		return err
+	}
+
+	if len(gr.Errors) > 0 {
+		responseError := ResponseError{Status: http.StatusOK}
+		for _, err := range gr.Errors {
+			if status := graphQLErrorStatus(err); responseError.Status == http.StatusOK {
+				responseError.Status = status
+			}
+			responseError.Errors = append(responseError.Errors, err.Message)
+		}
+		responseError.ResponseMetadata = *metadata
+		return responseError
+	}
+	return nil
+}
+
+// storefrontEndpoint returns the graphql endpoint of the storefront api, the
+// one of the shop at the api version of the client unless WithMockShop is set
+func (c *Client) storefrontEndpoint() string {
+	if c.storefrontURL != "" {
+		return c.storefrontURL
+	}
+	version := ApiVersion(c.currentApiVersion())
+	if !version.Valid() {
+		version = LatestStableVersion()
+	}
+	return fmt.Sprintf("api/%s/graphql.json", version)
+}
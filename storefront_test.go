@@ -0,0 +1,89 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestStorefrontQuery(t *testing.T) {
+	setup()
+	defer teardown()
+	WithStorefrontAccessToken("storefront-token")(client)
+
+	var header http.Header
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/api/%s/graphql.json", testApiVersion),
+		func(req *http.Request) (*http.Response, error) {
+			header = req.Header
+			return httpmock.NewStringResponse(200, `{"data":{"shop":{"name":"Foo"}}}`), nil
+		})
+
+	resp := struct {
+		Shop struct {
+			Name string `json:"name"`
+		} `json:"shop"`
+	}{}
+	if err := client.Storefront.Query(context.Background(), "{ shop { name } }", nil, &resp); err != nil {
+		t.Fatalf("Storefront.Query returned error: %v", err)
+	}
+
+	if resp.Shop.Name != "Foo" {
+		t.Errorf("Storefront.Query returned shop name %q, expected Foo", resp.Shop.Name)
+	}
+	if header.Get("X-Shopify-Storefront-Access-Token") != "storefront-token" {
+		t.Errorf("Storefront.Query sent storefront token %q", header.Get("X-Shopify-Storefront-Access-Token"))
+	}
+	if header.Get("X-Shopify-Access-Token") != "" || header.Get("Authorization") != "" {
+		t.Errorf("Storefront.Query sent the admin credentials in %v", header)
+	}
+}
+
+func TestStorefrontQueryMockShop(t *testing.T) {
+	setup()
+	defer teardown()
+	WithMockShop()(client)
+	WithReadOnly()(client)
+
+	httpmock.RegisterResponder("POST", MockShopURL,
+		httpmock.NewStringResponder(200, `{"errors":[{"message":"Field 'foo' doesn't exist on type 'QueryRoot'"}]}`))
+
+	err := client.Storefront.Query(context.Background(), "{ foo }", nil, nil)
+	var responseError ResponseError
+	if !errors.As(err, &responseError) || responseError.Status != http.StatusOK ||
+		responseError.RequestURL != MockShopURL || len(responseError.Errors) != 1 {
+		t.Errorf("Storefront.Query returned %#v, expected a ResponseError of the graphql error", err)
+	}
+}
+
+// TestMockShop queries shopify's public mock shop, it runs when
+// GOSHOPIFY_MOCK_SHOP is set since it needs network access
+func TestMockShop(t *testing.T) {
+	if os.Getenv("GOSHOPIFY_MOCK_SHOP") == "" {
+		t.Skip("set GOSHOPIFY_MOCK_SHOP to query https://mock.shop")
+	}
+
+	client, err := NewMockShopClient()
+	if err != nil {
+		t.Fatalf("NewMockShopClient returned error: %v", err)
+	}
+
+	resp := struct {
+		Products struct {
+			Nodes []struct {
+				Id    string `json:"id"`
+				Title string `json:"title"`
+			} `json:"nodes"`
+		} `json:"products"`
+	}{}
+	if err := client.Storefront.Query(context.Background(), "{ products(first: 3) { nodes { id title } } }", nil, &resp); err != nil {
+		t.Fatalf("Storefront.Query returned error: %v", err)
+	}
+	if len(resp.Products.Nodes) == 0 || resp.Products.Nodes[0].Title == "" {
+		t.Errorf("Storefront.Query returned products %+v", resp.Products.Nodes)
+	}
+}
@@ -0,0 +1,107 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// streamDecoder is a resource decoding the body of a response itself, see
+// decodeResponse
+type streamDecoder interface {
+	decodeStream(c *Client, body io.Reader) error
+}
+
+// listStream decodes the list of a list response one item at a time
+type listStream[T any] struct {
+	key  string
+	each func(T) error
+}
+
+// ListEach performs a GET request for the given list path and calls each with
+// every resource of the page as soon as it is decoded, instead of buffering
+// the page in a slice, e.g. for pages of 250 products with all their variants
+// and images:
+//
+//   - path is relative to the api version, e.g. "products.json"
+//   - key is the name of the list in the response, e.g. "products"
+//   - options are url encoded into the query string, see ListWithPagination
+//
+// An error returned by each stops the decoding and is returned as is. The
+// pagination of the page is returned once all its resources are decoded.
+// The page is still read in memory first when response bodies are logged at
+// debug level or dumped, see WithHTTPDump.
+func ListEach[T any](ctx context.Context, c *Client, path, key string, options interface{}, each func(T) error) (*Pagination, error) {
+	return c.ListWithPagination(ctx, path, &listStream[T]{key: key, each: each}, options)
+}
+
+func (s *listStream[T]) decodeStream(c *Client, body io.Reader) error {
+	dec := json.NewDecoder(body)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if token != s.key {
+			var skipped json.RawMessage
+			if err := dec.Decode(&skipped); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := expectDelim(dec, '['); err != nil {
+			return err
+		}
+		for dec.More() {
+			item, err := s.decodeItem(c, dec)
+			if err != nil {
+				return err
+			}
+			if err := s.each(item); err != nil {
+				return err
+			}
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+// decodeItem decodes the next item of the list, walking it along with its
+// raw JSON when unknown fields are captured or decode hooks are set
+func (s *listStream[T]) decodeItem(c *Client, dec *json.Decoder) (T, error) {
+	var item T
+	if !c.captureUnknownFields && len(c.decodeHooks) == 0 {
+		return item, dec.Decode(&item)
+	}
+
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return item, err
+	}
+	if err := json.Unmarshal(raw, &item); err != nil {
+		return item, err
+	}
+	return item, walkDecoded(reflect.ValueOf(&item), raw, c.visitDecoded)
+}
+
+// expectDelim reads the next token of dec, which must be delim
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return ResponseDecodingError{Message: fmt.Sprintf("expected %s, got %v", delim, token)}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestListEach(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/customers.json", client.pathPrefix)
+	httpmock.RegisterResponder("GET", listURL, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"count":2,"customers":[{"id":1,"tier":"gold"},{"id":2}],"extra":{"a":[1]}}`)
+		resp.Header.Set("Link", fmt.Sprintf(`<%s?page_info=pg2>; rel="next"`, listURL))
+		return resp, nil
+	})
+	WithUnknownFieldCapture()(client)
+
+	var customers []Customer
+	pagination, err := ListEach(context.Background(), client, "customers.json", "customers", nil, func(customer Customer) error {
+		customers = append(customers, customer)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ListEach returned error: %v", err)
+	}
+
+	expected := []Customer{
+		{Id: 1, UnknownJSONFields: UnknownJSONFields{UnknownFields: map[string]json.RawMessage{"tier": json.RawMessage(`"gold"`)}}},
+		{Id: 2},
+	}
+	if !reflect.DeepEqual(customers, expected) {
+		t.Errorf("ListEach decoded %+v, expected %+v", customers, expected)
+	}
+	if pagination.NextPageOptions == nil || pagination.NextPageOptions.PageInfo != "pg2" {
+		t.Errorf("ListEach returned pagination %+v, expected the next page pg2", pagination)
+	}
+}
+
+func TestListEachStop(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"customers":[{"id":1},{"id":2},{"id":3}]}`))
+
+	errStop := errors.New("stop")
+	var ids []uint64
+	_, err := ListEach(context.Background(), client, "customers.json", "customers", nil, func(customer Customer) error {
+		ids = append(ids, customer.Id)
+		if customer.Id == 2 {
+			return errStop
+		}
+		return nil
+	})
+	if err != errStop {
+		t.Errorf("ListEach returned error %v, expected %v", err, errStop)
+	}
+	if !reflect.DeepEqual(ids, []uint64{1, 2}) {
+		t.Errorf("ListEach decoded %v, expected [1 2]", ids)
+	}
+}
+
+func TestListEachInvalid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"customers":{"id":1}}`))
+
+	_, err := ListEach(context.Background(), client, "customers.json", "customers", nil, func(Customer) error { return nil })
+	var decodingErr ResponseDecodingError
+	if !errors.As(err, &decodingErr) || decodingErr.Message != "expected [, got {" {
+		t.Errorf("ListEach returned error %v, expected a ResponseDecodingError", err)
+	}
+}
+
+// limitedReader fails when more than limit bytes are read before ready is set
+type limitedReader struct {
+	r     io.Reader
+	read  int
+	limit int
+	ready *bool
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += n
+	if !*l.ready && l.read > l.limit {
+		return n, fmt.Errorf("read %d bytes of the body before the first resource", l.read)
+	}
+	return n, err
+}
+
+func TestListEachStreamsBody(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var page strings.Builder
+	page.WriteString(`{"customers":[`)
+	for i := 1; i <= 2000; i++ {
+		if i > 1 {
+			page.WriteString(",")
+		}
+		fmt.Fprintf(&page, `{"id":%d,"note":"%s"}`, i, strings.Repeat("x", 500))
+	}
+	page.WriteString(`]}`)
+
+	decoded := false
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			body := &limitedReader{r: strings.NewReader(page.String()), limit: 64 << 10, ready: &decoded}
+			return &http.Response{StatusCode: 200, Header: http.Header{}, Body: io.NopCloser(body), Request: req}, nil
+		})
+
+	count := 0
+	_, err := ListEach(context.Background(), client, "customers.json", "customers", nil, func(Customer) error {
+		decoded = true
+		count++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ListEach returned error: %v", err)
+	}
+	if count != 2000 {
+		t.Errorf("ListEach decoded %d customers, expected 2000", count)
+	}
+}
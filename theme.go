@@ -23,6 +23,9 @@ type ThemeService interface {
 	Get(context.Context, uint64, interface{}) (*Theme, error)
 	Update(context.Context, Theme) (*Theme, error)
 	Delete(context.Context, uint64) error
+	MainTheme(context.Context) (*Theme, error)
+	AppEmbedBlocks(context.Context, uint64) ([]AppEmbedBlock, error)
+	AppEmbedEnabled(ctx context.Context, extensionId, blockHandle string) (bool, error)
 }
 
 // ThemeServiceOp handles communication with the theme related methods of
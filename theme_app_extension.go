@@ -0,0 +1,132 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// themeSettingsDataKey is the asset holding the settings of a theme,
+// including its app embed blocks
+const themeSettingsDataKey = "config/settings_data.json"
+
+// appBlockTypePrefix prefixes the type of the blocks of theme app extensions,
+// e.g. "shopify://apps/my-app/blocks/app-embed/<extension uuid>"
+const appBlockTypePrefix = "shopify://apps/"
+
+// AppEmbedBlock is an app embed block of a theme app extension in the
+// settings of a theme
+type AppEmbedBlock struct {
+	// Id of the block in the theme settings
+	Id string
+	// Type of the block, "shopify://apps/<app>/blocks/<block>/<extension>"
+	Type        string
+	AppHandle   string
+	BlockHandle string
+	ExtensionId string
+	Disabled    bool
+}
+
+// themeSettingsBlock is a block of the current settings of a theme
+type themeSettingsBlock struct {
+	Type     string `json:"type"`
+	Disabled bool   `json:"disabled"`
+}
+
+// themeSettingsData is the content of config/settings_data.json. Current is
+// either the settings or, in older themes, the name of a preset.
+type themeSettingsData struct {
+	Current json.RawMessage `json:"current"`
+	Presets map[string]struct {
+		Blocks map[string]themeSettingsBlock `json:"blocks"`
+	} `json:"presets"`
+}
+
+// MainTheme returns the published theme of the shop
+func (s *ThemeServiceOp) MainTheme(ctx context.Context) (*Theme, error) {
+	themes, err := s.List(ctx, ThemeListOptions{Role: "main"})
+	if err != nil {
+		return nil, err
+	}
+	for _, theme := range themes {
+		if theme.Role == "main" {
+			return &theme, nil
+		}
+	}
+	return nil, fmt.Errorf("no published theme: %w", ErrNotFound)
+}
+
+// AppEmbedBlocks returns the app embed blocks of the theme app extensions
+// added to a theme, enabled or not, read from its config/settings_data.json
+func (s *ThemeServiceOp) AppEmbedBlocks(ctx context.Context, themeId uint64) ([]AppEmbedBlock, error) {
+	asset, err := s.client.Asset.Get(ctx, themeId, themeSettingsDataKey)
+	if err != nil {
+		return nil, err
+	}
+	if asset == nil {
+		return nil, nil
+	}
+	return parseAppEmbedBlocks([]byte(asset.Value))
+}
+
+// AppEmbedEnabled reports whether an app embed block of the theme app
+// extension is enabled in the published theme, the common onboarding check.
+// extensionId is the uuid of the extension, blockHandle the name of the
+// block liquid file without extension or "" to match any block of the
+// extension.
+func (s *ThemeServiceOp) AppEmbedEnabled(ctx context.Context, extensionId, blockHandle string) (bool, error) {
+	theme, err := s.MainTheme(ctx)
+	if err != nil {
+		return false, err
+	}
+	blocks, err := s.AppEmbedBlocks(ctx, theme.Id)
+	if err != nil {
+		return false, err
+	}
+	for _, block := range blocks {
+		if block.ExtensionId == extensionId && (blockHandle == "" || block.BlockHandle == blockHandle) && !block.Disabled {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// parseAppEmbedBlocks returns the app blocks of the current settings of a
+// config/settings_data.json
+func parseAppEmbedBlocks(settingsData []byte) ([]AppEmbedBlock, error) {
+	data := themeSettingsData{}
+	if err := json.Unmarshal(settingsData, &data); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", themeSettingsDataKey, err)
+	}
+	if len(data.Current) == 0 {
+		return nil, nil
+	}
+
+	current := struct {
+		Blocks map[string]themeSettingsBlock `json:"blocks"`
+	}{}
+	var preset string
+	if err := json.Unmarshal(data.Current, &preset); err == nil {
+		current.Blocks = data.Presets[preset].Blocks
+	} else if err := json.Unmarshal(data.Current, &current); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", themeSettingsDataKey, err)
+	}
+
+	var blocks []AppEmbedBlock
+	for id, block := range current.Blocks {
+		if !strings.HasPrefix(block.Type, appBlockTypePrefix) {
+			continue
+		}
+		embed := AppEmbedBlock{Id: id, Type: block.Type, Disabled: block.Disabled}
+		// <app>/blocks/<block>/<extension>
+		parts := strings.Split(strings.TrimPrefix(block.Type, appBlockTypePrefix), "/")
+		if len(parts) == 4 && parts[1] == "blocks" {
+			embed.AppHandle, embed.BlockHandle, embed.ExtensionId = parts[0], parts[2], parts[3]
+		}
+		blocks = append(blocks, embed)
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].Id < blocks[j].Id })
+	return blocks, nil
+}
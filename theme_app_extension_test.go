@@ -0,0 +1,110 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+const testSettingsData = `{
+	"current": {
+		"colors_accent": "#000",
+		"blocks": {
+			"1234": {"type": "shopify://apps/reviews/blocks/app-embed/ext-1", "disabled": false, "settings": {}},
+			"5678": {"type": "shopify://apps/chat/blocks/widget/ext-2", "disabled": true, "settings": {}}
+		}
+	},
+	"presets": {"Default": {}}
+}`
+
+func registerSettingsData(themeId uint64, settingsData string) {
+	value, _ := json.Marshal(settingsData)
+	httpmock.RegisterResponderWithQuery("GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/%d/assets.json", client.pathPrefix, themeId),
+		map[string]string{"asset[key]": "config/settings_data.json", "theme_id": fmt.Sprint(themeId)},
+		httpmock.NewStringResponder(200, fmt.Sprintf(`{"asset":{"key":"config/settings_data.json","value":%s}}`, value)))
+}
+
+func TestThemeAppEmbedBlocks(t *testing.T) {
+	setup()
+	defer teardown()
+	registerSettingsData(1, testSettingsData)
+
+	blocks, err := client.Theme.AppEmbedBlocks(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Theme.AppEmbedBlocks returned error: %v", err)
+	}
+
+	expected := []AppEmbedBlock{
+		{
+			Id: "1234", Type: "shopify://apps/reviews/blocks/app-embed/ext-1",
+			AppHandle: "reviews", BlockHandle: "app-embed", ExtensionId: "ext-1",
+		},
+		{
+			Id: "5678", Type: "shopify://apps/chat/blocks/widget/ext-2",
+			AppHandle: "chat", BlockHandle: "widget", ExtensionId: "ext-2", Disabled: true,
+		},
+	}
+	if !reflect.DeepEqual(blocks, expected) {
+		t.Errorf("Theme.AppEmbedBlocks returned %+v, expected %+v", blocks, expected)
+	}
+}
+
+func TestThemeAppEmbedBlocksPreset(t *testing.T) {
+	blocks, err := parseAppEmbedBlocks([]byte(`{
+		"current": "Default",
+		"presets": {"Default": {"blocks": {"1": {"type": "shopify://apps/reviews/blocks/app-embed/ext-1"}}}}
+	}`))
+	if err != nil {
+		t.Fatalf("parseAppEmbedBlocks returned error: %v", err)
+	}
+	if len(blocks) != 1 || blocks[0].ExtensionId != "ext-1" || blocks[0].Disabled {
+		t.Errorf("parseAppEmbedBlocks returned %+v", blocks)
+	}
+}
+
+func TestThemeAppEmbedEnabled(t *testing.T) {
+	setup()
+	defer teardown()
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/themes.json", client.pathPrefix),
+		map[string]string{"role": "main"},
+		httpmock.NewStringResponder(200, `{"themes":[{"id":2,"role":"main"}]}`))
+	registerSettingsData(2, testSettingsData)
+
+	cases := []struct {
+		extensionId, blockHandle string
+		expected                 bool
+	}{
+		{"ext-1", "", true},
+		{"ext-1", "app-embed", true},
+		{"ext-1", "other", false},
+		{"ext-2", "", false},
+		{"ext-3", "", false},
+	}
+
+	for _, c := range cases {
+		enabled, err := client.Theme.AppEmbedEnabled(context.Background(), c.extensionId, c.blockHandle)
+		if err != nil {
+			t.Fatalf("Theme.AppEmbedEnabled returned error: %v", err)
+		}
+		if enabled != c.expected {
+			t.Errorf("Theme.AppEmbedEnabled(%q, %q) returned %t, expected %t", c.extensionId, c.blockHandle, enabled, c.expected)
+		}
+	}
+}
+
+func TestThemeMainThemeNotFound(t *testing.T) {
+	setup()
+	defer teardown()
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/themes.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"themes":[]}`))
+
+	if _, err := client.Theme.MainTheme(context.Background()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Theme.MainTheme returned %v, expected ErrNotFound", err)
+	}
+}
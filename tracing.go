@@ -0,0 +1,89 @@
+package goshopify
+
+import (
+	"net/http"
+	"runtime"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of the spans, see WithTracerProvider
+const tracerName = "github.com/influxer-Engineering/go-shopify-influxer"
+
+// servicePrefix is the prefix of the functions of the services, e.g.
+// "github.com/influxer-Engineering/go-shopify-influxer.(*ProductServiceOp).ListAll"
+const servicePrefix = tracerName + ".(*"
+
+// startSpan starts the span of req when the client is traced, the span is
+// named after the service method sending the request, e.g. "Product.ListAll"
+func (c *Client) startSpan(req *http.Request) (*http.Request, trace.Span) {
+	if c.tracer == nil {
+		return req, nil
+	}
+
+	ctx, span := c.tracer.Start(req.Context(), spanName(req),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("shopify.shop", c.shop),
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.path", req.URL.Path),
+		))
+	return req.WithContext(ctx), span
+}
+
+// endSpan records the last response of req and err on span
+func (c *Client) endSpan(req *http.Request, span trace.Span, resp *http.Response, err error) {
+	if span == nil {
+		return
+	}
+
+	if attempts := requestAttempts(req); attempts > 0 {
+		span.SetAttributes(attribute.Int("shopify.retries", attempts-1))
+	}
+	if resp != nil {
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		if used, size, ok := parseCallLimit(resp.Header); ok {
+			span.SetAttributes(attribute.Int("shopify.rate_limit.remaining", size-used))
+		}
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// spanName returns the outermost service method in the call stack, requests
+// sent with Client.Get and the like are named after their http method
+func spanName(req *http.Request) string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+
+	name := "shopify " + req.Method
+	for {
+		frame, more := frames.Next()
+		if method, ok := serviceMethod(frame.Function); ok {
+			name = method
+		}
+		if !more {
+			return name
+		}
+	}
+}
+
+// serviceMethod turns the function of a service method into the name of the
+// service and the method, e.g. "Product.ListAll"
+func serviceMethod(function string) (string, bool) {
+	if !strings.HasPrefix(function, servicePrefix) {
+		return "", false
+	}
+	service, method, ok := strings.Cut(strings.TrimPrefix(function, servicePrefix), ").")
+	if !ok || !strings.HasSuffix(service, "ServiceOp") {
+		return "", false
+	}
+	method, _, _ = strings.Cut(method, ".")
+	return strings.TrimSuffix(service, "ServiceOp") + "." + method, true
+}
@@ -0,0 +1,143 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// recordedSpan keeps what the client records on a span
+type recordedSpan struct {
+	noop.Span
+	name       string
+	attributes map[attribute.Key]attribute.Value
+	status     codes.Code
+	err        error
+	ended      bool
+}
+
+func (s *recordedSpan) SetAttributes(attributes ...attribute.KeyValue) {
+	for _, kv := range attributes {
+		s.attributes[kv.Key] = kv.Value
+	}
+}
+
+func (s *recordedSpan) SetStatus(code codes.Code, _ string) { s.status = code }
+
+func (s *recordedSpan) RecordError(err error, _ ...trace.EventOption) { s.err = err }
+
+func (s *recordedSpan) End(...trace.SpanEndOption) { s.ended = true }
+
+type recordingTracer struct {
+	noop.Tracer
+	spans []*recordedSpan
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	span := &recordedSpan{name: name, attributes: map[attribute.Key]attribute.Value{}}
+	config := trace.NewSpanStartConfig(opts...)
+	span.SetAttributes(config.Attributes()...)
+	t.spans = append(t.spans, span)
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+type recordingTracerProvider struct {
+	noop.TracerProvider
+	tracer *recordingTracer
+}
+
+func (p recordingTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return p.tracer
+}
+
+func TestWithTracerProvider(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tracer := &recordingTracer{}
+	WithTracerProvider(recordingTracerProvider{tracer: tracer})(client)
+	client.retries = 2
+
+	attempts := 0
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts == 1 {
+				return httpmock.NewStringResponse(http.StatusServiceUnavailable, ""), nil
+			}
+			if trace.SpanFromContext(req.Context()) != tracer.spans[0] {
+				t.Error("request was not sent with the span in its context")
+			}
+			resp := httpmock.NewStringResponse(200, `{"products":[{"id":1}]}`)
+			resp.Header.Set("X-Shopify-Shop-Api-Call-Limit", "4/40")
+			return resp, nil
+		})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/2.json", client.pathPrefix),
+		httpmock.NewStringResponder(404, `{"errors":"Not Found"}`))
+
+	if _, err := client.Product.ListAll(context.Background(), nil); err != nil {
+		t.Fatalf("Product.ListAll returned error: %v", err)
+	}
+	if _, err := client.Product.Get(context.Background(), 2, nil); err == nil {
+		t.Fatal("Product.Get returned no error")
+	}
+
+	if len(tracer.spans) != 2 {
+		t.Fatalf("client started %d spans, expected 2", len(tracer.spans))
+	}
+
+	listed := tracer.spans[0]
+	if listed.name != "Product.ListAll" {
+		t.Errorf("span was named %q, expected Product.ListAll", listed.name)
+	}
+	expected := map[attribute.Key]attribute.Value{
+		"shopify.shop":                 attribute.StringValue("fooshop.myshopify.com"),
+		"http.request.method":          attribute.StringValue("GET"),
+		"url.path":                     attribute.StringValue(fmt.Sprintf("/%s/products.json", client.pathPrefix)),
+		"http.response.status_code":    attribute.IntValue(200),
+		"shopify.retries":              attribute.IntValue(1),
+		"shopify.rate_limit.remaining": attribute.IntValue(36),
+	}
+	for key, value := range expected {
+		if listed.attributes[key] != value {
+			t.Errorf("span attribute %s was %v, expected %v", key, listed.attributes[key].Emit(), value.Emit())
+		}
+	}
+	if !listed.ended || listed.status != codes.Unset || listed.err != nil {
+		t.Errorf("span of a successful request was %+v", listed)
+	}
+
+	failed := tracer.spans[1]
+	if failed.name != "Product.Get" || failed.attributes["http.response.status_code"] != attribute.IntValue(404) {
+		t.Errorf("span of a failed request was %+v", failed)
+	}
+	if !failed.ended || failed.status != codes.Error || failed.err == nil {
+		t.Errorf("span of a failed request was %+v, expected an error status", failed)
+	}
+}
+
+func TestServiceMethod(t *testing.T) {
+	cases := []struct {
+		function string
+		expected string
+	}{
+		{tracerName + ".(*ProductServiceOp).ListAll", "Product.ListAll"},
+		{tracerName + ".(*PaymentsTransactionsServiceOp).Get.func1", "PaymentsTransactions.Get"},
+		{tracerName + ".(*Client).Get", ""},
+		{"example.com/app.(*ProductServiceOp).ListAll", ""},
+	}
+
+	for _, c := range cases {
+		actual, _ := serviceMethod(c.function)
+		if actual != c.expected {
+			t.Errorf("serviceMethod(%q) returned %q, expected %q", c.function, actual, c.expected)
+		}
+	}
+}
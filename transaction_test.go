@@ -78,25 +78,25 @@ func TransactionTests(t *testing.T, transaction Transaction) {
 	}
 
 	// Check that the LocationId value is assigned to the returned transaction
-	var expectedLocationId *int64
+	var expectedLocationId *uint64
 	if transaction.LocationId != expectedLocationId {
 		t.Errorf("Transaction.LocationId returned %+v, expected %+v", transaction.LocationId, expectedLocationId)
 	}
 
 	// Check that the UserId value is assigned to the returned transaction
-	var expectedUserId *int64
+	var expectedUserId *uint64
 	if transaction.UserId != expectedUserId {
 		t.Errorf("Transaction.UserId returned %+v, expected %+v", transaction.UserId, expectedUserId)
 	}
 
 	// Check that the ParentId value is assigned to the returned transaction
-	var expectedParentId *int64
+	var expectedParentId *uint64
 	if transaction.ParentId != expectedParentId {
 		t.Errorf("Transaction.ParentId returned %+v, expected %+v", transaction.ParentId, expectedParentId)
 	}
 
 	// Check that the DeviceId value is assigned to the returned transaction
-	var expectedDeviceId *int64
+	var expectedDeviceId *uint64
 	if transaction.DeviceId != expectedDeviceId {
 		t.Errorf("Transacion.DeviceId returned %+v, expected %+v", transaction.DeviceId, expectedDeviceId)
 	}
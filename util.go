@@ -2,6 +2,7 @@ package goshopify
 
 import (
 	"fmt"
+	"math"
 	"net/url"
 	"strings"
 	"time"
@@ -49,6 +50,24 @@ func FulfillmentPathPrefix(resource string, resourceId uint64) string {
 	return prefix
 }
 
+// IdFromInt64 converts an id stored as a signed integer, e.g. a BIGINT
+// column, to the uint64 used by the resources. Negative ids return an error.
+func IdFromInt64(id int64) (uint64, error) {
+	if id < 0 {
+		return 0, fmt.Errorf("invalid id %d", id)
+	}
+	return uint64(id), nil
+}
+
+// IdToInt64 converts a resource id to a signed integer. Ids which do not fit
+// in an int64 return an error instead of overflowing.
+func IdToInt64(id uint64) (int64, error) {
+	if id > math.MaxInt64 {
+		return 0, fmt.Errorf("id %d overflows int64", id)
+	}
+	return int64(id), nil
+}
+
 type OnlyDate struct {
 	time.Time
 }
@@ -68,7 +87,12 @@ func (c *OnlyDate) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON returns null for the zero date, which Shopify rejects as
+// "0001-01-01"
 func (c *OnlyDate) MarshalJSON() ([]byte, error) {
+	if c.IsZero() {
+		return []byte("null"), nil
+	}
 	return []byte(c.String()), nil
 }
 
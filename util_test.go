@@ -1,7 +1,10 @@
 package goshopify
 
 import (
+	"encoding/json"
+	"math"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 )
@@ -109,7 +112,7 @@ func TestOnlyDateMarshal(t *testing.T) {
 		expected string
 	}{
 		{OnlyDate{time.Date(2023, 03, 31, 0, 0, 0, 0, time.Local)}, "\"2023-03-31\""},
-		{OnlyDate{}, "\"0001-01-01\""},
+		{OnlyDate{}, "null"},
 	}
 
 	for _, c := range cases {
@@ -120,6 +123,26 @@ func TestOnlyDateMarshal(t *testing.T) {
 	}
 }
 
+func TestZeroDatesOmitted(t *testing.T) {
+	cases := []interface{}{
+		Payout{Id: 1},
+		PaymentsTransactions{Id: 1},
+		FulfillmentOrderDeliveryMethod{Id: 1},
+		FulfillmentOrderMerchantRequest{Message: "a"},
+		Location{Id: 1},
+	}
+
+	for _, c := range cases {
+		actual, err := json.Marshal(c)
+		if err != nil {
+			t.Fatalf("json.Marshal(%T) returned error: %v", c, err)
+		}
+		if strings.Contains(string(actual), "0001-01-01") {
+			t.Errorf("json.Marshal(%T) returned a zero date: %s", c, actual)
+		}
+	}
+}
+
 func TestOnlyDateUnmarshal(t *testing.T) {
 	cases := []struct {
 		in       string
@@ -156,3 +179,21 @@ func TestOnlyDateEncode(t *testing.T) {
 		}
 	}
 }
+
+func TestIdConversions(t *testing.T) {
+	id, err := IdFromInt64(2147483648)
+	if err != nil || id != 2147483648 {
+		t.Errorf("IdFromInt64 returned %d, %v", id, err)
+	}
+	if _, err := IdFromInt64(-1); err == nil {
+		t.Error("IdFromInt64(-1) expected an error")
+	}
+
+	signed, err := IdToInt64(6654094787)
+	if err != nil || signed != 6654094787 {
+		t.Errorf("IdToInt64 returned %d, %v", signed, err)
+	}
+	if _, err := IdToInt64(math.MaxUint64); err == nil {
+		t.Error("IdToInt64(math.MaxUint64) expected an error")
+	}
+}
@@ -0,0 +1,228 @@
+package goshopify
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+const (
+	minListLimit = 1
+	maxListLimit = 250
+)
+
+// Validator is implemented by options which can be checked before the request
+// is dispatched. NewRequest calls Validate on any options implementing it.
+type Validator interface {
+	Validate() error
+}
+
+// ValidationError is returned when options or payloads are rejected client
+// side, before a request is sent to Shopify.
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Message)
+}
+
+// validateLimit checks an optional page size
+func validateLimit(limit int) error {
+	if limit != 0 && (limit < minListLimit || limit > maxListLimit) {
+		return ValidationError{
+			Field:   "limit",
+			Message: fmt.Sprintf("must be between %d and %d, got %d", minListLimit, maxListLimit, limit),
+		}
+	}
+	return nil
+}
+
+// validateTimeRange checks that min is not after max when both are set
+func validateTimeRange(field string, min, max time.Time) error {
+	if !min.IsZero() && !max.IsZero() && min.After(max) {
+		return ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf("%s_min %s is after %s_max %s", field, min.Format(time.RFC3339), field, max.Format(time.RFC3339)),
+		}
+	}
+	return nil
+}
+
+// validateTimePtrRange is validateTimeRange for optional times
+func validateTimePtrRange(field string, min, max *time.Time) error {
+	if min == nil || max == nil {
+		return nil
+	}
+	return validateTimeRange(field, *min, *max)
+}
+
+// validateDateRange is validateTimeRange for optional dates
+func validateDateRange(field string, min, max *OnlyDate) error {
+	if min == nil || max == nil {
+		return nil
+	}
+	return validateTimeRange(field, min.Time, max.Time)
+}
+
+// validatePageInfo rejects filters sent alongside page_info, shopify only
+// accepts limit and fields on subsequent pages
+func validatePageInfo(pageInfo string, hasFilters bool) error {
+	if pageInfo != "" && hasFilters {
+		return ValidationError{
+			Field:   "page_info",
+			Message: "cannot be combined with filters other than limit and fields",
+		}
+	}
+	return nil
+}
+
+// isNilPointer reports whether v holds a nil pointer, value receiver
+// Validate methods panic on those
+func isNilPointer(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+// firstError returns the first non nil error
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasFilters reports whether any filter other than page_info, limit and
+// fields is set
+func (o ListOptions) hasFilters() bool {
+	return o.Page != 0 || o.SinceId != nil || !o.CreatedAtMin.IsZero() || !o.CreatedAtMax.IsZero() ||
+		!o.UpdatedAtMin.IsZero() || !o.UpdatedAtMax.IsZero() || o.Order != "" || o.Vendor != "" || len(o.Ids) > 0
+}
+
+// Validate checks the limit range, date ordering and that page_info is not
+// combined with other filters
+func (o ListOptions) Validate() error {
+	return firstError(
+		validateLimit(o.Limit),
+		validatePageInfo(o.PageInfo, o.hasFilters()),
+		validateTimeRange("created_at", o.CreatedAtMin, o.CreatedAtMax),
+		validateTimeRange("updated_at", o.UpdatedAtMin, o.UpdatedAtMax),
+	)
+}
+
+// Validate checks the date ordering
+func (o CountOptions) Validate() error {
+	return firstError(
+		validateTimeRange("created_at", o.CreatedAtMin, o.CreatedAtMax),
+		validateTimeRange("updated_at", o.UpdatedAtMin, o.UpdatedAtMax),
+	)
+}
+
+// Validate checks the embedded ListOptions and the order specific filters
+func (o OrderListOptions) Validate() error {
+	hasFilters := o.Status != "" || o.FinancialStatus != "" || o.FulfillmentStatus != "" ||
+		!o.ProcessedAtMin.IsZero() || !o.ProcessedAtMax.IsZero() || o.Order != ""
+
+	return firstError(
+		o.ListOptions.Validate(),
+		validatePageInfo(o.PageInfo, hasFilters),
+		validateTimeRange("processed_at", o.ProcessedAtMin, o.ProcessedAtMax),
+	)
+}
+
+// Validate checks the limit range and date ordering
+func (o OrderCountOptions) Validate() error {
+	return firstError(
+		validateLimit(o.Limit),
+		validateTimeRange("created_at", o.CreatedAtMin, o.CreatedAtMax),
+		validateTimeRange("updated_at", o.UpdatedAtMin, o.UpdatedAtMax),
+	)
+}
+
+// Validate checks the embedded ListOptions and the product specific filters
+func (o ProductListOptions) Validate() error {
+	hasFilters := o.CollectionId != 0 || o.ProductType != "" || o.Vendor != "" || o.Handle != "" ||
+		!o.PublishedAtMin.IsZero() || !o.PublishedAtMax.IsZero() || o.PublishedStatus != "" ||
+		o.PresentmentCurrencies != "" || len(o.PresentmentCurrencyCodes) > 0 || len(o.Status) > 0 || o.Title != ""
+
+	var currenciesErr error
+	if o.PresentmentCurrencies != "" && len(o.PresentmentCurrencyCodes) > 0 {
+		currenciesErr = ValidationError{
+			Field:   "presentment_currencies",
+			Message: "set either PresentmentCurrencies or PresentmentCurrencyCodes",
+		}
+	}
+
+	return firstError(
+		o.ListOptions.Validate(),
+		validatePageInfo(o.PageInfo, hasFilters),
+		validateTimeRange("published_at", o.PublishedAtMin, o.PublishedAtMax),
+		currenciesErr,
+	)
+}
+
+// Validate checks the embedded CountOptions and the published_at ordering
+func (o ProductCountOptions) Validate() error {
+	return firstError(
+		o.CountOptions.Validate(),
+		validateTimeRange("published_at", o.PublishedAtMin, o.PublishedAtMax),
+	)
+}
+
+// Validate checks the embedded ListOptions and the collection specific filters
+func (o CollectionListOptions) Validate() error {
+	hasFilters := o.Handle != "" || o.Title != "" || o.ProductId != 0 ||
+		!o.PublishedAtMin.IsZero() || !o.PublishedAtMax.IsZero() || o.PublishedStatus != ""
+
+	return firstError(
+		o.ListOptions.Validate(),
+		validatePageInfo(o.PageInfo, hasFilters),
+		validateTimeRange("published_at", o.PublishedAtMin, o.PublishedAtMax),
+	)
+}
+
+// Validate checks the limit range and date ordering
+func (o DraftOrderListOptions) Validate() error {
+	return firstError(
+		validateLimit(o.Limit),
+		validateTimePtrRange("updated_at", o.UpdatedAtMin, o.UpdatedAtMax),
+	)
+}
+
+// Validate checks the limit range
+func (o InventoryLevelListOptions) Validate() error {
+	return validateLimit(o.Limit)
+}
+
+// Validate checks the limit range, date ordering and that page_info is not
+// combined with other filters
+func (o PayoutsListOptions) Validate() error {
+	hasFilters := o.LastId != 0 || o.SinceId != 0 || o.Status != "" || o.DateMin != nil || o.DateMax != nil || o.Date != nil
+
+	return firstError(
+		validateLimit(o.Limit),
+		validatePageInfo(o.PageInfo, hasFilters),
+		validateDateRange("date", o.DateMin, o.DateMax),
+	)
+}
+
+// Validate checks the limit range, date ordering and that page_info is not
+// combined with other filters
+func (o PaymentsTransactionsListOptions) Validate() error {
+	hasFilters := o.LastId != 0 || o.SinceId != 0 || o.PayoutId != 0 || o.PayoutStatus != "" ||
+		o.DateMin != nil || o.DateMax != nil || o.ProcessedAt != nil
+
+	return firstError(
+		validateLimit(o.Limit),
+		validatePageInfo(o.PageInfo, hasFilters),
+		validateDateRange("date", o.DateMin, o.DateMax),
+	)
+}
+
+// Validate checks the limit range
+func (o CustomerSearchOptions) Validate() error {
+	return validateLimit(o.Limit)
+}
@@ -0,0 +1,79 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestOptionsValidate(t *testing.T) {
+	sinceId := uint64(1)
+	early := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	late := early.Add(24 * time.Hour)
+	earlyDate := &OnlyDate{early}
+	lateDate := &OnlyDate{late}
+
+	cases := []struct {
+		description   string
+		options       Validator
+		expectedField string
+	}{
+		{"empty list options", ListOptions{}, ""},
+		{"limit in range", ListOptions{Limit: 250}, ""},
+		{"limit too high", ListOptions{Limit: 251}, "limit"},
+		{"negative limit", ListOptions{Limit: -1}, "limit"},
+		{"page_info with limit and fields", ListOptions{PageInfo: "abc", Limit: 50, Fields: "id"}, ""},
+		{"page_info with since_id", ListOptions{PageInfo: "abc", SinceId: &sinceId}, "page_info"},
+		{"created_at ordered", ListOptions{CreatedAtMin: early, CreatedAtMax: late}, ""},
+		{"created_at reversed", ListOptions{CreatedAtMin: late, CreatedAtMax: early}, "created_at"},
+		{"count updated_at reversed", CountOptions{UpdatedAtMin: late, UpdatedAtMax: early}, "updated_at"},
+		{"order page_info with status", OrderListOptions{ListOptions: ListOptions{PageInfo: "abc"}, Status: OrderStatusAny}, "page_info"},
+		{"order embedded limit", OrderListOptions{ListOptions: ListOptions{Limit: 500}}, "limit"},
+		{"order processed_at reversed", OrderListOptions{ProcessedAtMin: late, ProcessedAtMax: early}, "processed_at"},
+		{"order count limit", OrderCountOptions{Limit: 300}, "limit"},
+		{"product page_info with handle", ProductListOptions{ListOptions: ListOptions{PageInfo: "abc"}, Handle: "shoe"}, "page_info"},
+		{"product published_at reversed", ProductListOptions{PublishedAtMin: late, PublishedAtMax: early}, "published_at"},
+		{"product both presentment currencies", ProductListOptions{PresentmentCurrencies: "USD", PresentmentCurrencyCodes: []string{"CAD"}}, "presentment_currencies"},
+		{"draft order updated_at reversed", DraftOrderListOptions{UpdatedAtMin: &late, UpdatedAtMax: &early}, "updated_at"},
+		{"inventory level limit", InventoryLevelListOptions{Limit: 1000}, "limit"},
+		{"payouts date reversed", PayoutsListOptions{DateMin: lateDate, DateMax: earlyDate}, "date"},
+		{"payouts page_info with status", PayoutsListOptions{PageInfo: "abc", Status: PayoutStatusPaid}, "page_info"},
+		{"payments transactions page_info with payout", PaymentsTransactionsListOptions{PageInfo: "abc", PayoutId: 1}, "page_info"},
+		{"customer search limit", CustomerSearchOptions{Limit: 251}, "limit"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			err := c.options.Validate()
+			if c.expectedField == "" {
+				if err != nil {
+					t.Errorf("Validate() returned %v, expected nil", err)
+				}
+				return
+			}
+
+			var validationErr ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != c.expectedField {
+				t.Errorf("Validate() returned %v, expected ValidationError on %s", err, c.expectedField)
+			}
+		})
+	}
+}
+
+func TestNewRequestValidatesOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.Product.List(context.Background(), ListOptions{Limit: 1000})
+	expected := "invalid limit: must be between 1 and 250, got 1000"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Product.List returned error %v, expected %s", err, expected)
+	}
+
+	// nil pointers are skipped
+	var options *ListOptions
+	if _, err := client.NewRequest(context.Background(), "GET", "products.json", nil, options); err != nil {
+		t.Errorf("NewRequest returned error %v for nil options", err)
+	}
+}
@@ -3,6 +3,8 @@ package goshopify
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -23,6 +25,8 @@ type VariantService interface {
 	Create(context.Context, uint64, Variant) (*Variant, error)
 	Update(context.Context, Variant) (*Variant, error)
 	Delete(context.Context, uint64, uint64) error
+	AttachImage(context.Context, uint64, uint64) (*Variant, error)
+	AttachImages(context.Context, uint64, map[uint64]uint64) ([]Image, error)
 
 	// MetafieldsService used for Variant resource to communicate with Metafields resource
 	MetafieldsService
@@ -49,45 +53,69 @@ const (
 
 // Variant represents a Shopify variant
 type Variant struct {
-	Id                   uint64                 `json:"id,omitempty"`
-	ProductId            uint64                 `json:"product_id,omitempty"`
-	Title                string                 `json:"title,omitempty"`
-	Sku                  string                 `json:"sku,omitempty"`
-	Position             int                    `json:"position,omitempty"`
-	Grams                int                    `json:"grams,omitempty"`
-	InventoryPolicy      variantInventoryPolicy `json:"inventory_policy,omitempty"`
-	Price                *decimal.Decimal       `json:"price,omitempty"`
-	CompareAtPrice       *decimal.Decimal       `json:"compare_at_price,omitempty"`
-	FulfillmentService   string                 `json:"fulfillment_service,omitempty"`
-	InventoryManagement  string                 `json:"inventory_management,omitempty"`
-	InventoryItemId      uint64                 `json:"inventory_item_id,omitempty"`
-	Option1              string                 `json:"option1,omitempty"`
-	Option2              string                 `json:"option2,omitempty"`
-	Option3              string                 `json:"option3,omitempty"`
-	CreatedAt            *time.Time             `json:"created_at,omitempty"`
-	UpdatedAt            *time.Time             `json:"updated_at,omitempty"`
-	Taxable              bool                   `json:"taxable,omitempty"`
-	TaxCode              string                 `json:"tax_code,omitempty"`
-	Barcode              string                 `json:"barcode,omitempty"`
-	ImageId              uint64                 `json:"image_id,omitempty"`
-	InventoryQuantity    int                    `json:"inventory_quantity,omitempty"`
-	Weight               *decimal.Decimal       `json:"weight,omitempty"`
-	WeightUnit           string                 `json:"weight_unit,omitempty"`
-	OldInventoryQuantity int                    `json:"old_inventory_quantity,omitempty"`
-	RequireShipping      bool                   `json:"requires_shipping"`
-	AdminGraphqlApiId    string                 `json:"admin_graphql_api_id,omitempty"`
-	Metafields           []Metafield            `json:"metafields,omitempty"`
-	PresentmentPrices    []presentmentPrices    `json:"presentment_prices,omitempty"`
-}
-
-type presentmentPrices struct {
-	Price          *presentmentPrice `json:"price,omitempty"`
-	CompareAtPrice *presentmentPrice `json:"compare_at_price,omitempty"`
-}
-
-type presentmentPrice struct {
-	Amount       string `json:"amount,omitempty"`
-	CurrencyCode string `json:"currency_code,omitempty"`
+	Id                   uint64                    `json:"id,omitempty"`
+	ProductId            uint64                    `json:"product_id,omitempty"`
+	Title                string                    `json:"title,omitempty"`
+	Sku                  string                    `json:"sku,omitempty"`
+	Position             int                       `json:"position,omitempty"`
+	Grams                int                       `json:"grams,omitempty"`
+	InventoryPolicy      variantInventoryPolicy    `json:"inventory_policy,omitempty"`
+	Price                *decimal.Decimal          `json:"price,omitempty"`
+	CompareAtPrice       *decimal.Decimal          `json:"compare_at_price,omitempty"`
+	FulfillmentService   string                    `json:"fulfillment_service,omitempty"`
+	InventoryManagement  string                    `json:"inventory_management,omitempty"`
+	InventoryItemId      uint64                    `json:"inventory_item_id,omitempty"`
+	Option1              string                    `json:"option1,omitempty"`
+	Option2              string                    `json:"option2,omitempty"`
+	Option3              string                    `json:"option3,omitempty"`
+	CreatedAt            *time.Time                `json:"created_at,omitempty"`
+	UpdatedAt            *time.Time                `json:"updated_at,omitempty"`
+	Taxable              bool                      `json:"taxable,omitempty"`
+	TaxCode              string                    `json:"tax_code,omitempty"`
+	Barcode              string                    `json:"barcode,omitempty"`
+	ImageId              uint64                    `json:"image_id,omitempty"`
+	InventoryQuantity    int                       `json:"inventory_quantity,omitempty"`
+	Weight               *decimal.Decimal          `json:"weight,omitempty"`
+	WeightUnit           string                    `json:"weight_unit,omitempty"`
+	OldInventoryQuantity int                       `json:"old_inventory_quantity,omitempty"`
+	RequireShipping      bool                      `json:"requires_shipping"`
+	AdminGraphqlApiId    string                    `json:"admin_graphql_api_id,omitempty"`
+	Metafields           []Metafield               `json:"metafields,omitempty"`
+	PresentmentPrices    []VariantPresentmentPrice `json:"presentment_prices,omitempty"`
+	NullFields           []string                  `json:"-"`
+	UnknownJSONFields
+}
+
+// VariantPresentmentPrice represents the price of a variant in one of the
+// presentment currencies of the shop
+type VariantPresentmentPrice struct {
+	Price          *PresentmentMoney `json:"price,omitempty"`
+	CompareAtPrice *PresentmentMoney `json:"compare_at_price,omitempty"`
+}
+
+// PresentmentMoney represents an amount in a presentment currency
+type PresentmentMoney struct {
+	Amount       *decimal.Decimal `json:"amount,omitempty"`
+	CurrencyCode string           `json:"currency_code,omitempty"`
+}
+
+// PresentmentPrice returns the presentment price of the variant in the given
+// currency, or nil when the variant was not fetched with that currency in the
+// presentment_currencies option
+func (v Variant) PresentmentPrice(currency string) *VariantPresentmentPrice {
+	for i, price := range v.PresentmentPrices {
+		if price.Price != nil && strings.EqualFold(price.Price.CurrencyCode, currency) {
+			return &v.PresentmentPrices[i]
+		}
+	}
+	return nil
+}
+
+// VariantListOptions represents the filters of the products/X/variants.json
+// endpoint
+type VariantListOptions struct {
+	ListOptions
+	PresentmentCurrencies []string `url:"presentment_currencies,omitempty,comma"`
 }
 
 // VariantResource represents the result from the variants/X.json endpoint
@@ -145,12 +173,128 @@ func (s *VariantServiceOp) Delete(ctx context.Context, productId uint64, variant
 	return s.client.Delete(ctx, fmt.Sprintf("%s/%d/variants/%d.json", productsBasePath, productId, variantId))
 }
 
+// AttachImage sets the image of a variant, an imageId of 0 removes the image
+// from the variant
+func (s *VariantServiceOp) AttachImage(ctx context.Context, variantId uint64, imageId uint64) (*Variant, error) {
+	// the image_id is sent as null to detach, which Variant's omitempty cannot express
+	variant := struct {
+		Id      uint64  `json:"id"`
+		ImageId *uint64 `json:"image_id"`
+	}{Id: variantId}
+	if imageId != 0 {
+		variant.ImageId = &imageId
+	}
+
+	path := fmt.Sprintf("%s/%d.json", variantsBasePath, variantId)
+	wrappedData := map[string]interface{}{"variant": variant}
+	resource := new(VariantResource)
+	err := s.client.Put(ctx, path, wrappedData, resource)
+	return resource.Variant, err
+}
+
+// AttachImages reconciles the images of the variants of a product with the
+// given variant id to image id mapping, an image id of 0 removes the image
+// from the variant. Variants missing from the mapping are left untouched.
+//
+// The product images are listed once and only images whose variants change
+// are updated, one call per image. Moving a variant to another image only
+// updates the new image as shopify detaches it from the previous one.
+// The updated images are returned.
+func (s *VariantServiceOp) AttachImages(ctx context.Context, productId uint64, variantImages map[uint64]uint64) ([]Image, error) {
+	if len(variantImages) == 0 {
+		return nil, nil
+	}
+
+	images, err := s.client.Image.List(ctx, productId, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	imageIds := make(map[uint64]bool, len(images))
+	for _, image := range images {
+		imageIds[image.Id] = true
+	}
+	for variantId, imageId := range variantImages {
+		if imageId != 0 && !imageIds[imageId] {
+			return nil, ValidationError{
+				Field:   "image_id",
+				Message: fmt.Sprintf("image %d of variant %d does not belong to product %d", imageId, variantId, productId),
+			}
+		}
+	}
+
+	var updated []Image
+	for _, image := range images {
+		variantIds, changed := reconcileImageVariants(image, variantImages)
+		if !changed {
+			continue
+		}
+
+		// variant_ids is always sent so the last variant can be detached
+		data := map[string]interface{}{
+			"image": struct {
+				Id         uint64   `json:"id"`
+				VariantIds []uint64 `json:"variant_ids"`
+			}{Id: image.Id, VariantIds: variantIds},
+		}
+		path := fmt.Sprintf("%s/%d/images/%d.json", productsBasePath, productId, image.Id)
+		resource := new(ImageResource)
+		if err := s.client.Put(ctx, path, data, resource); err != nil {
+			return updated, err
+		}
+		if resource.Image != nil {
+			updated = append(updated, *resource.Image)
+		}
+	}
+
+	return updated, nil
+}
+
+// reconcileImageVariants returns the variant ids an image should have and
+// whether an update is required to get there
+func reconcileImageVariants(image Image, variantImages map[uint64]uint64) ([]uint64, bool) {
+	variantIds := []uint64{}
+	changed := false
+
+	for _, variantId := range image.VariantIds {
+		imageId, ok := variantImages[variantId]
+		switch {
+		case !ok || imageId == image.Id:
+			variantIds = append(variantIds, variantId)
+		case imageId == 0:
+			// detaching requires updating the current image
+			changed = true
+		}
+		// otherwise the variant moves to another image, which detaches it
+	}
+
+	current := make(map[uint64]bool, len(image.VariantIds))
+	for _, variantId := range image.VariantIds {
+		current[variantId] = true
+	}
+	for variantId, imageId := range variantImages {
+		if imageId == image.Id && !current[variantId] {
+			variantIds = append(variantIds, variantId)
+			changed = true
+		}
+	}
+
+	sort.Slice(variantIds, func(i, j int) bool { return variantIds[i] < variantIds[j] })
+	return variantIds, changed
+}
+
 // ListMetafields for a variant
 func (s *VariantServiceOp) ListMetafields(ctx context.Context, variantId uint64, options interface{}) ([]Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: variantsResourceName, resourceId: variantId}
 	return metafieldService.List(ctx, options)
 }
 
+// ListMetafieldsByNamespace lists all metafields of a namespace for a variant
+func (s *VariantServiceOp) ListMetafieldsByNamespace(ctx context.Context, variantId uint64, namespace string) ([]Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: variantsResourceName, resourceId: variantId}
+	return metafieldService.ListByNamespace(ctx, namespace)
+}
+
 // CountMetafields for a variant
 func (s *VariantServiceOp) CountMetafields(ctx context.Context, variantId uint64, options interface{}) (int, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: variantsResourceName, resourceId: variantId}
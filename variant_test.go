@@ -2,7 +2,10 @@ package goshopify
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"reflect"
 	"testing"
 	"time"
@@ -455,3 +458,129 @@ func variantTestsWithTaxCode(t *testing.T, variant Variant) {
 		t.Errorf("Variant.TaxCode returned %+v, expected %+v", variant.TaxCode, expectedTacCode)
 	}
 }
+
+func TestVariantAttachImage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body string
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/variants/1.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := io.ReadAll(req.Body)
+			body = string(b)
+			return httpmock.NewStringResponse(200, `{"variant":{"id":1,"image_id":2}}`), nil
+		})
+
+	variant, err := client.Variant.AttachImage(context.Background(), 1, 2)
+	if err != nil {
+		t.Errorf("Variant.AttachImage returned error: %v", err)
+	}
+	if variant.ImageId != 2 {
+		t.Errorf("Variant.AttachImage returned image id %d, expected 2", variant.ImageId)
+	}
+	if expected := `{"variant":{"id":1,"image_id":2}}`; body != expected {
+		t.Errorf("Variant.AttachImage sent %s, expected %s", body, expected)
+	}
+
+	_, err = client.Variant.AttachImage(context.Background(), 1, 0)
+	if err != nil {
+		t.Errorf("Variant.AttachImage returned error: %v", err)
+	}
+	if expected := `{"variant":{"id":1,"image_id":null}}`; body != expected {
+		t.Errorf("Variant.AttachImage sent %s, expected %s", body, expected)
+	}
+}
+
+func TestVariantAttachImages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	imagesURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1/images", client.pathPrefix)
+	httpmock.RegisterResponder("GET", imagesURL+".json",
+		httpmock.NewStringResponder(200, `{"images":[
+			{"id":10,"variant_ids":[100,101]},
+			{"id":11,"variant_ids":[102]},
+			{"id":12,"variant_ids":[103]}
+		]}`))
+
+	bodies := map[string]string{}
+	responder := func(req *http.Request) (*http.Response, error) {
+		b, _ := io.ReadAll(req.Body)
+		bodies[req.URL.Path] = string(b)
+		return httpmock.NewStringResponse(200, string(b)), nil
+	}
+	httpmock.RegisterResponder("PUT", imagesURL+"/10.json", responder)
+	httpmock.RegisterResponder("PUT", imagesURL+"/11.json", responder)
+	httpmock.RegisterResponder("PUT", imagesURL+"/12.json", responder)
+
+	// 101 moves from 10 to 11, 103 is detached and 100 is unchanged
+	updated, err := client.Variant.AttachImages(context.Background(), 1, map[uint64]uint64{
+		100: 10,
+		101: 11,
+		103: 0,
+	})
+	if err != nil {
+		t.Fatalf("Variant.AttachImages returned error: %v", err)
+	}
+
+	expectedBodies := map[string]string{
+		fmt.Sprintf("/%s/products/1/images/11.json", client.pathPrefix): `{"image":{"id":11,"variant_ids":[101,102]}}`,
+		fmt.Sprintf("/%s/products/1/images/12.json", client.pathPrefix): `{"image":{"id":12,"variant_ids":[]}}`,
+	}
+	if !reflect.DeepEqual(bodies, expectedBodies) {
+		t.Errorf("Variant.AttachImages sent %v, expected %v", bodies, expectedBodies)
+	}
+
+	if len(updated) != 2 {
+		t.Errorf("Variant.AttachImages returned %d images, expected 2", len(updated))
+	}
+}
+
+func TestVariantAttachImagesUnknownImage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1/images.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"images":[{"id":10}]}`))
+
+	_, err := client.Variant.AttachImages(context.Background(), 1, map[uint64]uint64{100: 99})
+	var validationErr ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("Variant.AttachImages returned %v, expected ValidationError", err)
+	}
+}
+
+func TestVariantListWithPresentmentPrices(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{"presentment_currencies": "USD,EUR"}
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1/variants.json", client.pathPrefix),
+		params, httpmock.NewStringResponder(200, `{"variants":[{"id":1,"presentment_prices":[
+			{"price":{"amount":"199.00","currency_code":"USD"},"compare_at_price":null},
+			{"price":{"amount":"181.99","currency_code":"EUR"},"compare_at_price":{"amount":"200.00","currency_code":"EUR"}}
+		]}]}`))
+
+	variants, err := client.Variant.List(context.Background(), 1, VariantListOptions{PresentmentCurrencies: []string{"USD", "EUR"}})
+	if err != nil {
+		t.Fatalf("Variant.List returned error: %v", err)
+	}
+
+	price := variants[0].PresentmentPrice("eur")
+	if price == nil {
+		t.Fatal("Variant.PresentmentPrice returned nil for EUR")
+	}
+	if !price.Price.Amount.Equal(decimal.RequireFromString("181.99")) {
+		t.Errorf("Variant.PresentmentPrice price returned %s, expected 181.99", price.Price.Amount)
+	}
+	if price.CompareAtPrice == nil || !price.CompareAtPrice.Amount.Equal(decimal.NewFromInt(200)) {
+		t.Errorf("Variant.PresentmentPrice compare at price returned %+v, expected 200", price.CompareAtPrice)
+	}
+
+	if usd := variants[0].PresentmentPrice("USD"); usd == nil || usd.CompareAtPrice != nil {
+		t.Errorf("Variant.PresentmentPrice returned %+v for USD, expected no compare at price", usd)
+	}
+	if variants[0].PresentmentPrice("CAD") != nil {
+		t.Error("Variant.PresentmentPrice returned a price for CAD")
+	}
+}
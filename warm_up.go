@@ -0,0 +1,235 @@
+package goshopify
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultWarmUpConcurrency = 2
+	defaultWarmUpFirstWindow = 24 * time.Hour
+	defaultWarmUpWindows     = 8
+)
+
+// WarmUpWindow is a range of updated_at fetched by a WarmUpResource, the
+// last window of a resource has a zero UpdatedAtMin and covers everything
+// older
+type WarmUpWindow struct {
+	UpdatedAtMin time.Time
+	UpdatedAtMax time.Time
+}
+
+// ListOptions returns the options listing the resources updated in the
+// window, with the largest page size
+func (w WarmUpWindow) ListOptions() ListOptions {
+	return ListOptions{Limit: 250, UpdatedAtMin: w.UpdatedAtMin, UpdatedAtMax: w.UpdatedAtMax}
+}
+
+// WarmUpResource is a resource rebuilt by a WarmUpScheduler
+type WarmUpResource struct {
+	// Name of the resource, e.g. "orders", reported in errors
+	Name string
+
+	// Priority moves the windows of the resource ahead of the windows of the
+	// same age of other resources, by as many windows as its value, e.g.
+	// orders with priority 2 fetch their third window before the first one
+	// of products with priority 0
+	Priority int
+
+	// Weight is the number of windows of the resource fetched at once,
+	// defaults to 1
+	Weight int
+
+	// Sync fetches and stores the resources updated in window, e.g.
+	//
+	//	orders, err := client.Order.ListAll(ctx, goshopify.OrderListOptions{
+	//		ListOptions: window.ListOptions(),
+	//		Status:      goshopify.OrderStatusAny,
+	//	})
+	Sync func(ctx context.Context, window WarmUpWindow) error
+}
+
+// WarmUpScheduler rebuilds a local cache of resources after an install,
+// most valuable data first. Every resource is fetched in windows of
+// updated_at growing exponentially into the past, the last day, the day
+// before, the two days before and so on, and the most recent windows of the
+// resources with the highest priority are fetched first:
+//
+//	scheduler := goshopify.WarmUpScheduler{
+//		Resources: []goshopify.WarmUpResource{
+//			{Name: "orders", Priority: 2, Weight: 2, Sync: syncOrders},
+//			{Name: "products", Sync: syncProducts},
+//		},
+//	}
+//	err := scheduler.Run(ctx)
+//
+// Use it with a client configured WithRateLimiter so the windows fetched
+// concurrently share the rate limit of the shop.
+type WarmUpScheduler struct {
+	Resources []WarmUpResource
+
+	// Concurrency is the number of windows fetched at once, defaults to 2
+	Concurrency int
+
+	// FirstWindow is the duration of the most recent window, defaults to a
+	// day
+	FirstWindow time.Duration
+
+	// Windows is the number of windows of a resource before the last one
+	// covering everything older, defaults to 8
+	Windows int
+}
+
+// warmUpJob is a window of a resource waiting to be fetched
+type warmUpJob struct {
+	resource *WarmUpResource
+	window   WarmUpWindow
+	rank     int
+	order    int
+}
+
+// warmUpQueue is a heap of the jobs, lowest rank first
+type warmUpQueue []*warmUpJob
+
+func (q warmUpQueue) Len() int { return len(q) }
+
+func (q warmUpQueue) Less(i, j int) bool {
+	if q[i].rank != q[j].rank {
+		return q[i].rank < q[j].rank
+	}
+	if q[i].resource.Priority != q[j].resource.Priority {
+		return q[i].resource.Priority > q[j].resource.Priority
+	}
+	return q[i].order < q[j].order
+}
+
+func (q warmUpQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *warmUpQueue) Push(x interface{}) { *q = append(*q, x.(*warmUpJob)) }
+
+func (q *warmUpQueue) Pop() interface{} {
+	old := *q
+	job := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return job
+}
+
+// popRunnable pops the first job whose resource fetches less windows than
+// its weight
+func (q *warmUpQueue) popRunnable(running map[*WarmUpResource]int) (*warmUpJob, bool) {
+	var skipped []*warmUpJob
+	defer func() {
+		for _, job := range skipped {
+			heap.Push(q, job)
+		}
+	}()
+
+	for q.Len() > 0 {
+		job := heap.Pop(q).(*warmUpJob)
+		weight := job.resource.Weight
+		if weight < 1 {
+			weight = 1
+		}
+		if running[job.resource] < weight {
+			return job, true
+		}
+		skipped = append(skipped, job)
+	}
+	return nil, false
+}
+
+// windows returns the windows of a resource ending at now, most recent
+// first
+func (s *WarmUpScheduler) windows(now time.Time) []WarmUpWindow {
+	first, count := s.FirstWindow, s.Windows
+	if first <= 0 {
+		first = defaultWarmUpFirstWindow
+	}
+	if count <= 0 {
+		count = defaultWarmUpWindows
+	}
+
+	windows := make([]WarmUpWindow, 0, count+1)
+	end, length := now, first
+	for i := 0; i < count; i++ {
+		windows = append(windows, WarmUpWindow{UpdatedAtMin: end.Add(-length), UpdatedAtMax: end})
+		end, length = end.Add(-length), length*2
+	}
+	return append(windows, WarmUpWindow{UpdatedAtMax: end})
+}
+
+// queue returns the jobs of all the windows of the resources
+func (s *WarmUpScheduler) queue(now time.Time) *warmUpQueue {
+	queue := &warmUpQueue{}
+	windows := s.windows(now)
+	for i := range s.Resources {
+		resource := &s.Resources[i]
+		for age, window := range windows {
+			*queue = append(*queue, &warmUpJob{
+				resource: resource,
+				window:   window,
+				rank:     age - resource.Priority,
+				order:    len(*queue),
+			})
+		}
+	}
+	heap.Init(queue)
+	return queue
+}
+
+// Run fetches all the windows of the resources, it stops at the first error
+// and returns it once the windows being fetched are done
+func (s *WarmUpScheduler) Run(ctx context.Context) error {
+	return s.run(ctx, time.Now())
+}
+
+func (s *WarmUpScheduler) run(ctx context.Context, now time.Time) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	concurrency := s.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultWarmUpConcurrency
+	}
+
+	type result struct {
+		job *warmUpJob
+		err error
+	}
+	queue := s.queue(now)
+	running := map[*WarmUpResource]int{}
+	done := make(chan result)
+	inFlight := 0
+	var firstErr error
+
+	for {
+		for inFlight < concurrency && firstErr == nil {
+			job, ok := queue.popRunnable(running)
+			if !ok {
+				break
+			}
+			running[job.resource]++
+			inFlight++
+			go func() {
+				done <- result{job: job, err: job.resource.Sync(ctx, job.window)}
+			}()
+		}
+		if inFlight == 0 {
+			return firstErr
+		}
+
+		r := <-done
+		running[r.job.resource]--
+		inFlight--
+		if r.err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("warm up %s updated before %s: %w", r.job.resource.Name,
+				r.job.window.UpdatedAtMax.Format(time.RFC3339), r.err)
+			cancel()
+		}
+		if firstErr == nil && ctx.Err() != nil {
+			firstErr = ctx.Err()
+		}
+	}
+}
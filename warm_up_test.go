@@ -0,0 +1,126 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWarmUpSchedulerWindows(t *testing.T) {
+	now := time.Date(2024, time.May, 10, 12, 0, 0, 0, time.UTC)
+	scheduler := WarmUpScheduler{FirstWindow: time.Hour, Windows: 2}
+
+	expected := []WarmUpWindow{
+		{UpdatedAtMin: now.Add(-time.Hour), UpdatedAtMax: now},
+		{UpdatedAtMin: now.Add(-3 * time.Hour), UpdatedAtMax: now.Add(-time.Hour)},
+		{UpdatedAtMax: now.Add(-3 * time.Hour)},
+	}
+	if windows := scheduler.windows(now); !reflect.DeepEqual(windows, expected) {
+		t.Errorf("WarmUpScheduler.windows returned %+v, expected %+v", windows, expected)
+	}
+}
+
+func TestWarmUpSchedulerRunOrder(t *testing.T) {
+	now := time.Date(2024, time.May, 10, 12, 0, 0, 0, time.UTC)
+	scheduler := WarmUpScheduler{Windows: 3}
+	windows := scheduler.windows(now)
+
+	var mu sync.Mutex
+	var fetched []string
+	syncer := func(name string) func(context.Context, WarmUpWindow) error {
+		return func(_ context.Context, window WarmUpWindow) error {
+			for age := range windows {
+				if windows[age] == window {
+					mu.Lock()
+					fetched = append(fetched, fmt.Sprintf("%s %d", name, age))
+					mu.Unlock()
+				}
+			}
+			return nil
+		}
+	}
+
+	scheduler.Resources = []WarmUpResource{
+		{Name: "products", Sync: syncer("products")},
+		{Name: "orders", Priority: 2, Sync: syncer("orders")},
+	}
+	scheduler.Concurrency = 1
+	if err := scheduler.run(context.Background(), now); err != nil {
+		t.Fatalf("WarmUpScheduler.Run returned error: %v", err)
+	}
+
+	expected := []string{
+		"orders 0", "orders 1", "orders 2", "products 0", "orders 3",
+		"products 1", "products 2", "products 3",
+	}
+	if !reflect.DeepEqual(fetched, expected) {
+		t.Errorf("WarmUpScheduler.Run fetched %v, expected %v", fetched, expected)
+	}
+}
+
+func TestWarmUpSchedulerRunWeight(t *testing.T) {
+	var mu sync.Mutex
+	running, maxRunning := map[string]int{}, map[string]int{}
+	syncer := func(name string) func(context.Context, WarmUpWindow) error {
+		return func(context.Context, WarmUpWindow) error {
+			mu.Lock()
+			running[name]++
+			if running[name] > maxRunning[name] {
+				maxRunning[name] = running[name]
+			}
+			mu.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+
+			mu.Lock()
+			running[name]--
+			mu.Unlock()
+			return nil
+		}
+	}
+
+	scheduler := WarmUpScheduler{
+		Resources: []WarmUpResource{
+			{Name: "orders", Priority: 8, Weight: 2, Sync: syncer("orders")},
+			{Name: "products", Sync: syncer("products")},
+		},
+		Concurrency: 4,
+	}
+	if err := scheduler.Run(context.Background()); err != nil {
+		t.Fatalf("WarmUpScheduler.Run returned error: %v", err)
+	}
+
+	expected := map[string]int{"orders": 2, "products": 1}
+	if !reflect.DeepEqual(maxRunning, expected) {
+		t.Errorf("WarmUpScheduler.Run fetched at most %v windows at once, expected %v", maxRunning, expected)
+	}
+}
+
+func TestWarmUpSchedulerRunError(t *testing.T) {
+	now := time.Date(2024, time.May, 10, 12, 0, 0, 0, time.UTC)
+	errSync := errors.New("sync failed")
+	calls := 0
+
+	scheduler := WarmUpScheduler{
+		Resources: []WarmUpResource{{
+			Name: "orders",
+			Sync: func(context.Context, WarmUpWindow) error {
+				calls++
+				return errSync
+			},
+		}},
+	}
+	err := scheduler.run(context.Background(), now)
+
+	expected := "warm up orders updated before 2024-05-10T12:00:00Z: sync failed"
+	if !errors.Is(err, errSync) || err.Error() != expected {
+		t.Errorf("WarmUpScheduler.Run returned %v, expected %s", err, expected)
+	}
+	if calls != 1 {
+		t.Errorf("WarmUpScheduler.Run fetched %d windows after the error, expected 1", calls)
+	}
+}
@@ -0,0 +1,160 @@
+package goshopify
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	shopifyWebhookIdHeader   = "X-Shopify-Webhook-Id"
+	shopifyTriggeredAtHeader = "X-Shopify-Triggered-At"
+	webhookSeenKeyPrefix     = "webhook:"
+	defaultWebhookSeenIdsTTL = 48 * time.Hour
+	defaultWebhookClaimTTL   = time.Minute
+	webhookTriggeredAtFormat = time.RFC3339Nano
+)
+
+// Errors returned by WebhookReplayGuard.Check for deliveries to drop
+var (
+	ErrWebhookReplayed = errors.New("shopify: webhook already delivered")
+	ErrWebhookExpired  = errors.New("shopify: webhook delivery outside the tolerance")
+)
+
+// WebhookReplayGuard rejects webhook deliveries already handled, keyed on
+// their X-Shopify-Webhook-Id header, and deliveries triggered longer than
+// Tolerance ago. Shopify retries a failed delivery with the same id and
+// trigger time for 48 hours, a Tolerance shorter than that drops those
+// retries.
+//
+// Check claims a delivery before it is handled, so copies of it arriving at
+// once are rejected, then Mark records it once it was handled or Release
+// gives it up so the retry of shopify is accepted:
+//
+//	if err := guard.Check(r); err != nil {
+//		return err
+//	}
+//	if err := handle(r); err != nil {
+//		guard.Release(r)
+//		return err
+//	}
+//	return guard.Mark(r)
+type WebhookReplayGuard struct {
+	// Store keeps the ids of the deliveries seen, e.g. a redisstore.Store
+	// shared by every instance of the app
+	Store AtomicCacheStore
+
+	// Tolerance is the maximum age of a delivery, 0 disables the check
+	Tolerance time.Duration
+
+	// ClaimTTL is how long a delivery claimed by Check but neither marked
+	// nor released, e.g. by a process which crashed, is rejected. Defaults
+	// to a minute, shopify waits 5 seconds for a response.
+	ClaimTTL time.Duration
+
+	// used to override time.Now in tests
+	now func() time.Time
+}
+
+// NewWebhookReplayGuard returns a guard keeping the ids seen in store
+func NewWebhookReplayGuard(store AtomicCacheStore, tolerance time.Duration) *WebhookReplayGuard {
+	return &WebhookReplayGuard{Store: store, Tolerance: tolerance}
+}
+
+// Check returns ErrWebhookExpired or ErrWebhookReplayed when the delivery must
+// be dropped, because it was handled or is being handled, and claims it
+// otherwise. The request must be verified first so forged requests cannot
+// fill the store.
+func (g *WebhookReplayGuard) Check(r *http.Request) error {
+	id, err := webhookId(r)
+	if err != nil {
+		return err
+	}
+
+	if g.Tolerance > 0 {
+		triggeredAt, err := time.Parse(webhookTriggeredAtFormat, r.Header.Get(shopifyTriggeredAtHeader))
+		if err != nil {
+			return fmt.Errorf("header %s: %w", shopifyTriggeredAtHeader, err)
+		}
+		now := time.Now
+		if g.now != nil {
+			now = g.now
+		}
+		if age := now().Sub(triggeredAt); age > g.Tolerance || age < -g.Tolerance {
+			return fmt.Errorf("%w: %s triggered at %s", ErrWebhookExpired, id, triggeredAt)
+		}
+	}
+
+	claimTTL := g.ClaimTTL
+	if claimTTL <= 0 {
+		claimTTL = defaultWebhookClaimTTL
+	}
+	claimed, err := g.Store.SetNX(r.Context(), webhookSeenKeyPrefix+id, []byte{0}, claimTTL)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return fmt.Errorf("%w: %s", ErrWebhookReplayed, id)
+	}
+	return nil
+}
+
+// Mark records a delivery claimed by Check as handled, its retries are
+// rejected from then on
+func (g *WebhookReplayGuard) Mark(r *http.Request) error {
+	id, err := webhookId(r)
+	if err != nil {
+		return err
+	}
+
+	// ids only need to outlive the deliveries the tolerance accepts
+	ttl := defaultWebhookSeenIdsTTL
+	if g.Tolerance > 0 {
+		ttl = 2 * g.Tolerance
+	}
+	return g.Store.Set(r.Context(), webhookSeenKeyPrefix+id, []byte{1}, ttl)
+}
+
+// Release gives up a delivery claimed by Check which failed to be handled, so
+// its retry is accepted
+func (g *WebhookReplayGuard) Release(r *http.Request) error {
+	id, err := webhookId(r)
+	if err != nil {
+		return err
+	}
+	return g.Store.Delete(r.Context(), webhookSeenKeyPrefix+id)
+}
+
+func webhookId(r *http.Request) (string, error) {
+	id := r.Header.Get(shopifyWebhookIdHeader)
+	if id == "" {
+		return "", fmt.Errorf("header %s not set", shopifyWebhookIdHeader)
+	}
+	return id, nil
+}
+
+// VerifyWebhookRequestOnce verifies a webhook request like
+// VerifyWebhookRequestVerbose, checks it against guard and calls handle. The
+// delivery is marked as handled only when handle succeeds, otherwise it is
+// released so the retry of shopify is accepted and the error of handle is
+// returned. The error matches ErrWebhookReplayed or ErrWebhookExpired for
+// deliveries to drop. The body of the request is still readable by handle.
+func (app App) VerifyWebhookRequestOnce(r *http.Request, guard *WebhookReplayGuard, handle func() error) (bool, error) {
+	if ok, err := app.VerifyWebhookRequestVerbose(r); !ok {
+		return false, err
+	}
+	if err := guard.Check(r); err != nil {
+		return false, err
+	}
+	if err := handle(); err != nil {
+		if releaseErr := guard.Release(r); releaseErr != nil {
+			return false, errors.Join(err, releaseErr)
+		}
+		return false, err
+	}
+	if err := guard.Mark(r); err != nil {
+		return false, err
+	}
+	return true, nil
+}
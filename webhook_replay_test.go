@@ -0,0 +1,148 @@
+package goshopify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newWebhookTestRequest(secret, body, id string, triggeredAt time.Time) *http.Request {
+	req, _ := http.NewRequest("POST", "https://app.example.com/webhooks", bytes.NewBufferString(body))
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	req.Header.Set(shopifyChecksumHeader, base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	req.Header.Set(shopifyWebhookIdHeader, id)
+	req.Header.Set(shopifyTriggeredAtHeader, triggeredAt.Format(time.RFC3339Nano))
+	return req
+}
+
+func TestVerifyWebhookRequestOnce(t *testing.T) {
+	setup()
+	defer teardown()
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	guard := NewWebhookReplayGuard(NewMemoryCacheStore(), 5*time.Minute)
+	guard.now = func() time.Time { return now }
+
+	handled := 0
+	handle := func() error {
+		handled++
+		return nil
+	}
+
+	ok, err := app.VerifyWebhookRequestOnce(newWebhookTestRequest(app.ApiSecret, `{"id":1}`, "a", now.Add(-time.Minute)), guard, handle)
+	if !ok || err != nil {
+		t.Errorf("VerifyWebhookRequestOnce returned %v, %v for a first delivery", ok, err)
+	}
+
+	ok, err = app.VerifyWebhookRequestOnce(newWebhookTestRequest(app.ApiSecret, `{"id":1}`, "a", now.Add(-time.Minute)), guard, handle)
+	if ok || !errors.Is(err, ErrWebhookReplayed) {
+		t.Errorf("VerifyWebhookRequestOnce returned %v, %v for a replayed delivery", ok, err)
+	}
+
+	ok, err = app.VerifyWebhookRequestOnce(newWebhookTestRequest(app.ApiSecret, `{"id":1}`, "b", now.Add(-time.Hour)), guard, handle)
+	if ok || !errors.Is(err, ErrWebhookExpired) {
+		t.Errorf("VerifyWebhookRequestOnce returned %v, %v for an old delivery", ok, err)
+	}
+
+	ok, err = app.VerifyWebhookRequestOnce(newWebhookTestRequest("wrong", `{"id":1}`, "c", now), guard, handle)
+	if ok || err == nil {
+		t.Errorf("VerifyWebhookRequestOnce returned %v, %v for a forged delivery", ok, err)
+	}
+	if _, seen, _ := guard.Store.Get(context.Background(), webhookSeenKeyPrefix+"c"); seen {
+		t.Errorf("VerifyWebhookRequestOnce recorded the id of a forged delivery")
+	}
+	if handled != 1 {
+		t.Errorf("VerifyWebhookRequestOnce handled %d deliveries, expected 1", handled)
+	}
+}
+
+func TestVerifyWebhookRequestOnceHandlerError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	guard := NewWebhookReplayGuard(NewMemoryCacheStore(), 0)
+	handlerErr := errors.New("database unavailable")
+
+	ok, err := app.VerifyWebhookRequestOnce(newWebhookTestRequest(app.ApiSecret, `{"id":1}`, "a", time.Now()), guard, func() error {
+		return handlerErr
+	})
+	if ok || !errors.Is(err, handlerErr) {
+		t.Errorf("VerifyWebhookRequestOnce returned %v, %v, expected the error of the handler", ok, err)
+	}
+
+	// shopify retries the failed delivery with the same id
+	ok, err = app.VerifyWebhookRequestOnce(newWebhookTestRequest(app.ApiSecret, `{"id":1}`, "a", time.Now()), guard, func() error {
+		return nil
+	})
+	if !ok || err != nil {
+		t.Errorf("VerifyWebhookRequestOnce returned %v, %v for the retry of a failed delivery", ok, err)
+	}
+}
+
+func TestWebhookReplayGuardConcurrentDeliveries(t *testing.T) {
+	guard := NewWebhookReplayGuard(NewMemoryCacheStore(), 0)
+
+	var wg sync.WaitGroup
+	var accepted atomic.Int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if guard.Check(newWebhookTestRequest("secret", `{}`, "a", time.Now())) == nil {
+				accepted.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if accepted.Load() != 1 {
+		t.Errorf("WebhookReplayGuard.Check accepted %d copies of a delivery, expected 1", accepted.Load())
+	}
+}
+
+func TestWebhookReplayGuardRelease(t *testing.T) {
+	guard := NewWebhookReplayGuard(NewMemoryCacheStore(), 0)
+	req := newWebhookTestRequest("secret", `{}`, "a", time.Now())
+
+	if err := guard.Check(req); err != nil {
+		t.Fatalf("WebhookReplayGuard.Check returned %v", err)
+	}
+	if err := guard.Check(req); !errors.Is(err, ErrWebhookReplayed) {
+		t.Errorf("WebhookReplayGuard.Check returned %v for a delivery being handled, expected ErrWebhookReplayed", err)
+	}
+	if err := guard.Release(req); err != nil {
+		t.Fatalf("WebhookReplayGuard.Release returned %v", err)
+	}
+	if err := guard.Check(req); err != nil {
+		t.Errorf("WebhookReplayGuard.Check returned %v for a released delivery", err)
+	}
+	if err := guard.Mark(req); err != nil {
+		t.Fatalf("WebhookReplayGuard.Mark returned %v", err)
+	}
+	if err := guard.Check(req); !errors.Is(err, ErrWebhookReplayed) {
+		t.Errorf("WebhookReplayGuard.Check returned %v for a handled delivery, expected ErrWebhookReplayed", err)
+	}
+}
+
+func TestWebhookReplayGuardWithoutTolerance(t *testing.T) {
+	guard := &WebhookReplayGuard{Store: NewMemoryCacheStore()}
+
+	req := newWebhookTestRequest("secret", `{}`, "a", time.Now().Add(-24*time.Hour))
+	if err := guard.Check(req); err != nil {
+		t.Errorf("WebhookReplayGuard.Check returned %v", err)
+	}
+
+	req.Header.Del(shopifyWebhookIdHeader)
+	if err := guard.Check(req); err == nil {
+		t.Errorf("WebhookReplayGuard.Check accepted a delivery without id")
+	}
+}
@@ -0,0 +1,181 @@
+package goshopify
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// PIIAction is what a PIIPolicy does with the value of a PII field
+type PIIAction int
+
+const (
+	// PIIRemove sets the field to its zero value
+	PIIRemove PIIAction = iota + 1
+
+	// PIIHash replaces string values with their hex encoded HMAC-SHA256, so
+	// events of the same customer can still be correlated. Other values are
+	// removed.
+	PIIHash
+)
+
+// PIIPolicy scrubs the PII fields of decoded webhook payloads before they are
+// persisted. Fields are matched by JSON name at any depth, in structs and
+// maps alike.
+type PIIPolicy struct {
+	Fields map[string]PIIAction
+
+	// HashKey keys the hashes of PIIHash so they cannot be reversed by
+	// hashing known emails or phones, it should be kept secret
+	HashKey []byte
+}
+
+// DefaultPIIPolicy returns a policy hashing emails and phones and removing
+// names, street addresses, coordinates and client details. Countries and
+// provinces are kept.
+func DefaultPIIPolicy(hashKey []byte) PIIPolicy {
+	return PIIPolicy{
+		Fields: map[string]PIIAction{
+			"email":            PIIHash,
+			"contact_email":    PIIHash,
+			"phone":            PIIHash,
+			"first_name":       PIIRemove,
+			"last_name":        PIIRemove,
+			"name":             PIIRemove,
+			"company":          PIIRemove,
+			"address1":         PIIRemove,
+			"address2":         PIIRemove,
+			"city":             PIIRemove,
+			"zip":              PIIRemove,
+			"latitude":         PIIRemove,
+			"longitude":        PIIRemove,
+			"browser_ip":       PIIRemove,
+			"client_details":   PIIRemove,
+			"customer_locale":  PIIRemove,
+			"note":             PIIRemove,
+			"note_attributes":  PIIRemove,
+			"landing_site":     PIIRemove,
+			"referring_site":   PIIRemove,
+			"order_status_url": PIIRemove,
+			"token":            PIIRemove,
+			"cart_token":       PIIRemove,
+			"checkout_token":   PIIRemove,
+		},
+		HashKey: hashKey,
+	}
+}
+
+// Scrub removes or hashes the PII fields of v in place, v must be a pointer
+// to a decoded payload, e.g. a *Order or a *map[string]interface{}.
+//
+// Note that "name" is a PII field of addresses but also the name of orders
+// and line items, the default policy removes all of them.
+func (p PIIPolicy) Scrub(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("scrub: expected a non-nil pointer, got %T", v)
+	}
+	p.scrub(rv.Elem())
+	return nil
+}
+
+func (p PIIPolicy) scrub(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			p.scrub(v.Elem())
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, embedded := jsonFieldName(field)
+			if action, ok := p.Fields[name]; ok && !embedded {
+				p.apply(v.Field(i), action)
+				continue
+			}
+			p.scrub(v.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			p.scrub(v.Index(i))
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return
+		}
+		for _, key := range v.MapKeys() {
+			value := v.MapIndex(key)
+			action, ok := p.Fields[key.String()]
+			if !ok {
+				// map values are not addressable, scrub a copy and store it back
+				copied := reflect.New(value.Type()).Elem()
+				copied.Set(value)
+				p.scrub(copied)
+				v.SetMapIndex(key, copied)
+				continue
+			}
+			if hashed, ok := p.hashed(value, action); ok {
+				v.SetMapIndex(key, hashed)
+			} else {
+				v.SetMapIndex(key, reflect.Value{})
+			}
+		}
+	}
+}
+
+// apply applies action to a struct field
+func (p PIIPolicy) apply(field reflect.Value, action PIIAction) {
+	if !field.CanSet() {
+		return
+	}
+	if hashed, ok := p.hashed(field, action); ok {
+		field.Set(hashed)
+		return
+	}
+	field.Set(reflect.Zero(field.Type()))
+}
+
+// hashed returns the hash of a string value, or of the string a pointer or
+// interface value holds, converted to the type of value
+func (p PIIPolicy) hashed(value reflect.Value, action PIIAction) (reflect.Value, bool) {
+	if action != PIIHash {
+		return reflect.Value{}, false
+	}
+	inner := value
+	for inner.Kind() == reflect.Interface || inner.Kind() == reflect.Ptr {
+		if inner.IsNil() {
+			return reflect.Value{}, false
+		}
+		inner = inner.Elem()
+	}
+	if inner.Kind() != reflect.String || inner.String() == "" {
+		return reflect.Value{}, false
+	}
+
+	hashed := reflect.ValueOf(p.hash(inner.String())).Convert(inner.Type())
+	switch value.Kind() {
+	case reflect.Interface:
+		return hashed, true
+	case reflect.Ptr:
+		if value.Elem().Kind() != reflect.String {
+			return reflect.Value{}, false
+		}
+		ptr := reflect.New(inner.Type())
+		ptr.Elem().Set(hashed)
+		return ptr, true
+	}
+	return hashed, true
+}
+
+func (p PIIPolicy) hash(value string) string {
+	mac := hmac.New(sha256.New, p.HashKey)
+	mac.Write([]byte(strings.ToLower(strings.TrimSpace(value))))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,65 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPIIPolicyScrubOrder(t *testing.T) {
+	policy := DefaultPIIPolicy([]byte("secret"))
+
+	order := Order{}
+	if err := json.Unmarshal(loadFixture("order.json"), &struct {
+		Order *Order `json:"order"`
+	}{&order}); err != nil {
+		t.Fatal(err)
+	}
+	email := order.Email
+	if email == "" || order.BillingAddress == nil || order.Customer == nil {
+		t.Fatal("order fixture expected to have an email, a billing address and a customer")
+	}
+	country := order.BillingAddress.Country
+	customerEmail := order.Customer.Email
+
+	if err := policy.Scrub(&order); err != nil {
+		t.Fatalf("Scrub returned error: %v", err)
+	}
+
+	if order.Email != policy.hash(email) {
+		t.Errorf("Scrub set email to %s, expected its hash", order.Email)
+	}
+	if order.BillingAddress.Address1 != "" || order.BillingAddress.FirstName != "" || order.BillingAddress.Latitude != 0 {
+		t.Errorf("Scrub kept billing address %+v", order.BillingAddress)
+	}
+	if order.BillingAddress.Country != country {
+		t.Errorf("Scrub removed the billing address country")
+	}
+	if order.Customer.Email != policy.hash(customerEmail) {
+		t.Errorf("Scrub set customer email to %s", order.Customer.Email)
+	}
+}
+
+func TestPIIPolicyScrubMap(t *testing.T) {
+	policy := PIIPolicy{Fields: map[string]PIIAction{"email": PIIHash, "address1": PIIRemove}}
+
+	payload := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(`{"id":1,"email":"Bob@example.com ","addresses":[{"address1":"1 Main St","country":"CA"}]}`), &payload); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := policy.Scrub(&payload); err != nil {
+		t.Fatalf("Scrub returned error: %v", err)
+	}
+
+	if payload["email"] != policy.hash("bob@example.com") {
+		t.Errorf("Scrub set email to %v, expected the hash of the normalized email", payload["email"])
+	}
+	address := payload["addresses"].([]interface{})[0].(map[string]interface{})
+	if _, ok := address["address1"]; ok || address["country"] != "CA" {
+		t.Errorf("Scrub returned address %v", address)
+	}
+
+	if err := policy.Scrub(payload); err == nil {
+		t.Errorf("Scrub expected an error for a non pointer")
+	}
+}
@@ -0,0 +1,224 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// WriteBuffer coalesces writes to the same resource made within a flush
+// window into a single call, e.g. five adjustments of the same inventory item
+// at a location are sent as one adjustment of their sum. Writes are sent in
+// the order their resource was first written.
+//
+//	buffer := goshopify.NewWriteBuffer(client, 2*time.Second, func(err error) { log.Println(err) })
+//	defer buffer.Close(ctx)
+//	buffer.AdjustInventory(itemId, locationId, -1)
+type WriteBuffer struct {
+	client  *Client
+	window  time.Duration
+	onError func(error)
+
+	mu      sync.Mutex
+	pending map[string]*bufferedWrite
+	order   []string
+	timer   *time.Timer
+}
+
+// bufferedWrite is the coalesced write of one resource
+type bufferedWrite struct {
+	inventory *inventoryWrite
+	path      string
+	body      interface{}
+}
+
+// inventoryWrite is the coalesced change of an inventory level, the level is
+// set to available plus adjust when available is set
+type inventoryWrite struct {
+	itemId     uint64
+	locationId uint64
+	available  *int
+	adjust     int
+}
+
+// NewWriteBuffer returns a buffer sending its writes window after the first
+// write since the last flush. onError receives the errors of those flushes
+// and is optional.
+func NewWriteBuffer(client *Client, window time.Duration, onError func(error)) *WriteBuffer {
+	return &WriteBuffer{
+		client:  client,
+		window:  window,
+		onError: onError,
+		pending: map[string]*bufferedWrite{},
+	}
+}
+
+// AdjustInventory adds adjust to the available quantity of an inventory item
+// at a location, adjustments of the same level are summed
+func (b *WriteBuffer) AdjustInventory(inventoryItemId, locationId uint64, adjust int) {
+	b.add(fmt.Sprintf("inventory_level:%d:%d", inventoryItemId, locationId), func(w *bufferedWrite) {
+		if w.inventory == nil {
+			w.inventory = &inventoryWrite{itemId: inventoryItemId, locationId: locationId}
+		}
+		w.inventory.adjust += adjust
+	})
+}
+
+// SetInventory sets the available quantity of an inventory item at a
+// location, replacing the writes of the level buffered before
+func (b *WriteBuffer) SetInventory(inventoryItemId, locationId uint64, available int) {
+	b.add(fmt.Sprintf("inventory_level:%d:%d", inventoryItemId, locationId), func(w *bufferedWrite) {
+		w.inventory = &inventoryWrite{itemId: inventoryItemId, locationId: locationId, available: &available}
+	})
+}
+
+// Put buffers a PUT of body to path, the last body buffered for a path is
+// the one sent
+func (b *WriteBuffer) Put(path string, body interface{}) {
+	b.add("put:"+path, func(w *bufferedWrite) {
+		w.path = path
+		w.body = body
+	})
+}
+
+// Len returns the number of resources with buffered writes
+func (b *WriteBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.order)
+}
+
+func (b *WriteBuffer) add(key string, merge func(*bufferedWrite)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	w, ok := b.pending[key]
+	if !ok {
+		w = &bufferedWrite{}
+		b.pending[key] = w
+		b.order = append(b.order, key)
+	}
+	merge(w)
+
+	if b.timer == nil && b.window > 0 {
+		b.timer = time.AfterFunc(b.window, func() {
+			if err := b.Flush(context.Background()); err != nil && b.onError != nil {
+				b.onError(err)
+			}
+		})
+	}
+}
+
+// Flush sends the buffered writes now. Every write is attempted, the errors
+// are joined and name the resource which failed. The writes which failed stay
+// buffered, merged with the writes of their resource buffered since, and are
+// sent again by the next flush, e.g. a Flush call of the caller retrying them.
+// The window does not flush them again on its own, see Discard to drop them.
+func (b *WriteBuffer) Flush(ctx context.Context) error {
+	b.mu.Lock()
+	pending, order := b.pending, b.order
+	b.pending, b.order = map[string]*bufferedWrite{}, nil
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	b.mu.Unlock()
+
+	var errs []error
+	var failed []string
+	for _, key := range order {
+		if err := b.send(ctx, pending[key]); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+			failed = append(failed, key)
+		}
+	}
+	b.requeue(pending, failed)
+	return errors.Join(errs...)
+}
+
+// requeue buffers the failed writes of a flush back ahead of the writes
+// buffered during the flush
+func (b *WriteBuffer) requeue(pending map[string]*bufferedWrite, failed []string) {
+	if len(failed) == 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	order := make([]string, 0, len(failed)+len(b.order))
+	requeued := make(map[string]bool, len(failed))
+	for _, key := range failed {
+		w := pending[key]
+		if newer, ok := b.pending[key]; ok {
+			w.merge(newer)
+		}
+		b.pending[key] = w
+		order = append(order, key)
+		requeued[key] = true
+	}
+	for _, key := range b.order {
+		if !requeued[key] {
+			order = append(order, key)
+		}
+	}
+	b.order = order
+}
+
+// merge applies to w the writes of its resource buffered after it
+func (w *bufferedWrite) merge(newer *bufferedWrite) {
+	if newer.inventory != nil {
+		if w.inventory == nil || newer.inventory.available != nil {
+			w.inventory = newer.inventory
+		} else {
+			w.inventory.adjust += newer.inventory.adjust
+		}
+	}
+	if newer.path != "" {
+		w.path, w.body = newer.path, newer.body
+	}
+}
+
+// Discard drops the buffered writes without sending them, e.g. writes which
+// keep failing
+func (b *WriteBuffer) Discard() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.pending, b.order = map[string]*bufferedWrite{}, nil
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+}
+
+// Close flushes the buffered writes, e.g. before the app exits. The writes
+// which failed stay buffered like with Flush.
+func (b *WriteBuffer) Close(ctx context.Context) error {
+	return b.Flush(ctx)
+}
+
+func (b *WriteBuffer) send(ctx context.Context, w *bufferedWrite) error {
+	if inventory := w.inventory; inventory != nil {
+		if inventory.available != nil {
+			_, err := b.client.InventoryLevel.Set(ctx, InventoryLevel{
+				InventoryItemId: inventory.itemId,
+				LocationId:      inventory.locationId,
+				Available:       *inventory.available + inventory.adjust,
+			})
+			return err
+		}
+		if inventory.adjust == 0 {
+			return nil
+		}
+		_, err := b.client.InventoryLevel.Adjust(ctx, InventoryLevelAdjustOptions{
+			InventoryItemId: inventory.itemId,
+			LocationId:      inventory.locationId,
+			Adjust:          inventory.adjust,
+		})
+		return err
+	}
+	return b.client.Put(ctx, w.path, w.body, nil)
+}
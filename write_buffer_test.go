@@ -0,0 +1,142 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func registerWriteBufferResponders(sent *[]string) {
+	for _, path := range []string{"inventory_levels/adjust.json", "inventory_levels/set.json", "products/1.json"} {
+		path := path
+		method := "POST"
+		if path == "products/1.json" {
+			method = "PUT"
+		}
+		httpmock.RegisterResponder(method, fmt.Sprintf("https://fooshop.myshopify.com/%s/%s", client.pathPrefix, path),
+			func(req *http.Request) (*http.Response, error) {
+				body := map[string]interface{}{}
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					return nil, err
+				}
+				data, _ := json.Marshal(body)
+				*sent = append(*sent, path+" "+string(data))
+				return httpmock.NewStringResponse(200, `{}`), nil
+			})
+	}
+}
+
+func TestWriteBufferFlush(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []string
+	registerWriteBufferResponders(&sent)
+
+	buffer := NewWriteBuffer(client, 0, nil)
+	for i := 0; i < 5; i++ {
+		buffer.AdjustInventory(1, 2, -1)
+	}
+	buffer.AdjustInventory(3, 2, 4)
+	buffer.AdjustInventory(3, 2, -4)
+	buffer.SetInventory(5, 2, 10)
+	buffer.AdjustInventory(5, 2, 2)
+	buffer.Put("products/1.json", map[string]interface{}{"product": map[string]interface{}{"title": "A"}})
+	buffer.Put("products/1.json", map[string]interface{}{"product": map[string]interface{}{"title": "B"}})
+
+	if buffer.Len() != 4 {
+		t.Errorf("WriteBuffer.Len returned %d, expected 4", buffer.Len())
+	}
+	if err := buffer.Flush(context.Background()); err != nil {
+		t.Fatalf("WriteBuffer.Flush returned error: %v", err)
+	}
+
+	expected := []string{
+		`inventory_levels/adjust.json {"available_adjustment":-5,"inventory_item_id":1,"location_id":2}`,
+		`inventory_levels/set.json {"available":12,"inventory_item_id":5,"location_id":2}`,
+		`products/1.json {"product":{"title":"B"}}`,
+	}
+	if !reflect.DeepEqual(sent, expected) {
+		t.Errorf("WriteBuffer.Flush sent %v, expected %v", sent, expected)
+	}
+	if buffer.Len() != 0 {
+		t.Errorf("WriteBuffer.Len returned %d after flush", buffer.Len())
+	}
+}
+
+func TestWriteBufferFlushWindow(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []string
+	registerWriteBufferResponders(&sent)
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/inventory_levels/adjust.json", client.pathPrefix),
+		httpmock.NewStringResponder(422, `{"errors":"Inventory item does not exist"}`))
+
+	errs := make(chan error, 1)
+	buffer := NewWriteBuffer(client, 10*time.Millisecond, func(err error) { errs <- err })
+	buffer.AdjustInventory(1, 2, 1)
+	buffer.AdjustInventory(1, 2, 1)
+
+	select {
+	case err := <-errs:
+		if err.Error() != "inventory_level:1:2: Inventory item does not exist" {
+			t.Errorf("WriteBuffer flush returned error %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("WriteBuffer did not flush after its window")
+	}
+}
+
+func TestWriteBufferFlushKeepsFailedWrites(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []string
+	registerWriteBufferResponders(&sent)
+	adjustURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/inventory_levels/adjust.json", client.pathPrefix)
+	httpmock.RegisterResponder("POST", adjustURL, httpmock.NewStringResponder(503, `{"errors":"Service Unavailable"}`))
+
+	buffer := NewWriteBuffer(client, 0, nil)
+	buffer.AdjustInventory(1, 2, -1)
+	buffer.Put("products/1.json", map[string]interface{}{"product": map[string]interface{}{"title": "A"}})
+	if err := buffer.Flush(context.Background()); err == nil {
+		t.Fatal("WriteBuffer.Flush returned no error for a failed write")
+	}
+	if buffer.Len() != 1 {
+		t.Errorf("WriteBuffer.Len returned %d after a failed flush, expected the failed write", buffer.Len())
+	}
+
+	// the failed adjustment is merged with the ones buffered since
+	buffer.AdjustInventory(1, 2, -2)
+	registerWriteBufferResponders(&sent)
+	if err := buffer.Flush(context.Background()); err != nil {
+		t.Fatalf("WriteBuffer.Flush returned error: %v", err)
+	}
+
+	expected := []string{
+		`products/1.json {"product":{"title":"A"}}`,
+		`inventory_levels/adjust.json {"available_adjustment":-3,"inventory_item_id":1,"location_id":2}`,
+	}
+	if !reflect.DeepEqual(sent, expected) {
+		t.Errorf("WriteBuffer.Flush sent %v, expected %v", sent, expected)
+	}
+	if buffer.Len() != 0 {
+		t.Errorf("WriteBuffer.Len returned %d after flush", buffer.Len())
+	}
+}
+
+func TestWriteBufferDiscard(t *testing.T) {
+	buffer := NewWriteBuffer(client, time.Hour, nil)
+	buffer.AdjustInventory(1, 2, -1)
+	buffer.Discard()
+	if buffer.Len() != 0 {
+		t.Errorf("WriteBuffer.Len returned %d after Discard", buffer.Len())
+	}
+}